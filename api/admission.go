@@ -0,0 +1,156 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+type admissionRule struct {
+	Name   string `json:"name" binding:"required,max=64"`
+	Rule   string `json:"rule" binding:"required,max=1024"`
+	Reason string `json:"reason" binding:"max=255"`
+}
+
+// Rules has no "min" binding as uploading none removes them
+type setAdmissionRulesRequest struct {
+	Event map[string]interface{} `json:"event"`
+	Rules []admissionRule        `json:"rules" binding:"required,dive"`
+}
+
+// setAdmissionRules godoc
+// @Summary Uploads a new version of the admission rules
+// @Description Compiles every rule, e.g. `deny if party.size > guest.rsvp_size + 2 and not guest.vip`, and stores them as the next version of the admission rules which every arrival must pass from then on, alongside the policies configured for the event. event holds values the rules refer to as event.<name>, RFC 3339 strings are times. A rule which doesn't compile is rejected with the column it fails at. Uploading no rules removes them.
+// @Accept json
+// @Produce json
+// @Param    request  body      setAdmissionRulesRequest  true  "Event values and rules"
+// @Success 200 {object} db.AdmissionRuleSet
+// @Failure 400 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /admission_rules [put]
+func (server *Server) setAdmissionRules(ctx *gin.Context) {
+	var req setAdmissionRulesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.SetAdmissionRulesTxParams{
+		AuditInfo: auditInfo(ctx),
+		Rules: db.AdmissionRules{
+			Event: req.Event,
+			Rules: make([]db.AdmissionRule, len(req.Rules)),
+		},
+	}
+	for i, rule := range req.Rules {
+		arg.Rules.Rules[i] = db.AdmissionRule(rule)
+	}
+
+	ruleSet, err := server.store.SetAdmissionRulesTx(ctx, arg)
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidAdmissionRules) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ruleSet)
+}
+
+type getAdmissionRulesRequest struct {
+	Version int32 `form:"version" binding:"min=0"`
+}
+
+// getAdmissionRules godoc
+// @Summary returns a version of the admission rules
+// @Description Fetches the admission rules in force, or the version given, with who uploaded them and when.
+// @Accept json
+// @Produce json
+// @Param        version     query      int  false  "Version, the latest when left out"
+// @Success 200 {object} db.AdmissionRuleSet
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /admission_rules [get]
+func (server *Server) getAdmissionRules(ctx *gin.Context) {
+	var req getAdmissionRulesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var ruleSet db.AdmissionRuleSet
+	var err error
+	if req.Version == 0 {
+		ruleSet, err = server.store.GetLatestAdmissionRuleSet(ctx)
+	} else {
+		ruleSet, err = server.store.GetAdmissionRuleSet(ctx, req.Version)
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ruleSet)
+}
+
+// Every filter is optional, an unset one matches all decisions
+type listAdmissionLogRequest struct {
+	GuestName string `form:"guest_name"`
+	Allowed   *bool  `form:"allowed"`
+	PageID    int32  `form:"page_id" binding:"required,min=1"`
+	PageSize  int32  `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listAdmissionLog godoc
+// @Summary returns the decisions made on arrivals
+// @Description Fetches an array of admission decisions ([]AdmissionLog) oldest first, one for every admission policy and rule evaluated on every arrival, including those which turned the party away. Decisions can be filtered by guest name and whether they allowed the arrival, and are paginated with a minimum page_id of 1 and page_size of 5-10.
+// @Accept json
+// @Produce json
+// @Param        guest_name  query      string  false  "Guest Name"
+// @Param        allowed     query      bool    false  "Only decisions which allowed, or denied, the arrival"
+// @Param        page_id     query      int     true   "Page ID"
+// @Param        page_size   query      int     true   "Page Size"
+// @Success 200 {object} []db.AdmissionLog
+// @Failure 400 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /admission_log [get]
+func (server *Server) listAdmissionLog(ctx *gin.Context) {
+	var req listAdmissionLogRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListAdmissionLogParams{
+		GuestName: sql.NullString{String: req.GuestName, Valid: req.GuestName != ""},
+		Limit:     req.PageSize,
+		Offset:    (req.PageID - 1) * req.PageSize,
+	}
+	if req.Allowed != nil {
+		arg.Allowed = sql.NullBool{Bool: *req.Allowed, Valid: true}
+	}
+
+	decisions, err := server.store.ListAdmissionLog(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, decisions)
+}
@@ -0,0 +1,271 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func randomAdmissionRuleSet(t *testing.T, rules db.AdmissionRules) db.AdmissionRuleSet {
+	document, err := json.Marshal(rules)
+	require.NoError(t, err)
+	return db.AdmissionRuleSet{
+		Version:   int32(util.RandomInt(1, 100)),
+		Document:  document,
+		CreatedBy: testUsername,
+		CreatedAt: time.Now().Truncate(time.Second).UTC(),
+	}
+}
+
+func TestSetAdmissionRulesAPI(t *testing.T) {
+	rules := db.AdmissionRules{
+		Event: map[string]interface{}{"doors_close": "2026-10-19T23:00:00Z", "grace": float64(2)},
+		Rules: []db.AdmissionRule{
+			{Name: "rsvp_growth", Rule: `deny if party.size > guest.rsvp_size + event.grace and not guest.vip`},
+			{Name: "doors", Rule: `deny if now > event.doors_close`, Reason: "the doors have closed"},
+		},
+	}
+	ruleSet := randomAdmissionRuleSet(t, rules)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"event": rules.Event, "rules": rules.Rules},
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetAdmissionRulesTx(gomock.Any(), gomock.Eq(db.SetAdmissionRulesTxParams{AuditInfo: testAudit, Rules: rules})).
+					Times(1).
+					Return(ruleSet, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.AdmissionRuleSet
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, ruleSet.Version, got.Version)
+				require.JSONEq(t, string(ruleSet.Document), string(got.Document))
+			},
+		},
+		{
+			name: "RemoveRules",
+			body: gin.H{"rules": []db.AdmissionRule{}},
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetAdmissionRulesTx(gomock.Any(), gomock.Eq(db.SetAdmissionRulesTxParams{
+						AuditInfo: testAudit,
+						Rules:     db.AdmissionRules{Rules: []db.AdmissionRule{}},
+					})).
+					Times(1).
+					Return(randomAdmissionRuleSet(t, db.AdmissionRules{Rules: []db.AdmissionRule{}}), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "RuleDoesNotCompile",
+			body: gin.H{"rules": []db.AdmissionRule{{Name: "typo", Rule: `deny if party.sise > 4`}}},
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetAdmissionRulesTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AdmissionRuleSet{}, fmt.Errorf("rule typo: column 9: unknown variable \"party.sise\": %w", db.ErrInvalidAdmissionRules))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "column 9")
+			},
+		},
+		{
+			name: "MissingRules",
+			body: gin.H{"event": rules.Event},
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetAdmissionRulesTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "UnnamedRule",
+			body: gin.H{"rules": []gin.H{{"rule": `deny if true`}}},
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetAdmissionRulesTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "DoorStaff",
+			body: gin.H{"rules": rules.Rules},
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetAdmissionRulesTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPut, "/admission_rules", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, tc.role, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetAdmissionRulesAPI(t *testing.T) {
+	ruleSet := randomAdmissionRuleSet(t, db.AdmissionRules{
+		Rules: []db.AdmissionRule{{Name: "big_parties", Rule: `deny if party.size > 8`}},
+	})
+
+	testCases := []struct {
+		name          string
+		query         string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Latest",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLatestAdmissionRuleSet(gomock.Any()).Times(1).Return(ruleSet, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "Version",
+			query: fmt.Sprintf("?version=%d", ruleSet.Version),
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLatestAdmissionRuleSet(gomock.Any()).Times(0)
+				store.EXPECT().GetAdmissionRuleSet(gomock.Any(), gomock.Eq(ruleSet.Version)).Times(1).Return(ruleSet, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.AdmissionRuleSet
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, ruleSet.Version, got.Version)
+			},
+		},
+		{
+			name: "NoneUploaded",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetLatestAdmissionRuleSet(gomock.Any()).Times(1).Return(db.AdmissionRuleSet{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/admission_rules"+tc.query, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListAdmissionLogAPI(t *testing.T) {
+	guest := randomGuest()
+	decisions := []db.AdmissionLog{
+		{
+			ID: 1, GuestID: guest.ID, GuestName: guest.GuestName, TableID: guest.TableID, PartySize: 3,
+			Policy: db.PolicyTableCapacity, Allowed: true, Reason: "party of 3 fits in the 4 free seats at table 1",
+			DecidedBy: testUsername, RequestID: "req", DecidedAt: time.Now().Truncate(time.Second).UTC(),
+		},
+		{
+			ID: 2, GuestID: guest.ID, GuestName: guest.GuestName, TableID: guest.TableID, PartySize: 3,
+			Policy: "rule:doors", RulesVersion: 2, Allowed: false, Reason: "the doors have closed",
+			DecidedBy: testUsername, RequestID: "req", DecidedAt: time.Now().Truncate(time.Second).UTC(),
+		},
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().
+		ListAdmissionLog(gomock.Any(), gomock.Eq(db.ListAdmissionLogParams{
+			GuestName: sql.NullString{String: guest.GuestName, Valid: true},
+			Allowed:   sql.NullBool{Bool: false, Valid: true},
+			Limit:     5,
+			Offset:    0,
+		})).
+		Times(1).
+		Return(decisions[1:], nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/admission_log?guest_name=%s&allowed=false&page_id=1&page_size=5", guest.GuestName)
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got []db.AdmissionLog
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Equal(t, decisions[1:], got)
+}
@@ -0,0 +1,250 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+// maxArrivalBuckets stops a long window cut into short intervals from building a huge response
+const maxArrivalBuckets = 1000
+
+// The window of the event is optional, it runs from the first arrival or change of occupancy
+// until now by default
+type analyticsRequest struct {
+	From  time.Time `form:"from"`
+	Until time.Time `form:"until"`
+}
+
+type arrivalAnalyticsRequest struct {
+	analyticsRequest
+	IntervalMinutes int32 `form:"interval_minutes" binding:"omitempty,min=1,max=1440"`
+}
+
+var (
+	// errInvalidAnalyticsWindow is returned when the window of the event closes before it opens
+	errInvalidAnalyticsWindow = errors.New("from must be before until")
+	// errTooManyBuckets is returned when the window would be cut into more than maxArrivalBuckets intervals
+	errTooManyBuckets = errors.New("the window holds too many intervals, use a longer interval or a shorter window")
+)
+
+// eventWindow returns the window of the event requested, closing at the time of the request unless
+// until is given. From is left zero for the window to open at the first activity. It writes the
+// error response and returns false if the window closes before it opens.
+func eventWindow(ctx *gin.Context, req analyticsRequest) (db.EventWindow, bool) {
+	window := db.EventWindow{From: req.From, Until: req.Until}
+	if window.Until.IsZero() {
+		window.Until = time.Now()
+	}
+	if !window.From.IsZero() && !window.From.Before(window.Until) {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errInvalidAnalyticsWindow))
+		return db.EventWindow{}, false
+	}
+	return window, true
+}
+
+// loadEventData reads the arrivals and the occupancy snapshots for the window requested, writing
+// the error response and returning false if it can't
+func (server *Server) loadEventData(ctx *gin.Context, req analyticsRequest) (db.EventActivity, bool) {
+	window, ok := eventWindow(ctx, req)
+	if !ok {
+		return db.EventActivity{}, false
+	}
+
+	data, err := db.LoadEventActivity(ctx, server.store, window)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.EventActivity{}, false
+	}
+	return data, true
+}
+
+type analyticsSummaryResponse struct {
+	Window             db.EventWindow      `json:"window"`
+	Arrivals           int64               `json:"arrivals"`
+	People             int64               `json:"people"`
+	PeakOccupancy      db.OccupancyPoint   `json:"peak_occupancy"`
+	CompletedStays     int64               `json:"completed_stays"`
+	AverageStayMinutes float64             `json:"average_stay_minutes"`
+	NoShows            db.NoShowSummary    `json:"no_shows"`
+	Entourage          db.EntourageSummary `json:"entourage"`
+}
+
+// getAnalyticsSummary godoc
+// @Summary returns the headline analytics of the event
+// @Description Computes, over the window of the event, the number of arrivals and people let in, the peak occupancy of the venue and when it was reached, the average stay of the parties who have left, the no-show rate of the guest list and how the parties who arrived compared with what they were booked for. The window runs from the first arrival or change of occupancy until now unless from or until are given.
+// @Accept json
+// @Produce json
+// @Param        from   query      string  false  "Start of the window (RFC 3339)"
+// @Param        until  query      string  false  "End of the window (RFC 3339)"
+// @Success 200 {object} analyticsSummaryResponse
+// @Failure 400 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /analytics/summary [get]
+func (server *Server) getAnalyticsSummary(ctx *gin.Context) {
+	var req analyticsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	data, ok := server.loadEventData(ctx, req)
+	if !ok {
+		return
+	}
+
+	counts, err := server.store.CountGuestsByStatus(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	stays := db.SummariseStays(data.Arrivals)
+	rsp := analyticsSummaryResponse{
+		Window:             data.Window,
+		Arrivals:           int64(len(data.Arrivals)),
+		PeakOccupancy:      db.PeakOccupancy(db.OccupancySeries(data.Snapshots, data.Window)),
+		CompletedStays:     stays.Completed,
+		AverageStayMinutes: stays.Average.Minutes(),
+		NoShows:            db.SummariseNoShows(counts),
+		Entourage:          db.CompareEntourages(data.Arrivals),
+	}
+	for _, arrival := range data.Arrivals {
+		rsp.People += int64(arrival.PartySize)
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type arrivalAnalyticsResponse struct {
+	Window          db.EventWindow     `json:"window"`
+	IntervalMinutes int32              `json:"interval_minutes"`
+	Buckets         []db.ArrivalBucket `json:"buckets"`
+}
+
+// getArrivalAnalytics godoc
+// @Summary returns the number of arrivals in each interval of the event
+// @Description Counts the arrivals, and the people in their parties, in every interval of the window of the event, 15 minutes by default. Intervals are aligned to their length, e.g. on the quarter hour, and empty ones are included.
+// @Accept json
+// @Produce json
+// @Param        from              query      string  false  "Start of the window (RFC 3339)"
+// @Param        until             query      string  false  "End of the window (RFC 3339)"
+// @Param        interval_minutes  query      int     false  "Length of each interval in minutes"
+// @Success 200 {object} arrivalAnalyticsResponse
+// @Failure 400 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /analytics/arrivals [get]
+func (server *Server) getArrivalAnalytics(ctx *gin.Context) {
+	var req arrivalAnalyticsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	if req.IntervalMinutes == 0 {
+		req.IntervalMinutes = 15
+	}
+	interval := time.Duration(req.IntervalMinutes) * time.Minute
+
+	data, ok := server.loadEventData(ctx, req.analyticsRequest)
+	if !ok {
+		return
+	}
+	if data.Window.Until.Sub(data.Window.From.Truncate(interval)) > maxArrivalBuckets*interval {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errTooManyBuckets))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, arrivalAnalyticsResponse{
+		Window:          data.Window,
+		IntervalMinutes: req.IntervalMinutes,
+		Buckets:         db.BucketArrivals(data.Arrivals, data.Window, interval),
+	})
+}
+
+type occupancyAnalyticsResponse struct {
+	Window db.EventWindow      `json:"window"`
+	Peak   db.OccupancyPoint   `json:"peak"`
+	Series []db.OccupancyPoint `json:"series"`
+}
+
+// getOccupancyAnalytics godoc
+// @Summary returns the occupancy of the venue over the event
+// @Description Returns the time-series of the venue's occupancy, seated and standing, over the window of the event, starting with the occupancy when the window opened followed by every change within it, along with the peak and when it was first reached.
+// @Accept json
+// @Produce json
+// @Param        from   query      string  false  "Start of the window (RFC 3339)"
+// @Param        until  query      string  false  "End of the window (RFC 3339)"
+// @Success 200 {object} occupancyAnalyticsResponse
+// @Failure 400 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /analytics/occupancy [get]
+func (server *Server) getOccupancyAnalytics(ctx *gin.Context) {
+	var req analyticsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	data, ok := server.loadEventData(ctx, req)
+	if !ok {
+		return
+	}
+
+	series := db.OccupancySeries(data.Snapshots, data.Window)
+	ctx.JSON(http.StatusOK, occupancyAnalyticsResponse{
+		Window: data.Window,
+		Peak:   db.PeakOccupancy(series),
+		Series: series,
+	})
+}
+
+type tableAnalyticsResponse struct {
+	Window db.EventWindow        `json:"window"`
+	Tables []db.TableUtilisation `json:"tables"`
+}
+
+// getTableAnalytics godoc
+// @Summary returns the utilisation of every table over the event
+// @Description Returns, for every table over the window of the event, the number of arrivals at it, the most people sat at it at once, the seat hours it was occupied for and its utilisation, the share of its seat time that was occupied.
+// @Accept json
+// @Produce json
+// @Param        from   query      string  false  "Start of the window (RFC 3339)"
+// @Param        until  query      string  false  "End of the window (RFC 3339)"
+// @Success 200 {object} tableAnalyticsResponse
+// @Failure 400 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /analytics/tables [get]
+func (server *Server) getTableAnalytics(ctx *gin.Context) {
+	var req analyticsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	data, ok := server.loadEventData(ctx, req)
+	if !ok {
+		return
+	}
+
+	tables, err := server.store.ListTables(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tableAnalyticsResponse{
+		Window: data.Window,
+		Tables: db.UtiliseTables(tables, data.Snapshots, data.Arrivals, data.Window),
+	})
+}
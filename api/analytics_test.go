@@ -0,0 +1,372 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+type analyticsTestCase struct {
+	name          string
+	query         url.Values
+	role          string
+	buildStubs    func(store *mockdb.MockStore)
+	checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+}
+
+// randomEvent returns the arrivals and occupancy snapshots of an hour long event at a table
+func randomEvent(from time.Time) (db.Table, []db.ListEventArrivalsRow, []db.OccupancySnapshot) {
+	table := randomTable()
+	table.Size = 4
+	tableID := sql.NullInt32{Int32: table.ID, Valid: true}
+
+	arrivals := []db.ListEventArrivalsRow{
+		{ID: 1, GuestID: 1, GuestName: util.RandomGuestName(), TableID: table.ID, PartySize: 2, BookedPartySize: 3, ArrivedAt: from.Add(5 * time.Minute),
+			DepartedAt: sql.NullTime{Time: from.Add(35 * time.Minute), Valid: true}},
+		{ID: 2, GuestID: 2, GuestName: util.RandomGuestName(), TableID: table.ID, PartySize: 1, BookedPartySize: 1, ArrivedAt: from.Add(20 * time.Minute)},
+	}
+	snapshots := []db.OccupancySnapshot{
+		{ID: 1, TableID: tableID, TableOccupied: 2, VenueOccupancy: 2, RecordedAt: from.Add(5 * time.Minute)},
+		{ID: 2, TableID: tableID, TableOccupied: 3, VenueOccupancy: 3, RecordedAt: from.Add(20 * time.Minute)},
+		{ID: 3, TableID: tableID, TableOccupied: 1, VenueOccupancy: 1, RecordedAt: from.Add(35 * time.Minute)},
+	}
+	return table, arrivals, snapshots
+}
+
+func TestAnalyticsSummaryAPI(t *testing.T) {
+	from := time.Date(2022, 10, 20, 19, 0, 0, 0, time.UTC)
+	until := from.Add(time.Hour)
+	_, arrivals, snapshots := randomEvent(from)
+	counts := []db.CountGuestsByStatusRow{
+		{Status: db.GuestArrived, Guests: 1},
+		{Status: db.GuestLeft, Guests: 1},
+		{Status: db.GuestNoShow, Guests: 2},
+	}
+
+	testCases := []analyticsTestCase{
+		{
+			name:  "OK",
+			query: url.Values{"from": {from.Format(time.RFC3339)}, "until": {until.Format(time.RFC3339)}},
+			role:  util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Eq(db.ListEventArrivalsParams{From: from, Until: until})).
+					Times(1).Return(arrivals, nil)
+				store.EXPECT().ListLastOccupancySnapshots(gomock.Any(), gomock.Eq(from)).Times(1).Return([]db.OccupancySnapshot{}, nil)
+				store.EXPECT().ListOccupancySnapshots(gomock.Any(), gomock.Eq(db.ListOccupancySnapshotsParams{From: from, Until: until})).Times(1).Return(snapshots, nil)
+				store.EXPECT().CountGuestsByStatus(gomock.Any()).Times(1).Return(counts, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp analyticsSummaryResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, int64(2), rsp.Arrivals)
+				require.Equal(t, int64(3), rsp.People)
+				require.Equal(t, int32(3), rsp.PeakOccupancy.Occupancy)
+				require.True(t, from.Add(20*time.Minute).Equal(rsp.PeakOccupancy.At))
+				require.Equal(t, int64(1), rsp.CompletedStays)
+				require.Equal(t, float64(30), rsp.AverageStayMinutes)
+				require.Equal(t, db.NoShowSummary{Expected: 4, NoShows: 2, Rate: 0.5}, rsp.NoShows)
+				require.Equal(t, int64(-1), rsp.Entourage.Delta)
+				require.Len(t, rsp.Entourage.Deltas, 1)
+			},
+		},
+		{
+			name: "NoWindow",
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Any()).Times(1).Return(arrivals, nil)
+				store.EXPECT().ListLastOccupancySnapshots(gomock.Any(), gomock.Any()).Times(1).Return([]db.OccupancySnapshot{}, nil)
+				store.EXPECT().ListOccupancySnapshots(gomock.Any(), gomock.Any()).Times(1).Return(snapshots, nil)
+				store.EXPECT().CountGuestsByStatus(gomock.Any()).Times(1).Return(counts, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				// The window opens at the first arrival
+				var rsp analyticsSummaryResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.True(t, arrivals[0].ArrivedAt.Equal(rsp.Window.From))
+			},
+		},
+		{
+			name:  "InvalidWindow",
+			query: url.Values{"from": {until.Format(time.RFC3339)}, "until": {from.Format(time.RFC3339)}},
+			role:  util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidTime",
+			query: url.Values{"from": {"yesterday"}},
+			role:  util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "DoorStaffForbidden",
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+				store.EXPECT().ListLastOccupancySnapshots(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().ListOccupancySnapshots(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	runAnalyticsTests(t, "/analytics/summary", testCases)
+}
+
+func TestArrivalAnalyticsAPI(t *testing.T) {
+	from := time.Date(2022, 10, 20, 19, 0, 0, 0, time.UTC)
+	until := from.Add(time.Hour)
+	_, arrivals, snapshots := randomEvent(from)
+	window := url.Values{"from": {from.Format(time.RFC3339)}, "until": {until.Format(time.RFC3339)}}
+
+	withInterval := func(interval string) url.Values {
+		query := url.Values{"interval_minutes": {interval}}
+		for key, value := range window {
+			query[key] = value
+		}
+		return query
+	}
+
+	testCases := []analyticsTestCase{
+		{
+			name:  "OK",
+			query: window,
+			role:  util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Eq(db.ListEventArrivalsParams{From: from, Until: until})).
+					Times(1).Return(arrivals, nil)
+				store.EXPECT().ListLastOccupancySnapshots(gomock.Any(), gomock.Eq(from)).Times(1).Return([]db.OccupancySnapshot{}, nil)
+				store.EXPECT().ListOccupancySnapshots(gomock.Any(), gomock.Eq(db.ListOccupancySnapshotsParams{From: from, Until: until})).Times(1).Return(snapshots, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp arrivalAnalyticsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, int32(15), rsp.IntervalMinutes)
+				require.Len(t, rsp.Buckets, 4)
+				require.Equal(t, int64(2), rsp.Buckets[0].People)
+				require.Equal(t, int64(1), rsp.Buckets[1].People)
+			},
+		},
+		{
+			name:  "Interval",
+			query: withInterval("30"),
+			role:  util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Any()).Times(1).Return(arrivals, nil)
+				store.EXPECT().ListLastOccupancySnapshots(gomock.Any(), gomock.Any()).Times(1).Return([]db.OccupancySnapshot{}, nil)
+				store.EXPECT().ListOccupancySnapshots(gomock.Any(), gomock.Any()).Times(1).Return(snapshots, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp arrivalAnalyticsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp.Buckets, 2)
+				require.Equal(t, int64(2), rsp.Buckets[0].Arrivals)
+			},
+		},
+		{
+			name: "TooManyBuckets",
+			query: url.Values{
+				"from":             {from.AddDate(0, -1, 0).Format(time.RFC3339)},
+				"until":            {until.Format(time.RFC3339)},
+				"interval_minutes": {"1"},
+			},
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Any()).Times(1).Return(arrivals, nil)
+				store.EXPECT().ListLastOccupancySnapshots(gomock.Any(), gomock.Any()).Times(1).Return([]db.OccupancySnapshot{}, nil)
+				store.EXPECT().ListOccupancySnapshots(gomock.Any(), gomock.Any()).Times(1).Return(snapshots, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidInterval",
+			query: withInterval("0"),
+			role:  util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	runAnalyticsTests(t, "/analytics/arrivals", testCases)
+}
+
+func TestOccupancyAnalyticsAPI(t *testing.T) {
+	from := time.Date(2022, 10, 20, 19, 0, 0, 0, time.UTC)
+	until := from.Add(time.Hour)
+	_, arrivals, snapshots := randomEvent(from)
+
+	testCases := []analyticsTestCase{
+		{
+			name:  "OK",
+			query: url.Values{"from": {from.Format(time.RFC3339)}, "until": {until.Format(time.RFC3339)}},
+			role:  util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Any()).Times(1).Return(arrivals, nil)
+				store.EXPECT().ListLastOccupancySnapshots(gomock.Any(), gomock.Eq(from)).Times(1).Return([]db.OccupancySnapshot{}, nil)
+				store.EXPECT().ListOccupancySnapshots(gomock.Any(), gomock.Eq(db.ListOccupancySnapshotsParams{From: from, Until: until})).Times(1).Return(snapshots, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp occupancyAnalyticsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp.Series, len(snapshots)+1)
+				require.Equal(t, int32(0), rsp.Series[0].Occupancy)
+				require.Equal(t, int32(3), rsp.Peak.Occupancy)
+			},
+		},
+		{
+			name:  "OpensWithLastSnapshot",
+			query: url.Values{"from": {from.Format(time.RFC3339)}, "until": {until.Format(time.RFC3339)}},
+			role:  util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Any()).Times(1).Return(arrivals, nil)
+				store.EXPECT().ListLastOccupancySnapshots(gomock.Any(), gomock.Eq(from)).Times(1).Return([]db.OccupancySnapshot{
+					{ID: 0, TableID: snapshots[0].TableID, TableOccupied: 4, VenueOccupancy: 4, RecordedAt: from.Add(-time.Hour)},
+				}, nil)
+				store.EXPECT().ListOccupancySnapshots(gomock.Any(), gomock.Any()).Times(1).Return(snapshots, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				// The venue was already at 4 when the window opened
+				var rsp occupancyAnalyticsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp.Series, len(snapshots)+1)
+				require.Equal(t, int32(4), rsp.Series[0].Occupancy)
+				require.Equal(t, int32(4), rsp.Peak.Occupancy)
+				require.True(t, from.Equal(rsp.Peak.At))
+			},
+		},
+		{
+			name: "InternalError",
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Any()).Times(1).Return(arrivals, nil)
+				store.EXPECT().ListLastOccupancySnapshots(gomock.Any(), gomock.Any()).Times(1).Return([]db.OccupancySnapshot{}, nil)
+				store.EXPECT().ListOccupancySnapshots(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	runAnalyticsTests(t, "/analytics/occupancy", testCases)
+}
+
+func TestTableAnalyticsAPI(t *testing.T) {
+	from := time.Date(2022, 10, 20, 19, 0, 0, 0, time.UTC)
+	until := from.Add(time.Hour)
+	table, arrivals, snapshots := randomEvent(from)
+
+	testCases := []analyticsTestCase{
+		{
+			name:  "OK",
+			query: url.Values{"from": {from.Format(time.RFC3339)}, "until": {until.Format(time.RFC3339)}},
+			role:  util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Any()).Times(1).Return(arrivals, nil)
+				store.EXPECT().ListLastOccupancySnapshots(gomock.Any(), gomock.Any()).Times(1).Return([]db.OccupancySnapshot{}, nil)
+				store.EXPECT().ListOccupancySnapshots(gomock.Any(), gomock.Any()).Times(1).Return(snapshots, nil)
+				store.EXPECT().ListTables(gomock.Any()).Times(1).Return([]db.Table{table}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				// 2 people for 15 minutes, 3 for 15 and 1 for 25 is 100 seat minutes of 240
+				var rsp tableAnalyticsResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Len(t, rsp.Tables, 1)
+				require.Equal(t, table.ID, rsp.Tables[0].TableID)
+				require.Equal(t, int64(2), rsp.Tables[0].Arrivals)
+				require.Equal(t, int32(3), rsp.Tables[0].PeakOccupied)
+				require.InDelta(t, 100.0/60, rsp.Tables[0].SeatHours, 1e-9)
+				require.InDelta(t, 100.0/240, rsp.Tables[0].Utilisation, 1e-9)
+			},
+		},
+		{
+			name: "InternalError",
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Any()).Times(1).Return(arrivals, nil)
+				store.EXPECT().ListLastOccupancySnapshots(gomock.Any(), gomock.Any()).Times(1).Return([]db.OccupancySnapshot{}, nil)
+				store.EXPECT().ListOccupancySnapshots(gomock.Any(), gomock.Any()).Times(1).Return(snapshots, nil)
+				store.EXPECT().ListTables(gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	runAnalyticsTests(t, "/analytics/tables", testCases)
+}
+
+func runAnalyticsTests(t *testing.T, path string, testCases []analyticsTestCase) {
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, path+"?"+tc.query.Encode(), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, tc.role, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
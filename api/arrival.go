@@ -2,27 +2,51 @@ package api
 
 import (
 	"database/sql"
+	"errors"
 	"net/http"
 
 	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
 	"github.com/gin-gonic/gin"
 )
 
+// Either the entourage or the companions arriving with the guest are given, when both are the
+// entourage must be the number of companions. Seats optionally gives the numbered seats the party
+// sit in, the guest's first followed by those of the companions in order.
 type arriveGuestRequest struct {
-	Entourage int32 `json:"entourage" binding:"required,min=0"`
+	Entourage  *int32  `json:"entourage" binding:"omitempty,min=0"`
+	Companions []int32 `json:"companions" binding:"omitempty,unique,dive,min=1"`
+	Seats      []int32 `json:"seats" binding:"omitempty,unique,dive,min=1"`
 }
 
+// admissionError documents the body written when an arrival is refused, decisions are only given
+// when it's the event's admission policies that turned the party away
+type admissionError struct {
+	Error     string                 `json:"error" example:"admission denied: doors closed at 2026-10-19T23:00:00Z"`
+	Decisions []db.AdmissionDecision `json:"decisions,omitempty"`
+}
+
+// admissionDeniedResponse explains the decision of every admission policy to the door
+func admissionDeniedResponse(err *db.AdmissionDeniedError) admissionError {
+	return admissionError{Error: err.Error(), Decisions: err.Decisions}
+}
+
+// errMissingEntourage is returned when an arrival gives neither the entourage nor the companions
+var errMissingEntourage = errors.New("either entourage or companions is required")
+
 // arriveGuest godoc
 // @Summary Arrives the guest into the party
-// @Description Performs a PUT action to the arrivals table to record an arrival of the guest and their party.
+// @Description Performs a PUT action to the arrivals table to record an arrival of the guest and their party. The party is given either as an entourage count or as the IDs of the named companions arriving. A guest who has left may re-enter in a new arrival session unless the event denies re-entry. The party must pass every one of the event's admission policies, by default that they fit in the free seats at the table, and a denial is a 409 explaining the decision of each, unless the only policy turning them away is that they don't fit, which is a 400. The venue, and the zone of the guest's table, must be under their maximum occupancy. The party can be given numbered seats, the guest's first followed by the companions', otherwise they keep any seats reserved at booking and are given the free seats closest together. A guest who has since been put on the ban list is refused with a 403 and an alert is raised.
 // @Accept json
 // @Produce json
-// @Param        name        path       string  true  "Guest Name"
-// @Param        entourage   body       int     true  "Entourage (May be different to original)"
-// @Success 200 {object} string
-// @Failure 400 {object} httputil.HTTPError
-// @Failure 404 {object} httputil.HTTPError
-// @Failure 500 {object} httputil.HTTPError
+// @Param        name        path       string              true  "Guest Name"
+// @Param        request     body       arriveGuestRequest  true  "Entourage (May be different to original) or arriving companions"
+// @Success 200 {string} string
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 409 {object} admissionError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
 // @Router /guests/{name} [put]
 func (server *Server) arriveGuest(ctx *gin.Context) {
 	var reqName getGuestFromNameRequest
@@ -37,6 +61,21 @@ func (server *Server) arriveGuest(ctx *gin.Context) {
 		return
 	}
 
+	var entourage int32
+	switch {
+	case reqEntourage.Companions != nil:
+		entourage = int32(len(reqEntourage.Companions))
+		if reqEntourage.Entourage != nil && *reqEntourage.Entourage != entourage {
+			ctx.JSON(http.StatusBadRequest, errorResponse(db.ErrInvalidCompanions))
+			return
+		}
+	case reqEntourage.Entourage != nil:
+		entourage = *reqEntourage.Entourage
+	default:
+		ctx.JSON(http.StatusBadRequest, errorResponse(errMissingEntourage))
+		return
+	}
+
 	guest, err := server.store.GetGuestFromName(ctx, reqName.Name)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -50,32 +89,114 @@ func (server *Server) arriveGuest(ctx *gin.Context) {
 	arg := db.AssignTableTxParams{
 		UserID:       int64(guest.ID),
 		TableID:      int64(guest.TableID),
-		NewEntourage: int64(reqEntourage.Entourage),
+		NewEntourage: int64(entourage),
+		CompanionIDs: reqEntourage.Companions,
+		Seats:        reqEntourage.Seats,
+		AuditInfo:    auditInfo(ctx),
 	}
 
 	assignTableResult, err := server.store.AssignTableTx(ctx, arg)
 	if err != nil {
-		if err.Error() == db.InsufficientTableSizeErr(int(guest.TableID)).Error() {
+		var denied *db.AdmissionDeniedError
+		switch {
+		case errors.As(err, &denied) && !denied.TableCapacityOnly():
+			ctx.JSON(http.StatusConflict, admissionDeniedResponse(denied))
+		case errors.Is(err, db.ErrBanned):
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+		case errors.Is(err, db.ErrInsufficientTableSize), errors.Is(err, db.ErrPartySize), errors.Is(err, db.ErrInvalidCompanions),
+			errors.Is(err, db.ErrInvalidSeats):
 			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		case err == db.ErrGuestAlreadyArrived, err == db.ErrReentryDenied, errors.Is(err, db.ErrIllegalTransition),
+			errors.Is(err, db.ErrVenueFull), errors.Is(err, db.ErrZoneFull), errors.Is(err, db.ErrSeatTaken):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+	ctx.JSON(http.StatusOK, assignTableResult.Guest.GuestName)
+}
+
+// Entourage has no "required" binding as that would reject shrinking to the guest alone,
+// its presence is enforced by the openapi spec instead
+type updatePartyRequest struct {
+	Entourage int32 `json:"entourage" binding:"min=0"`
+}
+
+// updateParty godoc
+// @Summary Grows or shrinks the party of an arrived guest
+// @Description Changes the entourage of a guest who is already seated, e.g. when a latecomer joins them or some of their party leave early. The party must be within the sizes the table takes. A bigger party must pass the event's admission policies, against the entourage the guest was booked with, and be within the maximum occupancy of the venue and zone, a smaller one frees up its seats and the named companions beyond the new entourage. Every change is kept in the history of the arrival.
+// @Accept json
+// @Produce json
+// @Param        name        path       string              true  "Guest Name"
+// @Param        request     body       updatePartyRequest  true  "New entourage"
+// @Success 200 {object} db.UpdatePartyTxResult
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 409 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /guests/{name}/party [patch]
+func (server *Server) updateParty(ctx *gin.Context) {
+	var reqName getGuestFromNameRequest
+	if err := ctx.ShouldBindUri(&reqName); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req updatePartyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	guest, err := server.store.GetGuestFromName(ctx, reqName.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
 			return
 		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
-	ctx.JSON(http.StatusOK, assignTableResult.Guest.GuestName)
+
+	result, err := server.store.UpdatePartyTx(ctx, db.UpdatePartyTxParams{
+		AuditInfo:    auditInfo(ctx),
+		ID:           guest.ID,
+		NewEntourage: req.Entourage,
+	})
+	if err != nil {
+		var denied *db.AdmissionDeniedError
+		switch {
+		case errors.As(err, &denied) && !denied.TableCapacityOnly():
+			ctx.JSON(http.StatusConflict, admissionDeniedResponse(denied))
+		case errors.Is(err, db.ErrInsufficientTableSize), errors.Is(err, db.ErrPartySize):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		case err == db.ErrGuestNotArrived, errors.Is(err, db.ErrVenueFull), errors.Is(err, db.ErrZoneFull):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
 }
 
 // getArrivedGuests godoc
 // @Summary returns all guests already arrived
-// @Description Fetches an array of guest object ([]Guest), who have already undergone an arrival event. The requests are paginated with a minimum page_id of 1 and page_size of 5-20. Running a make test will generate some default data via the mysql unit tests.
+// @Description Fetches an array of guest object ([]Guest), who have already undergone an arrival event. The requests are paginated with a minimum page_id of 1 and page_size of 5-10. The guests' email and phone are left out for viewers. Running a make test will generate some default data via the mysql unit tests.
 // @Accept json
 // @Produce json
 // @Param        page_id     query      int  true  "Page ID"
 // @Param        page_size   query      int  true  "Page Size"
-// @Success 200 {object} []db.Guest
-// @Failure 400 {object} httputil.HTTPError
-// @Failure 500 {object} httputil.HTTPError
-// @Router /guests/ [get]
+// @Success 200 {object} []guestResponse
+// @Failure 400 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /guests [get]
 func (server *Server) getArrivedGuests(ctx *gin.Context) {
 	var req getGuestsRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
@@ -93,5 +214,5 @@ func (server *Server) getArrivedGuests(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, guests)
+	ctx.JSON(http.StatusOK, newGuestsResponse(ctx, guests))
 }
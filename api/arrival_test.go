@@ -107,7 +107,7 @@ func TestGetArrivedGuests(t *testing.T) {
 			store := mockdb.NewMockStore(controller)
 			tc.buildStubs(store)
 
-			server := NewServer(store)
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
 			req, err := http.NewRequest(http.MethodGet, "/guests", nil)
@@ -117,6 +117,7 @@ func TestGetArrivedGuests(t *testing.T) {
 			params.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
 			req.URL.RawQuery = params.Encode()
 
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
 			server.router.ServeHTTP(recorder, req)
 			tc.checkResponse(recorder)
 		})
@@ -150,6 +151,7 @@ func TestPutArrivedGuest(t *testing.T) {
 					UserID:       int64(guest.ID),
 					TableID:      int64(guest.TableID),
 					NewEntourage: int64(guest.Entourage),
+					AuditInfo:    testAudit,
 				})).
 					Times(1).
 					Return(createAssignTxTableResult(guest, table, int(guest.Entourage)), nil)
@@ -172,15 +174,93 @@ func TestPutArrivedGuest(t *testing.T) {
 					UserID:       int64(guest.ID),
 					TableID:      int64(guest.TableID),
 					NewEntourage: int64(guest.Entourage + table.Size),
+					AuditInfo:    testAudit,
 				})).
 					Times(1).
-					Return(db.AssignTableTxResult{}, db.InsufficientTableSizeErr(int(guest.TableID)))
+					Return(db.AssignTableTxResult{}, &db.AdmissionDeniedError{Decisions: []db.AdmissionDecision{
+						{Policy: db.PolicyTableCapacity, Allowed: false, Reason: "party doesn't fit"},
+					}})
 				gomock.InOrder(first, second)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
+		{
+			name:      "TableFullAndOverBooking",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"entourage": guest.Entourage + table.Size,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().AssignTableTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AssignTableTxResult{}, &db.AdmissionDeniedError{Decisions: []db.AdmissionDecision{
+						{Policy: db.PolicyTableCapacity, Allowed: false, Reason: "party doesn't fit"},
+						{Policy: db.PolicyRSVPLimit, Allowed: false, Reason: "entourage is over the booking"},
+					}})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+
+				var got admissionError
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got.Decisions, 2)
+			},
+		},
+		{
+			name:      "AdmissionDenied",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"entourage": guest.Entourage + 1,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				first := store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				second := store.EXPECT().AssignTableTx(gomock.Any(), gomock.Eq(db.AssignTableTxParams{
+					UserID:       int64(guest.ID),
+					TableID:      int64(guest.TableID),
+					NewEntourage: int64(guest.Entourage + 1),
+					AuditInfo:    testAudit,
+				})).
+					Times(1).
+					Return(db.AssignTableTxResult{}, &db.AdmissionDeniedError{Decisions: []db.AdmissionDecision{
+						{Policy: db.PolicyTableCapacity, Allowed: true, Reason: "party fits"},
+						{Policy: db.PolicyRSVPLimit, Allowed: false, Reason: "entourage is over the booking"},
+					}})
+				gomock.InOrder(first, second)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+
+				var got admissionError
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, "admission denied: entourage is over the booking", got.Error)
+				require.Len(t, got.Decisions, 2)
+				require.False(t, got.Decisions[1].Allowed)
+			},
+		},
+		{
+			name:      "Banned",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"entourage": guest.Entourage,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().AssignTableTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AssignTableTxResult{Guest: guest}, &db.BannedError{
+						GuestName: guest.GuestName,
+						Person:    db.BannedPerson{ID: 7, Name: guest.GuestName},
+						Match:     db.BanMatchName,
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "banned person 7")
+			},
+		},
 		{
 			name:      "NoNameFound",
 			guestName: "InvalidUser",
@@ -218,6 +298,7 @@ func TestPutArrivedGuest(t *testing.T) {
 					UserID:       int64(guest.ID),
 					TableID:      int64(guest.TableID),
 					NewEntourage: int64(guest.Entourage),
+					AuditInfo:    testAudit,
 				})).
 					Times(1).
 					Return(db.AssignTableTxResult{}, sql.ErrConnDone)
@@ -226,6 +307,150 @@ func TestPutArrivedGuest(t *testing.T) {
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
+		}, {
+			name:      "ReentryDenied",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"entourage": guest.Entourage,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().AssignTableTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AssignTableTxResult{}, db.ErrReentryDenied)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		}, {
+			name:      "VenueFull",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"entourage": guest.Entourage,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().AssignTableTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AssignTableTxResult{}, &db.CapacityError{MaxOccupancy: 100, Occupancy: 99, PartySize: 2})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		}, {
+			name:      "ByCompanions",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"companions": []int32{3, 7},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().AssignTableTx(gomock.Any(), gomock.Eq(db.AssignTableTxParams{
+					UserID:       int64(guest.ID),
+					TableID:      int64(guest.TableID),
+					NewEntourage: 2,
+					CompanionIDs: []int32{3, 7},
+					AuditInfo:    testAudit,
+				})).
+					Times(1).
+					Return(createAssignTxTableResult(guest, table, 2), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		}, {
+			name:      "WithSeats",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"companions": []int32{3, 7},
+				"seats":      []int32{5, 6, 4},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().AssignTableTx(gomock.Any(), gomock.Eq(db.AssignTableTxParams{
+					UserID:       int64(guest.ID),
+					TableID:      int64(guest.TableID),
+					NewEntourage: 2,
+					CompanionIDs: []int32{3, 7},
+					Seats:        []int32{5, 6, 4},
+					AuditInfo:    testAudit,
+				})).
+					Times(1).
+					Return(createAssignTxTableResult(guest, table, 2), nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		}, {
+			name:      "WrongNumberOfSeats",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"entourage": 2,
+				"seats":     []int32{5, 6},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().AssignTableTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AssignTableTxResult{}, fmt.Errorf("2 seats requested for a party of 3: %w", db.ErrInvalidSeats))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		}, {
+			name:      "SeatTaken",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"entourage": 0,
+				"seats":     []int32{5},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().AssignTableTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AssignTableTxResult{}, &db.SeatTakenError{TableID: guest.TableID, Number: 5})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		}, {
+			name:      "CompanionsDontMatchEntourage",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"entourage":  1,
+				"companions": []int32{3, 7},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AssignTableTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		}, {
+			name:      "UnknownCompanion",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"companions": []int32{3},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().AssignTableTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AssignTableTxResult{}, fmt.Errorf("companion 3: %w", db.ErrInvalidCompanions))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		}, {
+			name:      "MissingEntourage",
+			guestName: guest.GuestName,
+			body:      gin.H{},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AssignTableTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
 		}, {
 			name:      "InvalidEntourageValue",
 			guestName: guest.GuestName,
@@ -263,7 +488,7 @@ func TestPutArrivedGuest(t *testing.T) {
 			store := mockdb.NewMockStore(controller)
 			tc.buildStubs(store)
 
-			server := NewServer(store)
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
 			data, err := json.Marshal(tc.body)
@@ -273,6 +498,196 @@ func TestPutArrivedGuest(t *testing.T) {
 			req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
 			require.NoError(t, err)
 
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUpdatePartyAPI(t *testing.T) {
+	table := randomTable()
+	guest := randomGuest()
+	guest.TableID = table.ID
+	guest.Status = db.GuestArrived
+
+	grown := guest
+	grown.Entourage = guest.Entourage + 1
+	arrival := createArrival(guest.ID, table.ID, grown.Entourage+1)
+
+	grownTable := table
+	grownTable.Occupied = table.Occupied + 1
+
+	result := db.UpdatePartyTxResult{
+		Guest:   grown,
+		Arrival: arrival,
+		Table:   grownTable,
+		Seats:   randomSeats(grown, table, arrival.PartySize),
+		History: []db.PartyChange{{
+			ID:           util.RandomInt(1, 1000),
+			ArrivalID:    arrival.ID,
+			OldPartySize: guest.Entourage + 1,
+			NewPartySize: grown.Entourage + 1,
+			ChangedBy:    testUsername,
+			ChangedAt:    time.Now().Truncate(time.Second).UTC(),
+		}},
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"entourage": grown.Entourage},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().
+					UpdatePartyTx(gomock.Any(), gomock.Eq(db.UpdatePartyTxParams{
+						AuditInfo:    testAudit,
+						ID:           guest.ID,
+						NewEntourage: grown.Entourage,
+					})).
+					Times(1).
+					Return(result, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.UpdatePartyTxResult
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, result.Guest, got.Guest)
+				require.Equal(t, result.Seats, got.Seats)
+				require.Equal(t, result.History, got.History)
+			},
+		},
+		{
+			name: "TableFull",
+			body: gin.H{"entourage": grown.Entourage},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().
+					UpdatePartyTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UpdatePartyTxResult{}, db.InsufficientTableSizeErr(int(table.ID)))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "PartySize",
+			body: gin.H{"entourage": grown.Entourage},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().
+					UpdatePartyTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UpdatePartyTxResult{}, &db.PartySizeError{TableID: table.ID, PartySize: grown.Entourage + 1, MinParty: 1, MaxParty: grown.Entourage})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "AdmissionDenied",
+			body: gin.H{"entourage": grown.Entourage},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().
+					UpdatePartyTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UpdatePartyTxResult{}, &db.AdmissionDeniedError{Decisions: []db.AdmissionDecision{
+						{Policy: db.PolicyTableCapacity, Allowed: true, Reason: "party fits"},
+						{Policy: db.PolicyRSVPLimit, Allowed: false, Reason: "entourage is over the booking"},
+					}})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+
+				var got admissionError
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got.Decisions, 2)
+				require.False(t, got.Decisions[1].Allowed)
+			},
+		},
+		{
+			name: "ZoneFull",
+			body: gin.H{"entourage": grown.Entourage},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().
+					UpdatePartyTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UpdatePartyTxResult{}, &db.CapacityError{Zone: "terrace", MaxOccupancy: 20, Occupancy: 20, PartySize: 1})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotArrived",
+			body: gin.H{"entourage": grown.Entourage},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().
+					UpdatePartyTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.UpdatePartyTxResult{}, db.ErrGuestNotArrived)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NegativeEntourage",
+			body: gin.H{"entourage": -1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdatePartyTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{"entourage": grown.Entourage},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(db.Guest{}, sql.ErrNoRows)
+				store.EXPECT().
+					UpdatePartyTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/guests/%s/party", guest.GuestName)
+			req, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, util.DoorStaffRole, time.Minute)
 			server.router.ServeHTTP(recorder, req)
 			tc.checkResponse(recorder)
 		})
@@ -294,5 +709,6 @@ func createArrival(guestID int32, tableID int32, partySize int32) db.Arrival {
 		GuestID:   guestID,
 		TableID:   tableID,
 		PartySize: partySize,
+		ArrivedAt: time.Now().Truncate(time.Second).UTC(),
 	}
 }
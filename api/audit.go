@@ -0,0 +1,65 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+	"time"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+// Every filter is optional, an unset one matches all events
+type listAuditEventsRequest struct {
+	GuestName string    `form:"guest_name"`
+	TableID   int32     `form:"table_id" binding:"min=0"`
+	Actor     string    `form:"actor"`
+	From      time.Time `form:"from"`
+	To        time.Time `form:"to"`
+	PageID    int32     `form:"page_id" binding:"required,min=1"`
+	PageSize  int32     `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listAuditEvents godoc
+// @Summary returns the audit trail of changes to guests and tables
+// @Description Fetches an array of audit events ([]AuditEvent) oldest first, each holding the actor, action, request ID and the before/after state of the guest or table. Events can be filtered by guest name, table ID, actor and a [from, to) time range, and are paginated with a minimum page_id of 1 and page_size of 5-10.
+// @Accept json
+// @Produce json
+// @Param        guest_name  query      string  false  "Guest Name"
+// @Param        table_id    query      int     false  "Table ID"
+// @Param        actor       query      string  false  "Username of whoever made the change"
+// @Param        from        query      string  false  "Earliest event time (RFC 3339)"
+// @Param        to          query      string  false  "Time events must precede (RFC 3339)"
+// @Param        page_id     query      int     true   "Page ID"
+// @Param        page_size   query      int     true   "Page Size"
+// @Success 200 {object} []db.AuditEvent
+// @Failure 400 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /audit [get]
+func (server *Server) listAuditEvents(ctx *gin.Context) {
+	var req listAuditEventsRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListAuditEventsParams{
+		GuestName: sql.NullString{String: req.GuestName, Valid: req.GuestName != ""},
+		TableID:   sql.NullInt32{Int32: req.TableID, Valid: req.TableID != 0},
+		Actor:     sql.NullString{String: req.Actor, Valid: req.Actor != ""},
+		From:      sql.NullTime{Time: req.From, Valid: !req.From.IsZero()},
+		To:        sql.NullTime{Time: req.To, Valid: !req.To.IsZero()},
+		Limit:     req.PageSize,
+		Offset:    (req.PageID - 1) * req.PageSize,
+	}
+
+	events, err := server.store.ListAuditEvents(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, events)
+}
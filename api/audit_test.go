@@ -0,0 +1,164 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListAuditEventsAPI(t *testing.T) {
+	guest := randomGuest()
+	from := time.Date(2022, 10, 20, 18, 0, 0, 0, time.UTC)
+	to := from.Add(6 * time.Hour)
+
+	events := []db.AuditEvent{
+		randomAuditEvent(guest, db.AuditCreateGuest, nil, json.RawMessage(`{"guest":{"id":1}}`)),
+		randomAuditEvent(guest, db.AuditDeleteGuest, json.RawMessage(`{"guest":{"id":1}}`), nil),
+	}
+
+	testCases := []struct {
+		name          string
+		query         url.Values
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			query: url.Values{
+				"guest_name": {guest.GuestName},
+				"actor":      {testUsername},
+				"from":       {from.Format(time.RFC3339)},
+				"to":         {to.Format(time.RFC3339)},
+				"page_id":    {"2"},
+				"page_size":  {"5"},
+			},
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAuditEvents(gomock.Any(), gomock.Eq(db.ListAuditEventsParams{
+					GuestName: sql.NullString{String: guest.GuestName, Valid: true},
+					Actor:     sql.NullString{String: testUsername, Valid: true},
+					From:      sql.NullTime{Time: from, Valid: true},
+					To:        sql.NullTime{Time: to, Valid: true},
+					Limit:     5,
+					Offset:    5,
+				})).Times(1).Return(events, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchAuditEvents(t, recorder.Body, events)
+			},
+		},
+		{
+			name:  "NoFilters",
+			query: url.Values{"page_id": {"1"}, "page_size": {"10"}},
+			role:  util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAuditEvents(gomock.Any(), gomock.Eq(db.ListAuditEventsParams{Limit: 10})).
+					Times(1).Return([]db.AuditEvent{}, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidTime",
+			query: url.Values{"from": {"yesterday"}, "page_id": {"1"}, "page_size": {"10"}},
+			role:  util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAuditEvents(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "DoorStaffForbidden",
+			query: url.Values{"page_id": {"1"}, "page_size": {"10"}},
+			role:  util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAuditEvents(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:  "InternalError",
+			query: url.Values{"page_id": {"1"}, "page_size": {"10"}},
+			role:  util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListAuditEvents(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, "/audit?"+tc.query.Encode(), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, tc.role, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func randomAuditEvent(guest db.Guest, action string, before, after json.RawMessage) db.AuditEvent {
+	return db.AuditEvent{
+		ID:        util.RandomInt(1, 1000),
+		Action:    action,
+		Actor:     testUsername,
+		RequestID: util.RandomString(16),
+		GuestID:   sql.NullInt32{Int32: guest.ID, Valid: true},
+		GuestName: guest.GuestName,
+		TableID:   sql.NullInt32{Int32: guest.TableID, Valid: true},
+		Before:    before,
+		After:     after,
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+}
+
+// requireBodyMatchAuditEvents requires the responder body to hold the expected audit events
+func requireBodyMatchAuditEvents(t *testing.T, body *bytes.Buffer, events []db.AuditEvent) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var eventsFetched []db.AuditEvent
+	err = json.Unmarshal(data, &eventsFetched)
+	require.NoError(t, err)
+	require.Len(t, eventsFetched, len(events))
+	for i := range events {
+		require.Equal(t, events[i].ID, eventsFetched[i].ID)
+		require.Equal(t, events[i].Action, eventsFetched[i].Action)
+		require.Equal(t, events[i].RequestID, eventsFetched[i].RequestID)
+		require.Equal(t, events[i].GuestID, eventsFetched[i].GuestID)
+		require.True(t, events[i].CreatedAt.Equal(eventsFetched[i].CreatedAt))
+	}
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"database/sql"
+	"net/http"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+// Email and phone are optional, a person is always matched by name
+type banPersonRequest struct {
+	Name   string `json:"name" binding:"required,min=5,max=255"`
+	Email  string `json:"email" binding:"omitempty,email,max=255"`
+	Phone  string `json:"phone" binding:"max=32"`
+	Reason string `json:"reason" binding:"required,max=1024"`
+}
+
+// banPerson godoc
+// @Summary Adds a person to the ban list
+// @Description Guests matching the person can no longer be added to the guest list or arrive, both are refused with a 403 and raise a ban_match alert in the audit log. Guests are matched by email or phone when the person has them, and otherwise by name: exactly once accents, case, punctuation and the order of the words are ignored, or within a letter or two for longer names.
+// @Accept json
+// @Produce json
+// @Param    request  body      banPersonRequest  true  "Name, optionally email and phone, and the reason for the ban"
+// @Success 200 {object} db.BannedPerson
+// @Failure 400 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /bans [post]
+func (server *Server) banPerson(ctx *gin.Context) {
+	var req banPersonRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	person, err := server.store.BanPersonTx(ctx, db.BanPersonTxParams{
+		AuditInfo: auditInfo(ctx),
+		Name:      req.Name,
+		Email:     req.Email,
+		Phone:     req.Phone,
+		Reason:    req.Reason,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, person)
+}
+
+type listBansRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listBans godoc
+// @Summary returns the ban list
+// @Description Fetches an array of banned people ([]BannedPerson) in the order they were banned, paginated with a minimum page_id of 1 and page_size of 5-10.
+// @Accept json
+// @Produce json
+// @Param        page_id     query      int     true   "Page ID"
+// @Param        page_size   query      int     true   "Page Size"
+// @Success 200 {object} []db.BannedPerson
+// @Failure 400 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /bans [get]
+func (server *Server) listBans(ctx *gin.Context) {
+	var req listBansRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	bans, err := server.store.ListBannedPeople(ctx, db.ListBannedPeopleParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, bans)
+}
+
+type banRequestURI struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+// unbanPerson godoc
+// @Summary Removes a person from the ban list
+// @Description Guests matching the person can be added to the guest list and arrive again.
+// @Accept json
+// @Produce json
+// @Param    id       path      int     true  "Banned person ID"
+// @Success 200 {string} string
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /bans/{id} [delete]
+func (server *Server) unbanPerson(ctx *gin.Context) {
+	var uri banRequestURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	err := server.store.UnbanPersonTx(ctx, db.UnbanPersonTxParams{
+		AuditInfo: auditInfo(ctx),
+		ID:        uri.ID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, "unbanned")
+}
@@ -0,0 +1,227 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func randomBannedPerson() db.BannedPerson {
+	return db.BannedPerson{
+		ID:        int32(util.RandomInt(1, 1000)),
+		Name:      util.RandomGuestName(),
+		Email:     fmt.Sprintf("%s@example.com", util.RandomString(6)),
+		Reason:    "Fought the bouncers",
+		CreatedBy: testUsername,
+		CreatedAt: time.Now().Truncate(time.Second).UTC(),
+	}
+}
+
+func TestBanPersonAPI(t *testing.T) {
+	person := randomBannedPerson()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"name": person.Name, "email": person.Email, "reason": person.Reason},
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					BanPersonTx(gomock.Any(), gomock.Eq(db.BanPersonTxParams{
+						AuditInfo: testAudit,
+						Name:      person.Name,
+						Email:     person.Email,
+						Reason:    person.Reason,
+					})).
+					Times(1).
+					Return(person, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.BannedPerson
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, person, got)
+			},
+		},
+		{
+			name: "MissingReason",
+			body: gin.H{"name": person.Name},
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().BanPersonTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidEmail",
+			body: gin.H{"name": person.Name, "email": "not-an-email", "reason": person.Reason},
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().BanPersonTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "DoorStaff",
+			body: gin.H{"name": person.Name, "reason": person.Reason},
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().BanPersonTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			body: gin.H{"name": person.Name, "reason": person.Reason},
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().BanPersonTx(gomock.Any(), gomock.Any()).Times(1).Return(db.BannedPerson{}, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/bans", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, tc.role, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestListBansAPI(t *testing.T) {
+	bans := []db.BannedPerson{randomBannedPerson(), randomBannedPerson()}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().
+		ListBannedPeople(gomock.Any(), gomock.Eq(db.ListBannedPeopleParams{Limit: 5, Offset: 5})).
+		Times(1).
+		Return(bans, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/bans?page_id=2&page_size=5", nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got []db.BannedPerson
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Equal(t, bans, got)
+}
+
+func TestUnbanPersonAPI(t *testing.T) {
+	person := randomBannedPerson()
+
+	testCases := []struct {
+		name          string
+		id            int32
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			id:   person.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UnbanPersonTx(gomock.Any(), gomock.Eq(db.UnbanPersonTxParams{AuditInfo: testAudit, ID: person.ID})).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			id:   person.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UnbanPersonTx(gomock.Any(), gomock.Any()).Times(1).Return(sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidID",
+			id:   0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().UnbanPersonTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/bans/%d", tc.id), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
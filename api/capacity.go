@@ -0,0 +1,241 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+// capacityUsage is the headcount of the venue, or of a zone when Zone is set, against its limit.
+// A MaxOccupancy of 0 means there is no limit.
+type capacityUsage struct {
+	Zone         string `json:"zone,omitempty"`
+	MaxOccupancy int32  `json:"max_occupancy"`
+	Occupancy    int64  `json:"occupancy"`
+}
+
+type capacityResponse struct {
+	Venue capacityUsage   `json:"venue"`
+	Zones []capacityUsage `json:"zones"`
+}
+
+// getCapacity godoc
+// @Summary returns the occupancy of the venue and its zones
+// @Description Fetches the headcount of the venue, seated guests and their entourage plus standing admissions, and of each zone with seated guests or a limit of its own, against their maximum occupancy. A max_occupancy of 0 means there is no limit.
+// @Accept json
+// @Produce json
+// @Success 200 {object} capacityResponse
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /capacity [get]
+func (server *Server) getCapacity(ctx *gin.Context) {
+	limits, err := server.store.ListCapacityLimits(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	occupancy, err := server.store.GetVenueOccupancy(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	zoneOccupancies, err := server.store.GetZoneOccupancies(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := capacityResponse{
+		Venue: capacityUsage{Occupancy: occupancy},
+		Zones: []capacityUsage{},
+	}
+	zones := map[string]int{}
+	for _, zone := range zoneOccupancies {
+		zones[zone.Zone] = len(rsp.Zones)
+		rsp.Zones = append(rsp.Zones, capacityUsage{Zone: zone.Zone, Occupancy: zone.Occupancy})
+	}
+	for _, limit := range limits {
+		if limit.Zone == "" {
+			rsp.Venue.MaxOccupancy = limit.MaxOccupancy
+			continue
+		}
+		i, ok := zones[limit.Zone]
+		if !ok {
+			i = len(rsp.Zones)
+			rsp.Zones = append(rsp.Zones, capacityUsage{Zone: limit.Zone})
+		}
+		rsp.Zones[i].MaxOccupancy = limit.MaxOccupancy
+	}
+
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// Zone is left out to set the limit of the venue as a whole. MaxOccupancy has no "required"
+// binding as that would reject removing a limit with 0, its presence is enforced by the openapi
+// spec instead.
+type setCapacityRequest struct {
+	Zone         string `json:"zone" binding:"max=255"`
+	MaxOccupancy int32  `json:"max_occupancy" binding:"min=0"`
+}
+
+// setCapacity godoc
+// @Summary Sets the maximum occupancy of the venue or a zone
+// @Description Sets the legal headcount limit of the venue, or of the zone given, which arrivals, party changes and standing admissions are checked against alongside the table's own size. A max_occupancy of 0 removes the limit. Lowering a limit below the current headcount doesn't remove anybody, it only stops admissions until enough people leave.
+// @Accept json
+// @Produce json
+// @Param    request  body      setCapacityRequest  true  "Zone and maximum occupancy"
+// @Success 200 {object} db.CapacityLimit
+// @Failure 400 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /capacity [put]
+func (server *Server) setCapacity(ctx *gin.Context) {
+	var req setCapacityRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	limit, err := server.store.SetCapacityLimitTx(ctx, db.SetCapacityLimitTxParams{
+		AuditInfo:    auditInfo(ctx),
+		Zone:         req.Zone,
+		MaxOccupancy: req.MaxOccupancy,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, limit)
+}
+
+type listStandingRequest struct {
+	PageID   int32 `form:"page_id" binding:"required,min=1"`
+	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
+}
+
+// listStanding godoc
+// @Summary returns the standing parties still in the venue
+// @Description Fetches an array of standing admissions ([]StandingAdmission) which haven't left, oldest first. The requests are paginated with a minimum page_id of 1 and page_size of 5-10.
+// @Accept json
+// @Produce json
+// @Param        page_id     query      int  true  "Page ID"
+// @Param        page_size   query      int  true  "Page Size"
+// @Success 200 {object} []db.StandingAdmission
+// @Failure 400 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /standing [get]
+func (server *Server) listStanding(ctx *gin.Context) {
+	var req listStandingRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	admissions, err := server.store.ListOpenStandingAdmissions(ctx, db.ListOpenStandingAdmissionsParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, admissions)
+}
+
+// Name is optional, e.g. for a walk-up party nobody asked the name of
+type admitStandingRequest struct {
+	Name      string `json:"name" binding:"max=255"`
+	PartySize int32  `json:"party_size" binding:"required,min=1"`
+}
+
+// admitStanding godoc
+// @Summary Admits a party without a table
+// @Description Lets a standing party in, e.g. to the bar, who count towards the venue's maximum occupancy until they leave. They don't count towards any zone, which only hold seated guests.
+// @Accept json
+// @Produce json
+// @Param    request  body      admitStandingRequest  true  "Name and party size"
+// @Success 200 {object} db.StandingAdmission
+// @Failure 400 {object} httpError
+// @Failure 409 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /standing [post]
+func (server *Server) admitStanding(ctx *gin.Context) {
+	var req admitStandingRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	admission, err := server.store.AdmitStandingTx(ctx, db.AdmitStandingTxParams{
+		AuditInfo: auditInfo(ctx),
+		Name:      req.Name,
+		PartySize: req.PartySize,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrVenueFull) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, admission)
+}
+
+type standingRequestURI struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+// departStanding godoc
+// @Summary Records a standing party leaving
+// @Description Ends the standing admission, freeing its share of the venue's maximum occupancy.
+// @Accept json
+// @Produce json
+// @Param    id  path  int  true  "Standing admission ID"
+// @Success 200 {object} db.StandingAdmission
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 409 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /standing/{id} [delete]
+func (server *Server) departStanding(ctx *gin.Context) {
+	var req standingRequestURI
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	admission, err := server.store.DepartStandingTx(ctx, db.DepartStandingTxParams{
+		AuditInfo: auditInfo(ctx),
+		ID:        req.ID,
+	})
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case err == db.ErrStandingDeparted:
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, admission)
+}
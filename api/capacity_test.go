@@ -0,0 +1,338 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCapacityAPI(t *testing.T) {
+	now := time.Now().Truncate(time.Second).UTC()
+	limits := []db.CapacityLimit{
+		{Zone: "", MaxOccupancy: 200, UpdatedBy: testUsername, UpdatedAt: now},
+		{Zone: "mezzanine", MaxOccupancy: 30, UpdatedBy: testUsername, UpdatedAt: now},
+		{Zone: "terrace", MaxOccupancy: 40, UpdatedBy: testUsername, UpdatedAt: now},
+	}
+	zones := []db.GetZoneOccupanciesRow{
+		{Zone: "garden", Occupancy: 12},
+		{Zone: "terrace", Occupancy: 25},
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().ListCapacityLimits(gomock.Any()).Times(1).Return(limits, nil)
+	store.EXPECT().GetVenueOccupancy(gomock.Any()).Times(1).Return(int64(45), nil)
+	store.EXPECT().GetZoneOccupancies(gomock.Any()).Times(1).Return(zones, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/capacity", nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, util.ViewerRole, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got capacityResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Equal(t, capacityUsage{MaxOccupancy: 200, Occupancy: 45}, got.Venue)
+	require.Equal(t, []capacityUsage{
+		{Zone: "garden", Occupancy: 12},
+		{Zone: "terrace", MaxOccupancy: 40, Occupancy: 25},
+		{Zone: "mezzanine", MaxOccupancy: 30},
+	}, got.Zones)
+}
+
+func TestSetCapacityAPI(t *testing.T) {
+	limit := db.CapacityLimit{
+		Zone:         "terrace",
+		MaxOccupancy: 40,
+		UpdatedBy:    testUsername,
+		UpdatedAt:    time.Now().Truncate(time.Second).UTC(),
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"zone": limit.Zone, "max_occupancy": limit.MaxOccupancy},
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetCapacityLimitTx(gomock.Any(), gomock.Eq(db.SetCapacityLimitTxParams{
+						AuditInfo:    testAudit,
+						Zone:         limit.Zone,
+						MaxOccupancy: limit.MaxOccupancy,
+					})).
+					Times(1).
+					Return(limit, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.CapacityLimit
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, limit, got)
+			},
+		},
+		{
+			name: "RemoveVenueLimit",
+			body: gin.H{"max_occupancy": 0},
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					SetCapacityLimitTx(gomock.Any(), gomock.Eq(db.SetCapacityLimitTxParams{AuditInfo: testAudit})).
+					Times(1).
+					Return(db.CapacityLimit{UpdatedBy: testUsername, UpdatedAt: limit.UpdatedAt}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "MissingMaxOccupancy",
+			body: gin.H{"zone": limit.Zone},
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetCapacityLimitTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NegativeMaxOccupancy",
+			body: gin.H{"max_occupancy": -1},
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetCapacityLimitTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "DoorStaff",
+			body: gin.H{"max_occupancy": limit.MaxOccupancy},
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetCapacityLimitTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPut, "/capacity", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, tc.role, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestAdmitStandingAPI(t *testing.T) {
+	admission := randomStandingAdmission()
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"name": admission.Name, "party_size": admission.PartySize},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					AdmitStandingTx(gomock.Any(), gomock.Eq(db.AdmitStandingTxParams{
+						AuditInfo: testAudit,
+						Name:      admission.Name,
+						PartySize: admission.PartySize,
+					})).
+					Times(1).
+					Return(admission, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.StandingAdmission
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, admission, got)
+			},
+		},
+		{
+			name: "VenueFull",
+			body: gin.H{"party_size": admission.PartySize},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					AdmitStandingTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.StandingAdmission{}, &db.CapacityError{MaxOccupancy: 100, Occupancy: 100, PartySize: admission.PartySize})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NoPartySize",
+			body: gin.H{"name": admission.Name},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().AdmitStandingTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/standing", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, util.DoorStaffRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestDepartStandingAPI(t *testing.T) {
+	admission := randomStandingAdmission()
+	departed := admission
+	departed.DepartedAt = sql.NullTime{Time: admission.AdmittedAt.Add(time.Hour), Valid: true}
+
+	testCases := []struct {
+		name          string
+		id            int32
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			id:   admission.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DepartStandingTx(gomock.Any(), gomock.Eq(db.DepartStandingTxParams{AuditInfo: testAudit, ID: admission.ID})).
+					Times(1).
+					Return(departed, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.StandingAdmission
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.True(t, got.DepartedAt.Valid)
+			},
+		},
+		{
+			name: "AlreadyDeparted",
+			id:   admission.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DepartStandingTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.StandingAdmission{}, db.ErrStandingDeparted)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			id:   admission.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					DepartStandingTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.StandingAdmission{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/standing/%d", tc.id), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, util.DoorStaffRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomStandingAdmission() db.StandingAdmission {
+	return db.StandingAdmission{
+		ID:         util.RandomInt(1, 1000),
+		Name:       util.RandomGuestName(),
+		PartySize:  util.RandomInt(1, 6),
+		AdmittedBy: testUsername,
+		AdmittedAt: time.Now().Truncate(time.Second).UTC(),
+	}
+}
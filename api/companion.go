@@ -0,0 +1,248 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+type companionRequestURI struct {
+	Name string `uri:"name" binding:"required,min=5"`
+	ID   int32  `uri:"id" binding:"required,min=1"`
+}
+
+// Attributes are free-form (e.g. dietary requirements), the openapi spec enforces they're an object
+type companionRequest struct {
+	Name       string          `json:"name" binding:"max=255"`
+	Attributes json.RawMessage `json:"attributes"`
+}
+
+type companionResponse struct {
+	db.Companion
+	// Arrived is true when the companion came in with the guest's current arrival
+	Arrived bool `json:"arrived"`
+}
+
+// listCompanions godoc
+// @Summary returns the named companions of the guest
+// @Description Fetches the companions named for the guest's entourage, marking those who came in with the guest's current arrival.
+// @Accept json
+// @Produce json
+// @Param    name     path      string  true  "Guest Name"
+// @Success 200 {object} []companionResponse
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /guests/{name}/companions [get]
+func (server *Server) listCompanions(ctx *gin.Context) {
+	var req getGuestFromNameRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	guest, err := server.store.GetGuestFromName(ctx, req.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	companions, err := server.store.ListCompanions(ctx, guest.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	arrived := map[int32]bool{}
+	if guest.Status == db.GuestArrived {
+		arrival, err := server.store.GetOpenArrivalFromGuest(ctx, guest.ID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		arriving, err := server.store.ListArrivalCompanions(ctx, arrival.ID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		for _, companion := range arriving {
+			arrived[companion.ID] = true
+		}
+	}
+
+	rsp := make([]companionResponse, len(companions))
+	for i, companion := range companions {
+		rsp[i] = companionResponse{Companion: companion, Arrived: arrived[companion.ID]}
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+// addCompanion godoc
+// @Summary Names one of the guest's companions
+// @Description Adds a companion to the guest, the name and attributes are optional. A guest can't have more companions than their entourage.
+// @Accept json
+// @Produce json
+// @Param    name     path      string            true  "Guest Name"
+// @Param    request  body      companionRequest  true  "Name and attributes"
+// @Success 200 {object} db.Companion
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 409 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /guests/{name}/companions [post]
+func (server *Server) addCompanion(ctx *gin.Context) {
+	var reqName getGuestFromNameRequest
+	if err := ctx.ShouldBindUri(&reqName); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req companionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	guest, err := server.store.GetGuestFromName(ctx, reqName.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	companion, err := server.store.AddCompanionTx(ctx, db.AddCompanionTxParams{
+		AuditInfo:  auditInfo(ctx),
+		GuestID:    guest.ID,
+		Name:       req.Name,
+		Attributes: req.Attributes,
+	})
+	if err != nil {
+		if err == db.ErrCompanionLimit {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, companion)
+}
+
+// updateCompanion godoc
+// @Summary Renames one of the guest's companions
+// @Description Replaces the companion's name, and their attributes if given.
+// @Accept json
+// @Produce json
+// @Param    name     path      string            true  "Guest Name"
+// @Param    id       path      int               true  "Companion ID"
+// @Param    request  body      companionRequest  true  "Name and attributes"
+// @Success 200 {object} db.Companion
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /guests/{name}/companions/{id} [put]
+func (server *Server) updateCompanion(ctx *gin.Context) {
+	var uri companionRequestURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req companionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	guest, err := server.store.GetGuestFromName(ctx, uri.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	companion, err := server.store.UpdateCompanionTx(ctx, db.UpdateCompanionTxParams{
+		AuditInfo:  auditInfo(ctx),
+		ID:         uri.ID,
+		GuestID:    guest.ID,
+		Name:       req.Name,
+		Attributes: req.Attributes,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, companion)
+}
+
+// removeCompanion godoc
+// @Summary Removes one of the guest's companions
+// @Description The guest's entourage is unchanged, the seat just goes back to being anonymous.
+// @Accept json
+// @Produce json
+// @Param    name     path      string  true  "Guest Name"
+// @Param    id       path      int     true  "Companion ID"
+// @Success 200 {string} string
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /guests/{name}/companions/{id} [delete]
+func (server *Server) removeCompanion(ctx *gin.Context) {
+	var uri companionRequestURI
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	guest, err := server.store.GetGuestFromName(ctx, uri.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.store.RemoveCompanionTx(ctx, db.RemoveCompanionTxParams{
+		AuditInfo: auditInfo(ctx),
+		ID:        uri.ID,
+		GuestID:   guest.ID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, guest.GuestName)
+}
@@ -0,0 +1,255 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCompanionsAPI(t *testing.T) {
+	guest := randomGuest()
+	guest.Status = db.GuestArrived
+	companions := []db.Companion{randomCompanion(guest), randomCompanion(guest)}
+	arrival := createArrival(guest.ID, guest.TableID, 2)
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+	store.EXPECT().ListCompanions(gomock.Any(), gomock.Eq(guest.ID)).Times(1).Return(companions, nil)
+	store.EXPECT().GetOpenArrivalFromGuest(gomock.Any(), gomock.Eq(guest.ID)).Times(1).Return(arrival, nil)
+	store.EXPECT().ListArrivalCompanions(gomock.Any(), gomock.Eq(arrival.ID)).Times(1).Return(companions[1:], nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodGet, "/guests/"+guest.GuestName+"/companions", nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, util.DoorStaffRole, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got []companionResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Len(t, got, 2)
+	require.Equal(t, companions[0].ID, got[0].ID)
+	require.False(t, got[0].Arrived)
+	require.True(t, got[1].Arrived)
+}
+
+func TestAddCompanionAPI(t *testing.T) {
+	guest := randomGuest()
+	companion := randomCompanion(guest)
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"name": companion.Name, "attributes": gin.H{"dietary": "vegan"}},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().
+					AddCompanionTx(gomock.Any(), gomock.Eq(db.AddCompanionTxParams{
+						AuditInfo:  testAudit,
+						GuestID:    guest.ID,
+						Name:       companion.Name,
+						Attributes: companion.Attributes,
+					})).
+					Times(1).
+					Return(companion, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.Companion
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, companion.ID, got.ID)
+				require.JSONEq(t, string(companion.Attributes), string(got.Attributes))
+			},
+		},
+		{
+			name: "WholeEntourageNamed",
+			body: gin.H{"name": companion.Name},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().
+					AddCompanionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Companion{}, db.ErrCompanionLimit)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "AttributesNotAnObject",
+			body: gin.H{"name": companion.Name, "attributes": "vegan"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					AddCompanionTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/guests/"+guest.GuestName+"/companions", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUpdateCompanionAPI(t *testing.T) {
+	guest := randomGuest()
+	companion := randomCompanion(guest)
+
+	renamed := companion
+	renamed.Name = util.RandomGuestName()
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().
+					UpdateCompanionTx(gomock.Any(), gomock.Eq(db.UpdateCompanionTxParams{
+						AuditInfo: testAudit,
+						ID:        companion.ID,
+						GuestID:   guest.ID,
+						Name:      renamed.Name,
+					})).
+					Times(1).
+					Return(renamed, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.Companion
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, renamed.Name, got.Name)
+			},
+		},
+		{
+			name: "AnotherGuestsCompanion",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().
+					UpdateCompanionTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Companion{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{"name": renamed.Name})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/guests/%s/companions/%d", guest.GuestName, companion.ID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestRemoveCompanionAPI(t *testing.T) {
+	guest := randomGuest()
+	companion := randomCompanion(guest)
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+	store.EXPECT().
+		RemoveCompanionTx(gomock.Any(), gomock.Eq(db.RemoveCompanionTxParams{
+			AuditInfo: testAudit,
+			ID:        companion.ID,
+			GuestID:   guest.ID,
+		})).
+		Times(1).
+		Return(nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	url := fmt.Sprintf("/guests/%s/companions/%d", guest.GuestName, companion.ID)
+	request, err := http.NewRequest(http.MethodDelete, url, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	requireBodyMatchGuestName(t, recorder.Body, guest.GuestName)
+}
+
+func randomCompanion(guest db.Guest) db.Companion {
+	return db.Companion{
+		ID:         util.RandomInt(1, 1000),
+		GuestID:    guest.ID,
+		Name:       util.RandomGuestName(),
+		Attributes: json.RawMessage(`{"dietary":"vegan"}`),
+		CreatedAt:  time.Now().Truncate(time.Second).UTC(),
+	}
+}
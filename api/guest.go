@@ -2,19 +2,57 @@ package api
 
 import (
 	"database/sql"
-	"fmt"
+	"errors"
 	"net/http"
 
 	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
 
 	"github.com/gin-gonic/gin"
 	_ "github.com/go-sql-driver/mysql"
-	_ "github.com/swaggo/swag/example/celler/httputil"
 )
 
+// Entourage has no "required" binding as that would reject a guest coming alone,
+// its presence is enforced by the openapi spec instead. The table is given by either its ID or label.
 type createGuestRequest struct {
-	Entourage int32 `json:"entourage" binding:"required"`
-	TableID   int32 `json:"table_id" binding:"required,min=0"`
+	Entourage     int32  `json:"entourage" binding:"min=0"`
+	TableID       int32  `json:"table_id" binding:"required_without=TableLabel,excluded_with=TableLabel,omitempty,min=1"`
+	TableLabel    string `json:"table_label" binding:"max=64"`
+	Dietary       string `json:"dietary" binding:"max=255"`
+	Accessibility string `json:"accessibility" binding:"max=255"`
+	VipTier       string `json:"vip_tier" binding:"omitempty,oneof=silver gold platinum"`
+	Email         string `json:"email" binding:"omitempty,email,max=255"`
+	Phone         string `json:"phone" binding:"max=32"`
+	Notes         string `json:"notes" binding:"max=1024"`
+	// Seats optionally reserves numbered seats at the table, one for each of the party
+	Seats []int32 `json:"seats" binding:"omitempty,unique,dive,min=1"`
+	// Optionally when the guest is expected to arrive, otherwise their table's window applies
+	arrivalWindowRequest
+}
+
+// guestResponse is a guest as returned to the caller, viewers aren't shown the guest's contact
+// details so Email and Phone shadow those of the embedded guest and are left out for them
+type guestResponse struct {
+	db.Guest
+	Email *string `json:"email,omitempty"`
+	Phone *string `json:"phone,omitempty"`
+}
+
+func newGuestResponse(ctx *gin.Context, guest db.Guest) guestResponse {
+	rsp := guestResponse{Guest: guest}
+	if actingRole(ctx) != util.ViewerRole {
+		rsp.Email = &rsp.Guest.Email
+		rsp.Phone = &rsp.Guest.Phone
+	}
+	return rsp
+}
+
+func newGuestsResponse(ctx *gin.Context, guests []db.Guest) []guestResponse {
+	rsp := make([]guestResponse, len(guests))
+	for i, guest := range guests {
+		rsp[i] = newGuestResponse(ctx, guest)
+	}
+	return rsp
 }
 
 // Normally this would go in the above createGuestRequest but to conform to the project
@@ -25,16 +63,18 @@ type createGuestRequestURI struct {
 
 // createGuest godoc
 // @Summary Creates a guest according to the name, table, and entourage arguments.
-// @Description Executes a POST request preceeding the check to see if the table is big enough for the party (1 + entourage).
+// @Description Executes a POST request preceeding the check to see if the table is big enough for the party (1 + entourage) and takes parties of that size. The table is given by either its ID or label. Numbered seats can optionally be reserved for the party, one for each of them, a seat already held by another guest is rejected with a 409. A guest matching somebody on the ban list by name, email or phone is refused with a 403 and an alert is raised. An arrival window can be given for the guest, see PUT /guests/{name}/arrival_window.
 // @Accept json
 // @Produce json
-// @Param    name         path      string  true  "Guest Name"
-// @Param    entourage    body      int     true  "Entourage"
-// @Param    table_id     body      int     true  "Table ID - unique identifier of the table (see getTables)"
-// @Success 200 {object} sql.Result
-// @Failure 400 {object} httputil.HTTPError
-// @Failure 404 {object} httputil.HTTPError
-// @Failure 500 {object} httputil.HTTPError
+// @Param    name         path      string              true  "Guest Name"
+// @Param    request      body      createGuestRequest  true  "Entourage, Table ID - unique identifier of the table (see getTables) - or label, and optionally the guest's profile"
+// @Success 200 {string} string
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 409 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
 // @Router /guest_list/{name} [post]
 func (server *Server) createGuest(ctx *gin.Context) {
 	var reqUri createGuestRequestURI
@@ -49,28 +89,51 @@ func (server *Server) createGuest(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.CreateGuestParams{
-		GuestName:   reqUri.GuestName,
-		Entourage:   reqBody.Entourage,
-		TableID:     reqBody.TableID,
-		ArrivalTime: sql.NullTime{},
-	}
-
-	// Must check first the table they provided is big enough
-	table, err := server.store.GetTable(ctx, reqBody.TableID)
+	// Must check first the table they provided takes a party of their size
+	table, err := server.getTableByRef(ctx, reqBody.TableID, reqBody.TableLabel)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, errorResponse(err))
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
-	if table.Size < (arg.Entourage + 1) {
-		ctx.JSON(http.StatusBadRequest, fmt.Errorf("the table size %d is not big enough to hold the capacity of your party %d", table.Size, arg.Entourage+1))
+	arg := db.CreateGuestTxParams{
+		AuditInfo:     auditInfo(ctx),
+		GuestName:     reqUri.GuestName,
+		Entourage:     reqBody.Entourage,
+		TableID:       table.ID,
+		Seats:         reqBody.Seats,
+		ArrivalWindow: reqBody.arrivalWindow(),
+		GuestProfile: db.GuestProfile{
+			Dietary:       reqBody.Dietary,
+			Accessibility: reqBody.Accessibility,
+			VipTier:       reqBody.VipTier,
+			Email:         reqBody.Email,
+			Phone:         reqBody.Phone,
+			Notes:         reqBody.Notes,
+		},
+	}
+
+	if err = db.CheckPartySize(table, arg.Entourage+1); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
-	_, err = server.store.CreateGuest(ctx, arg)
+	_, err = server.store.CreateGuestTx(ctx, arg)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		switch {
+		case errors.Is(err, db.ErrInvalidSeats), errors.Is(err, db.ErrInvalidArrivalWindow):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		case errors.Is(err, db.ErrSeatTaken):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		case errors.Is(err, db.ErrBanned):
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
 		return
 	}
 
@@ -83,13 +146,16 @@ type getGuestFromNameRequest struct {
 
 // getGuestFromName godoc
 // @Summary returns a guest based on their GuestName value.
-// @Description Fetches a guest object (Guest)
+// @Description Fetches a guest object (Guest), the guest's email and phone are left out for viewers
 // @Accept json
 // @Produce json
 // @Param    name     path      string  true  "Guest Name"
-// @Success 200 {object} db.Guest
-// @Failure 400 {object} httputil.HTTPError
-// @Failure 500 {object} httputil.HTTPError
+// @Success 200 {object} guestResponse
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
 // @Router /guests/{name} [get]
 func (server *Server) getGuestFromName(ctx *gin.Context) {
 	var req getGuestFromNameRequest
@@ -108,7 +174,7 @@ func (server *Server) getGuestFromName(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, guest)
+	ctx.JSON(http.StatusOK, newGuestResponse(ctx, guest))
 }
 
 type getGuestsRequest struct {
@@ -116,29 +182,65 @@ type getGuestsRequest struct {
 	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
 }
 
+// listGuestsRequest is a page of the guest list, optionally only the guests with a given status,
+// at a given table, with a dietary requirement, of a VIP tier or who walked in
+type listGuestsRequest struct {
+	getGuestsRequest
+	Status     string `form:"status" binding:"omitempty,oneof=invited confirmed declined cancelled arrived left no_show"`
+	TableID    int32  `form:"table_id" binding:"omitempty,min=1,excluded_with=TableLabel"`
+	TableLabel string `form:"table_label" binding:"omitempty,max=64"`
+	Dietary    string `form:"dietary" binding:"omitempty,max=255,excludesall=0x2C"`
+	VipTier    string `form:"vip_tier" binding:"omitempty,oneof=silver gold platinum"`
+	WalkIn     *bool  `form:"walk_in"`
+}
+
 // @BasePath /
 
 // getGuests godoc
 // @Summary returns all guests on the guest_list
-// @Description Fetches an array of guest object ([]Guest), the requests are paginated with a minimum page_id of 1 and page_size of 5-20. Running a make test will generate some default data via the mysql unit tests.
+// @Description Fetches an array of guest object ([]Guest), the requests are paginated with a minimum page_id of 1 and page_size of 5-10. The guests' email and phone are left out for viewers. Running a make test will generate some default data via the mysql unit tests.
 // @Accept json
 // @Produce json
 // @Param        page_id   query      int  true  "Page ID"
 // @Param        page_size   query      int  true  "Page Size"
-// @Success 200 {object} []db.Guest
-// @Failure 400 {object} httputil.HTTPError
-// @Failure 500 {object} httputil.HTTPError
-// @Router /guest_list/ [get]
+// @Param        status      query      string  false  "Only guests with this status"
+// @Param        table_id    query      int     false  "Only guests at this table"
+// @Param        table_label query      string  false  "Only guests at the table with this label"
+// @Param        dietary     query      string  false  "Only guests with this dietary requirement"
+// @Param        vip_tier    query      string  false  "Only guests of this VIP tier"
+// @Param        walk_in     query      bool    false  "Only walk-ins, or only guests who were on the list"
+// @Success 200 {object} []guestResponse
+// @Failure 400 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /guest_list [get]
 func (server *Server) getGuests(ctx *gin.Context) {
-	var req getGuestsRequest
+	var req listGuestsRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, errorResponse(err))
 		return
 	}
 
+	tableID := sql.NullInt32{Int32: req.TableID, Valid: req.TableID != 0}
+	if req.TableLabel != "" {
+		var err error
+		tableID, err = server.tableIDFromLabel(ctx, req.TableLabel)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+	}
+
+	dietary := db.NormalizeDietary(req.Dietary)
 	arg := db.GetGuestsParams{
-		Limit:  req.PageSize,
-		Offset: (req.PageID - 1) * req.PageSize,
+		Status:  sql.NullString{String: req.Status, Valid: req.Status != ""},
+		TableID: tableID,
+		Dietary: sql.NullString{String: dietary, Valid: dietary != ""},
+		VipTier: sql.NullString{String: req.VipTier, Valid: req.VipTier != ""},
+		WalkIn:  nullBool(req.WalkIn),
+		Limit:   req.PageSize,
+		Offset:  (req.PageID - 1) * req.PageSize,
 	}
 
 	guests, err := server.store.GetGuests(ctx, arg)
@@ -147,20 +249,23 @@ func (server *Server) getGuests(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, guests)
+	ctx.JSON(http.StatusOK, newGuestsResponse(ctx, guests))
 }
 
 // deleteGuest godoc
-// @Summary Deletes a guest based on their Guest Name value.
-// @Description Checks there is a valid record based on the name value then performs a DELETE action.
+// @Summary Records an arrived guest leaving.
+// @Description Frees the seats the guest's party occupied, the guest stays on the list with the status left and may re-enter.
 // @Accept json
 // @Produce json
 // @Param        name   path    string  true  "Guest Name"
-// @Success 200 {object} []db.Guest
-// @Failure 400 {object} httputil.HTTPError
-// @Failure 404 {object} httputil.HTTPError
-// @Failure 500 {object} httputil.HTTPError
-// @Router /guest/{name} [delete]
+// @Success 200 {string} string
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 409 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /guests/{name} [delete]
 func (server *Server) deleteGuest(ctx *gin.Context) {
 	var req getGuestFromNameRequest
 	if err := ctx.ShouldBindUri(&req); err != nil {
@@ -177,11 +282,194 @@ func (server *Server) deleteGuest(ctx *gin.Context) {
 		return
 	}
 
-	err = server.store.DeleteGuestTx(ctx, guest.ID)
+	_, err = server.store.LeaveGuestTx(ctx, db.LeaveGuestTxParams{
+		AuditInfo: auditInfo(ctx),
+		ID:        guest.ID,
+	})
 	if err != nil {
+		if errors.Is(err, db.ErrIllegalTransition) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
 		return
 	}
 
 	ctx.JSON(http.StatusOK, guest.GuestName)
 }
+
+// removeGuest godoc
+// @Summary Removes a guest from the guest list.
+// @Description Deletes the guest, freeing any seats they hold and recording an arrived party leaving first. The removal is kept in the audit trail and listed as a correction in the report. Requires the organiser role.
+// @Accept json
+// @Produce json
+// @Param        name   path    string  true  "Guest Name"
+// @Success 200 {string} string
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /guest_list/{name} [delete]
+func (server *Server) removeGuest(ctx *gin.Context) {
+	var req getGuestFromNameRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+	guest, err := server.store.GetGuestFromName(ctx, req.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.store.DeleteGuestTx(ctx, db.DeleteGuestTxParams{
+		AuditInfo: auditInfo(ctx),
+		ID:        guest.ID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, guest.GuestName)
+}
+
+type updateGuestStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=confirmed declined cancelled no_show"`
+}
+
+// updateGuestStatus godoc
+// @Summary Changes the status of a guest.
+// @Description Moves the guest to a status which doesn't change who is seated (confirmed, declined, cancelled or no_show), arriving and leaving are done with PUT and DELETE /guests/{name}. Transitions the guest's current status doesn't allow are rejected with a 409.
+// @Accept json
+// @Produce json
+// @Param    name     path      string                    true  "Guest Name"
+// @Param    request  body      updateGuestStatusRequest  true  "New status"
+// @Success 200 {object} db.Guest
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 409 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /guests/{name}/status [put]
+func (server *Server) updateGuestStatus(ctx *gin.Context) {
+	var reqName getGuestFromNameRequest
+	var req updateGuestStatusRequest
+	if err := ctx.ShouldBindUri(&reqName); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	guest, err := server.store.GetGuestFromName(ctx, reqName.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	guest, err = server.store.TransitionGuestTx(ctx, db.TransitionGuestTxParams{
+		AuditInfo: auditInfo(ctx),
+		ID:        guest.ID,
+		Status:    req.Status,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrIllegalTransition) {
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, guest)
+}
+
+// Profile fields left out of the request are kept as they are, an empty string clears one
+type updateGuestProfileRequest struct {
+	Dietary       *string `json:"dietary" binding:"omitempty,max=255"`
+	Accessibility *string `json:"accessibility" binding:"omitempty,max=255"`
+	VipTier       *string `json:"vip_tier" binding:"omitempty,oneof='' silver gold platinum"`
+	Email         *string `json:"email" binding:"omitempty,max=255,eq=|email"`
+	Phone         *string `json:"phone" binding:"omitempty,max=32"`
+	Notes         *string `json:"notes" binding:"omitempty,max=1024"`
+}
+
+func nullString(s *string) sql.NullString {
+	if s == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *s, Valid: true}
+}
+
+// updateGuestProfile godoc
+// @Summary Changes the profile of a guest.
+// @Description Updates any of the dietary requirements, accessibility needs, VIP tier, contact email and phone or host notes of a guest, fields which aren't given are left unchanged.
+// @Accept json
+// @Produce json
+// @Param    name     path      string                     true  "Guest Name"
+// @Param    request  body      updateGuestProfileRequest  true  "Profile fields to change"
+// @Success 200 {object} db.Guest
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /guests/{name}/profile [patch]
+func (server *Server) updateGuestProfile(ctx *gin.Context) {
+	var reqName getGuestFromNameRequest
+	var req updateGuestProfileRequest
+	if err := ctx.ShouldBindUri(&reqName); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	guest, err := server.store.GetGuestFromName(ctx, reqName.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	guest, err = server.store.UpdateGuestProfileTx(ctx, db.UpdateGuestProfileTxParams{
+		AuditInfo:     auditInfo(ctx),
+		ID:            guest.ID,
+		Dietary:       nullString(req.Dietary),
+		Accessibility: nullString(req.Accessibility),
+		VipTier:       nullString(req.VipTier),
+		Email:         nullString(req.Email),
+		Phone:         nullString(req.Phone),
+		Notes:         nullString(req.Notes),
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, guest)
+}
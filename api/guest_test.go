@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
 	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
@@ -24,6 +25,7 @@ func TestGetGuestAPI(t *testing.T) {
 	testCases := []struct {
 		name          string
 		guestName     string
+		role          string
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
 	}{
@@ -40,6 +42,26 @@ func TestGetGuestAPI(t *testing.T) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 				requireBodyMatchGuest(t, recorder.Body, guest)
 			},
+		},
+		{
+			name:      "ViewerWithoutContact",
+			guestName: guest.GuestName,
+			role:      util.ViewerRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).
+					Times(1).
+					Return(guest, nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var fetched map[string]interface{}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &fetched))
+				require.NotContains(t, fetched, "email")
+				require.NotContains(t, fetched, "phone")
+				require.Equal(t, guest.Dietary, fetched["dietary"])
+			},
 		}, {
 
 			name:      "NotFound",
@@ -92,32 +114,24 @@ func TestGetGuestAPI(t *testing.T) {
 			store := mockdb.NewMockStore(controller)
 			tc.buildStubs(store)
 
-			server := NewServer(store)
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
 			url := fmt.Sprintf("/guests/%s", tc.guestName)
 			req, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
 
+			role := tc.role
+			if role == "" {
+				role = util.OrganiserRole
+			}
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, role, time.Minute)
 			server.router.ServeHTTP(recorder, req)
 			tc.checkResponse(t, recorder)
 		})
 	}
 }
 
-type T struct {
-	S   int64
-	err error
-}
-
-func (t T) LastInsertID() (int64, error) {
-	return t.S, t.err
-}
-
-func (t T) RowsAffected() (int64, error) {
-	return t.S, t.err
-}
-
 func TestCreateGuestAPI(t *testing.T) {
 	table := randomTable()
 	table.Occupied = 0 // default it to an empty table
@@ -142,11 +156,11 @@ func TestCreateGuestAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				first := store.EXPECT().GetTable(gomock.Any(), guest.TableID).Times(1).Return(table, nil)
-				second := store.EXPECT().CreateGuest(gomock.Any(), db.CreateGuestParams{
-					GuestName:   guest.GuestName,
-					Entourage:   guest.Entourage,
-					TableID:     guest.TableID,
-					ArrivalTime: sql.NullTime{Valid: false},
+				second := store.EXPECT().CreateGuestTx(gomock.Any(), db.CreateGuestTxParams{
+					AuditInfo: testAudit,
+					GuestName: guest.GuestName,
+					Entourage: guest.Entourage,
+					TableID:   guest.TableID,
 				}).Times(1)
 				gomock.InOrder(first, second)
 			},
@@ -154,6 +168,183 @@ func TestCreateGuestAPI(t *testing.T) {
 				require.Equal(t, http.StatusOK, recorder.Code)
 			},
 		},
+		{
+			name:      "WithProfile",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"entourage": guest.Entourage,
+				"table_id":  table.ID,
+				"dietary":   "Vegetarian, Nut_Allergy",
+				"vip_tier":  db.VIPPlatinum,
+				"email":     guest.Email,
+				"notes":     "Speech after dinner",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTable(gomock.Any(), guest.TableID).Times(1).Return(table, nil)
+				store.EXPECT().CreateGuestTx(gomock.Any(), db.CreateGuestTxParams{
+					AuditInfo: testAudit,
+					GuestProfile: db.GuestProfile{
+						Dietary: "Vegetarian, Nut_Allergy",
+						VipTier: db.VIPPlatinum,
+						Email:   guest.Email,
+						Notes:   "Speech after dinner",
+					},
+					GuestName: guest.GuestName,
+					Entourage: guest.Entourage,
+					TableID:   guest.TableID,
+				}).Times(1)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "ByTableLabel",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"entourage":   guest.Entourage,
+				"table_label": table.Label,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTable(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetTableFromLabel(gomock.Any(), table.Label).Times(1).Return(table, nil)
+				store.EXPECT().CreateGuestTx(gomock.Any(), db.CreateGuestTxParams{
+					AuditInfo: testAudit,
+					GuestName: guest.GuestName,
+					Entourage: guest.Entourage,
+					TableID:   table.ID,
+				}).Times(1)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "WithSeats",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"entourage": 1,
+				"table_id":  table.ID,
+				"seats":     []int32{4, 5},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTable(gomock.Any(), gomock.Eq(table.ID)).Times(1).Return(table, nil)
+				store.EXPECT().CreateGuestTx(gomock.Any(), db.CreateGuestTxParams{
+					AuditInfo: testAudit,
+					GuestName: guest.GuestName,
+					Entourage: 1,
+					TableID:   table.ID,
+					Seats:     []int32{4, 5},
+				}).Times(1)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "SeatTaken",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"entourage": 0,
+				"table_id":  table.ID,
+				"seats":     []int32{1},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTable(gomock.Any(), gomock.Eq(table.ID)).Times(1).Return(table, nil)
+				store.EXPECT().CreateGuestTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Guest{}, &db.SeatTakenError{TableID: table.ID, Number: 1})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:      "Banned",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"entourage": guest.Entourage,
+				"table_id":  table.ID,
+				"email":     guest.Email,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTable(gomock.Any(), gomock.Eq(table.ID)).Times(1).Return(table, nil)
+				store.EXPECT().CreateGuestTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Guest{}, &db.BannedError{
+						GuestName: guest.GuestName,
+						Person:    db.BannedPerson{ID: 3, Name: "Someone Else", Email: guest.Email},
+						Match:     db.BanMatchEmail,
+					})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+				require.Contains(t, recorder.Body.String(), "banned person 3 by email")
+			},
+		},
+		{
+			name:      "RepeatedSeat",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"entourage": 1,
+				"table_id":  table.ID,
+				"seats":     []int32{2, 2},
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGuestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "TableIDAndLabel",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"entourage":   guest.Entourage,
+				"table_id":    table.ID,
+				"table_label": table.Label,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTable(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().GetTableFromLabel(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "PartyBelowTableMinimum",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"entourage": 0,
+				"table_id":  table.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				booth := table
+				booth.MinParty = 2
+				store.EXPECT().GetTable(gomock.Any(), table.ID).Times(1).Return(booth, nil)
+				store.EXPECT().CreateGuestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "InvalidEmail",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"entourage": guest.Entourage,
+				"table_id":  table.ID,
+				"email":     "not-an-email",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTable(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name:      "TableTooSmall",
 			guestName: guest.GuestName,
@@ -182,6 +373,21 @@ func TestCreateGuestAPI(t *testing.T) {
 				require.Equal(t, http.StatusNotFound, recorder.Code)
 			},
 		},
+		{
+			name:      "InternalErrorOnFetchTable",
+			guestName: guest.GuestName,
+			body: gin.H{
+				"entourage":   guest.Entourage,
+				"table_label": table.Label,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTableFromLabel(gomock.Any(), table.Label).Times(1).Return(db.Table{}, sql.ErrConnDone)
+				store.EXPECT().CreateGuestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
 		{
 			name:      "InvalidNameURI",
 			guestName: "-1",
@@ -216,13 +422,13 @@ func TestCreateGuestAPI(t *testing.T) {
 			},
 			buildStubs: func(store *mockdb.MockStore) {
 				first := store.EXPECT().GetTable(gomock.Any(), guest.TableID).Times(1).Return(table, nil)
-				second := store.EXPECT().CreateGuest(gomock.Any(), db.CreateGuestParams{
-					GuestName:   guest.GuestName,
-					Entourage:   guest.Entourage,
-					TableID:     guest.TableID,
-					ArrivalTime: sql.NullTime{Valid: false},
+				second := store.EXPECT().CreateGuestTx(gomock.Any(), db.CreateGuestTxParams{
+					AuditInfo: testAudit,
+					GuestName: guest.GuestName,
+					Entourage: guest.Entourage,
+					TableID:   guest.TableID,
 				}).Times(1)
-				gomock.InOrder(first, second.Return(nil, sql.ErrConnDone))
+				gomock.InOrder(first, second.Return(db.Guest{}, sql.ErrConnDone))
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -240,7 +446,7 @@ func TestCreateGuestAPI(t *testing.T) {
 			store := mockdb.NewMockStore(controller)
 			tc.buildStubs(store)
 
-			server := NewServer(store)
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
 			data, err := json.Marshal(tc.body)
@@ -250,6 +456,7 @@ func TestCreateGuestAPI(t *testing.T) {
 			req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
 			require.NoError(t, err)
 
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
 			server.router.ServeHTTP(recorder, req)
 			tc.checkResponse(recorder)
 		})
@@ -270,7 +477,7 @@ func TestDeleteGuestAPI(t *testing.T) {
 			guestName: guest.GuestName,
 			buildStubs: func(store *mockdb.MockStore) {
 				first := store.EXPECT().GetGuestFromName(gomock.Any(), guest.GuestName).Times(1).Return(guest, nil)
-				second := store.EXPECT().DeleteGuestTx(gomock.Any(), gomock.Eq(guest.ID)).Times(1).Return(nil)
+				second := store.EXPECT().LeaveGuestTx(gomock.Any(), gomock.Eq(db.LeaveGuestTxParams{AuditInfo: testAudit, ID: guest.ID})).Times(1).Return(guest, nil)
 				gomock.InOrder(first, second)
 			},
 			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
@@ -295,7 +502,7 @@ func TestDeleteGuestAPI(t *testing.T) {
 			guestName: guest.GuestName,
 			buildStubs: func(store *mockdb.MockStore) {
 				first := store.EXPECT().GetGuestFromName(gomock.Any(), guest.GuestName).Times(1).Return(guest, nil)
-				second := store.EXPECT().DeleteGuestTx(gomock.Any(), gomock.Eq(guest.ID)).Times(1).Return(sql.ErrConnDone)
+				second := store.EXPECT().LeaveGuestTx(gomock.Any(), gomock.Eq(db.LeaveGuestTxParams{AuditInfo: testAudit, ID: guest.ID})).Times(1).Return(db.Guest{}, sql.ErrConnDone)
 				gomock.InOrder(first, second)
 
 			},
@@ -303,6 +510,19 @@ func TestDeleteGuestAPI(t *testing.T) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
 			},
 		},
+		{
+			name:      "NotArrived",
+			guestName: guest.GuestName,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), guest.GuestName).Times(1).Return(guest, nil)
+				store.EXPECT().LeaveGuestTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Guest{}, &db.TransitionError{From: db.GuestInvited, To: db.GuestLeft})
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
 		{
 			name:      "InvalidName",
 			guestName: "-1",
@@ -335,13 +555,99 @@ func TestDeleteGuestAPI(t *testing.T) {
 			store := mockdb.NewMockStore(controller)
 			tc.buildStubs(store)
 
-			server := NewServer(store)
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			url := fmt.Sprintf("/guests/%s", tc.guestName)
+			req, err := http.NewRequest(http.MethodDelete, url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestRemoveGuestAPI(t *testing.T) {
+	guest := randomGuest()
+
+	testCases := []struct {
+		name          string
+		guestName     string
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			guestName: guest.GuestName,
+			role:      util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				first := store.EXPECT().GetGuestFromName(gomock.Any(), guest.GuestName).Times(1).Return(guest, nil)
+				second := store.EXPECT().DeleteGuestTx(gomock.Any(), gomock.Eq(db.DeleteGuestTxParams{AuditInfo: testAudit, ID: guest.ID})).Times(1).Return(nil)
+				gomock.InOrder(first, second)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchGuestName(t, recorder.Body, guest.GuestName)
+			},
+		},
+		{
+			name:      "NotFound",
+			guestName: "UnknownUser",
+			role:      util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), "UnknownUser").Times(1).Return(db.Guest{}, sql.ErrNoRows)
+				store.EXPECT().DeleteGuestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "InternalError",
+			guestName: guest.GuestName,
+			role:      util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), guest.GuestName).Times(1).Return(guest, nil)
+				store.EXPECT().DeleteGuestTx(gomock.Any(), gomock.Any()).Times(1).Return(sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+		{
+			name:      "DoorStaff",
+			guestName: guest.GuestName,
+			role:      util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Any()).Times(0)
+				store.EXPECT().DeleteGuestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
-			url := fmt.Sprintf("/guest/%s", tc.guestName)
+			url := fmt.Sprintf("/guest_list/%s", tc.guestName)
 			req, err := http.NewRequest(http.MethodDelete, url, nil)
 			require.NoError(t, err)
 
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, tc.role, time.Minute)
 			server.router.ServeHTTP(recorder, req)
 			tc.checkResponse(t, recorder)
 		})
@@ -358,11 +664,16 @@ func TestGetGuestsAPI(t *testing.T) {
 	type Query struct {
 		pageID   int
 		pageSize int
+		status   string
+		tableID  int
+		dietary  string
+		vipTier  string
 	}
 
 	testCases := []struct {
 		name          string
 		query         Query
+		role          string
 		buildStubs    func(store *mockdb.MockStore)
 		checkResponse func(recorder *httptest.ResponseRecorder)
 	}{
@@ -389,6 +700,115 @@ func TestGetGuestsAPI(t *testing.T) {
 				requireBodyMatchGuests(t, recorder.Body, guests)
 			},
 		},
+		{
+			name: "FilterByStatus",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+				status:   db.GuestConfirmed,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+
+				arg := db.GetGuestsParams{
+					Status: sql.NullString{String: db.GuestConfirmed, Valid: true},
+					Limit:  int32(n),
+					Offset: 0,
+				}
+
+				store.EXPECT().
+					GetGuests(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(guests, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "FilterByProfile",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+				tableID:  4,
+				dietary:  " Vegetarian ",
+				vipTier:  db.VIPGold,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+
+				arg := db.GetGuestsParams{
+					TableID: sql.NullInt32{Int32: 4, Valid: true},
+					Dietary: sql.NullString{String: "vegetarian", Valid: true},
+					VipTier: sql.NullString{String: db.VIPGold, Valid: true},
+					Limit:   int32(n),
+					Offset:  0,
+				}
+
+				store.EXPECT().
+					GetGuests(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(guests, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidVIPTier",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+				vipTier:  "diamond",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGuests(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ViewerWithoutContact",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+			},
+			role: util.ViewerRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGuests(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(guests, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var fetched []map[string]interface{}
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &fetched))
+				require.Len(t, fetched, n)
+				for _, guest := range fetched {
+					require.NotContains(t, guest, "email")
+					require.NotContains(t, guest, "phone")
+				}
+			},
+		},
+		{
+			name: "InvalidStatus",
+			query: Query{
+				pageID:   1,
+				pageSize: n,
+				status:   "partying",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGuests(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
 		{
 			name: "BadRequest",
 			query: Query{
@@ -441,7 +861,7 @@ func TestGetGuestsAPI(t *testing.T) {
 			store := mockdb.NewMockStore(controller)
 			tc.buildStubs(store)
 
-			server := NewServer(store)
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
 			req, err := http.NewRequest(http.MethodGet, "/guest_list", nil)
@@ -449,8 +869,25 @@ func TestGetGuestsAPI(t *testing.T) {
 			params := req.URL.Query()
 			params.Add("page_id", fmt.Sprintf("%d", tc.query.pageID))
 			params.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
+			if tc.query.status != "" {
+				params.Add("status", tc.query.status)
+			}
+			if tc.query.tableID != 0 {
+				params.Add("table_id", fmt.Sprintf("%d", tc.query.tableID))
+			}
+			if tc.query.dietary != "" {
+				params.Add("dietary", tc.query.dietary)
+			}
+			if tc.query.vipTier != "" {
+				params.Add("vip_tier", tc.query.vipTier)
+			}
 			req.URL.RawQuery = params.Encode()
 
+			role := tc.role
+			if role == "" {
+				role = util.OrganiserRole
+			}
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, role, time.Minute)
 			server.router.ServeHTTP(recorder, req)
 			tc.checkResponse(recorder)
 		})
@@ -464,6 +901,12 @@ func randomGuest() db.Guest {
 		Entourage:   util.RandomGuestSize(),
 		TableID:     util.RandomInt(1, 20),
 		ArrivalTime: util.RandomGuestArrivalTime(),
+		RsvpStatus:  db.RSVPPending,
+		Status:      db.GuestInvited,
+		Dietary:     "vegetarian",
+		VipTier:     db.VIPGold,
+		Email:       fmt.Sprintf("%s@example.com", util.RandomString(6)),
+		Phone:       "07700900123",
 	}
 }
 
@@ -499,3 +942,204 @@ func requireBodyMatchGuests(t *testing.T, body *bytes.Buffer, guests []db.Guest)
 	require.NoError(t, err)
 	require.Equal(t, guests, guestsFetched)
 }
+
+func TestUpdateGuestStatusAPI(t *testing.T) {
+	guest := randomGuest()
+
+	cancelled := guest
+	cancelled.Status = db.GuestCancelled
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"status": db.GuestCancelled},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.TransitionGuestTxParams{
+					AuditInfo: testAudit,
+					ID:        guest.ID,
+					Status:    db.GuestCancelled,
+				}
+
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().
+					TransitionGuestTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(cancelled, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchGuest(t, recorder.Body, cancelled)
+			},
+		},
+		{
+			name: "IllegalTransition",
+			body: gin.H{"status": db.GuestConfirmed},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(cancelled, nil)
+				store.EXPECT().
+					TransitionGuestTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Guest{}, &db.TransitionError{From: db.GuestCancelled, To: db.GuestConfirmed})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "ArrivedMustUseArrival",
+			body: gin.H{"status": db.GuestArrived},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					TransitionGuestTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{"status": db.GuestNoShow},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(db.Guest{}, sql.ErrNoRows)
+				store.EXPECT().
+					TransitionGuestTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/guests/%s/status", guest.GuestName)
+			req, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestUpdateGuestProfileAPI(t *testing.T) {
+	guest := randomGuest()
+
+	updated := guest
+	updated.VipTier = ""
+	updated.Notes = "Seat near the stage"
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"vip_tier": "", "email": "", "notes": updated.Notes},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.UpdateGuestProfileTxParams{
+					AuditInfo: testAudit,
+					ID:        guest.ID,
+					VipTier:   sql.NullString{String: "", Valid: true},
+					Email:     sql.NullString{String: "", Valid: true},
+					Notes:     sql.NullString{String: updated.Notes, Valid: true},
+				}
+
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().
+					UpdateGuestProfileTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(updated, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchGuest(t, recorder.Body, updated)
+			},
+		},
+		{
+			name: "InvalidVIPTier",
+			body: gin.H{"vip_tier": "diamond"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateGuestProfileTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidEmail",
+			body: gin.H{"email": "not-an-email"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					UpdateGuestProfileTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: gin.H{"notes": updated.Notes},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(db.Guest{}, sql.ErrNoRows)
+				store.EXPECT().
+					UpdateGuestProfileTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/guests/%s/profile", guest.GuestName)
+			req, err := http.NewRequest(http.MethodPatch, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
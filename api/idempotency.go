@@ -0,0 +1,156 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	idempotencyKeyTTL    = 24 * time.Hour
+)
+
+// idempotentResponse is the stored outcome of a request made with an Idempotency-Key,
+// done is false while the original request is still being processed
+type idempotentResponse struct {
+	done      bool
+	status    int
+	body      []byte
+	header    http.Header
+	expiresAt time.Time
+}
+
+// idempotencyExpiry is when the response stored under key expires
+type idempotencyExpiry struct {
+	key       string
+	expiresAt time.Time
+}
+
+// idempotencyStore remembers responses to mutating requests so that a client retrying
+// with the same Idempotency-Key gets the original response instead of repeating the action.
+// Every response is kept for the same TTL, so expiries lists them in the order they expire.
+type idempotencyStore struct {
+	mu        sync.Mutex
+	responses map[string]*idempotentResponse
+	expiries  []idempotencyExpiry
+}
+
+func newIdempotencyStore() *idempotencyStore {
+	return &idempotencyStore{responses: map[string]*idempotentResponse{}}
+}
+
+// begin returns the stored response for key, or reserves the key when it hasn't been seen
+func (s *idempotencyStore) begin(key string, now time.Time) (*idempotentResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expire(now)
+	if response, ok := s.responses[key]; ok {
+		return response, true
+	}
+	s.responses[key] = &idempotentResponse{}
+	return nil, false
+}
+
+// expire drops the responses which expired by now, from the front of the expiries. An expiry whose
+// key has since been abandoned or stored again doesn't drop the newer response.
+func (s *idempotencyStore) expire(now time.Time) {
+	for len(s.expiries) > 0 && now.After(s.expiries[0].expiresAt) {
+		expiry := s.expiries[0]
+		s.expiries = s.expiries[1:]
+		if response, ok := s.responses[expiry.key]; ok && response.done && response.expiresAt.Equal(expiry.expiresAt) {
+			delete(s.responses, expiry.key)
+		}
+	}
+}
+
+func (s *idempotencyStore) finish(key string, response *idempotentResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[key] = response
+	s.expiries = append(s.expiries, idempotencyExpiry{key: key, expiresAt: response.expiresAt})
+}
+
+func (s *idempotencyStore) abandon(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.responses, key)
+}
+
+// recordingWriter captures the response body as it is written
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// idempotency replays the stored response of a mutating request carrying an Idempotency-Key
+// header that has already been processed. Server errors are not stored so they can be retried, and
+// the key is released if the handler panics. Keys are scoped to the acting user so it must run after
+// authMiddleware.
+func (server *Server) idempotency() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader(idempotencyKeyHeader)
+		if key == "" || ctx.Request.Method == http.MethodGet {
+			ctx.Next()
+			return
+		}
+
+		storeKey := actingUser(ctx) + " " + ctx.Request.Method + " " + ctx.Request.URL.Path + " " + key
+		response, seen := server.idempotencyKeys.begin(storeKey, time.Now())
+		if seen {
+			if !response.done {
+				ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "a request with this Idempotency-Key is already in progress"})
+				return
+			}
+			// Set rather than Add, the middleware before this one has already set headers such as
+			// X-Request-ID which the stored response carries too
+			for name, values := range response.header {
+				ctx.Writer.Header().Set(name, values[0])
+				for _, value := range values[1:] {
+					ctx.Writer.Header().Add(name, value)
+				}
+			}
+			ctx.Writer.Header().Set("Idempotent-Replayed", "true")
+			ctx.Data(response.status, response.header.Get("Content-Type"), response.body)
+			ctx.Abort()
+			return
+		}
+
+		defer func() {
+			if r := recover(); r != nil {
+				server.idempotencyKeys.abandon(storeKey)
+				panic(r)
+			}
+		}()
+
+		writer := &recordingWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+		ctx.Next()
+
+		if writer.Status() >= http.StatusInternalServerError {
+			server.idempotencyKeys.abandon(storeKey)
+			return
+		}
+		server.idempotencyKeys.finish(storeKey, &idempotentResponse{
+			done:      true,
+			status:    writer.Status(),
+			body:      writer.body.Bytes(),
+			header:    writer.Header().Clone(),
+			expiresAt: time.Now().Add(idempotencyKeyTTL),
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	table := randomTable()
+
+	testCases := []struct {
+		name       string
+		keys       []string
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, recorders []*httptest.ResponseRecorder)
+	}{
+		{
+			name: "ReplaysSameKey",
+			keys: []string{"key-1", "key-1"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTableTx(gomock.Any(), gomock.Any()).Times(1).Return(table, nil)
+			},
+			check: func(t *testing.T, recorders []*httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorders[1].Code)
+				require.Equal(t, "true", recorders[1].Header().Get("Idempotent-Replayed"))
+				require.Equal(t, recorders[0].Body.String(), recorders[1].Body.String())
+				require.Len(t, recorders[1].Header().Values(util.RequestIDHeader), 1)
+			},
+		},
+		{
+			name: "DistinctKeys",
+			keys: []string{"key-1", "key-2"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTableTx(gomock.Any(), gomock.Any()).Times(2).Return(table, nil)
+			},
+			check: func(t *testing.T, recorders []*httptest.ResponseRecorder) {
+				for _, recorder := range recorders {
+					require.Equal(t, http.StatusOK, recorder.Code)
+					require.Empty(t, recorder.Header().Get("Idempotent-Replayed"))
+				}
+			},
+		},
+		{
+			name: "ServerErrorsAreRetried",
+			keys: []string{"key-1", "key-1"},
+			buildStubs: func(store *mockdb.MockStore) {
+				first := store.EXPECT().CreateTableTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Table{}, sql.ErrConnDone)
+				second := store.EXPECT().CreateTableTx(gomock.Any(), gomock.Any()).Times(1).Return(table, nil)
+				gomock.InOrder(first, second)
+			},
+			check: func(t *testing.T, recorders []*httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorders[0].Code)
+				require.Equal(t, http.StatusOK, recorders[1].Code)
+			},
+		},
+		{
+			name: "PanicsAreRetried",
+			keys: []string{"key-1", "key-1"},
+			buildStubs: func(store *mockdb.MockStore) {
+				first := store.EXPECT().CreateTableTx(gomock.Any(), gomock.Any()).Times(1).
+					Do(func(ctx interface{}, arg db.CreateTableTxParams) { panic("store went away") })
+				second := store.EXPECT().CreateTableTx(gomock.Any(), gomock.Any()).Times(1).Return(table, nil)
+				gomock.InOrder(first, second)
+			},
+			check: func(t *testing.T, recorders []*httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorders[0].Code)
+				require.Equal(t, http.StatusOK, recorders[1].Code)
+				require.Empty(t, recorders[1].Header().Get("Idempotent-Replayed"))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			data, err := json.Marshal(gin.H{"size": table.Size})
+			require.NoError(t, err)
+
+			var recorders []*httptest.ResponseRecorder
+			for _, key := range tc.keys {
+				recorder := httptest.NewRecorder()
+				req, err := http.NewRequest(http.MethodPost, "/tables", bytes.NewReader(data))
+				require.NoError(t, err)
+				req.Header.Set(idempotencyKeyHeader, key)
+
+				addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
+				server.router.ServeHTTP(recorder, req)
+				recorders = append(recorders, recorder)
+			}
+			tc.check(t, recorders)
+		})
+	}
+}
+
+func TestIdempotencyStoreExpiry(t *testing.T) {
+	store := newIdempotencyStore()
+	now := time.Now()
+
+	_, seen := store.begin("a", now)
+	require.False(t, seen)
+	store.finish("a", &idempotentResponse{done: true, status: http.StatusOK, expiresAt: now.Add(time.Minute)})
+
+	_, seen = store.begin("b", now.Add(time.Second))
+	require.False(t, seen)
+	store.finish("b", &idempotentResponse{done: true, status: http.StatusOK, expiresAt: now.Add(time.Second + time.Minute)})
+
+	// Only the responses which have expired are dropped, in the order they were stored
+	response, seen := store.begin("a", now.Add(time.Minute))
+	require.True(t, seen)
+	require.True(t, response.done)
+
+	_, seen = store.begin("c", now.Add(time.Minute+time.Millisecond))
+	require.False(t, seen)
+	require.NotContains(t, store.responses, "a")
+	require.Contains(t, store.responses, "b")
+	require.Len(t, store.expiries, 1)
+
+	// A key still in progress is never expired
+	_, seen = store.begin("c", now.Add(24*time.Hour))
+	require.True(t, seen)
+	require.NotContains(t, store.responses, "b")
+	require.Empty(t, store.expiries)
+}
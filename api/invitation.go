@@ -0,0 +1,267 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/token"
+	"github.com/gin-gonic/gin"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+// qrCodeSize is the width and height in pixels of the invitation QR codes
+const qrCodeSize = 256
+
+type invitationResponse struct {
+	GuestName string `json:"guest_name"`
+	Token     string `json:"token"`
+}
+
+// issueInvitation godoc
+// @Summary Issues a new invitation to the guest.
+// @Description Every guest is sent an invitation when they're added to the guest list, this replaces it (e.g. if the QR code was lost), revoking the old one.
+// @Accept json
+// @Produce json
+// @Param    name     path      string  true  "Guest Name"
+// @Success 200 {object} invitationResponse
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /guests/{name}/invitation [post]
+func (server *Server) issueInvitation(ctx *gin.Context) {
+	var req getGuestFromNameRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	guest, err := server.store.GetGuestFromName(ctx, req.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	invitation, err := server.store.IssueInvitationTx(ctx, db.IssueInvitationTxParams{
+		AuditInfo: auditInfo(ctx),
+		GuestID:   guest.ID,
+	})
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.newInvitationResponse(guest, invitation))
+}
+
+// getInvitation godoc
+// @Summary returns the guest's current invitation token.
+// @Description Fetches the token of the guest's unused, unrevoked invitation.
+// @Accept json
+// @Produce json
+// @Param    name     path      string  true  "Guest Name"
+// @Success 200 {object} invitationResponse
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /guests/{name}/invitation [get]
+func (server *Server) getInvitation(ctx *gin.Context) {
+	guest, invitation, ok := server.activeInvitation(ctx)
+	if !ok {
+		return
+	}
+
+	ctx.JSON(http.StatusOK, server.newInvitationResponse(guest, invitation))
+}
+
+// getInvitationQRCode godoc
+// @Summary returns the guest's current invitation as a QR code.
+// @Description Renders the token of the guest's unused, unrevoked invitation as a PNG QR code to be sent to the guest and scanned on arrival.
+// @Accept json
+// @Produce png
+// @Param    name     path      string  true  "Guest Name"
+// @Success 200 {file} file
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /guests/{name}/invitation/qr [get]
+func (server *Server) getInvitationQRCode(ctx *gin.Context) {
+	guest, invitation, ok := server.activeInvitation(ctx)
+	if !ok {
+		return
+	}
+
+	png, err := qrcode.Encode(server.invitationSigner.Sign(invitationClaims(guest, invitation)), qrcode.Medium, qrCodeSize)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.Data(http.StatusOK, "image/png", png)
+}
+
+// revokeInvitation godoc
+// @Summary Revokes the guest's invitation.
+// @Description The guest's current invitation can no longer be scanned to arrive, until a new one is issued.
+// @Accept json
+// @Produce json
+// @Param    name     path      string  true  "Guest Name"
+// @Success 200 {string} string
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /guests/{name}/invitation [delete]
+func (server *Server) revokeInvitation(ctx *gin.Context) {
+	var req getGuestFromNameRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	guest, err := server.store.GetGuestFromName(ctx, req.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	err = server.store.RevokeInvitationTx(ctx, db.RevokeInvitationTxParams{
+		AuditInfo: auditInfo(ctx),
+		GuestID:   guest.ID,
+	})
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, guest.GuestName)
+}
+
+// Entourage has no "required" binding as that would reject a guest coming alone,
+// its presence is enforced by the openapi spec instead
+type scanInvitationRequest struct {
+	Token     string `json:"token" binding:"required"`
+	Entourage int32  `json:"entourage" binding:"min=0"`
+}
+
+// scanInvitation godoc
+// @Summary Arrives the holder of a scanned invitation into the party
+// @Description Verifies the scanned token and arrives its guest and their party at their table, as PUT /guests/{name} does. Each invitation can only be used to arrive once.
+// @Accept json
+// @Produce json
+// @Param        request     body       scanInvitationRequest  true  "Scanned token and entourage (May be different to original)"
+// @Success 200 {object} db.Guest
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 409 {object} admissionError
+// @Failure 410 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /checkin/scan [post]
+func (server *Server) scanInvitation(ctx *gin.Context) {
+	var req scanInvitationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	claims, err := server.invitationSigner.Verify(req.Token)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	result, err := server.store.ArriveByInvitationTx(ctx, db.ArriveByInvitationTxParams{
+		AuditInfo:    auditInfo(ctx),
+		InvitationID: claims.InvitationID,
+		GuestID:      claims.GuestID,
+		NewEntourage: int64(req.Entourage),
+	})
+	if err != nil {
+		var denied *db.AdmissionDeniedError
+		switch {
+		case err == sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.As(err, &denied) && !denied.TableCapacityOnly():
+			ctx.JSON(http.StatusConflict, admissionDeniedResponse(denied))
+		case errors.Is(err, db.ErrBanned):
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+		case err == db.ErrInvitationRevoked:
+			ctx.JSON(http.StatusGone, errorResponse(err))
+		case err == db.ErrInvitationUsed, err == db.ErrGuestAlreadyArrived, err == db.ErrReentryDenied,
+			errors.Is(err, db.ErrIllegalTransition), errors.Is(err, db.ErrVenueFull), errors.Is(err, db.ErrZoneFull):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		case errors.Is(err, db.ErrInsufficientTableSize), errors.Is(err, db.ErrPartySize):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result.Guest)
+}
+
+// activeInvitation looks up the named guest and their current invitation, writing the error
+// response and returning false if either can't be found
+func (server *Server) activeInvitation(ctx *gin.Context) (db.Guest, db.Invitation, bool) {
+	var req getGuestFromNameRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return db.Guest{}, db.Invitation{}, false
+	}
+
+	guest, err := server.store.GetGuestFromName(ctx, req.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return db.Guest{}, db.Invitation{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.Guest{}, db.Invitation{}, false
+	}
+
+	invitation, err := server.store.GetActiveInvitationFromGuest(ctx, guest.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return db.Guest{}, db.Invitation{}, false
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return db.Guest{}, db.Invitation{}, false
+	}
+
+	return guest, invitation, true
+}
+
+func (server *Server) newInvitationResponse(guest db.Guest, invitation db.Invitation) invitationResponse {
+	return invitationResponse{
+		GuestName: guest.GuestName,
+		Token:     server.invitationSigner.Sign(invitationClaims(guest, invitation)),
+	}
+}
+
+func invitationClaims(guest db.Guest, invitation db.Invitation) token.InvitationClaims {
+	return token.InvitationClaims{InvitationID: invitation.ID, GuestID: guest.ID}
+}
@@ -0,0 +1,373 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"image/png"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/token"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestScanInvitationAPI(t *testing.T) {
+	guest := randomGuest()
+	invitation := randomInvitation(guest)
+	claims := token.InvitationClaims{InvitationID: invitation.ID, GuestID: guest.ID}
+
+	arg := db.ArriveByInvitationTxParams{
+		AuditInfo:    testAudit,
+		InvitationID: invitation.ID,
+		GuestID:      guest.ID,
+		NewEntourage: 2,
+	}
+
+	testCases := []struct {
+		name          string
+		body          func(signer *token.InvitationSigner) gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: func(signer *token.InvitationSigner) gin.H {
+				return gin.H{"token": signer.Sign(claims), "entourage": 2}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ArriveByInvitationTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.AssignTableTxResult{Guest: guest}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchGuest(t, recorder.Body, guest)
+			},
+		},
+		{
+			name: "ForgedToken",
+			body: func(signer *token.InvitationSigner) gin.H {
+				forger, err := token.NewInvitationSigner(util.RandomString(32), "test-event")
+				require.NoError(t, err)
+				return gin.H{"token": forger.Sign(claims), "entourage": 2}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ArriveByInvitationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			body: func(signer *token.InvitationSigner) gin.H {
+				return gin.H{"token": signer.Sign(claims), "entourage": 2}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ArriveByInvitationTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.AssignTableTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "Revoked",
+			body: func(signer *token.InvitationSigner) gin.H {
+				return gin.H{"token": signer.Sign(claims), "entourage": 2}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ArriveByInvitationTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.AssignTableTxResult{}, db.ErrInvitationRevoked)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusGone, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyUsed",
+			body: func(signer *token.InvitationSigner) gin.H {
+				return gin.H{"token": signer.Sign(claims), "entourage": 2}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ArriveByInvitationTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.AssignTableTxResult{}, db.ErrInvitationUsed)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "InsufficientTableSize",
+			body: func(signer *token.InvitationSigner) gin.H {
+				return gin.H{"token": signer.Sign(claims), "entourage": 2}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ArriveByInvitationTx(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(db.AssignTableTxResult{}, &db.AdmissionDeniedError{Decisions: []db.AdmissionDecision{
+						{Policy: db.PolicyTableCapacity, Allowed: false, Reason: "party doesn't fit"},
+					}})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "MissingToken",
+			body: func(signer *token.InvitationSigner) gin.H {
+				return gin.H{"entourage": 2}
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					ArriveByInvitationTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body(server.invitationSigner))
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/checkin/scan", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, util.DoorStaffRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestIssueInvitationAPI(t *testing.T) {
+	guest := randomGuest()
+	invitation := randomInvitation(guest)
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().
+		GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).
+		Times(1).
+		Return(guest, nil)
+	store.EXPECT().
+		IssueInvitationTx(gomock.Any(), gomock.Eq(db.IssueInvitationTxParams{AuditInfo: testAudit, GuestID: guest.ID})).
+		Times(1).
+		Return(invitation, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	request, err := http.NewRequest(http.MethodPost, "/guests/"+guest.GuestName+"/invitation", nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
+	server.router.ServeHTTP(recorder, request)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var got invitationResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+	require.Equal(t, guest.GuestName, got.GuestName)
+
+	// The token issued is the one the door will accept
+	claims, err := server.invitationSigner.Verify(got.Token)
+	require.NoError(t, err)
+	require.Equal(t, invitation.ID, claims.InvitationID)
+	require.Equal(t, guest.ID, claims.GuestID)
+}
+
+func TestGetInvitationQRCodeAPI(t *testing.T) {
+	guest := randomGuest()
+	invitation := randomInvitation(guest)
+
+	testCases := []struct {
+		name          string
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).
+					Times(1).
+					Return(guest, nil)
+				store.EXPECT().
+					GetActiveInvitationFromGuest(gomock.Any(), gomock.Eq(guest.ID)).
+					Times(1).
+					Return(invitation, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "image/png", recorder.Header().Get("Content-Type"))
+
+				body, err := ioutil.ReadAll(recorder.Body)
+				require.NoError(t, err)
+				img, err := png.Decode(bytes.NewReader(body))
+				require.NoError(t, err)
+				require.Equal(t, qrCodeSize, img.Bounds().Dx())
+			},
+		},
+		{
+			name: "NoActiveInvitation",
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).
+					Times(1).
+					Return(guest, nil)
+				store.EXPECT().
+					GetActiveInvitationFromGuest(gomock.Any(), gomock.Eq(guest.ID)).
+					Times(1).
+					Return(db.Invitation{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "DoorStaffForbidden",
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGuestFromName(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/guests/"+guest.GuestName+"/invitation/qr", nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, tc.role, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestRevokeInvitationAPI(t *testing.T) {
+	guest := randomGuest()
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).
+					Times(1).
+					Return(guest, nil)
+				store.EXPECT().
+					RevokeInvitationTx(gomock.Any(), gomock.Eq(db.RevokeInvitationTxParams{AuditInfo: testAudit, GuestID: guest.ID})).
+					Times(1).
+					Return(nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchGuestName(t, recorder.Body, guest.GuestName)
+			},
+		},
+		{
+			name: "NoActiveInvitation",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).
+					Times(1).
+					Return(guest, nil)
+				store.EXPECT().
+					RevokeInvitationTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodDelete, "/guests/"+guest.GuestName+"/invitation", nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func randomInvitation(guest db.Guest) db.Invitation {
+	return db.Invitation{
+		ID:        util.RandomInt(1, 1000),
+		GuestID:   guest.ID,
+		CreatedBy: testUsername,
+		CreatedAt: time.Now(),
+	}
+}
@@ -1,12 +1,62 @@
 package api
 
 import (
+	"fmt"
+	"net/http"
 	"os"
 	"testing"
+	"time"
 
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/token"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
 	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
 )
 
+const (
+	testUsername  = "test-user"
+	testAPIKey    = "test-api-key"
+	testRequestID = "test-request"
+)
+
+// testAudit is the audit info of every request authorized by addAuthorization
+var testAudit = db.AuditInfo{Actor: testUsername, RequestID: testRequestID}
+
+func newTestServer(t *testing.T, store db.Store) *Server {
+	config := util.Config{
+		TokenSymmetricKey:      util.RandomString(32),
+		AccessTokenDuration:    time.Minute,
+		EventName:              "test-event",
+		InvitationSymmetricKey: util.RandomString(32),
+		APIKeys:                fmt.Sprintf("dashboard:%s:%s", util.ViewerRole, testAPIKey),
+	}
+
+	server, err := NewServer(config, store)
+	require.NoError(t, err)
+	return server
+}
+
+// addAuthorization signs a token for username with role and sets it on the request, along with
+// testRequestID so the audit info passed to the store is predictable
+func addAuthorization(
+	t *testing.T,
+	request *http.Request,
+	tokenMaker token.Maker,
+	authorizationType string,
+	username string,
+	role string,
+	duration time.Duration,
+) {
+	accessToken, payload, err := tokenMaker.CreateToken(username, role, duration)
+	require.NoError(t, err)
+	require.NotEmpty(t, payload)
+
+	authorizationHeader := fmt.Sprintf("%s %s", authorizationType, accessToken)
+	request.Header.Set(authorizationHeaderKey, authorizationHeader)
+	request.Header.Set(util.RequestIDHeader, testRequestID)
+}
+
 func TestMain(m *testing.M) {
 	// Set gin to test mode to reduce logs
 	gin.SetMode(gin.TestMode)
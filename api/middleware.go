@@ -0,0 +1,105 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/token"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	authorizationHeaderKey  = "authorization"
+	authorizationTypeBearer = "bearer"
+	apiKeyHeaderKey         = "x-api-key"
+	authorizationPayloadKey = "authorization_payload"
+	requestIDKey            = "request_id"
+)
+
+// requestIDMiddleware tags every request with an ID, the caller's own X-Request-ID if they sent
+// one, which is echoed in the response and recorded with any change the request makes
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		requestID := util.RequestID(ctx.GetHeader(util.RequestIDHeader))
+
+		ctx.Set(requestIDKey, requestID)
+		ctx.Request = ctx.Request.WithContext(util.NewRequestIDContext(ctx.Request.Context(), requestID))
+		ctx.Header(util.RequestIDHeader, requestID)
+		ctx.Next()
+	}
+}
+
+// authMiddleware authenticates the caller from either a bearer JWT or an X-API-Key header
+// and stores their payload on both the gin context and the request context
+func authMiddleware(tokenMaker token.Maker, apiKeys *token.APIKeys) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		payload, err := authenticate(ctx, tokenMaker, apiKeys)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusUnauthorized, errorResponse(err))
+			return
+		}
+
+		ctx.Set(authorizationPayloadKey, payload)
+		ctx.Request = ctx.Request.WithContext(token.NewContext(ctx.Request.Context(), payload))
+		ctx.Next()
+	}
+}
+
+func authenticate(ctx *gin.Context, tokenMaker token.Maker, apiKeys *token.APIKeys) (*token.Payload, error) {
+	if key := ctx.GetHeader(apiKeyHeaderKey); key != "" {
+		return apiKeys.Verify(key)
+	}
+
+	authorizationHeader := ctx.GetHeader(authorizationHeaderKey)
+	if len(authorizationHeader) == 0 {
+		return nil, errors.New("authorization header is not provided")
+	}
+
+	fields := strings.Fields(authorizationHeader)
+	if len(fields) != 2 {
+		return nil, errors.New("invalid authorization header format")
+	}
+
+	authorizationType := strings.ToLower(fields[0])
+	if authorizationType != authorizationTypeBearer {
+		return nil, fmt.Errorf("unsupported authorization type %s", authorizationType)
+	}
+
+	return tokenMaker.VerifyToken(fields[1])
+}
+
+// requireRole rejects callers whose role is not one of roles, it must run after authMiddleware
+func requireRole(roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		payload := ctx.MustGet(authorizationPayloadKey).(*token.Payload)
+		for _, role := range roles {
+			if payload.Role == role {
+				ctx.Next()
+				return
+			}
+		}
+		ctx.AbortWithStatusJSON(http.StatusForbidden, errorResponse(fmt.Errorf("role %s is not permitted to %s %s", payload.Role, ctx.Request.Method, ctx.FullPath())))
+	}
+}
+
+// actingUser returns the username of the authenticated caller, to be recorded on mutations
+func actingUser(ctx *gin.Context) string {
+	return ctx.MustGet(authorizationPayloadKey).(*token.Payload).Username
+}
+
+// actingRole returns the role of the authenticated caller
+func actingRole(ctx *gin.Context) string {
+	return ctx.MustGet(authorizationPayloadKey).(*token.Payload).Role
+}
+
+// auditInfo attributes a mutation to the authenticated caller and the current request
+func auditInfo(ctx *gin.Context) db.AuditInfo {
+	return db.AuditInfo{
+		Actor:     actingUser(ctx),
+		RequestID: ctx.GetString(requestIDKey),
+	}
+}
@@ -0,0 +1,190 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	"github.com/ellisp97/BE_Task_Oct20/golang/token"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	testCases := []struct {
+		name          string
+		setupAuth     func(t *testing.T, request *http.Request, tokenMaker token.Maker)
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, testUsername, util.ViewerRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEmptySeats(gomock.Any()).Times(1).Return(int32(4), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "APIKey",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				request.Header.Set(apiKeyHeaderKey, testAPIKey)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEmptySeats(gomock.Any()).Times(1).Return(int32(4), nil)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidAPIKey",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				request.Header.Set(apiKeyHeaderKey, "not-a-key")
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEmptySeats(gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name:      "NoAuthorization",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEmptySeats(gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "UnsupportedAuthorization",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, "unsupported", testUsername, util.ViewerRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEmptySeats(gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidAuthorizationFormat",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, "", testUsername, util.ViewerRole, time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEmptySeats(gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+		{
+			name: "ExpiredToken",
+			setupAuth: func(t *testing.T, request *http.Request, tokenMaker token.Maker) {
+				addAuthorization(t, request, tokenMaker, authorizationTypeBearer, testUsername, util.ViewerRole, -time.Minute)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEmptySeats(gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusUnauthorized, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, "/seats_empty", nil)
+			require.NoError(t, err)
+
+			tc.setupAuth(t, req, server.tokenMaker)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(t, recorder)
+		})
+	}
+}
+
+func TestRolePermissions(t *testing.T) {
+	testCases := []struct {
+		name   string
+		role   string
+		method string
+		url    string
+	}{
+		{name: "ViewerUpdateGuestProfile", role: util.ViewerRole, method: http.MethodPatch, url: "/guests/someone/profile"},
+		{name: "ViewerArriveGuest", role: util.ViewerRole, method: http.MethodPut, url: "/guests/someone"},
+		{name: "ViewerGraphQL", role: util.ViewerRole, method: http.MethodPost, url: "/graphql"},
+		{name: "DoorStaffCreateGuest", role: util.DoorStaffRole, method: http.MethodPost, url: "/guest_list/someone"},
+		{name: "DoorStaffCreateTable", role: util.DoorStaffRole, method: http.MethodPost, url: "/tables"},
+		{name: "DoorStaffCreateToken", role: util.DoorStaffRole, method: http.MethodPost, url: "/tokens"},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			// Nothing reaches the store, so any call on the mock fails the test
+			store := mockdb.NewMockStore(controller)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(tc.method, tc.url, nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, tc.role, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			require.Equal(t, http.StatusForbidden, recorder.Code)
+		})
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().GetEmptySeats(gomock.Any()).AnyTimes().Return(int32(4), nil)
+	server := newTestServer(t, store)
+
+	// The caller's own ID is echoed back
+	recorder := httptest.NewRecorder()
+	req, err := http.NewRequest(http.MethodGet, "/seats_empty", nil)
+	require.NoError(t, err)
+	addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, util.ViewerRole, time.Minute)
+	server.router.ServeHTTP(recorder, req)
+	require.Equal(t, testRequestID, recorder.Header().Get(util.RequestIDHeader))
+
+	// Requests without one, even rejected ones, are given a fresh ID
+	recorder = httptest.NewRecorder()
+	req, err = http.NewRequest(http.MethodGet, "/seats_empty", nil)
+	require.NoError(t, err)
+	server.router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusUnauthorized, recorder.Code)
+	require.NotEmpty(t, recorder.Header().Get(util.RequestIDHeader))
+	require.NotEqual(t, testRequestID, recorder.Header().Get(util.RequestIDHeader))
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+// The table being moved to is given by either its ID or label
+type moveGuestRequest struct {
+	TableID    int32  `json:"table_id" binding:"required_without=TableLabel,omitempty,min=1,excluded_with=TableLabel"`
+	TableLabel string `json:"table_label" binding:"max=64"`
+}
+
+// moveGuest godoc
+// @Summary Moves a guest to a different table
+// @Description Moves a seated guest, or the reservation of a guest yet to arrive, to another table. A seated party must fit in the free seats at the new table, and within the maximum occupancy of its zone when it's in another one, and their arrival moves with them, freeing their seats at the old table. A reservation must fit alongside every other reservation at the new table, along with any numbered seats the guest was holding. The old and new tables are returned as they are after the move.
+// @Accept json
+// @Produce json
+// @Param        name        path       string            true  "Guest Name"
+// @Param        request     body       moveGuestRequest  true  "Table ID or label to move to"
+// @Success 200 {object} db.MoveGuestTxResult
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 409 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /guests/{name}/move [post]
+func (server *Server) moveGuest(ctx *gin.Context) {
+	var reqName getGuestFromNameRequest
+	if err := ctx.ShouldBindUri(&reqName); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	var req moveGuestRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	guest, err := server.store.GetGuestFromName(ctx, reqName.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	tableID := req.TableID
+	if req.TableLabel != "" {
+		table, err := server.store.GetTableFromLabel(ctx, req.TableLabel)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		tableID = table.ID
+	}
+
+	result, err := server.store.MoveGuestTx(ctx, db.MoveGuestTxParams{
+		AuditInfo: auditInfo(ctx),
+		ID:        guest.ID,
+		TableID:   tableID,
+	})
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, db.ErrInsufficientTableSize), errors.Is(err, db.ErrPartySize):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		case err == db.ErrAlreadyAtTable, errors.Is(err, db.ErrZoneFull):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,210 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMoveGuestAPI(t *testing.T) {
+	oldTable := randomTable()
+	table := randomTable()
+	guest := randomGuest()
+	guest.TableID = table.ID
+	guest.Status = db.GuestArrived
+	arrival := db.Arrival{ID: 1, GuestID: guest.ID, TableID: table.ID, PartySize: guest.Entourage + 1, ArrivedBy: util.DoorStaffRole}
+	seats := []db.Seat{
+		{ID: 1, TableID: table.ID, Number: 1, GuestID: sql.NullInt32{Int32: guest.ID, Valid: true}},
+	}
+	moved := db.MoveGuestTxResult{Guest: guest, Arrival: &arrival, OldTable: oldTable, Table: table, Seats: seats}
+
+	testCases := []struct {
+		name          string
+		guestName     string
+		body          gin.H
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:      "OK",
+			guestName: guest.GuestName,
+			body:      gin.H{"table_id": table.ID},
+			role:      util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().
+					MoveGuestTx(gomock.Any(), gomock.Eq(db.MoveGuestTxParams{
+						AuditInfo: testAudit,
+						ID:        guest.ID,
+						TableID:   table.ID,
+					})).
+					Times(1).
+					Return(moved, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.MoveGuestTxResult
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, oldTable.ID, got.OldTable.ID)
+				require.Equal(t, table.ID, got.Table.ID)
+				require.NotNil(t, got.Arrival)
+				require.Equal(t, table.ID, got.Arrival.TableID)
+			},
+		},
+		{
+			name:      "TableLabel",
+			guestName: guest.GuestName,
+			body:      gin.H{"table_label": table.Label},
+			role:      util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().GetTableFromLabel(gomock.Any(), gomock.Eq(table.Label)).Times(1).Return(table, nil)
+				store.EXPECT().
+					MoveGuestTx(gomock.Any(), gomock.Eq(db.MoveGuestTxParams{
+						AuditInfo: testAudit,
+						ID:        guest.ID,
+						TableID:   table.ID,
+					})).
+					Times(1).
+					Return(db.MoveGuestTxResult{Guest: guest, OldTable: oldTable, Table: table}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:      "MissingTable",
+			guestName: guest.GuestName,
+			body:      gin.H{},
+			role:      util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().MoveGuestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "GuestNotFound",
+			guestName: guest.GuestName,
+			body:      gin.H{"table_id": table.ID},
+			role:      util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Any()).Times(1).Return(db.Guest{}, sql.ErrNoRows)
+				store.EXPECT().MoveGuestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "TableNotFound",
+			guestName: guest.GuestName,
+			body:      gin.H{"table_id": table.ID},
+			role:      util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Any()).Times(1).Return(guest, nil)
+				store.EXPECT().MoveGuestTx(gomock.Any(), gomock.Any()).Times(1).Return(db.MoveGuestTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:      "InsufficientTableSize",
+			guestName: guest.GuestName,
+			body:      gin.H{"table_id": table.ID},
+			role:      util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Any()).Times(1).Return(guest, nil)
+				store.EXPECT().
+					MoveGuestTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MoveGuestTxResult{}, db.InsufficientTableSizeErr(int(table.ID)))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:      "AlreadyAtTable",
+			guestName: guest.GuestName,
+			body:      gin.H{"table_id": table.ID},
+			role:      util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Any()).Times(1).Return(guest, nil)
+				store.EXPECT().MoveGuestTx(gomock.Any(), gomock.Any()).Times(1).Return(db.MoveGuestTxResult{}, db.ErrAlreadyAtTable)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:      "ZoneFull",
+			guestName: guest.GuestName,
+			body:      gin.H{"table_id": table.ID},
+			role:      util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Any()).Times(1).Return(guest, nil)
+				store.EXPECT().
+					MoveGuestTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.MoveGuestTxResult{}, &db.CapacityError{Zone: "terrace", MaxOccupancy: 10, Occupancy: 9, PartySize: 2})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name:      "Viewer",
+			guestName: guest.GuestName,
+			body:      gin.H{"table_id": table.ID},
+			role:      util.ViewerRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().MoveGuestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/guests/%s/move", tc.guestName)
+			request, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, tc.role, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ellisp97/BE_Task_Oct20/golang/docs"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+)
+
+// Invitation QR codes and the HTML event report are the only non-JSON bodies, their responses are
+// validated as strings
+func init() {
+	openapi3filter.RegisterBodyDecoder("image/png", openapi3filter.FileBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("text/html", openapi3filter.FileBodyDecoder)
+}
+
+// loadSpec parses and validates the OpenAPI 3 document in docs/openapi.yaml
+func loadSpec() (*openapi3.T, routers.Router, error) {
+	spec, err := openapi3.NewLoader().LoadFromData(docs.OpenAPI)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot load openapi spec: %w", err)
+	}
+	if err = spec.Validate(context.Background()); err != nil {
+		return nil, nil, fmt.Errorf("invalid openapi spec: %w", err)
+	}
+
+	// Routes are matched on path alone, whichever host the server is reached on
+	spec.Servers = nil
+	router, err := gorillamux.NewRouter(spec)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot route openapi spec: %w", err)
+	}
+	return spec, router, nil
+}
+
+// bufferedWriter holds back the response body so it can be validated before being sent
+type bufferedWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+// validateOpenAPI rejects requests to documented routes which don't match the spec with a 400.
+// In gin's test mode responses are checked too, and replaced with a 500 describing the drift
+// so that any handler test exercising the route fails.
+func (server *Server) validateOpenAPI() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		route, pathParams, err := server.specRouter.FindRoute(ctx.Request)
+		if err != nil {
+			// Undocumented routes such as the swagger UI are served as they are
+			ctx.Next()
+			return
+		}
+
+		// Every body the API accepts is JSON, so callers aren't required to say so
+		if ctx.Request.ContentLength != 0 && ctx.GetHeader("Content-Type") == "" {
+			ctx.Request.Header.Set("Content-Type", gin.MIMEJSON)
+		}
+
+		// Credentials are checked by authMiddleware, which runs first
+		options := &openapi3filter.Options{
+			IncludeResponseStatus: true,
+			MultiError:            true,
+			AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+		}
+		requestInput := &openapi3filter.RequestValidationInput{
+			Request:    ctx.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		}
+		if err := openapi3filter.ValidateRequest(ctx, requestInput); err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+
+		if gin.Mode() != gin.TestMode {
+			ctx.Next()
+			return
+		}
+
+		writer := &bufferedWriter{ResponseWriter: ctx.Writer}
+		ctx.Writer = writer
+		ctx.Next()
+		ctx.Writer = writer.ResponseWriter
+
+		responseInput := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: requestInput,
+			Status:                 writer.Status(),
+			Header:                 writer.Header(),
+			Options:                options,
+		}
+		responseInput.SetBodyBytes(writer.body.Bytes())
+		if err := openapi3filter.ValidateResponse(ctx, responseInput); err != nil {
+			ctx.JSON(http.StatusInternalServerError, errorResponse(fmt.Errorf("response does not match the openapi spec: %w", err)))
+			return
+		}
+		ctx.Writer.Write(writer.body.Bytes())
+	}
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRoutesMatchSpec fails when a route is registered without being documented in docs/openapi.yaml, or the other way round
+func TestRoutesMatchSpec(t *testing.T) {
+	spec, _, err := loadSpec()
+	require.NoError(t, err)
+
+	server := newTestServer(t, nil)
+	param := regexp.MustCompile(`:(\w+)`)
+	registered := map[string]bool{}
+	for _, route := range server.router.Routes() {
+		if route.Path == "/swagger/*any" || route.Path == "/openapi.yaml" {
+			continue
+		}
+		path := param.ReplaceAllString(route.Path, "{$1}")
+		registered[route.Method+" "+path] = true
+
+		item := spec.Paths.Find(path)
+		require.NotNilf(t, item, "%s is not documented", path)
+		require.NotNilf(t, item.GetOperation(route.Method), "%s %s is not documented", route.Method, path)
+	}
+
+	for path, item := range spec.Paths {
+		for method := range item.Operations() {
+			require.Truef(t, registered[method+" "+path], "%s %s is documented but not served", method, path)
+		}
+	}
+}
+
+func TestRequestValidation(t *testing.T) {
+	guest := randomGuest()
+
+	testCases := []struct {
+		name       string
+		method     string
+		url        string
+		body       gin.H
+		buildStubs func(store *mockdb.MockStore)
+		code       int
+	}{
+		{
+			name:   "PageSizeAboveMaximum",
+			method: http.MethodGet,
+			url:    "/guest_list?page_id=1&page_size=20",
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "MissingEntourage",
+			method: http.MethodPost,
+			url:    "/guest_list/" + guest.GuestName,
+			body:   gin.H{"table_id": guest.TableID},
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "UnknownField",
+			method: http.MethodPut,
+			url:    "/guests/" + guest.GuestName,
+			body:   gin.H{"entourage": 1, "table_id": guest.TableID},
+			code:   http.StatusBadRequest,
+		},
+		{
+			name:   "GuestComingAlone",
+			method: http.MethodPut,
+			url:    "/guests/" + guest.GuestName,
+			body:   gin.H{"entourage": 0},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().AssignTableTx(gomock.Any(), gomock.Eq(db.AssignTableTxParams{
+					UserID:       int64(guest.ID),
+					TableID:      int64(guest.TableID),
+					NewEntourage: 0,
+					AuditInfo:    testAudit,
+				})).Times(1).Return(db.AssignTableTxResult{Guest: guest}, nil)
+			},
+			code: http.StatusOK,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			// Requests rejected by the spec never reach the store
+			store := mockdb.NewMockStore(controller)
+			if tc.buildStubs != nil {
+				tc.buildStubs(store)
+			}
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			var body bytes.Buffer
+			if tc.body != nil {
+				require.NoError(t, json.NewEncoder(&body).Encode(tc.body))
+			}
+			req, err := http.NewRequest(tc.method, tc.url, &body)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			require.Equal(t, tc.code, recorder.Code, recorder.Body.String())
+		})
+	}
+}
+
+func TestResponseValidation(t *testing.T) {
+	guest := randomGuest()
+	invalid := guest
+	invalid.GuestName = "abc" // the spec requires guest names of at least 5 characters
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(invalid, nil)
+
+	server := newTestServer(t, store)
+	recorder := httptest.NewRecorder()
+
+	req, err := http.NewRequest(http.MethodGet, "/guests/"+guest.GuestName, nil)
+	require.NoError(t, err)
+
+	addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
+	server.router.ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusInternalServerError, recorder.Code)
+	require.Contains(t, recorder.Body.String(), "openapi spec")
+}
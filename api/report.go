@@ -0,0 +1,60 @@
+package api
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/ellisp97/BE_Task_Oct20/golang/report"
+	"github.com/gin-gonic/gin"
+)
+
+// The report is JSON unless format is html
+type reportRequest struct {
+	analyticsRequest
+	Format string `form:"format" binding:"omitempty,oneof=json html"`
+}
+
+// getReport godoc
+// @Summary returns the end-of-event report
+// @Description Builds the report of the event over its window: the totals, every party turned away at the door with the reasons, the guests marked no-show within the window, how full each table was, a timeline of every arrival and departure, any periods a table, zone or the venue was over capacity, and the corrections made by hand. The window runs from the first arrival or change of occupancy until now unless from or until are given. With format=html the report is a self-contained HTML document.
+// @Accept json
+// @Produce json,html
+// @Param        from    query      string  false  "Start of the window (RFC 3339)"
+// @Param        until   query      string  false  "End of the window (RFC 3339)"
+// @Param        format  query      string  false  "json or html"
+// @Success 200 {object} report.Report
+// @Failure 400 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /report [get]
+func (server *Server) getReport(ctx *gin.Context) {
+	var req reportRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	window, ok := eventWindow(ctx, req.analyticsRequest)
+	if !ok {
+		return
+	}
+
+	rsp, err := report.Build(ctx, server.store, window)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	if req.Format != "html" {
+		ctx.JSON(http.StatusOK, rsp)
+		return
+	}
+
+	var page bytes.Buffer
+	if err = rsp.WriteHTML(&page); err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", page.Bytes())
+}
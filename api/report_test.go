@@ -0,0 +1,137 @@
+package api
+
+import (
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/report"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetReportAPI(t *testing.T) {
+	from := time.Date(2022, 10, 20, 19, 0, 0, 0, time.UTC)
+	until := from.Add(time.Hour)
+	table, arrivals, snapshots := randomEvent(from)
+	window := url.Values{"from": {from.Format(time.RFC3339)}, "until": {until.Format(time.RFC3339)}}
+	noShow := randomGuest()
+	noShow.Status = db.GuestNoShow
+
+	buildReportStubs := func(store *mockdb.MockStore) {
+		store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Eq(db.ListEventArrivalsParams{From: from, Until: until})).
+			Times(1).Return(arrivals, nil)
+		store.EXPECT().ListLastOccupancySnapshots(gomock.Any(), gomock.Eq(from)).Times(1).Return([]db.OccupancySnapshot{}, nil)
+		store.EXPECT().ListOccupancySnapshots(gomock.Any(), gomock.Eq(db.ListOccupancySnapshotsParams{From: from, Until: until})).Times(1).Return(snapshots, nil)
+		store.EXPECT().ListEventStandingAdmissions(gomock.Any(), gomock.Any()).Times(1).Return([]db.StandingAdmission{}, nil)
+		store.EXPECT().ListEventDeniedAdmissions(gomock.Any(), gomock.Any()).Times(1).Return([]db.AdmissionLog{
+			{GuestID: 3, GuestName: util.RandomGuestName(), TableID: table.ID, PartySize: 2, Reason: "doors closed", RequestID: "a", DecidedAt: from.Add(50 * time.Minute)},
+		}, nil)
+		store.EXPECT().ListEventAuditEvents(gomock.Any(), gomock.Any()).Times(1).Return([]db.AuditEvent{
+			{Action: db.AuditMarkNoShow, GuestID: sql.NullInt32{Int32: noShow.ID, Valid: true}, GuestName: noShow.GuestName, CreatedAt: from.Add(30 * time.Minute)},
+		}, nil)
+		store.EXPECT().CountGuestsByStatus(gomock.Any()).Times(1).Return([]db.CountGuestsByStatusRow{
+			{Status: db.GuestArrived, Guests: 2},
+			{Status: db.GuestNoShow, Guests: 1},
+		}, nil)
+		store.EXPECT().ListGuestsWithStatus(gomock.Any(), gomock.Eq(db.GuestNoShow)).Times(1).Return([]db.Guest{noShow}, nil)
+		store.EXPECT().ListTables(gomock.Any()).Times(1).Return([]db.Table{table}, nil)
+		store.EXPECT().ListCapacityLimits(gomock.Any()).Times(1).Return([]db.CapacityLimit{}, nil)
+	}
+
+	withFormat := func(format string) url.Values {
+		query := url.Values{"format": {format}}
+		for key, value := range window {
+			query[key] = value
+		}
+		return query
+	}
+
+	testCases := []analyticsTestCase{
+		{
+			name:       "OK",
+			query:      window,
+			role:       util.OrganiserRole,
+			buildStubs: buildReportStubs,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var rsp report.Report
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &rsp))
+				require.Equal(t, int64(2), rsp.Totals.Arrivals)
+				require.Equal(t, int64(1), rsp.Totals.TurnedAway)
+				require.Equal(t, []string{"doors closed"}, rsp.TurnedAway[0].Reasons)
+				require.Len(t, rsp.NoShows, 1)
+				require.Equal(t, noShow.GuestName, rsp.NoShows[0].GuestName)
+				require.Len(t, rsp.Tables, 1)
+				require.Len(t, rsp.Timeline, 3)
+			},
+		},
+		{
+			name:       "HTML",
+			query:      withFormat("html"),
+			role:       util.OrganiserRole,
+			buildStubs: buildReportStubs,
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+				require.Equal(t, "text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+				require.Contains(t, recorder.Body.String(), "<!DOCTYPE html>")
+				require.Contains(t, recorder.Body.String(), noShow.GuestName)
+			},
+		},
+		{
+			name:  "InvalidFormat",
+			query: withFormat("pdf"),
+			role:  util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:  "InvalidWindow",
+			query: url.Values{"from": {until.Format(time.RFC3339)}, "until": {from.Format(time.RFC3339)}},
+			role:  util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "DoorStaffForbidden",
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "InternalError",
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Any()).Times(1).Return(arrivals, nil)
+				store.EXPECT().ListLastOccupancySnapshots(gomock.Any(), gomock.Any()).Times(1).Return([]db.OccupancySnapshot{}, nil)
+				store.EXPECT().ListOccupancySnapshots(gomock.Any(), gomock.Any()).Times(1).Return(snapshots, nil)
+				store.EXPECT().ListEventStandingAdmissions(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	runAnalyticsTests(t, "/report", testCases)
+}
@@ -0,0 +1,173 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/token"
+	"github.com/gin-gonic/gin"
+)
+
+// rsvpActor is recorded as the actor of RSVPs, which guests make themselves with their invitation
+// rather than as a member of staff
+const rsvpActor = "guest"
+
+type rsvpRequestURI struct {
+	Token string `uri:"token" binding:"required"`
+}
+
+// Entourage is optional, the guest's current entourage is kept if it's left out
+type rsvpRequest struct {
+	Status    string `json:"status" binding:"required,oneof=accepted declined"`
+	Entourage *int32 `json:"entourage" binding:"omitempty,min=0"`
+}
+
+type rsvpResponse struct {
+	GuestName  string `json:"guest_name"`
+	Entourage  int32  `json:"entourage"`
+	TableID    int32  `json:"table_id"`
+	RsvpStatus string `json:"rsvp_status"`
+}
+
+func newRSVPResponse(guest db.Guest) rsvpResponse {
+	return rsvpResponse{
+		GuestName:  guest.GuestName,
+		Entourage:  guest.Entourage,
+		TableID:    guest.TableID,
+		RsvpStatus: guest.RsvpStatus,
+	}
+}
+
+// getRSVP godoc
+// @Summary returns the RSVP of the invitation holder.
+// @Description Guest facing, authenticated by the invitation token rather than a staff credential.
+// @Accept json
+// @Produce json
+// @Param    token    path      string  true  "Invitation token"
+// @Success 200 {object} rsvpResponse
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 410 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /rsvp/{token} [get]
+func (server *Server) getRSVP(ctx *gin.Context) {
+	claims, ok := server.verifyRSVPToken(ctx)
+	if !ok {
+		return
+	}
+
+	invitation, err := server.store.GetInvitation(ctx, claims.InvitationID)
+	if err == nil && invitation.GuestID != claims.GuestID {
+		err = sql.ErrNoRows
+	}
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if invitation.RevokedAt.Valid {
+		ctx.JSON(http.StatusGone, errorResponse(db.ErrInvitationRevoked))
+		return
+	}
+
+	guest, err := server.store.GetGuest(ctx, claims.GuestID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newRSVPResponse(guest))
+}
+
+// updateRSVP godoc
+// @Summary Accepts or declines the invitation.
+// @Description Guest facing, authenticated by the invitation token rather than a staff credential. An accepted party must fit at the guest's table alongside every other guest there who hasn't declined, and a changed party must be one the table takes.
+// @Accept json
+// @Produce json
+// @Param    token    path      string       true  "Invitation token"
+// @Param    request  body      rsvpRequest  true  "Status and expected entourage"
+// @Success 200 {object} rsvpResponse
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 409 {object} httpError
+// @Failure 410 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /rsvp/{token} [put]
+func (server *Server) updateRSVP(ctx *gin.Context) {
+	claims, ok := server.verifyRSVPToken(ctx)
+	if !ok {
+		return
+	}
+
+	var req rsvpRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	guest, err := server.store.GetGuest(ctx, claims.GuestID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	entourage := guest.Entourage
+	if req.Entourage != nil {
+		entourage = *req.Entourage
+	}
+
+	guest, err = server.store.RSVPTx(ctx, db.RSVPTxParams{
+		AuditInfo:    db.AuditInfo{Actor: rsvpActor, RequestID: ctx.GetString(requestIDKey)},
+		InvitationID: claims.InvitationID,
+		GuestID:      claims.GuestID,
+		Status:       req.Status,
+		Entourage:    entourage,
+	})
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case err == db.ErrInvitationRevoked:
+			ctx.JSON(http.StatusGone, errorResponse(err))
+		case err == db.ErrInvitationUsed, err == db.ErrGuestAlreadyArrived, errors.Is(err, db.ErrIllegalTransition):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		case errors.Is(err, db.ErrInsufficientTableSize), errors.Is(err, db.ErrPartySize):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, newRSVPResponse(guest))
+}
+
+// verifyRSVPToken checks the signature of the invitation token in the path, writing the error
+// response and returning false if it's invalid
+func (server *Server) verifyRSVPToken(ctx *gin.Context) (token.InvitationClaims, bool) {
+	var req rsvpRequestURI
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return token.InvitationClaims{}, false
+	}
+
+	claims, err := server.invitationSigner.Verify(req.Token)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return token.InvitationClaims{}, false
+	}
+	return claims, true
+}
@@ -0,0 +1,305 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/token"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateRSVPAPI(t *testing.T) {
+	guest := randomGuest()
+	invitation := randomInvitation(guest)
+	claims := token.InvitationClaims{InvitationID: invitation.ID, GuestID: guest.ID}
+
+	accepted := guest
+	accepted.Entourage = 1
+	accepted.RsvpStatus = db.RSVPAccepted
+
+	rsvpAudit := db.AuditInfo{Actor: rsvpActor, RequestID: testRequestID}
+
+	testCases := []struct {
+		name          string
+		token         func(signer *token.InvitationSigner) string
+		body          gin.H
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "Accept",
+			body: gin.H{"status": db.RSVPAccepted, "entourage": 1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGuest(gomock.Any(), gomock.Eq(guest.ID)).
+					Times(1).
+					Return(guest, nil)
+				store.EXPECT().
+					RSVPTx(gomock.Any(), gomock.Eq(db.RSVPTxParams{
+						AuditInfo:    rsvpAudit,
+						InvitationID: invitation.ID,
+						GuestID:      guest.ID,
+						Status:       db.RSVPAccepted,
+						Entourage:    1,
+					})).
+					Times(1).
+					Return(accepted, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got rsvpResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, newRSVPResponse(accepted), got)
+			},
+		},
+		{
+			name: "DeclineKeepsEntourage",
+			body: gin.H{"status": db.RSVPDeclined},
+			buildStubs: func(store *mockdb.MockStore) {
+				declined := guest
+				declined.RsvpStatus = db.RSVPDeclined
+
+				store.EXPECT().
+					GetGuest(gomock.Any(), gomock.Eq(guest.ID)).
+					Times(1).
+					Return(guest, nil)
+				store.EXPECT().
+					RSVPTx(gomock.Any(), gomock.Eq(db.RSVPTxParams{
+						AuditInfo:    rsvpAudit,
+						InvitationID: invitation.ID,
+						GuestID:      guest.ID,
+						Status:       db.RSVPDeclined,
+						Entourage:    guest.Entourage,
+					})).
+					Times(1).
+					Return(declined, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "TableFull",
+			body: gin.H{"status": db.RSVPAccepted, "entourage": 1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGuest(gomock.Any(), gomock.Eq(guest.ID)).
+					Times(1).
+					Return(guest, nil)
+				store.EXPECT().
+					RSVPTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Guest{}, db.InsufficientTableSizeErr(int(guest.TableID)))
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "PartySize",
+			body: gin.H{"status": db.RSVPAccepted, "entourage": 12},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGuest(gomock.Any(), gomock.Eq(guest.ID)).
+					Times(1).
+					Return(guest, nil)
+				store.EXPECT().
+					RSVPTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Guest{}, &db.PartySizeError{TableID: guest.TableID, PartySize: 13, MinParty: 1, MaxParty: 8})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "AlreadyArrived",
+			body: gin.H{"status": db.RSVPAccepted, "entourage": 1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGuest(gomock.Any(), gomock.Eq(guest.ID)).
+					Times(1).
+					Return(guest, nil)
+				store.EXPECT().
+					RSVPTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Guest{}, db.ErrGuestAlreadyArrived)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "Revoked",
+			body: gin.H{"status": db.RSVPAccepted, "entourage": 1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGuest(gomock.Any(), gomock.Eq(guest.ID)).
+					Times(1).
+					Return(guest, nil)
+				store.EXPECT().
+					RSVPTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Guest{}, db.ErrInvitationRevoked)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusGone, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidStatus",
+			body: gin.H{"status": "maybe"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					RSVPTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "ForgedToken",
+			token: func(signer *token.InvitationSigner) string {
+				forger, err := token.NewInvitationSigner(util.RandomString(32), "test-event")
+				require.NoError(t, err)
+				return forger.Sign(claims)
+			},
+			body: gin.H{"status": db.RSVPAccepted, "entourage": 1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGuest(gomock.Any(), gomock.Any()).
+					Times(0)
+				store.EXPECT().
+					RSVPTx(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			invitationToken := server.invitationSigner.Sign(claims)
+			if tc.token != nil {
+				invitationToken = tc.token(server.invitationSigner)
+			}
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			// No staff credential, the invitation token is all a guest has
+			request, err := http.NewRequest(http.MethodPut, "/rsvp/"+invitationToken, bytes.NewReader(data))
+			require.NoError(t, err)
+			request.Header.Set(util.RequestIDHeader, testRequestID)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetRSVPAPI(t *testing.T) {
+	guest := randomGuest()
+	invitation := randomInvitation(guest)
+	claims := token.InvitationClaims{InvitationID: invitation.ID, GuestID: guest.ID}
+
+	revoked := invitation
+	revoked.RevokedAt = sql.NullTime{Time: invitation.CreatedAt, Valid: true}
+
+	testCases := []struct {
+		name          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetInvitation(gomock.Any(), gomock.Eq(invitation.ID)).
+					Times(1).
+					Return(invitation, nil)
+				store.EXPECT().
+					GetGuest(gomock.Any(), gomock.Eq(guest.ID)).
+					Times(1).
+					Return(guest, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got rsvpResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, newRSVPResponse(guest), got)
+			},
+		},
+		{
+			name: "Revoked",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetInvitation(gomock.Any(), gomock.Eq(invitation.ID)).
+					Times(1).
+					Return(revoked, nil)
+				store.EXPECT().
+					GetGuest(gomock.Any(), gomock.Any()).
+					Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusGone, recorder.Code)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetInvitation(gomock.Any(), gomock.Eq(invitation.ID)).
+					Times(1).
+					Return(db.Invitation{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			request, err := http.NewRequest(http.MethodGet, "/rsvp/"+server.invitationSigner.Sign(claims), nil)
+			require.NoError(t, err)
+
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -1,8 +1,16 @@
 package api
 
 import (
+	"fmt"
+	"net/http"
+	"time"
+
 	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
 	docs "github.com/ellisp97/BE_Task_Oct20/golang/docs"
+	"github.com/ellisp97/BE_Task_Oct20/golang/graph"
+	"github.com/ellisp97/BE_Task_Oct20/golang/token"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/getkin/kin-openapi/routers"
 	"github.com/gin-gonic/gin"
 	ginSwagger "github.com/swaggo/gin-swagger"
 	swaggerFiles "github.com/swaggo/gin-swagger/swaggerFiles"
@@ -10,37 +18,171 @@ import (
 
 // Server serves HTTP requests for the guestlist service
 type Server struct {
-	store  db.Store
-	router *gin.Engine
+	config           util.Config
+	store            db.Store
+	tokenMaker       token.Maker
+	apiKeys          *token.APIKeys
+	invitationSigner *token.InvitationSigner
+	router           *gin.Engine
+	idempotencyKeys  *idempotencyStore
+	specRouter       routers.Router
 }
 
 // NewSever implements a new HTTP Server and sets up routing
-func NewServer(store db.Store) *Server {
-	server := &Server{store: store}
-	router := gin.Default()
-
-	router.POST("/guest_list/:name", server.createGuest)
-	router.PUT("/guests/:name", server.arriveGuest)
-	router.GET("/guests/:name", server.getGuestFromName)
-	router.GET("/guest_list", server.getGuests)
-	router.GET("/guests", server.getArrivedGuests)
-	router.GET("/seats_empty", server.getEmptySeats)
-	router.GET("/tables", server.getTables)
-	router.POST("/tables", server.createTable)
-	router.DELETE("/guest/:name", server.deleteGuest)
+func NewServer(config util.Config, store db.Store) (*Server, error) {
+	tokenMaker, err := token.NewJWTMaker(config.TokenSymmetricKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create token maker: %w", err)
+	}
+
+	apiKeys, err := token.ParseAPIKeys(config.APIKeys)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse api keys: %w", err)
+	}
+
+	invitationSigner, err := token.NewInvitationSigner(config.InvitationSymmetricKey, config.EventName)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create invitation signer: %w", err)
+	}
+
+	_, specRouter, err := loadSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	server := &Server{
+		config:           config,
+		store:            store,
+		tokenMaker:       tokenMaker,
+		apiKeys:          apiKeys,
+		invitationSigner: invitationSigner,
+		idempotencyKeys:  newIdempotencyStore(),
+		specRouter:       specRouter,
+	}
+	server.setupRouter()
+	return server, nil
+}
+
+// setupRouter groups the routes by the roles permitted to call them
+func (server *Server) setupRouter() {
+	router := gin.New()
+	router.Use(requestIDMiddleware(), gin.LoggerWithFormatter(logFormatter), gin.Recovery())
+
+	authRoutes := router.Group("/", authMiddleware(server.tokenMaker, server.apiKeys))
+
+	// Roles are checked before the request is validated, so a caller can't probe routes they may not use
+	roleRoutes := func(roles ...string) *gin.RouterGroup {
+		return authRoutes.Group("/", requireRole(roles...), server.validateOpenAPI(), server.idempotency())
+	}
+
+	// Viewers can only read the seat counts, tables and guest list, without guests' contact details
+	viewerRoutes := roleRoutes(util.OrganiserRole, util.DoorStaffRole, util.ViewerRole)
+	viewerRoutes.GET("/seats_empty", server.getEmptySeats)
+	viewerRoutes.GET("/tables", server.getTables)
+	viewerRoutes.GET("/tables/:id/seats", server.getSeatMap)
+	viewerRoutes.GET("/capacity", server.getCapacity)
+	viewerRoutes.GET("/guest_list", server.getGuests)
+	viewerRoutes.GET("/guests", server.getArrivedGuests)
+	viewerRoutes.GET("/guests/:name", server.getGuestFromName)
+
+	// Door staff arrive, move and remove guests, let in walk-ins, and can read the guest list and
+	// table rosters to do so
+	doorStaffRoutes := roleRoutes(util.OrganiserRole, util.DoorStaffRole)
+	doorStaffRoutes.GET("/tables/:id", server.getTable)
+	doorStaffRoutes.GET("/roster", server.getRoster)
+	doorStaffRoutes.PUT("/guests/:name", server.arriveGuest)
+	doorStaffRoutes.DELETE("/guests/:name", server.deleteGuest)
+	doorStaffRoutes.PATCH("/guests/:name/party", server.updateParty)
+	doorStaffRoutes.POST("/guests/:name/move", server.moveGuest)
+	doorStaffRoutes.GET("/guests/:name/companions", server.listCompanions)
+	doorStaffRoutes.POST("/checkin/scan", server.scanInvitation)
+	doorStaffRoutes.POST("/walkins", server.walkIn)
+	doorStaffRoutes.GET("/standing", server.listStanding)
+	doorStaffRoutes.POST("/standing", server.admitStanding)
+	doorStaffRoutes.DELETE("/standing/:id", server.departStanding)
+	doorStaffRoutes.POST("/graphql", gin.WrapH(graph.NewHandler(server.store)))
+
+	// Organisers have full control
+	organiserRoutes := roleRoutes(util.OrganiserRole)
+	organiserRoutes.POST("/guest_list/:name", server.createGuest)
+	organiserRoutes.DELETE("/guest_list/:name", server.removeGuest)
+	organiserRoutes.POST("/tables", server.createTable)
+	organiserRoutes.PUT("/tables/:id/arrival_window", server.setTableArrivalWindow)
+	organiserRoutes.PUT("/capacity", server.setCapacity)
+	organiserRoutes.POST("/tokens", server.createToken)
+	organiserRoutes.GET("/audit", server.listAuditEvents)
+	organiserRoutes.GET("/admission_rules", server.getAdmissionRules)
+	organiserRoutes.PUT("/admission_rules", server.setAdmissionRules)
+	organiserRoutes.GET("/admission_log", server.listAdmissionLog)
+	organiserRoutes.GET("/analytics/summary", server.getAnalyticsSummary)
+	organiserRoutes.GET("/analytics/arrivals", server.getArrivalAnalytics)
+	organiserRoutes.GET("/analytics/occupancy", server.getOccupancyAnalytics)
+	organiserRoutes.GET("/analytics/tables", server.getTableAnalytics)
+	organiserRoutes.GET("/report", server.getReport)
+	organiserRoutes.GET("/bans", server.listBans)
+	organiserRoutes.POST("/bans", server.banPerson)
+	organiserRoutes.DELETE("/bans/:id", server.unbanPerson)
+	organiserRoutes.PUT("/guests/:name/status", server.updateGuestStatus)
+	organiserRoutes.PATCH("/guests/:name/profile", server.updateGuestProfile)
+	organiserRoutes.PUT("/guests/:name/arrival_window", server.setGuestArrivalWindow)
+	organiserRoutes.POST("/guests/:name/companions", server.addCompanion)
+	organiserRoutes.PUT("/guests/:name/companions/:id", server.updateCompanion)
+	organiserRoutes.DELETE("/guests/:name/companions/:id", server.removeCompanion)
+	organiserRoutes.POST("/guests/:name/invitation", server.issueInvitation)
+	organiserRoutes.GET("/guests/:name/invitation", server.getInvitation)
+	organiserRoutes.GET("/guests/:name/invitation/qr", server.getInvitationQRCode)
+	organiserRoutes.DELETE("/guests/:name/invitation", server.revokeInvitation)
+
+	// Guests RSVP with the token from their invitation in place of a staff credential
+	rsvpRoutes := router.Group("/rsvp", server.validateOpenAPI())
+	rsvpRoutes.GET("/:token", server.getRSVP)
+	rsvpRoutes.PUT("/:token", server.updateRSVP)
 
 	// Set up documentation
+	router.GET("/openapi.yaml", func(ctx *gin.Context) {
+		ctx.Data(http.StatusOK, "application/yaml", docs.OpenAPI)
+	})
 	docs.SwaggerInfo.BasePath = "/"
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("http://localhost:3000/swagger/doc.json"),
 		ginSwagger.DefaultModelsExpandDepth(-1)))
 	server.router = router
-	return server
 }
 
 func (server *Server) Start(address string) error {
 	return server.router.Run(address)
 }
 
+// ServeHTTP lets the server be mounted as a plain http.Handler, e.g. by httptest
+func (server *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	server.router.ServeHTTP(w, req)
+}
+
+// logFormatter is gin's default log line followed by the acting user and request ID, so every
+// mutation can be traced back to whoever made it and matched to its audit events
+func logFormatter(param gin.LogFormatterParams) string {
+	user := "-"
+	if payload, ok := param.Keys[authorizationPayloadKey].(*token.Payload); ok {
+		user = payload.Username + "(" + payload.Role + ")"
+	}
+	requestID, _ := param.Keys[requestIDKey].(string)
+	return fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | %-7s %#v | user=%s request_id=%s\n%s",
+		param.TimeStamp.Format(time.RFC3339),
+		param.StatusCode,
+		param.Latency,
+		param.ClientIP,
+		param.Method,
+		param.Path,
+		user,
+		requestID,
+		param.ErrorMessage,
+	)
+}
+
+// httpError documents the body written by errorResponse for the swagger docs
+type httpError struct {
+	Error string `json:"error" example:"record not found"`
+}
+
 // Wrapper for gin errors
 func errorResponse(err error) gin.H {
 	return gin.H{"error": err.Error()}
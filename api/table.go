@@ -1,26 +1,39 @@
 package api
 
 import (
+	"database/sql"
 	"net/http"
 
 	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
 	"github.com/gin-gonic/gin"
 )
 
+// A table without a label is labelled after its ID, min_party and max_party default to 1 and the size
 type createTableRequest struct {
-	Size int32 `json:"size" binding:"required,min=1"`
+	Size       int32  `json:"size" binding:"required,min=1"`
+	Label      string `json:"label" binding:"max=64"`
+	Zone       string `json:"zone" binding:"max=64"`
+	Accessible bool   `json:"accessible"`
+	Shape      string `json:"shape" binding:"omitempty,oneof=round rectangular square booth"`
+	MinParty   int32  `json:"min_party" binding:"min=0"`
+	MaxParty   int32  `json:"max_party" binding:"min=0"`
+	// Optionally when the table's guests are expected to arrive
+	arrivalWindowRequest
 }
 
 // createTable godoc
 // @Summary Creates a table according to the table size.
-// @Description Executes a POST request adding the table object to the db..
+// @Description Executes a POST request adding the table object to the db. Labels are unique, a table without one is labelled after its ID. An arrival window can be given for the table's guests, see PUT /tables/{id}/arrival_window.
 // @Accept json
 // @Produce json
-// @Param    size     body      int     true  "Table Size - minimum value is 1"
-// @Success 200 {object} sql.Result
-// @Failure 400 {object} httputil.HTTPError
-// @Failure 500 {object} httputil.HTTPError
-// @Router /tables/ [post]
+// @Param    request  body      createTableRequest  true  "Table Size - minimum value is 1 - and optionally its label, zone, accessibility, shape and the smallest and biggest party it takes"
+// @Success 200 {object} db.Table
+// @Failure 400 {object} httpError
+// @Failure 409 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /tables [post]
 func (server *Server) createTable(ctx *gin.Context) {
 	var req createTableRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
@@ -28,19 +41,56 @@ func (server *Server) createTable(ctx *gin.Context) {
 		return
 	}
 
-	arg := db.CreateTableParams{
-		Size:     req.Size,
-		Occupied: 0,
+	arg := db.CreateTableTxParams{
+		AuditInfo:     auditInfo(ctx),
+		Size:          req.Size,
+		Label:         req.Label,
+		Zone:          req.Zone,
+		Accessible:    req.Accessible,
+		Shape:         req.Shape,
+		MinParty:      req.MinParty,
+		MaxParty:      req.MaxParty,
+		ArrivalWindow: req.arrivalWindow(),
 	}
 
-	table, err := server.store.CreateTable(ctx, arg)
+	table, err := server.store.CreateTableTx(ctx, arg)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		switch err {
+		case db.ErrInvalidPartyRange, db.ErrInvalidArrivalWindow:
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		case db.ErrTableLabelTaken:
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
 		return
 	}
 	ctx.JSON(http.StatusOK, table)
 }
 
+// getTableByRef fetches the table with id, or when id is zero the table labelled label, so that
+// callers can refer to tables by either
+func (server *Server) getTableByRef(ctx *gin.Context, id int32, label string) (db.Table, error) {
+	if id == 0 {
+		return server.store.GetTableFromLabel(ctx, label)
+	}
+	return server.store.GetTable(ctx, id)
+}
+
+// tableIDFromLabel returns the ID of the table labelled label as a filter, an unknown label matches
+// no table rather than failing
+func (server *Server) tableIDFromLabel(ctx *gin.Context, label string) (sql.NullInt32, error) {
+	if label == "" {
+		return sql.NullInt32{}, nil
+	}
+
+	table, err := server.store.GetTableFromLabel(ctx, label)
+	if err == sql.ErrNoRows {
+		return sql.NullInt32{Int32: 0, Valid: true}, nil
+	}
+	return sql.NullInt32{Int32: table.ID, Valid: true}, err
+}
+
 type getTablesRequest struct {
 	PageID   int32 `form:"page_id" binding:"required,min=1"`
 	PageSize int32 `form:"page_size" binding:"required,min=5,max=10"`
@@ -48,15 +98,17 @@ type getTablesRequest struct {
 
 // getTables godoc
 // @Summary returns all tables
-// @Description Fetches an array of table object ([]Table), the requests are paginated with a minimum page_id of 1 and page_size of 5-20. Running a make test will generate some default data via the mysql unit tests.
+// @Description Fetches an array of table object ([]Table), the requests are paginated with a minimum page_id of 1 and page_size of 5-10. Running a make test will generate some default data via the mysql unit tests.
 // @Accept json
 // @Produce json
 // @Param        page_id   query      int  true  "Page ID"
 // @Param        page_size   query      int  true  "Page Size"
 // @Success 200 {object} []db.Table
-// @Failure 400 {object} httputil.HTTPError
-// @Failure 500 {object} httputil.HTTPError
-// @Router /tables/ [get]
+// @Failure 400 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /tables [get]
 func (server *Server) getTables(ctx *gin.Context) {
 	var req getTablesRequest
 	if err := ctx.ShouldBindQuery(&req); err != nil {
@@ -78,13 +130,158 @@ func (server *Server) getTables(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, tables)
 }
 
+type getSeatMapRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+// seatResponse is a seat on the seat map, naming the guest or companion who holds it
+type seatResponse struct {
+	Number        int32  `json:"number"`
+	Status        string `json:"status"`
+	GuestName     string `json:"guest_name,omitempty"`
+	CompanionName string `json:"companion_name,omitempty"`
+}
+
+type seatMapResponse struct {
+	TableID int32          `json:"table_id"`
+	Label   string         `json:"label"`
+	Seats   []seatResponse `json:"seats"`
+}
+
+// getSeatMap godoc
+// @Summary returns the seat map of a table
+// @Description Fetches every numbered seat of the table with its status, free, reserved for a guest who hasn't arrived yet or occupied, and the guest or named companion holding it.
+// @Accept json
+// @Produce json
+// @Param    id  path  int  true  "Table ID"
+// @Success 200 {object} seatMapResponse
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /tables/{id}/seats [get]
+func (server *Server) getSeatMap(ctx *gin.Context) {
+	var req getSeatMapRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	table, err := server.store.GetTable(ctx, req.ID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	seats, err := server.store.ListSeatMap(ctx, table.ID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	rsp := seatMapResponse{TableID: table.ID, Label: table.Label, Seats: make([]seatResponse, len(seats))}
+	for i, seat := range seats {
+		rsp.Seats[i] = seatResponse{
+			Number:        seat.Number,
+			Status:        db.SeatStatus(seat),
+			GuestName:     seat.GuestName.String,
+			CompanionName: seat.CompanionName.String,
+		}
+	}
+	ctx.JSON(http.StatusOK, rsp)
+}
+
+type getTableRequest struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+// getTable godoc
+// @Summary returns a table with its live occupancy and guest roster
+// @Description Fetches the table with how many of its seats are free, reserved for guests who haven't arrived yet and taken, the guests seated there with the party they arrived with and the guests still expected with the party they booked and when they're expected.
+// @Accept json
+// @Produce json
+// @Param    id  path  int  true  "Table ID"
+// @Success 200 {object} db.TableRoster
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /tables/{id} [get]
+func (server *Server) getTable(ctx *gin.Context) {
+	var req getTableRequest
+	if err := ctx.ShouldBindUri(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListTableRosterParams{
+		TableID: sql.NullInt32{Int32: req.ID, Valid: true},
+		Limit:   1,
+		Offset:  0,
+	}
+
+	rows, err := server.store.ListTableRoster(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+	if len(rows) == 0 {
+		ctx.JSON(http.StatusNotFound, errorResponse(sql.ErrNoRows))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, db.TableRosters(rows)[0])
+}
+
+// getRoster godoc
+// @Summary returns a page of tables with their live occupancy and guest rosters
+// @Description Fetches an array of tables as GET /tables/{id} returns them, paginated like GET /tables with a minimum page_id of 1 and page_size of 5-10. Each page is read with a single query.
+// @Accept json
+// @Produce json
+// @Param        page_id   query      int  true  "Page ID"
+// @Param        page_size   query      int  true  "Page Size"
+// @Success 200 {object} []db.TableRoster
+// @Failure 400 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /roster [get]
+func (server *Server) getRoster(ctx *gin.Context) {
+	var req getTablesRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	arg := db.ListTableRosterParams{
+		Limit:  req.PageSize,
+		Offset: (req.PageID - 1) * req.PageSize,
+	}
+
+	rows, err := server.store.ListTableRoster(ctx, arg)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, db.TableRosters(rows))
+}
+
 // getEmptySeats godoc
 // @Summary Gets all the empty seats
-// @Description The empty seats are calculated from the difference between the Size and Occupied values in the table.
+// @Description The empty seats are the seats at each table nobody holds, less those still to be given to the guests booked there who haven't arrived, so reserved seats are never empty.
 // @Accept json
 // @Produce json
-// @Success 200 {object} int
-// @Failure 500 {object} httputil.HTTPError
+// @Success 200 {integer} int
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
 // @Router /seats_empty [get]
 func (server *Server) getEmptySeats(ctx *gin.Context) {
 	count, err := server.store.GetEmptySeats(ctx)
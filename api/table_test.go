@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
 	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
@@ -68,13 +69,14 @@ func TestGetEmptySeats(t *testing.T) {
 			store := mockdb.NewMockStore(controller)
 			tc.buildStubs(store)
 
-			server := NewServer(store)
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
 			url := "/seats_empty"
 			req, err := http.NewRequest(http.MethodGet, url, nil)
 			require.NoError(t, err)
 
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
 			server.router.ServeHTTP(recorder, req)
 			tc.checkResponse(t, recorder)
 		})
@@ -174,7 +176,7 @@ func TestGetTablesAPI(t *testing.T) {
 			store := mockdb.NewMockStore(controller)
 			tc.buildStubs(store)
 
-			server := NewServer(store)
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
 			req, err := http.NewRequest(http.MethodGet, "/tables", nil)
@@ -184,6 +186,7 @@ func TestGetTablesAPI(t *testing.T) {
 			params.Add("page_size", fmt.Sprintf("%d", tc.query.pageSize))
 			req.URL.RawQuery = params.Encode()
 
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
 			server.router.ServeHTTP(recorder, req)
 			tc.checkResponse(recorder)
 		})
@@ -205,17 +208,84 @@ func TestCreateTableAPI(t *testing.T) {
 				"size": table.Size,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateTable(gomock.Any(), db.CreateTableParams{Size: table.Size, Occupied: 0}).Times(1)
+				store.EXPECT().CreateTableTx(gomock.Any(), db.CreateTableTxParams{AuditInfo: testAudit, Size: table.Size}).Times(1).Return(table, nil)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusOK, recorder.Code)
+				requireBodyMatchTable(t, recorder.Body, table)
+			},
+		},
+		{
+			name: "WithDetails",
+			body: gin.H{
+				"size":       table.Size,
+				"label":      "VIP Booth A",
+				"zone":       "Terrace",
+				"accessible": true,
+				"shape":      db.ShapeBooth,
+				"min_party":  1,
+				"max_party":  table.Size,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTableTx(gomock.Any(), db.CreateTableTxParams{
+					AuditInfo:  testAudit,
+					Size:       table.Size,
+					Label:      "VIP Booth A",
+					Zone:       "Terrace",
+					Accessible: true,
+					Shape:      db.ShapeBooth,
+					MinParty:   1,
+					MaxParty:   table.Size,
+				}).Times(1).Return(table, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "LabelTaken",
+			body: gin.H{
+				"size":  table.Size,
+				"label": table.Label,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTableTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Table{}, db.ErrTableLabelTaken)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidPartyRange",
+			body: gin.H{
+				"size":      table.Size,
+				"max_party": table.Size + 1,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTableTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Table{}, db.ErrInvalidPartyRange)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "InvalidShape",
+			body: gin.H{
+				"size":  table.Size,
+				"shape": "hexagon",
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateTableTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
 			},
 		},
 		{
 			name: "InvalidNameURI",
 			body: nil,
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateTable(gomock.Any(), db.CreateTableParams{Size: table.Size, Occupied: 0}).Times(0)
+				store.EXPECT().CreateTableTx(gomock.Any(), gomock.Any()).Times(0)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusBadRequest, recorder.Code)
@@ -226,10 +296,10 @@ func TestCreateTableAPI(t *testing.T) {
 				"size": table.Size,
 			},
 			buildStubs: func(store *mockdb.MockStore) {
-				store.EXPECT().CreateTable(gomock.Any(), db.CreateTableParams{
-					Size:     table.Size,
-					Occupied: 0,
-				}).Times(1).Return(nil, sql.ErrConnDone)
+				store.EXPECT().CreateTableTx(gomock.Any(), db.CreateTableTxParams{
+					AuditInfo: testAudit,
+					Size:      table.Size,
+				}).Times(1).Return(db.Table{}, sql.ErrConnDone)
 			},
 			checkResponse: func(recorder *httptest.ResponseRecorder) {
 				require.Equal(t, http.StatusInternalServerError, recorder.Code)
@@ -247,7 +317,7 @@ func TestCreateTableAPI(t *testing.T) {
 			store := mockdb.NewMockStore(controller)
 			tc.buildStubs(store)
 
-			server := NewServer(store)
+			server := newTestServer(t, store)
 			recorder := httptest.NewRecorder()
 
 			data, err := json.Marshal(tc.body)
@@ -256,19 +326,369 @@ func TestCreateTableAPI(t *testing.T) {
 			req, err := http.NewRequest(http.MethodPost, "/tables", bytes.NewReader(data))
 			require.NoError(t, err)
 
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetSeatMapAPI(t *testing.T) {
+	table := randomTable()
+	seats := []db.ListSeatMapRow{
+		{Number: 1},
+		{
+			Number:      2,
+			GuestID:     sql.NullInt32{Int32: 7, Valid: true},
+			GuestName:   sql.NullString{String: "Aurora", Valid: true},
+			GuestStatus: sql.NullString{String: db.GuestArrived, Valid: true},
+		},
+		{
+			Number:        3,
+			GuestID:       sql.NullInt32{Int32: 7, Valid: true},
+			GuestName:     sql.NullString{String: "Aurora", Valid: true},
+			GuestStatus:   sql.NullString{String: db.GuestArrived, Valid: true},
+			CompanionID:   sql.NullInt32{Int32: 12, Valid: true},
+			CompanionName: sql.NullString{String: "Basil", Valid: true},
+		},
+		{
+			Number:      4,
+			GuestID:     sql.NullInt32{Int32: 9, Valid: true},
+			GuestName:   sql.NullString{String: "Cosimo", Valid: true},
+			GuestStatus: sql.NullString{String: db.GuestConfirmed, Valid: true},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		tableID       int32
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			tableID: table.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTable(gomock.Any(), gomock.Eq(table.ID)).Times(1).Return(table, nil)
+				store.EXPECT().ListSeatMap(gomock.Any(), gomock.Eq(table.ID)).Times(1).Return(seats, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got seatMapResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, seatMapResponse{
+					TableID: table.ID,
+					Label:   table.Label,
+					Seats: []seatResponse{
+						{Number: 1, Status: db.SeatFree},
+						{Number: 2, Status: db.SeatOccupied, GuestName: "Aurora"},
+						{Number: 3, Status: db.SeatOccupied, GuestName: "Aurora", CompanionName: "Basil"},
+						{Number: 4, Status: db.SeatReserved, GuestName: "Cosimo"},
+					},
+				}, got)
+			},
+		},
+		{
+			name:    "NotFound",
+			tableID: table.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTable(gomock.Any(), gomock.Eq(table.ID)).Times(1).Return(db.Table{}, sql.ErrNoRows)
+				store.EXPECT().ListSeatMap(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:    "InvalidID",
+			tableID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTable(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/tables/%d/seats", tc.tableID), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, util.ViewerRole, time.Minute)
 			server.router.ServeHTTP(recorder, req)
 			tc.checkResponse(recorder)
 		})
 	}
 }
 
+func TestGetTableAPI(t *testing.T) {
+	table := randomTable()
+	seated := randomGuest()
+	expected := randomGuest()
+	rows := []db.ListTableRosterRow{
+		{
+			ID:          table.ID,
+			Size:        table.Size,
+			Occupied:    3,
+			Label:       table.Label,
+			Shape:       table.Shape,
+			MinParty:    table.MinParty,
+			MaxParty:    table.MaxParty,
+			FreeSeats:   1,
+			GuestID:     sql.NullInt32{Int32: seated.ID, Valid: true},
+			GuestName:   sql.NullString{String: seated.GuestName, Valid: true},
+			Entourage:   sql.NullInt32{Int32: 1, Valid: true},
+			GuestStatus: sql.NullString{String: db.GuestArrived, Valid: true},
+			SeatsHeld:   3,
+			PartySize:   sql.NullInt32{Int32: 3, Valid: true},
+			ArrivedAt:   sql.NullTime{Time: time.Now().Truncate(time.Second).UTC(), Valid: true},
+		},
+		{
+			ID:          table.ID,
+			Size:        table.Size,
+			Occupied:    3,
+			Label:       table.Label,
+			Shape:       table.Shape,
+			MinParty:    table.MinParty,
+			MaxParty:    table.MaxParty,
+			FreeSeats:   1,
+			GuestID:     sql.NullInt32{Int32: expected.ID, Valid: true},
+			GuestName:   sql.NullString{String: expected.GuestName, Valid: true},
+			Entourage:   sql.NullInt32{Int32: 1, Valid: true},
+			GuestStatus: sql.NullString{String: db.GuestConfirmed, Valid: true},
+			SeatsHeld:   2,
+		},
+	}
+	arg := db.ListTableRosterParams{TableID: sql.NullInt32{Int32: table.ID, Valid: true}, Limit: 1}
+
+	testCases := []struct {
+		name          string
+		tableID       int32
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			tableID: table.ID,
+			role:    util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTableRoster(gomock.Any(), gomock.Eq(arg)).Times(1).Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.TableRoster
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, table.ID, got.TableID)
+				require.Equal(t, int32(1), got.FreeSeats)
+				require.Equal(t, int32(2), got.ReservedSeats)
+				require.Len(t, got.Seated, 1)
+				require.Equal(t, seated.GuestName, got.Seated[0].GuestName)
+				require.Equal(t, int32(3), got.Seated[0].PartySize)
+				require.Len(t, got.Expected, 1)
+				require.Equal(t, expected.GuestName, got.Expected[0].GuestName)
+				require.Equal(t, int32(2), got.Expected[0].PartySize)
+			},
+		},
+		{
+			name:    "NotFound",
+			tableID: table.ID,
+			role:    util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTableRoster(gomock.Any(), gomock.Eq(arg)).Times(1).Return([]db.ListTableRosterRow{}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:    "InvalidID",
+			tableID: 0,
+			role:    util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTableRoster(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:    "ViewerForbidden",
+			tableID: table.ID,
+			role:    util.ViewerRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTableRoster(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name:    "InternalError",
+			tableID: table.ID,
+			role:    util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTableRoster(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/tables/%d", tc.tableID), nil)
+			require.NoError(t, err)
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, tc.role, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestGetRosterAPI(t *testing.T) {
+	n := 5
+	rows := make([]db.ListTableRosterRow, n)
+	for i := range rows {
+		table := randomTable()
+		rows[i] = db.ListTableRosterRow{
+			ID:        table.ID,
+			Size:      table.Size,
+			Occupied:  table.Occupied,
+			Label:     table.Label,
+			Shape:     table.Shape,
+			MinParty:  table.MinParty,
+			MaxParty:  table.MaxParty,
+			FreeSeats: int64(table.Size),
+		}
+	}
+
+	testCases := []struct {
+		name          string
+		pageID        int
+		pageSize      int
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:     "OK",
+			pageID:   2,
+			pageSize: n,
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.ListTableRosterParams{Limit: int32(n), Offset: int32(n)}
+				store.EXPECT().ListTableRoster(gomock.Any(), gomock.Eq(arg)).Times(1).Return(rows, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got []db.TableRoster
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Equal(t, db.TableRosters(rows), got)
+			},
+		},
+		{
+			name:     "BadRequest",
+			pageID:   -1,
+			pageSize: 100,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTableRoster(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name:     "InternalError",
+			pageID:   1,
+			pageSize: n,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().ListTableRoster(gomock.Any(), gomock.Any()).Times(1).Return(nil, sql.ErrConnDone)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusInternalServerError, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			req, err := http.NewRequest(http.MethodGet, "/roster", nil)
+			require.NoError(t, err)
+			params := req.URL.Query()
+			params.Add("page_id", fmt.Sprintf("%d", tc.pageID))
+			params.Add("page_size", fmt.Sprintf("%d", tc.pageSize))
+			req.URL.RawQuery = params.Encode()
+
+			addAuthorization(t, req, server.tokenMaker, authorizationTypeBearer, testUsername, util.DoorStaffRole, time.Minute)
+			server.router.ServeHTTP(recorder, req)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+// randomSeats returns the first partySize seats of table, held by guest
+func randomSeats(guest db.Guest, table db.Table, partySize int32) []db.Seat {
+	seats := make([]db.Seat, partySize)
+	for i := range seats {
+		seats[i] = db.Seat{
+			ID:         util.RandomInt(1, 1000),
+			TableID:    table.ID,
+			Number:     int32(i) + 1,
+			GuestID:    sql.NullInt32{Int32: guest.ID, Valid: true},
+			AssignedAt: sql.NullTime{Time: time.Now().Truncate(time.Second).UTC(), Valid: true},
+		}
+	}
+	return seats
+}
+
 // randomTable returns random test copy of table object to mock
 func randomTable() db.Table {
 	size := util.RandomTableSize()
+	id := util.RandomInt(1, 1000)
 	return db.Table{
-		ID:       util.RandomInt(1, 1000),
+		ID:       id,
 		Size:     size,
 		Occupied: size - util.RandomInt(1, size),
+		Label:    fmt.Sprintf("Table %d", id),
+		Shape:    db.ShapeRound,
+		MinParty: 1,
+		MaxParty: size,
 	}
 }
 
@@ -283,6 +703,17 @@ func requireEmptySeatsCorrect(t *testing.T, body *bytes.Buffer, emptySeats int)
 	require.Equal(t, emptySeatsFetched, emptySeats)
 }
 
+// requireBodyMatchTable requires mock returned table object to be equal to the expected value
+func requireBodyMatchTable(t *testing.T, body *bytes.Buffer, table db.Table) {
+	data, err := ioutil.ReadAll(body)
+	require.NoError(t, err)
+
+	var tableFetched db.Table
+	err = json.Unmarshal(data, &tableFetched)
+	require.NoError(t, err)
+	require.Equal(t, table, tableFetched)
+}
+
 // requireBodyMatchTables requires mock returned table array to be equal to the expected value
 func requireBodyMatchTables(t *testing.T, body *bytes.Buffer, guests []db.Table) {
 	data, err := ioutil.ReadAll(body)
@@ -0,0 +1,53 @@
+package api
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type createTokenRequest struct {
+	Username string `json:"username" binding:"required"`
+	Role     string `json:"role" binding:"required,oneof=organiser door_staff viewer"`
+}
+
+type createTokenResponse struct {
+	AccessToken          string    `json:"access_token"`
+	AccessTokenExpiresAt time.Time `json:"access_token_expires_at"`
+	Username             string    `json:"username"`
+	Role                 string    `json:"role"`
+}
+
+// createToken godoc
+// @Summary Issues an access token for a member of staff.
+// @Description Organisers issue JWTs to door staff and viewers (e.g. for a shift), the token expires after the configured ACCESS_TOKEN_DURATION.
+// @Accept json
+// @Produce json
+// @Param    request  body      createTokenRequest  true  "Username and role of the token holder"
+// @Success 200 {object} createTokenResponse
+// @Failure 400 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /tokens [post]
+func (server *Server) createToken(ctx *gin.Context) {
+	var req createTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	accessToken, payload, err := server.tokenMaker.CreateToken(req.Username, req.Role, server.config.AccessTokenDuration)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, createTokenResponse{
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: payload.ExpiredAt,
+		Username:             payload.Username,
+		Role:                 payload.Role,
+	})
+}
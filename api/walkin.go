@@ -0,0 +1,121 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+// The table is given by either its ID or label, or left out to seat the party at whichever table
+// fits them best. Seats can only be given along with the table they're at.
+type walkInRequest struct {
+	GuestName     string  `json:"guest_name" binding:"required,min=5,max=255"`
+	Entourage     int32   `json:"entourage" binding:"min=0"`
+	TableID       int32   `json:"table_id" binding:"omitempty,min=1,excluded_with=TableLabel"`
+	TableLabel    string  `json:"table_label" binding:"max=64"`
+	Seats         []int32 `json:"seats" binding:"omitempty,unique,dive,min=1"`
+	Dietary       string  `json:"dietary" binding:"max=255"`
+	Accessibility string  `json:"accessibility" binding:"max=255"`
+	VipTier       string  `json:"vip_tier" binding:"omitempty,oneof=silver gold platinum"`
+	Email         string  `json:"email" binding:"omitempty,email,max=255"`
+	Phone         string  `json:"phone" binding:"max=32"`
+	Notes         string  `json:"notes" binding:"max=1024"`
+}
+
+// walkInResponse tells the door where the walk-in party have been seated
+type walkInResponse struct {
+	Guest db.Guest  `json:"guest"`
+	Table db.Table  `json:"table"`
+	Seats []db.Seat `json:"seats"`
+}
+
+// errSeatsWithoutTable is returned when a walk-in asks for numbered seats without saying at which table
+var errSeatsWithoutTable = errors.New("seats can only be given along with table_id or table_label")
+
+// walkIn godoc
+// @Summary Lets in a party who aren't on the guest list
+// @Description Adds the guest to the guest list, tagged as a walk-in, and arrives them with their party in one go. Without a table they're seated at the table with room for them that has the fewest seats to spare, keeping bigger tables for bigger parties, and a 409 is returned when no table has room. Their arrival must pass the event's admission policies and the maximum occupancy of the venue and zone exactly as any other arrival, and a walk-in matching somebody on the ban list is refused with a 403 and an alert is raised. A name already on the guest list is rejected with a 409, that guest should be arrived instead.
+// @Accept json
+// @Produce json
+// @Param    request  body      walkInRequest  true  "Guest name, entourage, optionally the table and seats, and the guest's profile"
+// @Success 200 {object} walkInResponse
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 409 {object} admissionError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /walkins [post]
+func (server *Server) walkIn(ctx *gin.Context) {
+	var req walkInRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if req.Seats != nil && req.TableID == 0 && req.TableLabel == "" {
+		ctx.JSON(http.StatusBadRequest, errorResponse(errSeatsWithoutTable))
+		return
+	}
+
+	tableID := req.TableID
+	if req.TableLabel != "" {
+		table, err := server.store.GetTableFromLabel(ctx, req.TableLabel)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				ctx.JSON(http.StatusNotFound, errorResponse(err))
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+			return
+		}
+		tableID = table.ID
+	}
+
+	result, err := server.store.WalkInTx(ctx, db.WalkInTxParams{
+		AuditInfo: auditInfo(ctx),
+		GuestName: req.GuestName,
+		Entourage: req.Entourage,
+		TableID:   tableID,
+		Seats:     req.Seats,
+		GuestProfile: db.GuestProfile{
+			Dietary:       req.Dietary,
+			Accessibility: req.Accessibility,
+			VipTier:       req.VipTier,
+			Email:         req.Email,
+			Phone:         req.Phone,
+			Notes:         req.Notes,
+		},
+	})
+	if err != nil {
+		var denied *db.AdmissionDeniedError
+		switch {
+		case err == sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.As(err, &denied) && !denied.TableCapacityOnly():
+			ctx.JSON(http.StatusConflict, admissionDeniedResponse(denied))
+		case errors.Is(err, db.ErrBanned):
+			ctx.JSON(http.StatusForbidden, errorResponse(err))
+		case errors.Is(err, db.ErrInsufficientTableSize), errors.Is(err, db.ErrPartySize), errors.Is(err, db.ErrInvalidSeats):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		case err == db.ErrGuestOnList, err == db.ErrNoTableAvailable, errors.Is(err, db.ErrVenueFull),
+			errors.Is(err, db.ErrZoneFull), errors.Is(err, db.ErrSeatTaken):
+			ctx.JSON(http.StatusConflict, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, walkInResponse{Guest: result.Guest, Table: result.Table, Seats: result.Seats})
+}
+
+func nullBool(b *bool) sql.NullBool {
+	if b == nil {
+		return sql.NullBool{}
+	}
+	return sql.NullBool{Bool: *b, Valid: true}
+}
@@ -0,0 +1,241 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalkInAPI(t *testing.T) {
+	table := randomTable()
+	guest := randomGuest()
+	guest.TableID = table.ID
+	guest.Entourage = 1
+	guest.Status = db.GuestArrived
+	guest.WalkIn = true
+	seats := []db.Seat{
+		{ID: 1, TableID: table.ID, Number: 1, GuestID: sql.NullInt32{Int32: guest.ID, Valid: true}},
+		{ID: 2, TableID: table.ID, Number: 2, GuestID: sql.NullInt32{Int32: guest.ID, Valid: true}},
+	}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "BestFit",
+			body: gin.H{"guest_name": guest.GuestName, "entourage": 1, "dietary": "vegetarian"},
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					WalkInTx(gomock.Any(), gomock.Eq(db.WalkInTxParams{
+						AuditInfo:    testAudit,
+						GuestProfile: db.GuestProfile{Dietary: "vegetarian"},
+						GuestName:    guest.GuestName,
+						Entourage:    1,
+					})).
+					Times(1).
+					Return(db.AssignTableTxResult{Guest: guest, Table: table, Seats: seats}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got walkInResponse
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.True(t, got.Guest.WalkIn)
+				require.Equal(t, table.ID, got.Table.ID)
+				require.Len(t, got.Seats, 2)
+			},
+		},
+		{
+			name: "TableLabel",
+			body: gin.H{"guest_name": guest.GuestName, "entourage": 1, "table_label": table.Label, "seats": []int32{1, 2}},
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTableFromLabel(gomock.Any(), gomock.Eq(table.Label)).Times(1).Return(table, nil)
+				store.EXPECT().
+					WalkInTx(gomock.Any(), gomock.Eq(db.WalkInTxParams{
+						AuditInfo: testAudit,
+						GuestName: guest.GuestName,
+						Entourage: 1,
+						TableID:   table.ID,
+						Seats:     []int32{1, 2},
+					})).
+					Times(1).
+					Return(db.AssignTableTxResult{Guest: guest, Table: table, Seats: seats}, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "SeatsWithoutTable",
+			body: gin.H{"guest_name": guest.GuestName, "entourage": 0, "seats": []int32{1}},
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().WalkInTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "TableIDAndLabel",
+			body: gin.H{"guest_name": guest.GuestName, "entourage": 0, "table_id": table.ID, "table_label": table.Label},
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().WalkInTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "TableNotFound",
+			body: gin.H{"guest_name": guest.GuestName, "entourage": 0, "table_id": table.ID},
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().WalkInTx(gomock.Any(), gomock.Any()).Times(1).Return(db.AssignTableTxResult{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "NoTableAvailable",
+			body: gin.H{"guest_name": guest.GuestName, "entourage": 12},
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().WalkInTx(gomock.Any(), gomock.Any()).Times(1).Return(db.AssignTableTxResult{}, db.ErrNoTableAvailable)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "GuestOnList",
+			body: gin.H{"guest_name": guest.GuestName, "entourage": 0},
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().WalkInTx(gomock.Any(), gomock.Any()).Times(1).Return(db.AssignTableTxResult{}, db.ErrGuestOnList)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "VenueFull",
+			body: gin.H{"guest_name": guest.GuestName, "entourage": 1},
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					WalkInTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AssignTableTxResult{}, &db.CapacityError{MaxOccupancy: 100, Occupancy: 99, PartySize: 2})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+			},
+		},
+		{
+			name: "TableFull",
+			body: gin.H{"guest_name": guest.GuestName, "entourage": 1, "table_id": table.ID},
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					WalkInTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AssignTableTxResult{}, &db.AdmissionDeniedError{Decisions: []db.AdmissionDecision{
+						{Policy: db.PolicyTableCapacity, Allowed: false, Reason: "party of 2 doesn't fit"},
+					}})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "AdmissionDenied",
+			body: gin.H{"guest_name": guest.GuestName, "entourage": 1},
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					WalkInTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AssignTableTxResult{}, &db.AdmissionDeniedError{Decisions: []db.AdmissionDecision{
+						{Policy: db.PolicyTableCapacity, Allowed: true, Reason: "party of 2 fits in the 4 free seats at table 1"},
+						{Policy: db.PolicyDoorsClose, Allowed: false, Reason: "doors closed"},
+					}})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusConflict, recorder.Code)
+
+				var got admissionError
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.Len(t, got.Decisions, 2)
+			},
+		},
+		{
+			name: "Banned",
+			body: gin.H{"guest_name": guest.GuestName, "entourage": 0},
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					WalkInTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.AssignTableTxResult{}, &db.BannedError{GuestName: guest.GuestName, Match: db.BanMatchName})
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+		{
+			name: "Viewer",
+			body: gin.H{"guest_name": guest.GuestName, "entourage": 0},
+			role: util.ViewerRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().WalkInTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			request, err := http.NewRequest(http.MethodPost, "/walkins", bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, tc.role, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"time"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/gin-gonic/gin"
+)
+
+// arrivalWindowRequest is when a guest, or the guests at a table, are expected to arrive. Either
+// end may be left out, leaving both out removes the window.
+type arrivalWindowRequest struct {
+	ExpectedFrom  *time.Time `json:"expected_from"`
+	ExpectedUntil *time.Time `json:"expected_until"`
+}
+
+func (req arrivalWindowRequest) arrivalWindow() db.ArrivalWindow {
+	return db.ArrivalWindow{
+		ExpectedFrom:  nullTime(req.ExpectedFrom),
+		ExpectedUntil: nullTime(req.ExpectedUntil),
+	}
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+// setGuestArrivalWindow godoc
+// @Summary Sets when a guest is expected to arrive
+// @Description Replaces the guest's arrival window, an end left out falls back to their table's window. A guest who hasn't arrived once their window closes, plus the NO_SHOW_GRACE period, is marked no_show and their reserved seats are released.
+// @Accept json
+// @Produce json
+// @Param    name     path      string                true  "Guest Name"
+// @Param    request  body      arrivalWindowRequest  true  "Start and end of the window, RFC 3339"
+// @Success 200 {object} db.Guest
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /guests/{name}/arrival_window [put]
+func (server *Server) setGuestArrivalWindow(ctx *gin.Context) {
+	var reqName getGuestFromNameRequest
+	var req arrivalWindowRequest
+	if err := ctx.ShouldBindUri(&reqName); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	guest, err := server.store.GetGuestFromName(ctx, reqName.Name)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	guest, err = server.store.SetGuestArrivalWindowTx(ctx, db.SetGuestArrivalWindowTxParams{
+		AuditInfo:     auditInfo(ctx),
+		ArrivalWindow: req.arrivalWindow(),
+		ID:            guest.ID,
+	})
+	if err != nil {
+		if errors.Is(err, db.ErrInvalidArrivalWindow) {
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, guest)
+}
+
+type tableRequestURI struct {
+	ID int32 `uri:"id" binding:"required,min=1"`
+}
+
+// setTableArrivalWindow godoc
+// @Summary Sets when the guests at a table are expected to arrive
+// @Description Replaces the table's arrival window, which applies to each of its guests without a window of their own. A guest who hasn't arrived once their window closes, plus the NO_SHOW_GRACE period, is marked no_show and their reserved seats are released.
+// @Accept json
+// @Produce json
+// @Param    id       path      int                   true  "Table ID"
+// @Param    request  body      arrivalWindowRequest  true  "Start and end of the window, RFC 3339"
+// @Success 200 {object} db.Table
+// @Failure 400 {object} httpError
+// @Failure 404 {object} httpError
+// @Failure 401 {object} httpError
+// @Failure 403 {object} httpError
+// @Failure 500 {object} httpError
+// @Router /tables/{id}/arrival_window [put]
+func (server *Server) setTableArrivalWindow(ctx *gin.Context) {
+	var uri tableRequestURI
+	var req arrivalWindowRequest
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		return
+	}
+
+	table, err := server.store.SetTableArrivalWindowTx(ctx, db.SetTableArrivalWindowTxParams{
+		AuditInfo:     auditInfo(ctx),
+		ArrivalWindow: req.arrivalWindow(),
+		ID:            uri.ID,
+	})
+	if err != nil {
+		switch {
+		case err == sql.ErrNoRows:
+			ctx.JSON(http.StatusNotFound, errorResponse(err))
+		case errors.Is(err, db.ErrInvalidArrivalWindow):
+			ctx.JSON(http.StatusBadRequest, errorResponse(err))
+		default:
+			ctx.JSON(http.StatusInternalServerError, errorResponse(err))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, table)
+}
@@ -0,0 +1,231 @@
+package api
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetGuestArrivalWindowAPI(t *testing.T) {
+	guest := randomGuest()
+	opens := time.Date(2026, 10, 19, 19, 0, 0, 0, time.UTC)
+	closes := opens.Add(time.Hour)
+
+	updated := guest
+	updated.ExpectedFrom = sql.NullTime{Time: opens, Valid: true}
+	updated.ExpectedUntil = sql.NullTime{Time: closes, Valid: true}
+
+	testCases := []struct {
+		name          string
+		body          gin.H
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name: "OK",
+			body: gin.H{"expected_from": opens, "expected_until": closes},
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().
+					SetGuestArrivalWindowTx(gomock.Any(), gomock.Eq(db.SetGuestArrivalWindowTxParams{
+						AuditInfo: testAudit,
+						ArrivalWindow: db.ArrivalWindow{
+							ExpectedFrom:  sql.NullTime{Time: opens, Valid: true},
+							ExpectedUntil: sql.NullTime{Time: closes, Valid: true},
+						},
+						ID: guest.ID,
+					})).
+					Times(1).
+					Return(updated, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+
+				var got db.Guest
+				require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &got))
+				require.True(t, got.ExpectedUntil.Valid)
+				require.True(t, closes.Equal(got.ExpectedUntil.Time))
+			},
+		},
+		{
+			name: "RemoveWindow",
+			body: gin.H{},
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().
+					SetGuestArrivalWindowTx(gomock.Any(), gomock.Eq(db.SetGuestArrivalWindowTxParams{AuditInfo: testAudit, ID: guest.ID})).
+					Times(1).
+					Return(guest, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name: "ClosesBeforeItOpens",
+			body: gin.H{"expected_from": closes, "expected_until": opens},
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().
+					SetGuestArrivalWindowTx(gomock.Any(), gomock.Any()).
+					Times(1).
+					Return(db.Guest{}, db.ErrInvalidArrivalWindow)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "NotATime",
+			body: gin.H{"expected_until": "half eight"},
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetGuestArrivalWindowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+		{
+			name: "GuestNotFound",
+			body: gin.H{"expected_until": closes},
+			role: util.OrganiserRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Any()).Times(1).Return(db.Guest{}, sql.ErrNoRows)
+				store.EXPECT().SetGuestArrivalWindowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name: "DoorStaff",
+			body: gin.H{"expected_until": closes},
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetGuestArrivalWindowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusForbidden, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(tc.body)
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/guests/%s/arrival_window", guest.GuestName)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, tc.role, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
+
+func TestSetTableArrivalWindowAPI(t *testing.T) {
+	table := randomTable()
+	closes := time.Date(2026, 10, 19, 20, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		tableID       int32
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(recorder *httptest.ResponseRecorder)
+	}{
+		{
+			name:    "OK",
+			tableID: table.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				updated := table
+				updated.ExpectedUntil = sql.NullTime{Time: closes, Valid: true}
+				store.EXPECT().
+					SetTableArrivalWindowTx(gomock.Any(), gomock.Eq(db.SetTableArrivalWindowTxParams{
+						AuditInfo:     testAudit,
+						ArrivalWindow: db.ArrivalWindow{ExpectedUntil: sql.NullTime{Time: closes, Valid: true}},
+						ID:            table.ID,
+					})).
+					Times(1).
+					Return(updated, nil)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusOK, recorder.Code)
+			},
+		},
+		{
+			name:    "NotFound",
+			tableID: table.ID,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetTableArrivalWindowTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Table{}, sql.ErrNoRows)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusNotFound, recorder.Code)
+			},
+		},
+		{
+			name:    "InvalidID",
+			tableID: 0,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().SetTableArrivalWindowTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(recorder *httptest.ResponseRecorder) {
+				require.Equal(t, http.StatusBadRequest, recorder.Code)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			recorder := httptest.NewRecorder()
+
+			data, err := json.Marshal(gin.H{"expected_until": closes})
+			require.NoError(t, err)
+
+			url := fmt.Sprintf("/tables/%d/arrival_window", tc.tableID)
+			request, err := http.NewRequest(http.MethodPut, url, bytes.NewReader(data))
+			require.NoError(t, err)
+
+			addAuthorization(t, request, server.tokenMaker, authorizationTypeBearer, testUsername, util.OrganiserRole, time.Minute)
+			server.router.ServeHTTP(recorder, request)
+			tc.checkResponse(recorder)
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// Package client is a typed Go client for the guestlist HTTP API
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// NullTime is a timestamp the API may leave unset, Time is only meaningful when Valid is true
+type NullTime struct {
+	Time  time.Time `json:"Time"`
+	Valid bool      `json:"Valid"`
+}
+
+const (
+	idempotencyKeyHeader = "Idempotency-Key"
+	apiKeyHeader         = "X-API-Key"
+	requestIDHeader      = "X-Request-ID"
+
+	// maxPageSize is the largest page_size accepted by the paginated endpoints
+	maxPageSize = 10
+
+	defaultMaxRetries = 3
+	defaultBackoff    = 100 * time.Millisecond
+)
+
+// Client calls the guestlist API. Mutating requests carry an Idempotency-Key which is reused
+// across retries, so a retried request is never applied twice.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	maxRetries int
+	backoff    time.Duration
+	token      string
+	apiKey     string
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient replaces the http.Client used to send requests
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithToken authenticates every request with a JWT issued by POST /tokens
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.token = token
+	}
+}
+
+// WithAPIKey authenticates every request with one of the server's configured API keys
+func WithAPIKey(apiKey string) Option {
+	return func(c *Client) {
+		c.apiKey = apiKey
+	}
+}
+
+// WithRetries sets how many times a failed request is retried and the initial backoff,
+// which doubles after every attempt
+func WithRetries(maxRetries int, backoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxRetries = maxRetries
+		c.backoff = backoff
+	}
+}
+
+// New creates a client for the API served at baseURL, e.g. http://localhost:3000
+func New(baseURL string, opts ...Option) (*Client, error) {
+	u, err := url.Parse(strings.TrimSuffix(baseURL, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		baseURL:    u,
+		httpClient: http.DefaultClient,
+		maxRetries: defaultMaxRetries,
+		backoff:    defaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// NewIdempotencyKey returns a random key suitable for the Idempotency-Key header
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+type idempotencyKeyCtxKey struct{}
+
+// WithIdempotencyKey makes the mutating request sent with ctx use key rather than a generated one,
+// which lets a caller safely repeat an operation across process restarts
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtxKey{}, key)
+}
+
+type requestIDCtxKey struct{}
+
+// WithRequestID makes the request sent with ctx carry id as its X-Request-ID, under which any
+// change it makes is recorded in the audit trail. Requests are otherwise given a random ID.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDCtxKey{}, id)
+}
+
+// do sends the request, retrying transport errors and retryable responses, and decodes
+// a successful JSON response into out when it is non nil
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	var idempotencyKey string
+	if method != http.MethodGet {
+		idempotencyKey, _ = ctx.Value(idempotencyKeyCtxKey{}).(string)
+		if idempotencyKey == "" {
+			idempotencyKey = NewIdempotencyKey()
+		}
+	}
+
+	// Retries share the request ID so they can be told apart from new requests in the audit trail
+	requestID, _ := ctx.Value(requestIDCtxKey{}).(string)
+	if requestID == "" {
+		requestID = NewIdempotencyKey()
+	}
+
+	u := *c.baseURL
+	u.Path += path
+	u.RawQuery = query.Encode()
+
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		err := c.send(ctx, method, u.String(), payload, idempotencyKey, requestID, out)
+		if err == nil || attempt >= c.maxRetries || !retryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, rawURL string, payload []byte, idempotencyKey, requestID string, out interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set(requestIDHeader, requestID)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if idempotencyKey != "" {
+		req.Header.Set(idempotencyKeyHeader, idempotencyKey)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.apiKey != "" {
+		req.Header.Set(apiKeyHeader, c.apiKey)
+	}
+
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	data, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		apiErr := &Error{StatusCode: res.StatusCode}
+		var errBody struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(data, &errBody) == nil {
+			apiErr.Message = errBody.Error
+		}
+		return apiErr
+	}
+
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("guestlist: decoding %s %s response: %w", method, rawURL, err)
+	}
+	return nil
+}
+
+// retryable reports whether err came from the transport or a retryable status, a cancelled
+// or expired context is never retried
+func retryable(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr.retryable()
+	}
+	return true
+}
+
+// pageQuery builds the page_id/page_size query used by the paginated endpoints
+func pageQuery(pageID, pageSize int) url.Values {
+	return url.Values{
+		"page_id":   {fmt.Sprint(pageID)},
+		"page_size": {fmt.Sprint(pageSize)},
+	}
+}
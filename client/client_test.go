@@ -0,0 +1,333 @@
+package client
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ellisp97/BE_Task_Oct20/golang/api"
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testUsername  = "test-organiser"
+	testAPIKey    = "test-organiser-key"
+	testRequestID = "test-request"
+)
+
+// testAudit is the audit info of requests sent with testContext
+var testAudit = db.AuditInfo{Actor: testUsername, RequestID: testRequestID}
+
+func testContext() context.Context {
+	return WithRequestID(context.Background(), testRequestID)
+}
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// newTestServer serves a real api.Server backed by the mock store, organisers authenticate with testAPIKey
+func newTestServer(t *testing.T, store db.Store) *httptest.Server {
+	config := util.Config{
+		TokenSymmetricKey:      util.RandomString(32),
+		AccessTokenDuration:    time.Minute,
+		EventName:              "test-event",
+		InvitationSymmetricKey: util.RandomString(32),
+		APIKeys:                fmt.Sprintf("%s:%s:%s", testUsername, util.OrganiserRole, testAPIKey),
+	}
+	server, err := api.NewServer(config, store)
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(server)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// newTestClient returns an organiser's client for a test server backed by the mock store
+func newTestClient(t *testing.T, store db.Store) *Client {
+	ts := newTestServer(t, store)
+
+	c, err := New(ts.URL, WithAPIKey(testAPIKey), WithRetries(2, time.Millisecond))
+	require.NoError(t, err)
+	return c
+}
+
+func TestCreateGuest(t *testing.T) {
+	table := randomTable()
+	guest := randomGuest()
+	guest.TableID = table.ID
+
+	testCases := []struct {
+		name       string
+		entourage  int32
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, name string, err error)
+	}{
+		{
+			name:      "OK",
+			entourage: table.Size - 1,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTable(gomock.Any(), gomock.Eq(table.ID)).Times(1).Return(table, nil)
+				store.EXPECT().CreateGuestTx(gomock.Any(), gomock.Eq(db.CreateGuestTxParams{
+					AuditInfo: testAudit,
+					GuestName: guest.GuestName,
+					Entourage: table.Size - 1,
+					TableID:   table.ID,
+				})).Times(1).Return(guest, nil)
+			},
+			check: func(t *testing.T, name string, err error) {
+				require.NoError(t, err)
+				require.Equal(t, guest.GuestName, name)
+			},
+		},
+		{
+			name:      "TableTooSmall",
+			entourage: table.Size,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTable(gomock.Any(), gomock.Eq(table.ID)).Times(1).Return(table, nil)
+				store.EXPECT().CreateGuestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, name string, err error) {
+				require.True(t, errors.Is(err, ErrBadRequest))
+				var apiErr *Error
+				require.True(t, errors.As(err, &apiErr))
+				require.NotEmpty(t, apiErr.Message)
+			},
+		},
+		{
+			name:      "TableNotFound",
+			entourage: table.Size - 1,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTable(gomock.Any(), gomock.Eq(table.ID)).Times(1).Return(db.Table{}, sql.ErrNoRows)
+			},
+			check: func(t *testing.T, name string, err error) {
+				require.True(t, errors.Is(err, ErrNotFound))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			c := newTestClient(t, store)
+			name, err := c.CreateGuest(testContext(), guest.GuestName, CreateGuestRequest{
+				Entourage: tc.entourage,
+				TableID:   table.ID,
+			})
+			tc.check(t, name, err)
+		})
+	}
+}
+
+func TestArriveGuestRetriesServerErrors(t *testing.T) {
+	table := randomTable()
+	guest := randomGuest()
+	guest.TableID = table.ID
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	arg := db.AssignTableTxParams{
+		UserID:       int64(guest.ID),
+		TableID:      int64(guest.TableID),
+		NewEntourage: 2,
+		AuditInfo:    testAudit,
+	}
+
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(2).Return(guest, nil)
+	gomock.InOrder(
+		store.EXPECT().AssignTableTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.AssignTableTxResult{}, sql.ErrConnDone),
+		store.EXPECT().AssignTableTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.AssignTableTxResult{Guest: guest}, nil),
+	)
+
+	c := newTestClient(t, store)
+	name, err := c.ArriveGuest(testContext(), guest.GuestName, 2)
+	require.NoError(t, err)
+	require.Equal(t, guest.GuestName, name)
+}
+
+func TestArriveGuestDoesNotRetryClientErrors(t *testing.T) {
+	table := randomTable()
+	guest := randomGuest()
+	guest.TableID = table.ID
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+	denied := &db.AdmissionDeniedError{Decisions: []db.AdmissionDecision{
+		{Policy: db.PolicyTableCapacity, Allowed: false, Reason: fmt.Sprintf("party of 3 doesn't fit in the 2 free seats at table %d", table.ID)},
+	}}
+	store.EXPECT().AssignTableTx(gomock.Any(), gomock.Any()).Times(1).
+		Return(db.AssignTableTxResult{}, denied)
+
+	c := newTestClient(t, store)
+	_, err := c.ArriveGuest(context.Background(), guest.GuestName, 2)
+	require.True(t, errors.Is(err, ErrBadRequest))
+	require.Contains(t, err.Error(), denied.Error())
+}
+
+func TestListGuestsPaginates(t *testing.T) {
+	n := maxPageSize + 3
+	guests := make([]db.Guest, n)
+	for i := range guests {
+		guests[i] = randomGuest()
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mockdb.NewMockStore(controller)
+	gomock.InOrder(
+		store.EXPECT().GetGuests(gomock.Any(), gomock.Eq(db.GetGuestsParams{Limit: maxPageSize, Offset: 0})).
+			Times(1).Return(guests[:maxPageSize], nil),
+		store.EXPECT().GetGuests(gomock.Any(), gomock.Eq(db.GetGuestsParams{Limit: maxPageSize, Offset: maxPageSize})).
+			Times(1).Return(guests[maxPageSize:], nil),
+	)
+
+	c := newTestClient(t, store)
+	fetched, err := c.ListGuests(context.Background())
+	require.NoError(t, err)
+	require.Len(t, fetched, n)
+	for i := range guests {
+		require.Equal(t, guests[i].ID, fetched[i].ID)
+		require.Equal(t, guests[i].GuestName, fetched[i].GuestName)
+	}
+}
+
+func TestGetGuestNotFound(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Any()).Times(1).Return(db.Guest{}, sql.ErrNoRows)
+
+	c := newTestClient(t, store)
+	_, err := c.GetGuest(context.Background(), "unknown")
+	require.True(t, errors.Is(err, ErrNotFound))
+	require.False(t, errors.Is(err, ErrBadRequest))
+}
+
+func TestCancelledContextIsNotRetried(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().GetEmptySeats(gomock.Any()).Times(0)
+
+	c := newTestClient(t, store)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := c.EmptySeats(ctx)
+	require.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestCreateTable(t *testing.T) {
+	table := randomTable()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().CreateTableTx(gomock.Any(), gomock.Eq(db.CreateTableTxParams{AuditInfo: testAudit, Size: table.Size})).Times(1).Return(table, nil)
+
+	c := newTestClient(t, store)
+	created, err := c.CreateTable(testContext(), table.Size)
+	require.NoError(t, err)
+	require.Equal(t, Table{
+		ID:       table.ID,
+		Size:     table.Size,
+		Label:    table.Label,
+		Shape:    table.Shape,
+		MinParty: table.MinParty,
+		MaxParty: table.MaxParty,
+	}, created)
+}
+
+func TestCreateToken(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().GetEmptySeats(gomock.Any()).Times(1).Return(int32(4), nil)
+	store.EXPECT().CreateTableTx(gomock.Any(), gomock.Any()).Times(0)
+
+	ts := newTestServer(t, store)
+	organiser, err := New(ts.URL, WithAPIKey(testAPIKey))
+	require.NoError(t, err)
+
+	issued, err := organiser.CreateToken(context.Background(), "dashboard", util.ViewerRole)
+	require.NoError(t, err)
+	require.NotEmpty(t, issued.AccessToken)
+	require.Equal(t, "dashboard", issued.Username)
+	require.Equal(t, util.ViewerRole, issued.Role)
+	require.WithinDuration(t, time.Now().Add(time.Minute), issued.AccessTokenExpiresAt, time.Second)
+
+	viewer, err := New(ts.URL, WithToken(issued.AccessToken), WithRetries(0, 0))
+	require.NoError(t, err)
+
+	seats, err := viewer.EmptySeats(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int32(4), seats)
+
+	_, err = viewer.CreateTable(context.Background(), 4)
+	require.True(t, errors.Is(err, ErrForbidden))
+}
+
+func TestUnauthenticated(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().GetEmptySeats(gomock.Any()).Times(0)
+
+	ts := newTestServer(t, store)
+	c, err := New(ts.URL, WithAPIKey("wrong-key"))
+	require.NoError(t, err)
+
+	_, err = c.EmptySeats(context.Background())
+	require.True(t, errors.Is(err, ErrUnauthorized))
+}
+
+func randomGuest() db.Guest {
+	return db.Guest{
+		ID:         util.RandomInt(1, 1000),
+		GuestName:  util.RandomGuestName(),
+		Entourage:  util.RandomGuestSize(),
+		TableID:    util.RandomInt(1, 20),
+		RsvpStatus: db.RSVPPending,
+		Status:     db.GuestInvited,
+	}
+}
+
+func randomTable() db.Table {
+	size := util.RandomTableSize()
+	id := util.RandomInt(1, 1000)
+	return db.Table{
+		ID:       id,
+		Size:     size,
+		Occupied: 0,
+		Label:    fmt.Sprintf("Table %d", id),
+		Shape:    db.ShapeRound,
+		MinParty: 1,
+		MaxParty: size,
+	}
+}
@@ -0,0 +1,40 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Sentinel errors mirroring the status codes returned by the guestlist API, match them with errors.Is
+var (
+	ErrBadRequest   = &Error{StatusCode: http.StatusBadRequest}
+	ErrUnauthorized = &Error{StatusCode: http.StatusUnauthorized}
+	ErrForbidden    = &Error{StatusCode: http.StatusForbidden}
+	ErrNotFound     = &Error{StatusCode: http.StatusNotFound}
+	ErrConflict     = &Error{StatusCode: http.StatusConflict}
+	ErrInternal     = &Error{StatusCode: http.StatusInternalServerError}
+)
+
+// Error is returned for every non 2xx response, Message holds the server's {"error": ...} value
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("guestlist: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("guestlist: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Is matches any Error with the same status code, so errors.Is(err, ErrNotFound) works for every 404
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.StatusCode == e.StatusCode
+}
+
+// retryable reports whether a request which failed with this error may succeed if sent again
+func (e *Error) retryable() bool {
+	return e.StatusCode >= http.StatusInternalServerError || e.StatusCode == http.StatusTooManyRequests
+}
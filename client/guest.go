@@ -0,0 +1,108 @@
+package client
+
+import (
+	"context"
+)
+
+// Guest is an entry on the guest list. Email and Phone are left empty for viewers.
+type Guest struct {
+	ID            int32    `json:"id"`
+	GuestName     string   `json:"guest_name"`
+	Entourage     int32    `json:"entourage"`
+	TableID       int32    `json:"table_id"`
+	ArrivalTime   NullTime `json:"arrival_time"`
+	CreatedAt     NullTime `json:"created_at"`
+	CreatedBy     string   `json:"created_by"`
+	RsvpStatus    string   `json:"rsvp_status"`
+	RsvpAt        NullTime `json:"rsvp_at"`
+	Status        string   `json:"status"`
+	Dietary       string   `json:"dietary"`
+	Accessibility string   `json:"accessibility"`
+	VipTier       string   `json:"vip_tier"`
+	Email         string   `json:"email,omitempty"`
+	Phone         string   `json:"phone,omitempty"`
+	Notes         string   `json:"notes"`
+	ExpectedFrom  NullTime `json:"expected_from"`
+	ExpectedUntil NullTime `json:"expected_until"`
+	WalkIn        bool     `json:"walk_in"`
+}
+
+// CreateGuestRequest books a guest and their entourage onto a table
+type CreateGuestRequest struct {
+	Entourage int32 `json:"entourage"`
+	TableID   int32 `json:"table_id"`
+}
+
+// CreateGuest adds name to the guest list, it fails with ErrBadRequest if the table doesn't take a
+// party of their size
+func (c *Client) CreateGuest(ctx context.Context, name string, req CreateGuestRequest) (string, error) {
+	var guestName string
+	err := c.do(ctx, createGuestRoute.method, createGuestRoute.expand(name), nil, req, &guestName)
+	return guestName, err
+}
+
+// GetGuest returns the guest list entry of name
+func (c *Client) GetGuest(ctx context.Context, name string) (Guest, error) {
+	var guest Guest
+	err := c.do(ctx, getGuestRoute.method, getGuestRoute.expand(name), nil, nil, &guest)
+	return guest, err
+}
+
+// ArriveGuest arrives name with an entourage which may differ from the one booked,
+// it fails with ErrBadRequest if their table cannot fit the party and with ErrConflict if any
+// other admission policy turns them away
+func (c *Client) ArriveGuest(ctx context.Context, name string, entourage int32) (string, error) {
+	body := struct {
+		Entourage int32 `json:"entourage"`
+	}{Entourage: entourage}
+
+	var guestName string
+	err := c.do(ctx, arriveGuestRoute.method, arriveGuestRoute.expand(name), nil, body, &guestName)
+	return guestName, err
+}
+
+// LeaveGuest removes name and their entourage, freeing their seats
+func (c *Client) LeaveGuest(ctx context.Context, name string) (string, error) {
+	var guestName string
+	err := c.do(ctx, leaveGuestRoute.method, leaveGuestRoute.expand(name), nil, nil, &guestName)
+	return guestName, err
+}
+
+// ListGuestsPage returns a single page of the guest list, pageSize must be between 5 and 10
+func (c *Client) ListGuestsPage(ctx context.Context, pageID, pageSize int) ([]Guest, error) {
+	guests := []Guest{}
+	err := c.do(ctx, listGuestsRoute.method, listGuestsRoute.path, pageQuery(pageID, pageSize), nil, &guests)
+	return guests, err
+}
+
+// ListGuests returns the whole guest list, fetching every page in turn
+func (c *Client) ListGuests(ctx context.Context) ([]Guest, error) {
+	return c.listAll(ctx, c.ListGuestsPage)
+}
+
+// ListArrivedGuestsPage returns a single page of the guests who have arrived
+func (c *Client) ListArrivedGuestsPage(ctx context.Context, pageID, pageSize int) ([]Guest, error) {
+	guests := []Guest{}
+	err := c.do(ctx, listArrivedGuestsRoute.method, listArrivedGuestsRoute.path, pageQuery(pageID, pageSize), nil, &guests)
+	return guests, err
+}
+
+// ListArrivedGuests returns every guest who has arrived, fetching every page in turn
+func (c *Client) ListArrivedGuests(ctx context.Context) ([]Guest, error) {
+	return c.listAll(ctx, c.ListArrivedGuestsPage)
+}
+
+// listAll keeps requesting full pages until a short one signals the end of the list
+func (c *Client) listAll(ctx context.Context, page func(ctx context.Context, pageID, pageSize int) ([]Guest, error)) ([]Guest, error) {
+	all := []Guest{}
+	for pageID := 1; ; pageID++ {
+		guests, err := page(ctx, pageID, maxPageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, guests...)
+		if len(guests) < maxPageSize {
+			return all, nil
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package client
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// route is an API endpoint called by the client, the path uses the {param} form of the OpenAPI spec
+type route struct {
+	method string
+	path   string
+}
+
+var (
+	createGuestRoute       = route{http.MethodPost, "/guest_list/{name}"}
+	listGuestsRoute        = route{http.MethodGet, "/guest_list"}
+	getGuestRoute          = route{http.MethodGet, "/guests/{name}"}
+	arriveGuestRoute       = route{http.MethodPut, "/guests/{name}"}
+	listArrivedGuestsRoute = route{http.MethodGet, "/guests"}
+	leaveGuestRoute        = route{http.MethodDelete, "/guests/{name}"}
+	emptySeatsRoute        = route{http.MethodGet, "/seats_empty"}
+	createTableRoute       = route{http.MethodPost, "/tables"}
+	listTablesRoute        = route{http.MethodGet, "/tables"}
+	createTokenRoute       = route{http.MethodPost, "/tokens"}
+)
+
+// routes lists every endpoint the client calls, it is checked against docs/openapi.yaml
+var routes = []route{
+	createGuestRoute,
+	listGuestsRoute,
+	getGuestRoute,
+	arriveGuestRoute,
+	listArrivedGuestsRoute,
+	leaveGuestRoute,
+	emptySeatsRoute,
+	createTableRoute,
+	listTablesRoute,
+	createTokenRoute,
+}
+
+// expand substitutes params, in order, into the {param} segments of the path
+func (r route) expand(params ...string) string {
+	segments := strings.Split(r.path, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, "{") && len(params) > 0 {
+			segments[i] = url.PathEscape(params[0])
+			params = params[1:]
+		}
+	}
+	return strings.Join(segments, "/")
+}
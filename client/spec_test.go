@@ -0,0 +1,49 @@
+package client
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ellisp97/BE_Task_Oct20/golang/docs"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRoutesMatchSpec fails when the client calls an endpoint which isn't documented in docs/openapi.yaml
+func TestRoutesMatchSpec(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData(docs.OpenAPI)
+	require.NoError(t, err)
+
+	for _, r := range routes {
+		path := spec.Paths.Find(r.path)
+		require.NotNilf(t, path, "%s is not documented in docs/openapi.yaml", r.path)
+		require.NotNilf(t, path.GetOperation(r.method), "%s %s is not documented in docs/openapi.yaml", r.method, r.path)
+	}
+}
+
+// TestModelsMatchSpec fails when a model's JSON fields drift from its schema in docs/openapi.yaml
+func TestModelsMatchSpec(t *testing.T) {
+	spec, err := openapi3.NewLoader().LoadFromData(docs.OpenAPI)
+	require.NoError(t, err)
+
+	models := map[string]interface{}{
+		"Guest": Guest{},
+		"Table": Table{},
+	}
+	for name, model := range models {
+		schema := spec.Components.Schemas[name].Value
+		require.NotNilf(t, schema, "%s is not documented in docs/openapi.yaml", name)
+
+		fields := map[string]bool{}
+		modelType := reflect.TypeOf(model)
+		for i := 0; i < modelType.NumField(); i++ {
+			field := strings.Split(modelType.Field(i).Tag.Get("json"), ",")[0]
+			require.Containsf(t, schema.Properties, field, "%s.%s is not in the schema", name, field)
+			fields[field] = true
+		}
+		for property := range schema.Properties {
+			require.Truef(t, fields[property], "%s has no field for %s", name, property)
+		}
+	}
+}
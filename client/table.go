@@ -0,0 +1,62 @@
+package client
+
+import (
+	"context"
+)
+
+// Table is a table on the floor and how many of its seats are occupied
+type Table struct {
+	ID            int32    `json:"id"`
+	Size          int32    `json:"size"`
+	Occupied      int32    `json:"occupied"`
+	CreatedAt     NullTime `json:"created_at"`
+	CreatedBy     string   `json:"created_by"`
+	Label         string   `json:"label"`
+	Zone          string   `json:"zone"`
+	Accessible    bool     `json:"accessible"`
+	Shape         string   `json:"shape"`
+	MinParty      int32    `json:"min_party"`
+	MaxParty      int32    `json:"max_party"`
+	ExpectedFrom  NullTime `json:"expected_from"`
+	ExpectedUntil NullTime `json:"expected_until"`
+}
+
+// EmptySeats returns the number of empty seats across every table
+func (c *Client) EmptySeats(ctx context.Context) (int32, error) {
+	var count int32
+	err := c.do(ctx, emptySeatsRoute.method, emptySeatsRoute.path, nil, nil, &count)
+	return count, err
+}
+
+// CreateTable adds an empty table with size seats
+func (c *Client) CreateTable(ctx context.Context, size int32) (Table, error) {
+	body := struct {
+		Size int32 `json:"size"`
+	}{Size: size}
+
+	var table Table
+	err := c.do(ctx, createTableRoute.method, createTableRoute.path, nil, body, &table)
+	return table, err
+}
+
+// ListTablesPage returns a single page of tables, pageSize must be between 5 and 10
+func (c *Client) ListTablesPage(ctx context.Context, pageID, pageSize int) ([]Table, error) {
+	tables := []Table{}
+	err := c.do(ctx, listTablesRoute.method, listTablesRoute.path, pageQuery(pageID, pageSize), nil, &tables)
+	return tables, err
+}
+
+// ListTables returns every table, fetching every page in turn
+func (c *Client) ListTables(ctx context.Context) ([]Table, error) {
+	all := []Table{}
+	for pageID := 1; ; pageID++ {
+		tables, err := c.ListTablesPage(ctx, pageID, maxPageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, tables...)
+		if len(tables) < maxPageSize {
+			return all, nil
+		}
+	}
+}
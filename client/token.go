@@ -0,0 +1,26 @@
+package client
+
+import (
+	"context"
+	"time"
+)
+
+// Token is an access token issued by the API
+type Token struct {
+	AccessToken          string    `json:"access_token"`
+	AccessTokenExpiresAt time.Time `json:"access_token_expires_at"`
+	Username             string    `json:"username"`
+	Role                 string    `json:"role"`
+}
+
+// CreateToken issues a token for username with role, the client must be authenticated as an organiser
+func (c *Client) CreateToken(ctx context.Context, username, role string) (Token, error) {
+	body := struct {
+		Username string `json:"username"`
+		Role     string `json:"role"`
+	}{Username: username, Role: role}
+
+	var token Token
+	err := c.do(ctx, createTokenRoute.method, createTokenRoute.path, nil, body, &token)
+	return token, err
+}
@@ -0,0 +1,5 @@
+ALTER TABLE arrivals DROP COLUMN arrived_by;
+
+ALTER TABLE guests DROP COLUMN created_by;
+
+ALTER TABLE tables DROP COLUMN created_by;
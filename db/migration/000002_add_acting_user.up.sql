@@ -0,0 +1,5 @@
+ALTER TABLE tables ADD COLUMN created_by VARCHAR(255) NOT NULL DEFAULT '';
+
+ALTER TABLE guests ADD COLUMN created_by VARCHAR(255) NOT NULL DEFAULT '';
+
+ALTER TABLE arrivals ADD COLUMN arrived_by VARCHAR(255) NOT NULL DEFAULT '';
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS audit_events (
+    id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    action VARCHAR(64) NOT NULL,
+    actor VARCHAR(255) NOT NULL,
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    guest_id INT,
+    guest_name VARCHAR(255) NOT NULL DEFAULT '',
+    table_id INT,
+    `before` JSON,
+    `after` JSON,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- No foreign keys, events must outlive the guests and tables they describe
+    INDEX audit_events_guest_name (guest_name),
+    INDEX audit_events_table_id (table_id),
+    INDEX audit_events_actor (actor),
+    INDEX audit_events_created_at (created_at)
+) ENGINE=INNODB;
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS invitations (
+    id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    guest_id INT NOT NULL,
+    created_by VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    revoked_at TIMESTAMP NULL,
+    used_at TIMESTAMP NULL,
+
+    FOREIGN KEY (guest_id)
+        REFERENCES guests (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=INNODB;
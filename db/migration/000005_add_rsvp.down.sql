@@ -0,0 +1,3 @@
+ALTER TABLE guests DROP COLUMN rsvp_at;
+
+ALTER TABLE guests DROP COLUMN rsvp_status;
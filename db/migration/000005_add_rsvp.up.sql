@@ -0,0 +1,3 @@
+ALTER TABLE guests ADD COLUMN rsvp_status VARCHAR(16) NOT NULL DEFAULT 'pending';
+
+ALTER TABLE guests ADD COLUMN rsvp_at TIMESTAMP NULL;
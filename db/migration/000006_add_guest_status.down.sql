@@ -0,0 +1,3 @@
+DROP INDEX guests_status_idx ON guests;
+
+ALTER TABLE guests DROP COLUMN status;
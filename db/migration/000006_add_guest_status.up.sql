@@ -0,0 +1,10 @@
+ALTER TABLE guests ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'invited';
+
+CREATE INDEX guests_status_idx ON guests (status);
+
+-- Existing guests take the status their RSVP and arrival imply
+UPDATE guests SET status = 'confirmed' WHERE rsvp_status = 'accepted';
+
+UPDATE guests SET status = 'declined' WHERE rsvp_status = 'declined';
+
+UPDATE guests SET status = 'arrived' WHERE id IN (SELECT guest_id FROM arrivals);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS party_changes (
+    id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    arrival_id INT NOT NULL,
+    old_party_size INT NOT NULL,
+    new_party_size INT NOT NULL,
+    changed_by VARCHAR(255) NOT NULL DEFAULT '',
+    changed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (arrival_id)
+        REFERENCES arrivals (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=INNODB;
@@ -0,0 +1,8 @@
+DROP INDEX arrivals_guest_departed_idx ON arrivals;
+
+-- Only the open session of each guest fits the old one arrival per guest model
+DELETE FROM arrivals WHERE departed_at IS NOT NULL;
+
+ALTER TABLE arrivals DROP COLUMN departed_at;
+
+ALTER TABLE arrivals DROP COLUMN arrived_at;
@@ -0,0 +1,6 @@
+-- Each arrival is now a session, closed rather than deleted when the guest leaves
+ALTER TABLE arrivals ADD COLUMN arrived_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+ALTER TABLE arrivals ADD COLUMN departed_at TIMESTAMP NULL;
+
+CREATE INDEX arrivals_guest_departed_idx ON arrivals (guest_id, departed_at);
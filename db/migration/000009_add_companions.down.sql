@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS arrival_companions;
+
+DROP TABLE IF EXISTS companions;
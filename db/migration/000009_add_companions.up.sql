@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS companions (
+    id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    guest_id INT NOT NULL,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    attributes JSON,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    FOREIGN KEY (guest_id)
+        REFERENCES guests (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=INNODB;
+
+-- The named companions who came in with the guest in each arrival session
+CREATE TABLE IF NOT EXISTS arrival_companions (
+    arrival_id INT NOT NULL,
+    companion_id INT NOT NULL,
+
+    PRIMARY KEY (arrival_id, companion_id),
+
+    FOREIGN KEY (arrival_id)
+        REFERENCES arrivals (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE,
+
+    FOREIGN KEY (companion_id)
+        REFERENCES companions (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=INNODB;
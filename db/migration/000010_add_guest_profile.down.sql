@@ -0,0 +1,9 @@
+DROP INDEX guests_vip_tier_idx ON guests;
+
+ALTER TABLE guests
+    DROP COLUMN notes,
+    DROP COLUMN phone,
+    DROP COLUMN email,
+    DROP COLUMN vip_tier,
+    DROP COLUMN accessibility,
+    DROP COLUMN dietary;
@@ -0,0 +1,10 @@
+-- dietary is a comma separated list of requirements so it can be searched with FIND_IN_SET
+ALTER TABLE guests
+    ADD COLUMN dietary VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN accessibility VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN vip_tier VARCHAR(16) NOT NULL DEFAULT '',
+    ADD COLUMN email VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN phone VARCHAR(32) NOT NULL DEFAULT '',
+    ADD COLUMN notes VARCHAR(1024) NOT NULL DEFAULT '';
+
+CREATE INDEX guests_vip_tier_idx ON guests (vip_tier);
@@ -0,0 +1,10 @@
+DROP INDEX tables_zone_idx ON tables;
+DROP INDEX tables_label_idx ON tables;
+
+ALTER TABLE tables
+    DROP COLUMN max_party,
+    DROP COLUMN min_party,
+    DROP COLUMN shape,
+    DROP COLUMN accessible,
+    DROP COLUMN zone,
+    DROP COLUMN label;
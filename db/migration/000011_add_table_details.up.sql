@@ -0,0 +1,15 @@
+-- Every table is known on the floor by a unique label, existing tables are labelled after their ID.
+-- max_party defaults to the size of the table, min_party and max_party bound the size of the
+-- parties it may be given
+ALTER TABLE tables
+    ADD COLUMN label VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN zone VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN accessible BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN shape VARCHAR(16) NOT NULL DEFAULT 'round',
+    ADD COLUMN min_party INT NOT NULL DEFAULT 1,
+    ADD COLUMN max_party INT NOT NULL DEFAULT 0;
+
+UPDATE tables SET label = CONCAT('Table ', id), max_party = size;
+
+CREATE UNIQUE INDEX tables_label_idx ON tables (label);
+CREATE INDEX tables_zone_idx ON tables (zone);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS standing_admissions;
+DROP TABLE IF EXISTS capacity_limits;
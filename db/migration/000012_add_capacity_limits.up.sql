@@ -0,0 +1,22 @@
+-- The maximum occupancy of the venue (zone '') and of any zones with their own limit, 0 is no
+-- limit. The venue row always exists as every admission locks it to count the headcount.
+CREATE TABLE IF NOT EXISTS capacity_limits (
+    zone VARCHAR(64) NOT NULL PRIMARY KEY,
+    max_occupancy INT NOT NULL DEFAULT 0,
+    updated_by VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=INNODB;
+
+INSERT INTO capacity_limits (zone, max_occupancy) VALUES ('', 0);
+
+-- People admitted without a table, who count against the venue limit until they leave
+CREATE TABLE IF NOT EXISTS standing_admissions (
+    id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL DEFAULT '',
+    party_size INT NOT NULL,
+    admitted_by VARCHAR(255) NOT NULL,
+    admitted_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    departed_at TIMESTAMP NULL DEFAULT NULL,
+
+    INDEX standing_admissions_departed_idx (departed_at)
+) ENGINE=INNODB;
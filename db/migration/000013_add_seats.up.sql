@@ -0,0 +1,52 @@
+-- Every table has a numbered seat for each of its places. A seat is held by a guest from when it's
+-- assigned, at booking or on arrival, with the companion sitting in it if they've been named.
+CREATE TABLE IF NOT EXISTS seats (
+    id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    table_id INT NOT NULL,
+    number INT NOT NULL,
+    guest_id INT NULL,
+    companion_id INT NULL,
+    assigned_at TIMESTAMP NULL,
+
+    FOREIGN KEY (table_id)
+        REFERENCES tables (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE,
+
+    FOREIGN KEY (guest_id)
+        REFERENCES guests (id)
+        ON UPDATE RESTRICT ON DELETE SET NULL,
+
+    FOREIGN KEY (companion_id)
+        REFERENCES companions (id)
+        ON UPDATE RESTRICT ON DELETE SET NULL
+) ENGINE=INNODB;
+
+CREATE UNIQUE INDEX seats_table_number_idx ON seats (table_id, number);
+CREATE INDEX seats_guest_idx ON seats (guest_id);
+
+-- Seats 1 to size of the existing tables
+INSERT INTO seats (table_id, number)
+SELECT t.id, n.number FROM tables t
+JOIN (
+    SELECT a.d + b.d * 10 + c.d * 100 + 1 AS number
+    FROM (SELECT 0 AS d UNION ALL SELECT 1 UNION ALL SELECT 2 UNION ALL SELECT 3 UNION ALL SELECT 4
+          UNION ALL SELECT 5 UNION ALL SELECT 6 UNION ALL SELECT 7 UNION ALL SELECT 8 UNION ALL SELECT 9) a
+    CROSS JOIN (SELECT 0 AS d UNION ALL SELECT 1 UNION ALL SELECT 2 UNION ALL SELECT 3 UNION ALL SELECT 4
+          UNION ALL SELECT 5 UNION ALL SELECT 6 UNION ALL SELECT 7 UNION ALL SELECT 8 UNION ALL SELECT 9) b
+    CROSS JOIN (SELECT 0 AS d UNION ALL SELECT 1 UNION ALL SELECT 2 UNION ALL SELECT 3 UNION ALL SELECT 4
+          UNION ALL SELECT 5 UNION ALL SELECT 6 UNION ALL SELECT 7 UNION ALL SELECT 8 UNION ALL SELECT 9) c
+) n ON n.number <= t.size;
+
+-- Parties already seated take the next block of seats at their table in the order they arrived
+UPDATE seats s
+JOIN (
+    SELECT a.table_id, a.guest_id, a.party_size, (
+        SELECT IFNULL(SUM(b.party_size), 0) FROM arrivals b
+        WHERE b.table_id = a.table_id AND b.departed_at IS NULL AND b.id < a.id
+    ) AS first_seat
+    FROM arrivals a
+    WHERE a.departed_at IS NULL
+) seated ON seated.table_id = s.table_id
+    AND s.number > seated.first_seat
+    AND s.number <= seated.first_seat + seated.party_size
+SET s.guest_id = seated.guest_id, s.assigned_at = CURRENT_TIMESTAMP;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS admission_log;
+DROP TABLE IF EXISTS admission_rule_sets;
@@ -0,0 +1,27 @@
+-- Every version of the door rules uploaded by organisers, the highest is the one in force
+CREATE TABLE IF NOT EXISTS admission_rule_sets (
+    version INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    document JSON NOT NULL,
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+) ENGINE=INNODB;
+
+-- The decision of every admission policy and rule on every arrival, denied ones included
+CREATE TABLE IF NOT EXISTS admission_log (
+    id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    guest_id INT NOT NULL,
+    guest_name VARCHAR(255) NOT NULL,
+    table_id INT NOT NULL,
+    party_size INT NOT NULL,
+    policy VARCHAR(255) NOT NULL,
+    rules_version INT NOT NULL DEFAULT 0,
+    allowed BOOLEAN NOT NULL,
+    reason TEXT NOT NULL,
+    decided_by VARCHAR(255) NOT NULL,
+    request_id VARCHAR(64) NOT NULL DEFAULT '',
+    decided_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- No foreign keys, decisions must outlive the guests and tables they were made on
+    INDEX admission_log_guest_name (guest_name),
+    INDEX admission_log_decided_at (decided_at)
+) ENGINE=INNODB;
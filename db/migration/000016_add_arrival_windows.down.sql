@@ -0,0 +1,9 @@
+DROP INDEX guests_expected_until_idx ON guests;
+
+ALTER TABLE tables
+    DROP COLUMN expected_from,
+    DROP COLUMN expected_until;
+
+ALTER TABLE guests
+    DROP COLUMN expected_from,
+    DROP COLUMN expected_until;
@@ -0,0 +1,11 @@
+-- When guests are expected to arrive. A guest's own window takes precedence over their table's,
+-- and a guest who hasn't arrived once the window closes (plus a grace period) is marked no-show.
+ALTER TABLE guests
+    ADD COLUMN expected_from TIMESTAMP NULL,
+    ADD COLUMN expected_until TIMESTAMP NULL;
+
+ALTER TABLE tables
+    ADD COLUMN expected_from TIMESTAMP NULL,
+    ADD COLUMN expected_until TIMESTAMP NULL;
+
+CREATE INDEX guests_expected_until_idx ON guests (expected_until);
@@ -0,0 +1,2 @@
+ALTER TABLE guests
+    DROP COLUMN walk_in;
@@ -0,0 +1,3 @@
+-- Guests let in at the door without having been on the guest list, kept apart for reporting
+ALTER TABLE guests
+    ADD COLUMN walk_in BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,5 @@
+DROP INDEX arrivals_arrived_at_idx ON arrivals;
+
+ALTER TABLE arrivals DROP COLUMN booked_party_size;
+
+DROP TABLE IF EXISTS occupancy_snapshots;
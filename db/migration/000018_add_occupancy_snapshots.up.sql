@@ -0,0 +1,22 @@
+-- The occupancy of a table and of the whole venue every time somebody takes or gives up a seat,
+-- table_id is NULL when it was a standing party coming or going
+CREATE TABLE IF NOT EXISTS occupancy_snapshots (
+    id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    table_id INT NULL,
+    table_occupied INT NOT NULL DEFAULT 0,
+    venue_occupancy INT NOT NULL,
+    recorded_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    INDEX occupancy_snapshots_recorded_at_idx (recorded_at),
+
+    FOREIGN KEY (table_id)
+        REFERENCES tables (id)
+        ON UPDATE RESTRICT ON DELETE CASCADE
+) ENGINE=INNODB;
+
+-- The party the guest was booked with when they arrived, to compare with the party who came
+ALTER TABLE arrivals ADD COLUMN booked_party_size INT NOT NULL DEFAULT 0;
+
+UPDATE arrivals SET booked_party_size = party_size;
+
+CREATE INDEX arrivals_arrived_at_idx ON arrivals (arrived_at);
@@ -8,6 +8,7 @@ import (
 	context "context"
 	sql "database/sql"
 	reflect "reflect"
+	time "time"
 
 	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
 	gomock "github.com/golang/mock/gomock"
@@ -36,286 +37,1602 @@ func (m *MockStore) EXPECT() *MockStoreMockRecorder {
 	return m.recorder
 }
 
+// AddCompanionTx mocks base method.
+func (m *MockStore) AddCompanionTx(arg0 context.Context, arg1 db.AddCompanionTxParams) (db.Companion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCompanionTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Companion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCompanionTx indicates an expected call of AddCompanionTx.
+func (mr *MockStoreMockRecorder) AddCompanionTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCompanionTx", reflect.TypeOf((*MockStore)(nil).AddCompanionTx), arg0, arg1)
+}
+
+// AdmitStandingTx mocks base method.
+func (m *MockStore) AdmitStandingTx(arg0 context.Context, arg1 db.AdmitStandingTxParams) (db.StandingAdmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AdmitStandingTx", arg0, arg1)
+	ret0, _ := ret[0].(db.StandingAdmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AdmitStandingTx indicates an expected call of AdmitStandingTx.
+func (mr *MockStoreMockRecorder) AdmitStandingTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdmitStandingTx", reflect.TypeOf((*MockStore)(nil).AdmitStandingTx), arg0, arg1)
+}
+
+// ArriveByInvitationTx mocks base method.
+func (m *MockStore) ArriveByInvitationTx(arg0 context.Context, arg1 db.ArriveByInvitationTxParams) (db.AssignTableTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArriveByInvitationTx", arg0, arg1)
+	ret0, _ := ret[0].(db.AssignTableTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArriveByInvitationTx indicates an expected call of ArriveByInvitationTx.
+func (mr *MockStoreMockRecorder) ArriveByInvitationTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArriveByInvitationTx", reflect.TypeOf((*MockStore)(nil).ArriveByInvitationTx), arg0, arg1)
+}
+
+// AssignSeat mocks base method.
+func (m *MockStore) AssignSeat(arg0 context.Context, arg1 db.AssignSeatParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AssignSeat", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AssignSeat indicates an expected call of AssignSeat.
+func (mr *MockStoreMockRecorder) AssignSeat(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignSeat", reflect.TypeOf((*MockStore)(nil).AssignSeat), arg0, arg1)
+}
+
 // AssignTableTx mocks base method.
 func (m *MockStore) AssignTableTx(arg0 context.Context, arg1 db.AssignTableTxParams) (db.AssignTableTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AssignTableTx", arg0, arg1)
-	ret0, _ := ret[0].(db.AssignTableTxResult)
+	ret := m.ctrl.Call(m, "AssignTableTx", arg0, arg1)
+	ret0, _ := ret[0].(db.AssignTableTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AssignTableTx indicates an expected call of AssignTableTx.
+func (mr *MockStoreMockRecorder) AssignTableTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTableTx", reflect.TypeOf((*MockStore)(nil).AssignTableTx), arg0, arg1)
+}
+
+// BanPersonTx mocks base method.
+func (m *MockStore) BanPersonTx(arg0 context.Context, arg1 db.BanPersonTxParams) (db.BannedPerson, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BanPersonTx", arg0, arg1)
+	ret0, _ := ret[0].(db.BannedPerson)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BanPersonTx indicates an expected call of BanPersonTx.
+func (mr *MockStoreMockRecorder) BanPersonTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BanPersonTx", reflect.TypeOf((*MockStore)(nil).BanPersonTx), arg0, arg1)
+}
+
+// CountCompanions mocks base method.
+func (m *MockStore) CountCompanions(arg0 context.Context, arg1 int32) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountCompanions", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountCompanions indicates an expected call of CountCompanions.
+func (mr *MockStoreMockRecorder) CountCompanions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountCompanions", reflect.TypeOf((*MockStore)(nil).CountCompanions), arg0, arg1)
+}
+
+// CountGuestsByStatus mocks base method.
+func (m *MockStore) CountGuestsByStatus(arg0 context.Context) ([]db.CountGuestsByStatusRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountGuestsByStatus", arg0)
+	ret0, _ := ret[0].([]db.CountGuestsByStatusRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountGuestsByStatus indicates an expected call of CountGuestsByStatus.
+func (mr *MockStoreMockRecorder) CountGuestsByStatus(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountGuestsByStatus", reflect.TypeOf((*MockStore)(nil).CountGuestsByStatus), arg0)
+}
+
+// CreateAdmissionLogEntry mocks base method.
+func (m *MockStore) CreateAdmissionLogEntry(arg0 context.Context, arg1 db.CreateAdmissionLogEntryParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAdmissionLogEntry", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAdmissionLogEntry indicates an expected call of CreateAdmissionLogEntry.
+func (mr *MockStoreMockRecorder) CreateAdmissionLogEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdmissionLogEntry", reflect.TypeOf((*MockStore)(nil).CreateAdmissionLogEntry), arg0, arg1)
+}
+
+// CreateAdmissionRuleSet mocks base method.
+func (m *MockStore) CreateAdmissionRuleSet(arg0 context.Context, arg1 db.CreateAdmissionRuleSetParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAdmissionRuleSet", arg0, arg1)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAdmissionRuleSet indicates an expected call of CreateAdmissionRuleSet.
+func (mr *MockStoreMockRecorder) CreateAdmissionRuleSet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAdmissionRuleSet", reflect.TypeOf((*MockStore)(nil).CreateAdmissionRuleSet), arg0, arg1)
+}
+
+// CreateArrival mocks base method.
+func (m *MockStore) CreateArrival(arg0 context.Context, arg1 db.CreateArrivalParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateArrival", arg0, arg1)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateArrival indicates an expected call of CreateArrival.
+func (mr *MockStoreMockRecorder) CreateArrival(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArrival", reflect.TypeOf((*MockStore)(nil).CreateArrival), arg0, arg1)
+}
+
+// CreateArrivalCompanion mocks base method.
+func (m *MockStore) CreateArrivalCompanion(arg0 context.Context, arg1 db.CreateArrivalCompanionParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateArrivalCompanion", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateArrivalCompanion indicates an expected call of CreateArrivalCompanion.
+func (mr *MockStoreMockRecorder) CreateArrivalCompanion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArrivalCompanion", reflect.TypeOf((*MockStore)(nil).CreateArrivalCompanion), arg0, arg1)
+}
+
+// CreateAuditEvent mocks base method.
+func (m *MockStore) CreateAuditEvent(arg0 context.Context, arg1 db.CreateAuditEventParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditEvent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAuditEvent indicates an expected call of CreateAuditEvent.
+func (mr *MockStoreMockRecorder) CreateAuditEvent(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditEvent", reflect.TypeOf((*MockStore)(nil).CreateAuditEvent), arg0, arg1)
+}
+
+// CreateBannedPerson mocks base method.
+func (m *MockStore) CreateBannedPerson(arg0 context.Context, arg1 db.CreateBannedPersonParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBannedPerson", arg0, arg1)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBannedPerson indicates an expected call of CreateBannedPerson.
+func (mr *MockStoreMockRecorder) CreateBannedPerson(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBannedPerson", reflect.TypeOf((*MockStore)(nil).CreateBannedPerson), arg0, arg1)
+}
+
+// CreateCompanion mocks base method.
+func (m *MockStore) CreateCompanion(arg0 context.Context, arg1 db.CreateCompanionParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCompanion", arg0, arg1)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCompanion indicates an expected call of CreateCompanion.
+func (mr *MockStoreMockRecorder) CreateCompanion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCompanion", reflect.TypeOf((*MockStore)(nil).CreateCompanion), arg0, arg1)
+}
+
+// CreateGuest mocks base method.
+func (m *MockStore) CreateGuest(arg0 context.Context, arg1 db.CreateGuestParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGuest", arg0, arg1)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGuest indicates an expected call of CreateGuest.
+func (mr *MockStoreMockRecorder) CreateGuest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGuest", reflect.TypeOf((*MockStore)(nil).CreateGuest), arg0, arg1)
+}
+
+// CreateGuestTx mocks base method.
+func (m *MockStore) CreateGuestTx(arg0 context.Context, arg1 db.CreateGuestTxParams) (db.Guest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateGuestTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Guest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateGuestTx indicates an expected call of CreateGuestTx.
+func (mr *MockStoreMockRecorder) CreateGuestTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGuestTx", reflect.TypeOf((*MockStore)(nil).CreateGuestTx), arg0, arg1)
+}
+
+// CreateInvitation mocks base method.
+func (m *MockStore) CreateInvitation(arg0 context.Context, arg1 db.CreateInvitationParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateInvitation", arg0, arg1)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateInvitation indicates an expected call of CreateInvitation.
+func (mr *MockStoreMockRecorder) CreateInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateInvitation", reflect.TypeOf((*MockStore)(nil).CreateInvitation), arg0, arg1)
+}
+
+// CreateOccupancySnapshot mocks base method.
+func (m *MockStore) CreateOccupancySnapshot(arg0 context.Context, arg1 db.CreateOccupancySnapshotParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOccupancySnapshot", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateOccupancySnapshot indicates an expected call of CreateOccupancySnapshot.
+func (mr *MockStoreMockRecorder) CreateOccupancySnapshot(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOccupancySnapshot", reflect.TypeOf((*MockStore)(nil).CreateOccupancySnapshot), arg0, arg1)
+}
+
+// CreatePartyChange mocks base method.
+func (m *MockStore) CreatePartyChange(arg0 context.Context, arg1 db.CreatePartyChangeParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePartyChange", arg0, arg1)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePartyChange indicates an expected call of CreatePartyChange.
+func (mr *MockStoreMockRecorder) CreatePartyChange(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePartyChange", reflect.TypeOf((*MockStore)(nil).CreatePartyChange), arg0, arg1)
+}
+
+// CreateSeat mocks base method.
+func (m *MockStore) CreateSeat(arg0 context.Context, arg1 db.CreateSeatParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSeat", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSeat indicates an expected call of CreateSeat.
+func (mr *MockStoreMockRecorder) CreateSeat(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSeat", reflect.TypeOf((*MockStore)(nil).CreateSeat), arg0, arg1)
+}
+
+// CreateStandingAdmission mocks base method.
+func (m *MockStore) CreateStandingAdmission(arg0 context.Context, arg1 db.CreateStandingAdmissionParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStandingAdmission", arg0, arg1)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStandingAdmission indicates an expected call of CreateStandingAdmission.
+func (mr *MockStoreMockRecorder) CreateStandingAdmission(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStandingAdmission", reflect.TypeOf((*MockStore)(nil).CreateStandingAdmission), arg0, arg1)
+}
+
+// CreateTable mocks base method.
+func (m *MockStore) CreateTable(arg0 context.Context, arg1 db.CreateTableParams) (sql.Result, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTable", arg0, arg1)
+	ret0, _ := ret[0].(sql.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTable indicates an expected call of CreateTable.
+func (mr *MockStoreMockRecorder) CreateTable(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTable", reflect.TypeOf((*MockStore)(nil).CreateTable), arg0, arg1)
+}
+
+// CreateTableTx mocks base method.
+func (m *MockStore) CreateTableTx(arg0 context.Context, arg1 db.CreateTableTxParams) (db.Table, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTableTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Table)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTableTx indicates an expected call of CreateTableTx.
+func (mr *MockStoreMockRecorder) CreateTableTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTableTx", reflect.TypeOf((*MockStore)(nil).CreateTableTx), arg0, arg1)
+}
+
+// DeleteArrivalCompanion mocks base method.
+func (m *MockStore) DeleteArrivalCompanion(arg0 context.Context, arg1 db.DeleteArrivalCompanionParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteArrivalCompanion", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteArrivalCompanion indicates an expected call of DeleteArrivalCompanion.
+func (mr *MockStoreMockRecorder) DeleteArrivalCompanion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteArrivalCompanion", reflect.TypeOf((*MockStore)(nil).DeleteArrivalCompanion), arg0, arg1)
+}
+
+// DeleteBannedPerson mocks base method.
+func (m *MockStore) DeleteBannedPerson(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBannedPerson", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBannedPerson indicates an expected call of DeleteBannedPerson.
+func (mr *MockStoreMockRecorder) DeleteBannedPerson(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBannedPerson", reflect.TypeOf((*MockStore)(nil).DeleteBannedPerson), arg0, arg1)
+}
+
+// DeleteCompanion mocks base method.
+func (m *MockStore) DeleteCompanion(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCompanion", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCompanion indicates an expected call of DeleteCompanion.
+func (mr *MockStoreMockRecorder) DeleteCompanion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCompanion", reflect.TypeOf((*MockStore)(nil).DeleteCompanion), arg0, arg1)
+}
+
+// DeleteGuest mocks base method.
+func (m *MockStore) DeleteGuest(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGuest", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGuest indicates an expected call of DeleteGuest.
+func (mr *MockStoreMockRecorder) DeleteGuest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGuest", reflect.TypeOf((*MockStore)(nil).DeleteGuest), arg0, arg1)
+}
+
+// DeleteGuestTx mocks base method.
+func (m *MockStore) DeleteGuestTx(arg0 context.Context, arg1 db.DeleteGuestTxParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteGuestTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteGuestTx indicates an expected call of DeleteGuestTx.
+func (mr *MockStoreMockRecorder) DeleteGuestTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGuestTx", reflect.TypeOf((*MockStore)(nil).DeleteGuestTx), arg0, arg1)
+}
+
+// DeleteTable mocks base method.
+func (m *MockStore) DeleteTable(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTable", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTable indicates an expected call of DeleteTable.
+func (mr *MockStoreMockRecorder) DeleteTable(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTable", reflect.TypeOf((*MockStore)(nil).DeleteTable), arg0, arg1)
+}
+
+// DepartStandingTx mocks base method.
+func (m *MockStore) DepartStandingTx(arg0 context.Context, arg1 db.DepartStandingTxParams) (db.StandingAdmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DepartStandingTx", arg0, arg1)
+	ret0, _ := ret[0].(db.StandingAdmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DepartStandingTx indicates an expected call of DepartStandingTx.
+func (mr *MockStoreMockRecorder) DepartStandingTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DepartStandingTx", reflect.TypeOf((*MockStore)(nil).DepartStandingTx), arg0, arg1)
+}
+
+// EndArrival mocks base method.
+func (m *MockStore) EndArrival(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndArrival", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EndArrival indicates an expected call of EndArrival.
+func (mr *MockStoreMockRecorder) EndArrival(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndArrival", reflect.TypeOf((*MockStore)(nil).EndArrival), arg0, arg1)
+}
+
+// EndStandingAdmission mocks base method.
+func (m *MockStore) EndStandingAdmission(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EndStandingAdmission", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EndStandingAdmission indicates an expected call of EndStandingAdmission.
+func (mr *MockStoreMockRecorder) EndStandingAdmission(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EndStandingAdmission", reflect.TypeOf((*MockStore)(nil).EndStandingAdmission), arg0, arg1)
+}
+
+// GetActiveInvitationFromGuest mocks base method.
+func (m *MockStore) GetActiveInvitationFromGuest(arg0 context.Context, arg1 int32) (db.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveInvitationFromGuest", arg0, arg1)
+	ret0, _ := ret[0].(db.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveInvitationFromGuest indicates an expected call of GetActiveInvitationFromGuest.
+func (mr *MockStoreMockRecorder) GetActiveInvitationFromGuest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveInvitationFromGuest", reflect.TypeOf((*MockStore)(nil).GetActiveInvitationFromGuest), arg0, arg1)
+}
+
+// GetAdmissionRuleSet mocks base method.
+func (m *MockStore) GetAdmissionRuleSet(arg0 context.Context, arg1 int32) (db.AdmissionRuleSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdmissionRuleSet", arg0, arg1)
+	ret0, _ := ret[0].(db.AdmissionRuleSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdmissionRuleSet indicates an expected call of GetAdmissionRuleSet.
+func (mr *MockStoreMockRecorder) GetAdmissionRuleSet(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdmissionRuleSet", reflect.TypeOf((*MockStore)(nil).GetAdmissionRuleSet), arg0, arg1)
+}
+
+// GetArrival mocks base method.
+func (m *MockStore) GetArrival(arg0 context.Context, arg1 int32) (db.Arrival, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArrival", arg0, arg1)
+	ret0, _ := ret[0].(db.Arrival)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArrival indicates an expected call of GetArrival.
+func (mr *MockStoreMockRecorder) GetArrival(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArrival", reflect.TypeOf((*MockStore)(nil).GetArrival), arg0, arg1)
+}
+
+// GetArrivals mocks base method.
+func (m *MockStore) GetArrivals(arg0 context.Context, arg1 db.GetArrivalsParams) ([]db.Arrival, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArrivals", arg0, arg1)
+	ret0, _ := ret[0].([]db.Arrival)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArrivals indicates an expected call of GetArrivals.
+func (mr *MockStoreMockRecorder) GetArrivals(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArrivals", reflect.TypeOf((*MockStore)(nil).GetArrivals), arg0, arg1)
+}
+
+// GetArrivedGuests mocks base method.
+func (m *MockStore) GetArrivedGuests(arg0 context.Context, arg1 db.GetArrivedGuestsParams) ([]db.Guest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetArrivedGuests", arg0, arg1)
+	ret0, _ := ret[0].([]db.Guest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetArrivedGuests indicates an expected call of GetArrivedGuests.
+func (mr *MockStoreMockRecorder) GetArrivedGuests(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArrivedGuests", reflect.TypeOf((*MockStore)(nil).GetArrivedGuests), arg0, arg1)
+}
+
+// GetBannedPerson mocks base method.
+func (m *MockStore) GetBannedPerson(arg0 context.Context, arg1 int32) (db.BannedPerson, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBannedPerson", arg0, arg1)
+	ret0, _ := ret[0].(db.BannedPerson)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBannedPerson indicates an expected call of GetBannedPerson.
+func (mr *MockStoreMockRecorder) GetBannedPerson(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBannedPerson", reflect.TypeOf((*MockStore)(nil).GetBannedPerson), arg0, arg1)
+}
+
+// GetBannedPersonForUpdate mocks base method.
+func (m *MockStore) GetBannedPersonForUpdate(arg0 context.Context, arg1 int32) (db.BannedPerson, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBannedPersonForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.BannedPerson)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBannedPersonForUpdate indicates an expected call of GetBannedPersonForUpdate.
+func (mr *MockStoreMockRecorder) GetBannedPersonForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBannedPersonForUpdate", reflect.TypeOf((*MockStore)(nil).GetBannedPersonForUpdate), arg0, arg1)
+}
+
+// GetCapacityLimitForUpdate mocks base method.
+func (m *MockStore) GetCapacityLimitForUpdate(arg0 context.Context, arg1 string) (db.CapacityLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCapacityLimitForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.CapacityLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCapacityLimitForUpdate indicates an expected call of GetCapacityLimitForUpdate.
+func (mr *MockStoreMockRecorder) GetCapacityLimitForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCapacityLimitForUpdate", reflect.TypeOf((*MockStore)(nil).GetCapacityLimitForUpdate), arg0, arg1)
+}
+
+// GetCompanion mocks base method.
+func (m *MockStore) GetCompanion(arg0 context.Context, arg1 int32) (db.Companion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCompanion", arg0, arg1)
+	ret0, _ := ret[0].(db.Companion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCompanion indicates an expected call of GetCompanion.
+func (mr *MockStoreMockRecorder) GetCompanion(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCompanion", reflect.TypeOf((*MockStore)(nil).GetCompanion), arg0, arg1)
+}
+
+// GetEmptySeats mocks base method.
+func (m *MockStore) GetEmptySeats(arg0 context.Context) (int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmptySeats", arg0)
+	ret0, _ := ret[0].(int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmptySeats indicates an expected call of GetEmptySeats.
+func (mr *MockStoreMockRecorder) GetEmptySeats(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmptySeats", reflect.TypeOf((*MockStore)(nil).GetEmptySeats), arg0)
+}
+
+// GetEmptySeatsByTableIDs mocks base method.
+func (m *MockStore) GetEmptySeatsByTableIDs(arg0 context.Context, arg1 []int32) ([]db.TableEmptySeats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEmptySeatsByTableIDs", arg0, arg1)
+	ret0, _ := ret[0].([]db.TableEmptySeats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEmptySeatsByTableIDs indicates an expected call of GetEmptySeatsByTableIDs.
+func (mr *MockStoreMockRecorder) GetEmptySeatsByTableIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmptySeatsByTableIDs", reflect.TypeOf((*MockStore)(nil).GetEmptySeatsByTableIDs), arg0, arg1)
+}
+
+// GetGuest mocks base method.
+func (m *MockStore) GetGuest(arg0 context.Context, arg1 int32) (db.Guest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuest", arg0, arg1)
+	ret0, _ := ret[0].(db.Guest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGuest indicates an expected call of GetGuest.
+func (mr *MockStoreMockRecorder) GetGuest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuest", reflect.TypeOf((*MockStore)(nil).GetGuest), arg0, arg1)
+}
+
+// GetGuestForUpdate mocks base method.
+func (m *MockStore) GetGuestForUpdate(arg0 context.Context, arg1 int32) (db.Guest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuestForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Guest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGuestForUpdate indicates an expected call of GetGuestForUpdate.
+func (mr *MockStoreMockRecorder) GetGuestForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuestForUpdate", reflect.TypeOf((*MockStore)(nil).GetGuestForUpdate), arg0, arg1)
+}
+
+// GetGuestFromName mocks base method.
+func (m *MockStore) GetGuestFromName(arg0 context.Context, arg1 string) (db.Guest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuestFromName", arg0, arg1)
+	ret0, _ := ret[0].(db.Guest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGuestFromName indicates an expected call of GetGuestFromName.
+func (mr *MockStoreMockRecorder) GetGuestFromName(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuestFromName", reflect.TypeOf((*MockStore)(nil).GetGuestFromName), arg0, arg1)
+}
+
+// GetGuests mocks base method.
+func (m *MockStore) GetGuests(arg0 context.Context, arg1 db.GetGuestsParams) ([]db.Guest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuests", arg0, arg1)
+	ret0, _ := ret[0].([]db.Guest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGuests indicates an expected call of GetGuests.
+func (mr *MockStoreMockRecorder) GetGuests(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuests", reflect.TypeOf((*MockStore)(nil).GetGuests), arg0, arg1)
+}
+
+// GetGuestsByTableIDs mocks base method.
+func (m *MockStore) GetGuestsByTableIDs(arg0 context.Context, arg1 []int32) ([]db.Guest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGuestsByTableIDs", arg0, arg1)
+	ret0, _ := ret[0].([]db.Guest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGuestsByTableIDs indicates an expected call of GetGuestsByTableIDs.
+func (mr *MockStoreMockRecorder) GetGuestsByTableIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuestsByTableIDs", reflect.TypeOf((*MockStore)(nil).GetGuestsByTableIDs), arg0, arg1)
+}
+
+// GetInvitation mocks base method.
+func (m *MockStore) GetInvitation(arg0 context.Context, arg1 int32) (db.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitation", arg0, arg1)
+	ret0, _ := ret[0].(db.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitation indicates an expected call of GetInvitation.
+func (mr *MockStoreMockRecorder) GetInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitation", reflect.TypeOf((*MockStore)(nil).GetInvitation), arg0, arg1)
+}
+
+// GetInvitationForUpdate mocks base method.
+func (m *MockStore) GetInvitationForUpdate(arg0 context.Context, arg1 int32) (db.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInvitationForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInvitationForUpdate indicates an expected call of GetInvitationForUpdate.
+func (mr *MockStoreMockRecorder) GetInvitationForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInvitationForUpdate", reflect.TypeOf((*MockStore)(nil).GetInvitationForUpdate), arg0, arg1)
+}
+
+// GetLatestAdmissionRuleSet mocks base method.
+func (m *MockStore) GetLatestAdmissionRuleSet(arg0 context.Context) (db.AdmissionRuleSet, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestAdmissionRuleSet", arg0)
+	ret0, _ := ret[0].(db.AdmissionRuleSet)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestAdmissionRuleSet indicates an expected call of GetLatestAdmissionRuleSet.
+func (mr *MockStoreMockRecorder) GetLatestAdmissionRuleSet(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestAdmissionRuleSet", reflect.TypeOf((*MockStore)(nil).GetLatestAdmissionRuleSet), arg0)
+}
+
+// GetOpenArrivalFromGuest mocks base method.
+func (m *MockStore) GetOpenArrivalFromGuest(arg0 context.Context, arg1 int32) (db.Arrival, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenArrivalFromGuest", arg0, arg1)
+	ret0, _ := ret[0].(db.Arrival)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenArrivalFromGuest indicates an expected call of GetOpenArrivalFromGuest.
+func (mr *MockStoreMockRecorder) GetOpenArrivalFromGuest(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenArrivalFromGuest", reflect.TypeOf((*MockStore)(nil).GetOpenArrivalFromGuest), arg0, arg1)
+}
+
+// GetOpenArrivalsByGuestIDs mocks base method.
+func (m *MockStore) GetOpenArrivalsByGuestIDs(arg0 context.Context, arg1 []int32) ([]db.Arrival, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenArrivalsByGuestIDs", arg0, arg1)
+	ret0, _ := ret[0].([]db.Arrival)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenArrivalsByGuestIDs indicates an expected call of GetOpenArrivalsByGuestIDs.
+func (mr *MockStoreMockRecorder) GetOpenArrivalsByGuestIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenArrivalsByGuestIDs", reflect.TypeOf((*MockStore)(nil).GetOpenArrivalsByGuestIDs), arg0, arg1)
+}
+
+// GetReservedSeats mocks base method.
+func (m *MockStore) GetReservedSeats(arg0 context.Context, arg1 db.GetReservedSeatsParams) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReservedSeats", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReservedSeats indicates an expected call of GetReservedSeats.
+func (mr *MockStoreMockRecorder) GetReservedSeats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReservedSeats", reflect.TypeOf((*MockStore)(nil).GetReservedSeats), arg0, arg1)
+}
+
+// GetStandingAdmission mocks base method.
+func (m *MockStore) GetStandingAdmission(arg0 context.Context, arg1 int32) (db.StandingAdmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStandingAdmission", arg0, arg1)
+	ret0, _ := ret[0].(db.StandingAdmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStandingAdmission indicates an expected call of GetStandingAdmission.
+func (mr *MockStoreMockRecorder) GetStandingAdmission(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStandingAdmission", reflect.TypeOf((*MockStore)(nil).GetStandingAdmission), arg0, arg1)
+}
+
+// GetStandingAdmissionForUpdate mocks base method.
+func (m *MockStore) GetStandingAdmissionForUpdate(arg0 context.Context, arg1 int32) (db.StandingAdmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStandingAdmissionForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.StandingAdmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStandingAdmissionForUpdate indicates an expected call of GetStandingAdmissionForUpdate.
+func (mr *MockStoreMockRecorder) GetStandingAdmissionForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStandingAdmissionForUpdate", reflect.TypeOf((*MockStore)(nil).GetStandingAdmissionForUpdate), arg0, arg1)
+}
+
+// GetTable mocks base method.
+func (m *MockStore) GetTable(arg0 context.Context, arg1 int32) (db.Table, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTable", arg0, arg1)
+	ret0, _ := ret[0].(db.Table)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTable indicates an expected call of GetTable.
+func (mr *MockStoreMockRecorder) GetTable(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTable", reflect.TypeOf((*MockStore)(nil).GetTable), arg0, arg1)
+}
+
+// GetTableForUpdate mocks base method.
+func (m *MockStore) GetTableForUpdate(arg0 context.Context, arg1 int32) (db.Table, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTableForUpdate", arg0, arg1)
+	ret0, _ := ret[0].(db.Table)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTableForUpdate indicates an expected call of GetTableForUpdate.
+func (mr *MockStoreMockRecorder) GetTableForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTableForUpdate", reflect.TypeOf((*MockStore)(nil).GetTableForUpdate), arg0, arg1)
+}
+
+// GetTableFromLabel mocks base method.
+func (m *MockStore) GetTableFromLabel(arg0 context.Context, arg1 string) (db.Table, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTableFromLabel", arg0, arg1)
+	ret0, _ := ret[0].(db.Table)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTableFromLabel indicates an expected call of GetTableFromLabel.
+func (mr *MockStoreMockRecorder) GetTableFromLabel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTableFromLabel", reflect.TypeOf((*MockStore)(nil).GetTableFromLabel), arg0, arg1)
+}
+
+// GetTables mocks base method.
+func (m *MockStore) GetTables(arg0 context.Context, arg1 db.GetTablesParams) ([]db.Table, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTables", arg0, arg1)
+	ret0, _ := ret[0].([]db.Table)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTables indicates an expected call of GetTables.
+func (mr *MockStoreMockRecorder) GetTables(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTables", reflect.TypeOf((*MockStore)(nil).GetTables), arg0, arg1)
+}
+
+// GetTablesByIDs mocks base method.
+func (m *MockStore) GetTablesByIDs(arg0 context.Context, arg1 []int32) ([]db.Table, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTablesByIDs", arg0, arg1)
+	ret0, _ := ret[0].([]db.Table)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTablesByIDs indicates an expected call of GetTablesByIDs.
+func (mr *MockStoreMockRecorder) GetTablesByIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTablesByIDs", reflect.TypeOf((*MockStore)(nil).GetTablesByIDs), arg0, arg1)
+}
+
+// GetVenueOccupancy mocks base method.
+func (m *MockStore) GetVenueOccupancy(arg0 context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVenueOccupancy", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVenueOccupancy indicates an expected call of GetVenueOccupancy.
+func (mr *MockStoreMockRecorder) GetVenueOccupancy(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVenueOccupancy", reflect.TypeOf((*MockStore)(nil).GetVenueOccupancy), arg0)
+}
+
+// GetZoneOccupancies mocks base method.
+func (m *MockStore) GetZoneOccupancies(arg0 context.Context) ([]db.GetZoneOccupanciesRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetZoneOccupancies", arg0)
+	ret0, _ := ret[0].([]db.GetZoneOccupanciesRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetZoneOccupancies indicates an expected call of GetZoneOccupancies.
+func (mr *MockStoreMockRecorder) GetZoneOccupancies(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetZoneOccupancies", reflect.TypeOf((*MockStore)(nil).GetZoneOccupancies), arg0)
+}
+
+// GetZoneOccupancy mocks base method.
+func (m *MockStore) GetZoneOccupancy(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetZoneOccupancy", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetZoneOccupancy indicates an expected call of GetZoneOccupancy.
+func (mr *MockStoreMockRecorder) GetZoneOccupancy(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetZoneOccupancy", reflect.TypeOf((*MockStore)(nil).GetZoneOccupancy), arg0, arg1)
+}
+
+// IssueInvitationTx mocks base method.
+func (m *MockStore) IssueInvitationTx(arg0 context.Context, arg1 db.IssueInvitationTxParams) (db.Invitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IssueInvitationTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Invitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IssueInvitationTx indicates an expected call of IssueInvitationTx.
+func (mr *MockStoreMockRecorder) IssueInvitationTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IssueInvitationTx", reflect.TypeOf((*MockStore)(nil).IssueInvitationTx), arg0, arg1)
+}
+
+// LeaveGuestTx mocks base method.
+func (m *MockStore) LeaveGuestTx(arg0 context.Context, arg1 db.LeaveGuestTxParams) (db.Guest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LeaveGuestTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Guest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LeaveGuestTx indicates an expected call of LeaveGuestTx.
+func (mr *MockStoreMockRecorder) LeaveGuestTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LeaveGuestTx", reflect.TypeOf((*MockStore)(nil).LeaveGuestTx), arg0, arg1)
+}
+
+// ListAdmissionLog mocks base method.
+func (m *MockStore) ListAdmissionLog(arg0 context.Context, arg1 db.ListAdmissionLogParams) ([]db.AdmissionLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAdmissionLog", arg0, arg1)
+	ret0, _ := ret[0].([]db.AdmissionLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAdmissionLog indicates an expected call of ListAdmissionLog.
+func (mr *MockStoreMockRecorder) ListAdmissionLog(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAdmissionLog", reflect.TypeOf((*MockStore)(nil).ListAdmissionLog), arg0, arg1)
+}
+
+// ListAllBannedPeople mocks base method.
+func (m *MockStore) ListAllBannedPeople(arg0 context.Context) ([]db.BannedPerson, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAllBannedPeople", arg0)
+	ret0, _ := ret[0].([]db.BannedPerson)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAllBannedPeople indicates an expected call of ListAllBannedPeople.
+func (mr *MockStoreMockRecorder) ListAllBannedPeople(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAllBannedPeople", reflect.TypeOf((*MockStore)(nil).ListAllBannedPeople), arg0)
+}
+
+// ListArrivalCompanions mocks base method.
+func (m *MockStore) ListArrivalCompanions(arg0 context.Context, arg1 int32) ([]db.Companion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListArrivalCompanions", arg0, arg1)
+	ret0, _ := ret[0].([]db.Companion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListArrivalCompanions indicates an expected call of ListArrivalCompanions.
+func (mr *MockStoreMockRecorder) ListArrivalCompanions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListArrivalCompanions", reflect.TypeOf((*MockStore)(nil).ListArrivalCompanions), arg0, arg1)
+}
+
+// ListAuditEvents mocks base method.
+func (m *MockStore) ListAuditEvents(arg0 context.Context, arg1 db.ListAuditEventsParams) ([]db.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListAuditEvents", arg0, arg1)
+	ret0, _ := ret[0].([]db.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListAuditEvents indicates an expected call of ListAuditEvents.
+func (mr *MockStoreMockRecorder) ListAuditEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListAuditEvents", reflect.TypeOf((*MockStore)(nil).ListAuditEvents), arg0, arg1)
+}
+
+// ListBannedPeople mocks base method.
+func (m *MockStore) ListBannedPeople(arg0 context.Context, arg1 db.ListBannedPeopleParams) ([]db.BannedPerson, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBannedPeople", arg0, arg1)
+	ret0, _ := ret[0].([]db.BannedPerson)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBannedPeople indicates an expected call of ListBannedPeople.
+func (mr *MockStoreMockRecorder) ListBannedPeople(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBannedPeople", reflect.TypeOf((*MockStore)(nil).ListBannedPeople), arg0, arg1)
+}
+
+// ListCapacityLimits mocks base method.
+func (m *MockStore) ListCapacityLimits(arg0 context.Context) ([]db.CapacityLimit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCapacityLimits", arg0)
+	ret0, _ := ret[0].([]db.CapacityLimit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCapacityLimits indicates an expected call of ListCapacityLimits.
+func (mr *MockStoreMockRecorder) ListCapacityLimits(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCapacityLimits", reflect.TypeOf((*MockStore)(nil).ListCapacityLimits), arg0)
+}
+
+// ListCompanions mocks base method.
+func (m *MockStore) ListCompanions(arg0 context.Context, arg1 int32) ([]db.Companion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCompanions", arg0, arg1)
+	ret0, _ := ret[0].([]db.Companion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCompanions indicates an expected call of ListCompanions.
+func (mr *MockStoreMockRecorder) ListCompanions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCompanions", reflect.TypeOf((*MockStore)(nil).ListCompanions), arg0, arg1)
+}
+
+// ListEventArrivals mocks base method.
+func (m *MockStore) ListEventArrivals(arg0 context.Context, arg1 db.ListEventArrivalsParams) ([]db.ListEventArrivalsRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventArrivals", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListEventArrivalsRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventArrivals indicates an expected call of ListEventArrivals.
+func (mr *MockStoreMockRecorder) ListEventArrivals(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventArrivals", reflect.TypeOf((*MockStore)(nil).ListEventArrivals), arg0, arg1)
+}
+
+// ListEventAuditEvents mocks base method.
+func (m *MockStore) ListEventAuditEvents(arg0 context.Context, arg1 db.ListEventAuditEventsParams) ([]db.AuditEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventAuditEvents", arg0, arg1)
+	ret0, _ := ret[0].([]db.AuditEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventAuditEvents indicates an expected call of ListEventAuditEvents.
+func (mr *MockStoreMockRecorder) ListEventAuditEvents(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventAuditEvents", reflect.TypeOf((*MockStore)(nil).ListEventAuditEvents), arg0, arg1)
+}
+
+// ListEventDeniedAdmissions mocks base method.
+func (m *MockStore) ListEventDeniedAdmissions(arg0 context.Context, arg1 db.ListEventDeniedAdmissionsParams) ([]db.AdmissionLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventDeniedAdmissions", arg0, arg1)
+	ret0, _ := ret[0].([]db.AdmissionLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventDeniedAdmissions indicates an expected call of ListEventDeniedAdmissions.
+func (mr *MockStoreMockRecorder) ListEventDeniedAdmissions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventDeniedAdmissions", reflect.TypeOf((*MockStore)(nil).ListEventDeniedAdmissions), arg0, arg1)
+}
+
+// ListEventStandingAdmissions mocks base method.
+func (m *MockStore) ListEventStandingAdmissions(arg0 context.Context, arg1 db.ListEventStandingAdmissionsParams) ([]db.StandingAdmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListEventStandingAdmissions", arg0, arg1)
+	ret0, _ := ret[0].([]db.StandingAdmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListEventStandingAdmissions indicates an expected call of ListEventStandingAdmissions.
+func (mr *MockStoreMockRecorder) ListEventStandingAdmissions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEventStandingAdmissions", reflect.TypeOf((*MockStore)(nil).ListEventStandingAdmissions), arg0, arg1)
+}
+
+// ListGuestSeats mocks base method.
+func (m *MockStore) ListGuestSeats(arg0 context.Context, arg1 sql.NullInt32) ([]db.Seat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGuestSeats", arg0, arg1)
+	ret0, _ := ret[0].([]db.Seat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGuestSeats indicates an expected call of ListGuestSeats.
+func (mr *MockStoreMockRecorder) ListGuestSeats(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGuestSeats", reflect.TypeOf((*MockStore)(nil).ListGuestSeats), arg0, arg1)
+}
+
+// ListGuestsWithStatus mocks base method.
+func (m *MockStore) ListGuestsWithStatus(arg0 context.Context, arg1 string) ([]db.Guest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListGuestsWithStatus", arg0, arg1)
+	ret0, _ := ret[0].([]db.Guest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListGuestsWithStatus indicates an expected call of ListGuestsWithStatus.
+func (mr *MockStoreMockRecorder) ListGuestsWithStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListGuestsWithStatus", reflect.TypeOf((*MockStore)(nil).ListGuestsWithStatus), arg0, arg1)
+}
+
+// ListLastOccupancySnapshots mocks base method.
+func (m *MockStore) ListLastOccupancySnapshots(arg0 context.Context, arg1 time.Time) ([]db.OccupancySnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLastOccupancySnapshots", arg0, arg1)
+	ret0, _ := ret[0].([]db.OccupancySnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLastOccupancySnapshots indicates an expected call of ListLastOccupancySnapshots.
+func (mr *MockStoreMockRecorder) ListLastOccupancySnapshots(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLastOccupancySnapshots", reflect.TypeOf((*MockStore)(nil).ListLastOccupancySnapshots), arg0, arg1)
+}
+
+// ListOccupancySnapshots mocks base method.
+func (m *MockStore) ListOccupancySnapshots(arg0 context.Context, arg1 db.ListOccupancySnapshotsParams) ([]db.OccupancySnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOccupancySnapshots", arg0, arg1)
+	ret0, _ := ret[0].([]db.OccupancySnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOccupancySnapshots indicates an expected call of ListOccupancySnapshots.
+func (mr *MockStoreMockRecorder) ListOccupancySnapshots(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOccupancySnapshots", reflect.TypeOf((*MockStore)(nil).ListOccupancySnapshots), arg0, arg1)
+}
+
+// ListOpenStandingAdmissions mocks base method.
+func (m *MockStore) ListOpenStandingAdmissions(arg0 context.Context, arg1 db.ListOpenStandingAdmissionsParams) ([]db.StandingAdmission, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOpenStandingAdmissions", arg0, arg1)
+	ret0, _ := ret[0].([]db.StandingAdmission)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOpenStandingAdmissions indicates an expected call of ListOpenStandingAdmissions.
+func (mr *MockStoreMockRecorder) ListOpenStandingAdmissions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOpenStandingAdmissions", reflect.TypeOf((*MockStore)(nil).ListOpenStandingAdmissions), arg0, arg1)
+}
+
+// ListOverdueGuests mocks base method.
+func (m *MockStore) ListOverdueGuests(arg0 context.Context, arg1 time.Time) ([]int32, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOverdueGuests", arg0, arg1)
+	ret0, _ := ret[0].([]int32)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOverdueGuests indicates an expected call of ListOverdueGuests.
+func (mr *MockStoreMockRecorder) ListOverdueGuests(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOverdueGuests", reflect.TypeOf((*MockStore)(nil).ListOverdueGuests), arg0, arg1)
+}
+
+// ListPartyChanges mocks base method.
+func (m *MockStore) ListPartyChanges(arg0 context.Context, arg1 int32) ([]db.PartyChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPartyChanges", arg0, arg1)
+	ret0, _ := ret[0].([]db.PartyChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPartyChanges indicates an expected call of ListPartyChanges.
+func (mr *MockStoreMockRecorder) ListPartyChanges(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPartyChanges", reflect.TypeOf((*MockStore)(nil).ListPartyChanges), arg0, arg1)
+}
+
+// ListSeatMap mocks base method.
+func (m *MockStore) ListSeatMap(arg0 context.Context, arg1 int32) ([]db.ListSeatMapRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSeatMap", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListSeatMapRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSeatMap indicates an expected call of ListSeatMap.
+func (mr *MockStoreMockRecorder) ListSeatMap(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSeatMap", reflect.TypeOf((*MockStore)(nil).ListSeatMap), arg0, arg1)
+}
+
+// ListSeatsForUpdate mocks base method.
+func (m *MockStore) ListSeatsForUpdate(arg0 context.Context, arg1 int32) ([]db.Seat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSeatsForUpdate", arg0, arg1)
+	ret0, _ := ret[0].([]db.Seat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSeatsForUpdate indicates an expected call of ListSeatsForUpdate.
+func (mr *MockStoreMockRecorder) ListSeatsForUpdate(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSeatsForUpdate", reflect.TypeOf((*MockStore)(nil).ListSeatsForUpdate), arg0, arg1)
+}
+
+// ListTableRoster mocks base method.
+func (m *MockStore) ListTableRoster(arg0 context.Context, arg1 db.ListTableRosterParams) ([]db.ListTableRosterRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTableRoster", arg0, arg1)
+	ret0, _ := ret[0].([]db.ListTableRosterRow)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTableRoster indicates an expected call of ListTableRoster.
+func (mr *MockStoreMockRecorder) ListTableRoster(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTableRoster", reflect.TypeOf((*MockStore)(nil).ListTableRoster), arg0, arg1)
+}
+
+// ListTableSpace mocks base method.
+func (m *MockStore) ListTableSpace(arg0 context.Context) ([]db.ListTableSpaceRow, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTableSpace", arg0)
+	ret0, _ := ret[0].([]db.ListTableSpaceRow)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// AssignTableTx indicates an expected call of AssignTableTx.
-func (mr *MockStoreMockRecorder) AssignTableTx(arg0, arg1 interface{}) *gomock.Call {
+// ListTableSpace indicates an expected call of ListTableSpace.
+func (mr *MockStoreMockRecorder) ListTableSpace(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AssignTableTx", reflect.TypeOf((*MockStore)(nil).AssignTableTx), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTableSpace", reflect.TypeOf((*MockStore)(nil).ListTableSpace), arg0)
 }
 
-// CreateArrival mocks base method.
-func (m *MockStore) CreateArrival(arg0 context.Context, arg1 db.CreateArrivalParams) (sql.Result, error) {
+// ListTables mocks base method.
+func (m *MockStore) ListTables(arg0 context.Context) ([]db.Table, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateArrival", arg0, arg1)
-	ret0, _ := ret[0].(sql.Result)
+	ret := m.ctrl.Call(m, "ListTables", arg0)
+	ret0, _ := ret[0].([]db.Table)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateArrival indicates an expected call of CreateArrival.
-func (mr *MockStoreMockRecorder) CreateArrival(arg0, arg1 interface{}) *gomock.Call {
+// ListTables indicates an expected call of ListTables.
+func (mr *MockStoreMockRecorder) ListTables(arg0 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateArrival", reflect.TypeOf((*MockStore)(nil).CreateArrival), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTables", reflect.TypeOf((*MockStore)(nil).ListTables), arg0)
 }
 
-// CreateGuest mocks base method.
-func (m *MockStore) CreateGuest(arg0 context.Context, arg1 db.CreateGuestParams) (sql.Result, error) {
+// MarkNoShowsTx mocks base method.
+func (m *MockStore) MarkNoShowsTx(arg0 context.Context, arg1 db.MarkNoShowsTxParams) ([]db.Guest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateGuest", arg0, arg1)
-	ret0, _ := ret[0].(sql.Result)
+	ret := m.ctrl.Call(m, "MarkNoShowsTx", arg0, arg1)
+	ret0, _ := ret[0].([]db.Guest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateGuest indicates an expected call of CreateGuest.
-func (mr *MockStoreMockRecorder) CreateGuest(arg0, arg1 interface{}) *gomock.Call {
+// MarkNoShowsTx indicates an expected call of MarkNoShowsTx.
+func (mr *MockStoreMockRecorder) MarkNoShowsTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateGuest", reflect.TypeOf((*MockStore)(nil).CreateGuest), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkNoShowsTx", reflect.TypeOf((*MockStore)(nil).MarkNoShowsTx), arg0, arg1)
 }
 
-// CreateTable mocks base method.
-func (m *MockStore) CreateTable(arg0 context.Context, arg1 db.CreateTableParams) (sql.Result, error) {
+// MoveGuestTx mocks base method.
+func (m *MockStore) MoveGuestTx(arg0 context.Context, arg1 db.MoveGuestTxParams) (db.MoveGuestTxResult, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTable", arg0, arg1)
-	ret0, _ := ret[0].(sql.Result)
+	ret := m.ctrl.Call(m, "MoveGuestTx", arg0, arg1)
+	ret0, _ := ret[0].(db.MoveGuestTxResult)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateTable indicates an expected call of CreateTable.
-func (mr *MockStoreMockRecorder) CreateTable(arg0, arg1 interface{}) *gomock.Call {
+// MoveGuestTx indicates an expected call of MoveGuestTx.
+func (mr *MockStoreMockRecorder) MoveGuestTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTable", reflect.TypeOf((*MockStore)(nil).CreateTable), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveGuestTx", reflect.TypeOf((*MockStore)(nil).MoveGuestTx), arg0, arg1)
 }
 
-// DeleteGuest mocks base method.
-func (m *MockStore) DeleteGuest(arg0 context.Context, arg1 int32) error {
+// RSVPTx mocks base method.
+func (m *MockStore) RSVPTx(arg0 context.Context, arg1 db.RSVPTxParams) (db.Guest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteGuest", arg0, arg1)
+	ret := m.ctrl.Call(m, "RSVPTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Guest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RSVPTx indicates an expected call of RSVPTx.
+func (mr *MockStoreMockRecorder) RSVPTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RSVPTx", reflect.TypeOf((*MockStore)(nil).RSVPTx), arg0, arg1)
+}
+
+// ReleaseGuestSeats mocks base method.
+func (m *MockStore) ReleaseGuestSeats(arg0 context.Context, arg1 sql.NullInt32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseGuestSeats", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteGuest indicates an expected call of DeleteGuest.
-func (mr *MockStoreMockRecorder) DeleteGuest(arg0, arg1 interface{}) *gomock.Call {
+// ReleaseGuestSeats indicates an expected call of ReleaseGuestSeats.
+func (mr *MockStoreMockRecorder) ReleaseGuestSeats(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGuest", reflect.TypeOf((*MockStore)(nil).DeleteGuest), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseGuestSeats", reflect.TypeOf((*MockStore)(nil).ReleaseGuestSeats), arg0, arg1)
 }
 
-// DeleteGuestTx mocks base method.
-func (m *MockStore) DeleteGuestTx(arg0 context.Context, arg1 int32) error {
+// ReleaseSeat mocks base method.
+func (m *MockStore) ReleaseSeat(arg0 context.Context, arg1 int32) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteGuestTx", arg0, arg1)
+	ret := m.ctrl.Call(m, "ReleaseSeat", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteGuestTx indicates an expected call of DeleteGuestTx.
-func (mr *MockStoreMockRecorder) DeleteGuestTx(arg0, arg1 interface{}) *gomock.Call {
+// ReleaseSeat indicates an expected call of ReleaseSeat.
+func (mr *MockStoreMockRecorder) ReleaseSeat(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteGuestTx", reflect.TypeOf((*MockStore)(nil).DeleteGuestTx), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseSeat", reflect.TypeOf((*MockStore)(nil).ReleaseSeat), arg0, arg1)
 }
 
-// DeleteTable mocks base method.
-func (m *MockStore) DeleteTable(arg0 context.Context, arg1 int32) error {
+// RemoveCompanionTx mocks base method.
+func (m *MockStore) RemoveCompanionTx(arg0 context.Context, arg1 db.RemoveCompanionTxParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTable", arg0, arg1)
+	ret := m.ctrl.Call(m, "RemoveCompanionTx", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// DeleteTable indicates an expected call of DeleteTable.
-func (mr *MockStoreMockRecorder) DeleteTable(arg0, arg1 interface{}) *gomock.Call {
+// RemoveCompanionTx indicates an expected call of RemoveCompanionTx.
+func (mr *MockStoreMockRecorder) RemoveCompanionTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTable", reflect.TypeOf((*MockStore)(nil).DeleteTable), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveCompanionTx", reflect.TypeOf((*MockStore)(nil).RemoveCompanionTx), arg0, arg1)
 }
 
-// GetArrival mocks base method.
-func (m *MockStore) GetArrival(arg0 context.Context, arg1 int32) (db.Arrival, error) {
+// RevokeGuestInvitations mocks base method.
+func (m *MockStore) RevokeGuestInvitations(arg0 context.Context, arg1 int32) (int64, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetArrival", arg0, arg1)
-	ret0, _ := ret[0].(db.Arrival)
+	ret := m.ctrl.Call(m, "RevokeGuestInvitations", arg0, arg1)
+	ret0, _ := ret[0].(int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetArrival indicates an expected call of GetArrival.
-func (mr *MockStoreMockRecorder) GetArrival(arg0, arg1 interface{}) *gomock.Call {
+// RevokeGuestInvitations indicates an expected call of RevokeGuestInvitations.
+func (mr *MockStoreMockRecorder) RevokeGuestInvitations(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArrival", reflect.TypeOf((*MockStore)(nil).GetArrival), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeGuestInvitations", reflect.TypeOf((*MockStore)(nil).RevokeGuestInvitations), arg0, arg1)
 }
 
-// GetArrivalFromGuest mocks base method.
-func (m *MockStore) GetArrivalFromGuest(arg0 context.Context, arg1 int32) (db.Arrival, error) {
+// RevokeInvitationTx mocks base method.
+func (m *MockStore) RevokeInvitationTx(arg0 context.Context, arg1 db.RevokeInvitationTxParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetArrivalFromGuest", arg0, arg1)
-	ret0, _ := ret[0].(db.Arrival)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RevokeInvitationTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetArrivalFromGuest indicates an expected call of GetArrivalFromGuest.
-func (mr *MockStoreMockRecorder) GetArrivalFromGuest(arg0, arg1 interface{}) *gomock.Call {
+// RevokeInvitationTx indicates an expected call of RevokeInvitationTx.
+func (mr *MockStoreMockRecorder) RevokeInvitationTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArrivalFromGuest", reflect.TypeOf((*MockStore)(nil).GetArrivalFromGuest), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeInvitationTx", reflect.TypeOf((*MockStore)(nil).RevokeInvitationTx), arg0, arg1)
 }
 
-// GetArrivals mocks base method.
-func (m *MockStore) GetArrivals(arg0 context.Context, arg1 db.GetArrivalsParams) ([]db.Arrival, error) {
+// SetAdmissionRulesTx mocks base method.
+func (m *MockStore) SetAdmissionRulesTx(arg0 context.Context, arg1 db.SetAdmissionRulesTxParams) (db.AdmissionRuleSet, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetArrivals", arg0, arg1)
-	ret0, _ := ret[0].([]db.Arrival)
+	ret := m.ctrl.Call(m, "SetAdmissionRulesTx", arg0, arg1)
+	ret0, _ := ret[0].(db.AdmissionRuleSet)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetArrivals indicates an expected call of GetArrivals.
-func (mr *MockStoreMockRecorder) GetArrivals(arg0, arg1 interface{}) *gomock.Call {
+// SetAdmissionRulesTx indicates an expected call of SetAdmissionRulesTx.
+func (mr *MockStoreMockRecorder) SetAdmissionRulesTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArrivals", reflect.TypeOf((*MockStore)(nil).GetArrivals), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetAdmissionRulesTx", reflect.TypeOf((*MockStore)(nil).SetAdmissionRulesTx), arg0, arg1)
 }
 
-// GetArrivedGuests mocks base method.
-func (m *MockStore) GetArrivedGuests(arg0 context.Context, arg1 db.GetArrivedGuestsParams) ([]db.Guest, error) {
+// SetCapacityLimitTx mocks base method.
+func (m *MockStore) SetCapacityLimitTx(arg0 context.Context, arg1 db.SetCapacityLimitTxParams) (db.CapacityLimit, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetArrivedGuests", arg0, arg1)
-	ret0, _ := ret[0].([]db.Guest)
+	ret := m.ctrl.Call(m, "SetCapacityLimitTx", arg0, arg1)
+	ret0, _ := ret[0].(db.CapacityLimit)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetArrivedGuests indicates an expected call of GetArrivedGuests.
-func (mr *MockStoreMockRecorder) GetArrivedGuests(arg0, arg1 interface{}) *gomock.Call {
+// SetCapacityLimitTx indicates an expected call of SetCapacityLimitTx.
+func (mr *MockStoreMockRecorder) SetCapacityLimitTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetArrivedGuests", reflect.TypeOf((*MockStore)(nil).GetArrivedGuests), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCapacityLimitTx", reflect.TypeOf((*MockStore)(nil).SetCapacityLimitTx), arg0, arg1)
 }
 
-// GetEmptySeats mocks base method.
-func (m *MockStore) GetEmptySeats(arg0 context.Context) (int32, error) {
+// SetGuestArrivalWindowTx mocks base method.
+func (m *MockStore) SetGuestArrivalWindowTx(arg0 context.Context, arg1 db.SetGuestArrivalWindowTxParams) (db.Guest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEmptySeats", arg0)
-	ret0, _ := ret[0].(int32)
+	ret := m.ctrl.Call(m, "SetGuestArrivalWindowTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Guest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetEmptySeats indicates an expected call of GetEmptySeats.
-func (mr *MockStoreMockRecorder) GetEmptySeats(arg0 interface{}) *gomock.Call {
+// SetGuestArrivalWindowTx indicates an expected call of SetGuestArrivalWindowTx.
+func (mr *MockStoreMockRecorder) SetGuestArrivalWindowTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEmptySeats", reflect.TypeOf((*MockStore)(nil).GetEmptySeats), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetGuestArrivalWindowTx", reflect.TypeOf((*MockStore)(nil).SetGuestArrivalWindowTx), arg0, arg1)
 }
 
-// GetGuest mocks base method.
-func (m *MockStore) GetGuest(arg0 context.Context, arg1 int32) (db.Guest, error) {
+// SetTableArrivalWindowTx mocks base method.
+func (m *MockStore) SetTableArrivalWindowTx(arg0 context.Context, arg1 db.SetTableArrivalWindowTxParams) (db.Table, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGuest", arg0, arg1)
-	ret0, _ := ret[0].(db.Guest)
+	ret := m.ctrl.Call(m, "SetTableArrivalWindowTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Table)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetGuest indicates an expected call of GetGuest.
-func (mr *MockStoreMockRecorder) GetGuest(arg0, arg1 interface{}) *gomock.Call {
+// SetTableArrivalWindowTx indicates an expected call of SetTableArrivalWindowTx.
+func (mr *MockStoreMockRecorder) SetTableArrivalWindowTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuest", reflect.TypeOf((*MockStore)(nil).GetGuest), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTableArrivalWindowTx", reflect.TypeOf((*MockStore)(nil).SetTableArrivalWindowTx), arg0, arg1)
 }
 
-// GetGuestForUpdate mocks base method.
-func (m *MockStore) GetGuestForUpdate(arg0 context.Context, arg1 int32) (db.Guest, error) {
+// TransitionGuestTx mocks base method.
+func (m *MockStore) TransitionGuestTx(arg0 context.Context, arg1 db.TransitionGuestTxParams) (db.Guest, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGuestForUpdate", arg0, arg1)
+	ret := m.ctrl.Call(m, "TransitionGuestTx", arg0, arg1)
 	ret0, _ := ret[0].(db.Guest)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetGuestForUpdate indicates an expected call of GetGuestForUpdate.
-func (mr *MockStoreMockRecorder) GetGuestForUpdate(arg0, arg1 interface{}) *gomock.Call {
+// TransitionGuestTx indicates an expected call of TransitionGuestTx.
+func (mr *MockStoreMockRecorder) TransitionGuestTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuestForUpdate", reflect.TypeOf((*MockStore)(nil).GetGuestForUpdate), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TransitionGuestTx", reflect.TypeOf((*MockStore)(nil).TransitionGuestTx), arg0, arg1)
 }
 
-// GetGuestFromName mocks base method.
-func (m *MockStore) GetGuestFromName(arg0 context.Context, arg1 string) (db.Guest, error) {
+// UnbanPersonTx mocks base method.
+func (m *MockStore) UnbanPersonTx(arg0 context.Context, arg1 db.UnbanPersonTxParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGuestFromName", arg0, arg1)
-	ret0, _ := ret[0].(db.Guest)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UnbanPersonTx", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetGuestFromName indicates an expected call of GetGuestFromName.
-func (mr *MockStoreMockRecorder) GetGuestFromName(arg0, arg1 interface{}) *gomock.Call {
+// UnbanPersonTx indicates an expected call of UnbanPersonTx.
+func (mr *MockStoreMockRecorder) UnbanPersonTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuestFromName", reflect.TypeOf((*MockStore)(nil).GetGuestFromName), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnbanPersonTx", reflect.TypeOf((*MockStore)(nil).UnbanPersonTx), arg0, arg1)
 }
 
-// GetGuests mocks base method.
-func (m *MockStore) GetGuests(arg0 context.Context, arg1 db.GetGuestsParams) ([]db.Guest, error) {
+// UpdateArrivalPartySize mocks base method.
+func (m *MockStore) UpdateArrivalPartySize(arg0 context.Context, arg1 db.UpdateArrivalPartySizeParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetGuests", arg0, arg1)
-	ret0, _ := ret[0].([]db.Guest)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateArrivalPartySize", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetGuests indicates an expected call of GetGuests.
-func (mr *MockStoreMockRecorder) GetGuests(arg0, arg1 interface{}) *gomock.Call {
+// UpdateArrivalPartySize indicates an expected call of UpdateArrivalPartySize.
+func (mr *MockStoreMockRecorder) UpdateArrivalPartySize(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGuests", reflect.TypeOf((*MockStore)(nil).GetGuests), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArrivalPartySize", reflect.TypeOf((*MockStore)(nil).UpdateArrivalPartySize), arg0, arg1)
 }
 
-// GetTable mocks base method.
-func (m *MockStore) GetTable(arg0 context.Context, arg1 int32) (db.Table, error) {
+// UpdateArrivalTable mocks base method.
+func (m *MockStore) UpdateArrivalTable(arg0 context.Context, arg1 db.UpdateArrivalTableParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTable", arg0, arg1)
-	ret0, _ := ret[0].(db.Table)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateArrivalTable", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetTable indicates an expected call of GetTable.
-func (mr *MockStoreMockRecorder) GetTable(arg0, arg1 interface{}) *gomock.Call {
+// UpdateArrivalTable indicates an expected call of UpdateArrivalTable.
+func (mr *MockStoreMockRecorder) UpdateArrivalTable(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTable", reflect.TypeOf((*MockStore)(nil).GetTable), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateArrivalTable", reflect.TypeOf((*MockStore)(nil).UpdateArrivalTable), arg0, arg1)
 }
 
-// GetTableForUpdate mocks base method.
-func (m *MockStore) GetTableForUpdate(arg0 context.Context, arg1 int32) (db.Table, error) {
+// UpdateCompanion mocks base method.
+func (m *MockStore) UpdateCompanion(arg0 context.Context, arg1 db.UpdateCompanionParams) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTableForUpdate", arg0, arg1)
-	ret0, _ := ret[0].(db.Table)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateCompanion", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetTableForUpdate indicates an expected call of GetTableForUpdate.
-func (mr *MockStoreMockRecorder) GetTableForUpdate(arg0, arg1 interface{}) *gomock.Call {
+// UpdateCompanion indicates an expected call of UpdateCompanion.
+func (mr *MockStoreMockRecorder) UpdateCompanion(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTableForUpdate", reflect.TypeOf((*MockStore)(nil).GetTableForUpdate), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCompanion", reflect.TypeOf((*MockStore)(nil).UpdateCompanion), arg0, arg1)
 }
 
-// GetTables mocks base method.
-func (m *MockStore) GetTables(arg0 context.Context, arg1 db.GetTablesParams) ([]db.Table, error) {
+// UpdateCompanionTx mocks base method.
+func (m *MockStore) UpdateCompanionTx(arg0 context.Context, arg1 db.UpdateCompanionTxParams) (db.Companion, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTables", arg0, arg1)
-	ret0, _ := ret[0].([]db.Table)
+	ret := m.ctrl.Call(m, "UpdateCompanionTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Companion)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTables indicates an expected call of GetTables.
-func (mr *MockStoreMockRecorder) GetTables(arg0, arg1 interface{}) *gomock.Call {
+// UpdateCompanionTx indicates an expected call of UpdateCompanionTx.
+func (mr *MockStoreMockRecorder) UpdateCompanionTx(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTables", reflect.TypeOf((*MockStore)(nil).GetTables), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCompanionTx", reflect.TypeOf((*MockStore)(nil).UpdateCompanionTx), arg0, arg1)
 }
 
 // UpdateGuestArrival mocks base method.
@@ -332,6 +1649,106 @@ func (mr *MockStoreMockRecorder) UpdateGuestArrival(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGuestArrival", reflect.TypeOf((*MockStore)(nil).UpdateGuestArrival), arg0, arg1)
 }
 
+// UpdateGuestArrivalWindow mocks base method.
+func (m *MockStore) UpdateGuestArrivalWindow(arg0 context.Context, arg1 db.UpdateGuestArrivalWindowParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGuestArrivalWindow", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGuestArrivalWindow indicates an expected call of UpdateGuestArrivalWindow.
+func (mr *MockStoreMockRecorder) UpdateGuestArrivalWindow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGuestArrivalWindow", reflect.TypeOf((*MockStore)(nil).UpdateGuestArrivalWindow), arg0, arg1)
+}
+
+// UpdateGuestProfile mocks base method.
+func (m *MockStore) UpdateGuestProfile(arg0 context.Context, arg1 db.UpdateGuestProfileParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGuestProfile", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGuestProfile indicates an expected call of UpdateGuestProfile.
+func (mr *MockStoreMockRecorder) UpdateGuestProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGuestProfile", reflect.TypeOf((*MockStore)(nil).UpdateGuestProfile), arg0, arg1)
+}
+
+// UpdateGuestProfileTx mocks base method.
+func (m *MockStore) UpdateGuestProfileTx(arg0 context.Context, arg1 db.UpdateGuestProfileTxParams) (db.Guest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGuestProfileTx", arg0, arg1)
+	ret0, _ := ret[0].(db.Guest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateGuestProfileTx indicates an expected call of UpdateGuestProfileTx.
+func (mr *MockStoreMockRecorder) UpdateGuestProfileTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGuestProfileTx", reflect.TypeOf((*MockStore)(nil).UpdateGuestProfileTx), arg0, arg1)
+}
+
+// UpdateGuestRSVP mocks base method.
+func (m *MockStore) UpdateGuestRSVP(arg0 context.Context, arg1 db.UpdateGuestRSVPParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGuestRSVP", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGuestRSVP indicates an expected call of UpdateGuestRSVP.
+func (mr *MockStoreMockRecorder) UpdateGuestRSVP(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGuestRSVP", reflect.TypeOf((*MockStore)(nil).UpdateGuestRSVP), arg0, arg1)
+}
+
+// UpdateGuestStatus mocks base method.
+func (m *MockStore) UpdateGuestStatus(arg0 context.Context, arg1 db.UpdateGuestStatusParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGuestStatus", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGuestStatus indicates an expected call of UpdateGuestStatus.
+func (mr *MockStoreMockRecorder) UpdateGuestStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGuestStatus", reflect.TypeOf((*MockStore)(nil).UpdateGuestStatus), arg0, arg1)
+}
+
+// UpdateGuestTable mocks base method.
+func (m *MockStore) UpdateGuestTable(arg0 context.Context, arg1 db.UpdateGuestTableParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGuestTable", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGuestTable indicates an expected call of UpdateGuestTable.
+func (mr *MockStoreMockRecorder) UpdateGuestTable(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGuestTable", reflect.TypeOf((*MockStore)(nil).UpdateGuestTable), arg0, arg1)
+}
+
+// UpdatePartyTx mocks base method.
+func (m *MockStore) UpdatePartyTx(arg0 context.Context, arg1 db.UpdatePartyTxParams) (db.UpdatePartyTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePartyTx", arg0, arg1)
+	ret0, _ := ret[0].(db.UpdatePartyTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePartyTx indicates an expected call of UpdatePartyTx.
+func (mr *MockStoreMockRecorder) UpdatePartyTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePartyTx", reflect.TypeOf((*MockStore)(nil).UpdatePartyTx), arg0, arg1)
+}
+
 // UpdateTable mocks base method.
 func (m *MockStore) UpdateTable(arg0 context.Context, arg1 db.UpdateTableParams) error {
 	m.ctrl.T.Helper()
@@ -345,3 +1762,74 @@ func (mr *MockStoreMockRecorder) UpdateTable(arg0, arg1 interface{}) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTable", reflect.TypeOf((*MockStore)(nil).UpdateTable), arg0, arg1)
 }
+
+// UpdateTableArrivalWindow mocks base method.
+func (m *MockStore) UpdateTableArrivalWindow(arg0 context.Context, arg1 db.UpdateTableArrivalWindowParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTableArrivalWindow", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTableArrivalWindow indicates an expected call of UpdateTableArrivalWindow.
+func (mr *MockStoreMockRecorder) UpdateTableArrivalWindow(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTableArrivalWindow", reflect.TypeOf((*MockStore)(nil).UpdateTableArrivalWindow), arg0, arg1)
+}
+
+// UpdateTableLabel mocks base method.
+func (m *MockStore) UpdateTableLabel(arg0 context.Context, arg1 db.UpdateTableLabelParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTableLabel", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTableLabel indicates an expected call of UpdateTableLabel.
+func (mr *MockStoreMockRecorder) UpdateTableLabel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTableLabel", reflect.TypeOf((*MockStore)(nil).UpdateTableLabel), arg0, arg1)
+}
+
+// UpsertCapacityLimit mocks base method.
+func (m *MockStore) UpsertCapacityLimit(arg0 context.Context, arg1 db.UpsertCapacityLimitParams) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertCapacityLimit", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertCapacityLimit indicates an expected call of UpsertCapacityLimit.
+func (mr *MockStoreMockRecorder) UpsertCapacityLimit(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertCapacityLimit", reflect.TypeOf((*MockStore)(nil).UpsertCapacityLimit), arg0, arg1)
+}
+
+// UseInvitation mocks base method.
+func (m *MockStore) UseInvitation(arg0 context.Context, arg1 int32) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseInvitation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseInvitation indicates an expected call of UseInvitation.
+func (mr *MockStoreMockRecorder) UseInvitation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseInvitation", reflect.TypeOf((*MockStore)(nil).UseInvitation), arg0, arg1)
+}
+
+// WalkInTx mocks base method.
+func (m *MockStore) WalkInTx(arg0 context.Context, arg1 db.WalkInTxParams) (db.AssignTableTxResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WalkInTx", arg0, arg1)
+	ret0, _ := ret[0].(db.AssignTableTxResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WalkInTx indicates an expected call of WalkInTx.
+func (mr *MockStoreMockRecorder) WalkInTx(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WalkInTx", reflect.TypeOf((*MockStore)(nil).WalkInTx), arg0, arg1)
+}
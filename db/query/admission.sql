@@ -0,0 +1,40 @@
+-- name: CreateAdmissionLogEntry :exec
+INSERT INTO admission_log (
+    guest_id,
+    guest_name,
+    table_id,
+    party_size,
+    policy,
+    rules_version,
+    allowed,
+    reason,
+    decided_by,
+    request_id
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: CreateAdmissionRuleSet :execresult
+INSERT INTO admission_rule_sets (
+    document,
+    created_by
+) VALUES (
+    ?, ?
+);
+
+-- name: GetAdmissionRuleSet :one
+SELECT * FROM admission_rule_sets
+WHERE version = ? LIMIT 1;
+
+-- name: GetLatestAdmissionRuleSet :one
+SELECT * FROM admission_rule_sets
+ORDER BY version DESC
+LIMIT 1;
+
+-- name: ListAdmissionLog :many
+SELECT * FROM admission_log
+WHERE (sqlc.narg('guest_name') IS NULL OR guest_name = sqlc.narg('guest_name'))
+AND (sqlc.narg('allowed') IS NULL OR allowed = sqlc.narg('allowed'))
+ORDER BY id
+LIMIT ?
+OFFSET ?;
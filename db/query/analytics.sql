@@ -0,0 +1,34 @@
+-- name: CreateOccupancySnapshot :exec
+INSERT INTO occupancy_snapshots (
+    table_id,
+    table_occupied,
+    venue_occupancy
+) VALUES (
+    ?, ?, ?
+);
+
+-- name: ListOccupancySnapshots :many
+SELECT * FROM occupancy_snapshots
+WHERE recorded_at >= sqlc.arg('from') AND recorded_at < sqlc.arg('until')
+ORDER BY id;
+
+-- name: ListLastOccupancySnapshots :many
+SELECT * FROM occupancy_snapshots
+WHERE id IN (
+    SELECT MAX(id) FROM occupancy_snapshots
+    WHERE recorded_at < sqlc.arg('before')
+    GROUP BY table_id
+)
+ORDER BY id;
+
+-- name: ListEventArrivals :many
+SELECT a.*, g.guest_name, g.walk_in FROM arrivals a
+JOIN guests g ON g.id = a.guest_id
+WHERE a.arrived_at >= sqlc.arg('from') AND a.arrived_at < sqlc.arg('until')
+ORDER BY a.id;
+
+-- name: CountGuestsByStatus :many
+SELECT status, COUNT(*) AS guests FROM guests
+WHERE walk_in = FALSE
+GROUP BY status
+ORDER BY status;
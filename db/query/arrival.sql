@@ -2,18 +2,27 @@
 INSERT INTO arrivals (
     guest_id,
     table_id,
-    party_size
+    party_size,
+    booked_party_size,
+    arrived_by
 ) VALUES (
-    ?, ?, ?
+    ?, ?, ?, ?, ?
 );
 
+-- name: EndArrival :exec
+UPDATE arrivals
+SET departed_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
 -- name: GetArrival :one
 SELECT * from arrivals
 WHERE id =? LIMIT 1;
 
--- name: GetArrivalFromGuest :one
-SELECT * from arrivals
-WHERE guest_id =?;
+-- name: GetOpenArrivalFromGuest :one
+SELECT * FROM arrivals
+WHERE guest_id = ? AND departed_at IS NULL
+ORDER BY id DESC
+LIMIT 1;
 
 -- name: GetArrivals :many
 SELECT * FROM arrivals
@@ -22,4 +31,14 @@ WHERE
     table_id = ?
 ORDER BY id
 LIMIT ?
-OFFSET ?;
\ No newline at end of file
+OFFSET ?;
+
+-- name: UpdateArrivalPartySize :exec
+UPDATE arrivals
+SET party_size = ?
+WHERE id = ?;
+
+-- name: UpdateArrivalTable :exec
+UPDATE arrivals
+SET table_id = ?
+WHERE id = ?;
@@ -0,0 +1,24 @@
+-- name: CreateAuditEvent :exec
+INSERT INTO audit_events (
+    action,
+    actor,
+    request_id,
+    guest_id,
+    guest_name,
+    table_id,
+    `before`,
+    `after`
+) VALUES (
+    ?, ?, ?, ?, ?, ?, ?, ?
+);
+
+-- name: ListAuditEvents :many
+SELECT * FROM audit_events
+WHERE (sqlc.narg('guest_name') IS NULL OR guest_name = sqlc.narg('guest_name'))
+AND (sqlc.narg('table_id') IS NULL OR table_id = sqlc.narg('table_id'))
+AND (sqlc.narg('actor') IS NULL OR actor = sqlc.narg('actor'))
+AND (sqlc.narg('from') IS NULL OR created_at >= sqlc.narg('from'))
+AND (sqlc.narg('to') IS NULL OR created_at < sqlc.narg('to'))
+ORDER BY id
+LIMIT ?
+OFFSET ?;
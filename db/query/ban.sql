@@ -0,0 +1,33 @@
+-- name: CreateBannedPerson :execresult
+INSERT INTO banned_people (
+    name,
+    email,
+    phone,
+    reason,
+    created_by
+) VALUES (
+    ?, ?, ?, ?, ?
+);
+
+-- name: DeleteBannedPerson :exec
+DELETE FROM banned_people
+WHERE id = ?;
+
+-- name: GetBannedPerson :one
+SELECT * FROM banned_people
+WHERE id = ? LIMIT 1;
+
+-- name: GetBannedPersonForUpdate :one
+SELECT * FROM banned_people
+WHERE id = ? LIMIT 1
+FOR UPDATE;
+
+-- name: ListAllBannedPeople :many
+SELECT * FROM banned_people
+ORDER BY id;
+
+-- name: ListBannedPeople :many
+SELECT * FROM banned_people
+ORDER BY id
+LIMIT ?
+OFFSET ?;
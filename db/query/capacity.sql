@@ -0,0 +1,35 @@
+-- name: GetCapacityLimitForUpdate :one
+SELECT * FROM capacity_limits
+WHERE zone = ? LIMIT 1
+FOR UPDATE;
+
+-- name: GetVenueOccupancy :one
+SELECT CAST(
+    (SELECT IFNULL(SUM(occupied), 0) FROM tables) +
+    (SELECT IFNULL(SUM(party_size), 0) FROM standing_admissions WHERE departed_at IS NULL)
+AS SIGNED) AS occupancy;
+
+-- name: GetZoneOccupancies :many
+SELECT zone, CAST(IFNULL(SUM(occupied), 0) AS SIGNED) AS occupancy FROM tables
+WHERE zone <> ''
+GROUP BY zone
+ORDER BY zone;
+
+-- name: GetZoneOccupancy :one
+SELECT CAST(IFNULL(SUM(occupied), 0) AS SIGNED) AS occupancy FROM tables
+WHERE zone = ?;
+
+-- name: ListCapacityLimits :many
+SELECT * FROM capacity_limits
+ORDER BY zone;
+
+-- name: UpsertCapacityLimit :exec
+INSERT INTO capacity_limits (
+    zone,
+    max_occupancy,
+    updated_by
+) VALUES (
+    ?, ?, ?
+) ON DUPLICATE KEY UPDATE
+    max_occupancy = VALUES(max_occupancy),
+    updated_by = VALUES(updated_by);
@@ -0,0 +1,48 @@
+-- name: CreateCompanion :execresult
+INSERT INTO companions (
+    guest_id,
+    name,
+    attributes
+) VALUES (
+    ?, ?, ?
+);
+
+-- name: GetCompanion :one
+SELECT * FROM companions
+WHERE id = ? LIMIT 1;
+
+-- name: ListCompanions :many
+SELECT * FROM companions
+WHERE guest_id = ?
+ORDER BY id;
+
+-- name: CountCompanions :one
+SELECT COUNT(*) FROM companions
+WHERE guest_id = ?;
+
+-- name: UpdateCompanion :exec
+UPDATE companions
+SET name = ?, attributes = ?
+WHERE id = ?;
+
+-- name: DeleteCompanion :exec
+DELETE FROM companions
+WHERE id = ?;
+
+-- name: CreateArrivalCompanion :exec
+INSERT INTO arrival_companions (
+    arrival_id,
+    companion_id
+) VALUES (
+    ?, ?
+);
+
+-- name: DeleteArrivalCompanion :exec
+DELETE FROM arrival_companions
+WHERE arrival_id = ? AND companion_id = ?;
+
+-- name: ListArrivalCompanions :many
+SELECT companions.id, companions.guest_id, companions.name, companions.attributes, companions.created_at FROM companions
+JOIN arrival_companions ON arrival_companions.companion_id = companions.id
+WHERE arrival_companions.arrival_id = ?
+ORDER BY companions.id;
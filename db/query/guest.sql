@@ -3,13 +3,28 @@ INSERT INTO guests(
     guest_name,
     entourage,
     table_id,
-    arrival_time
+    arrival_time,
+    created_by,
+    dietary,
+    accessibility,
+    vip_tier,
+    email,
+    phone,
+    notes,
+    expected_from,
+    expected_until,
+    walk_in
 ) VALUES (
-    ?, ?, ?, ?
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 );
 
 -- name: GetGuests :many
-SELECT * FROM guests 
+SELECT * FROM guests
+WHERE (sqlc.narg('status') IS NULL OR status = sqlc.narg('status'))
+    AND (sqlc.narg('table_id') IS NULL OR table_id = sqlc.narg('table_id'))
+    AND (sqlc.narg('dietary') IS NULL OR FIND_IN_SET(sqlc.narg('dietary'), dietary) > 0)
+    AND (sqlc.narg('vip_tier') IS NULL OR vip_tier = sqlc.narg('vip_tier'))
+    AND (sqlc.narg('walk_in') IS NULL OR walk_in = sqlc.narg('walk_in'))
 ORDER BY id
 LIMIT ?
 OFFSET ?;
@@ -33,7 +48,12 @@ DELETE FROM guests
 WHERE id =?;
 
 -- name: GetEmptySeats :one
-SELECT (SELECT IFNULL(SUM(size), 0) from tables) - (SELECT IFNULL(SUM(occupied), 0) from tables) AS seats_empty;
+SELECT IFNULL(SUM(GREATEST(
+    (SELECT COUNT(*) FROM seats s WHERE s.table_id = t.id AND s.guest_id IS NULL)
+    - (SELECT IFNULL(SUM(GREATEST(g.entourage + 1 - (SELECT COUNT(*) FROM seats h WHERE h.guest_id = g.id), 0)), 0)
+        FROM guests g WHERE g.table_id = t.id AND g.status IN ('invited', 'confirmed')),
+    0)), 0) AS seats_empty
+FROM tables t;
 
 -- name: GetGuestFromName :one
 SELECT * FROM guests
@@ -41,9 +61,49 @@ WHERE guest_name = ? LIMIT 1;
 
 -- name: GetArrivedGuests :many
 SELECT * FROM guests
-WHERE id IN (
-    SELECT guest_id FROM arrivals
-)
+WHERE status = 'arrived'
 ORDER BY arrival_time
 LIMIT ?
-OFFSET ?;
\ No newline at end of file
+OFFSET ?;
+
+-- name: GetReservedSeats :one
+SELECT CAST(IFNULL(SUM(entourage + 1), 0) AS SIGNED) AS reserved_seats FROM guests
+WHERE table_id = ? AND id <> ? AND status IN ('invited', 'confirmed', 'arrived');
+
+-- name: UpdateGuestRSVP :exec
+UPDATE guests
+SET rsvp_status = ?, entourage = ?, rsvp_at = NOW()
+WHERE id = ?;
+
+-- name: UpdateGuestStatus :exec
+UPDATE guests
+SET status = ?
+WHERE id = ?;
+
+-- name: UpdateGuestProfile :exec
+UPDATE guests
+SET
+    dietary = COALESCE(sqlc.narg('dietary'), dietary),
+    accessibility = COALESCE(sqlc.narg('accessibility'), accessibility),
+    vip_tier = COALESCE(sqlc.narg('vip_tier'), vip_tier),
+    email = COALESCE(sqlc.narg('email'), email),
+    phone = COALESCE(sqlc.narg('phone'), phone),
+    notes = COALESCE(sqlc.narg('notes'), notes)
+WHERE id = sqlc.arg('id');
+
+-- name: UpdateGuestArrivalWindow :exec
+UPDATE guests
+SET expected_from = ?, expected_until = ?
+WHERE id = ?;
+
+-- name: ListOverdueGuests :many
+SELECT g.id FROM guests g
+JOIN tables t ON t.id = g.table_id
+WHERE g.status IN ('invited', 'confirmed')
+    AND COALESCE(g.expected_until, t.expected_until) < sqlc.arg('cutoff')
+ORDER BY g.id;
+
+-- name: UpdateGuestTable :exec
+UPDATE guests
+SET table_id = ?
+WHERE id = ?;
@@ -0,0 +1,32 @@
+-- name: CreateInvitation :execresult
+INSERT INTO invitations (
+    guest_id,
+    created_by
+) VALUES (
+    ?, ?
+);
+
+-- name: GetInvitation :one
+SELECT * FROM invitations
+WHERE id = ? LIMIT 1;
+
+-- name: GetInvitationForUpdate :one
+SELECT * FROM invitations
+WHERE id = ? LIMIT 1
+FOR UPDATE;
+
+-- name: GetActiveInvitationFromGuest :one
+SELECT * FROM invitations
+WHERE guest_id = ? AND revoked_at IS NULL AND used_at IS NULL
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: RevokeGuestInvitations :execrows
+UPDATE invitations
+SET revoked_at = CURRENT_TIMESTAMP
+WHERE guest_id = ? AND revoked_at IS NULL AND used_at IS NULL;
+
+-- name: UseInvitation :exec
+UPDATE invitations
+SET used_at = CURRENT_TIMESTAMP
+WHERE id = ?;
@@ -0,0 +1,14 @@
+-- name: CreatePartyChange :execresult
+INSERT INTO party_changes (
+    arrival_id,
+    old_party_size,
+    new_party_size,
+    changed_by
+) VALUES (
+    ?, ?, ?, ?
+);
+
+-- name: ListPartyChanges :many
+SELECT * FROM party_changes
+WHERE arrival_id = ?
+ORDER BY id;
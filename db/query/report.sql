@@ -0,0 +1,20 @@
+-- name: ListEventAuditEvents :many
+SELECT * FROM audit_events
+WHERE created_at >= sqlc.arg('from') AND created_at < sqlc.arg('until')
+ORDER BY id;
+
+-- name: ListEventDeniedAdmissions :many
+SELECT * FROM admission_log
+WHERE allowed = FALSE
+AND decided_at >= sqlc.arg('from') AND decided_at < sqlc.arg('until')
+ORDER BY id;
+
+-- name: ListEventStandingAdmissions :many
+SELECT * FROM standing_admissions
+WHERE admitted_at >= sqlc.arg('from') AND admitted_at < sqlc.arg('until')
+ORDER BY id;
+
+-- name: ListGuestsWithStatus :many
+SELECT * FROM guests
+WHERE status = ?
+ORDER BY guest_name;
@@ -0,0 +1,41 @@
+-- name: CreateSeat :exec
+INSERT INTO seats (
+    table_id,
+    number
+) VALUES (
+    ?, ?
+);
+
+-- name: ListSeatsForUpdate :many
+SELECT * FROM seats
+WHERE table_id = ?
+ORDER BY number
+FOR UPDATE;
+
+-- name: ListGuestSeats :many
+SELECT * FROM seats
+WHERE guest_id = ?
+ORDER BY number;
+
+-- name: ListSeatMap :many
+SELECT s.number, s.guest_id, g.guest_name, g.status AS guest_status, s.companion_id, c.name AS companion_name
+FROM seats s
+LEFT JOIN guests g ON g.id = s.guest_id
+LEFT JOIN companions c ON c.id = s.companion_id
+WHERE s.table_id = ?
+ORDER BY s.number;
+
+-- name: AssignSeat :exec
+UPDATE seats
+SET guest_id = ?, companion_id = ?, assigned_at = CURRENT_TIMESTAMP
+WHERE id = ?;
+
+-- name: ReleaseSeat :exec
+UPDATE seats
+SET guest_id = NULL, companion_id = NULL, assigned_at = NULL
+WHERE id = ?;
+
+-- name: ReleaseGuestSeats :exec
+UPDATE seats
+SET guest_id = NULL, companion_id = NULL, assigned_at = NULL
+WHERE guest_id = ?;
@@ -0,0 +1,29 @@
+-- name: CreateStandingAdmission :execresult
+INSERT INTO standing_admissions (
+    name,
+    party_size,
+    admitted_by
+) VALUES (
+    ?, ?, ?
+);
+
+-- name: EndStandingAdmission :exec
+UPDATE standing_admissions
+SET departed_at = NOW()
+WHERE id = ?;
+
+-- name: GetStandingAdmission :one
+SELECT * FROM standing_admissions
+WHERE id = ? LIMIT 1;
+
+-- name: GetStandingAdmissionForUpdate :one
+SELECT * FROM standing_admissions
+WHERE id = ? LIMIT 1
+FOR UPDATE;
+
+-- name: ListOpenStandingAdmissions :many
+SELECT * FROM standing_admissions
+WHERE departed_at IS NULL
+ORDER BY id
+LIMIT ?
+OFFSET ?;
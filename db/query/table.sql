@@ -1,9 +1,18 @@
 -- name: CreateTable :execresult
 INSERT INTO tables(
     size,
-    occupied
+    occupied,
+    created_by,
+    label,
+    zone,
+    accessible,
+    shape,
+    min_party,
+    max_party,
+    expected_from,
+    expected_until
 ) VALUES (
-    ?, ?
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 );
 
 -- name: GetTables :many
@@ -16,17 +25,68 @@ OFFSET ?;
 SELECT * FROM tables
 WHERE id = ? LIMIT 1; 
 
+-- name: GetTableFromLabel :one
+SELECT * FROM tables
+WHERE label = ? LIMIT 1;
+
 -- name: GetTableForUpdate :one
 SELECT * FROM tables
 WHERE id = ? LIMIT 1
 FOR UPDATE;
 
+-- name: ListTableRoster :many
+SELECT t.id, t.size, t.occupied, t.label, t.zone, t.accessible, t.shape, t.min_party, t.max_party,
+    t.expected_from, t.expected_until,
+    CAST(GREATEST(
+        (SELECT COUNT(*) FROM seats s WHERE s.table_id = t.id AND s.guest_id IS NULL)
+        - (SELECT IFNULL(SUM(GREATEST(e.entourage + 1 - (SELECT COUNT(*) FROM seats h WHERE h.guest_id = e.id), 0)), 0)
+            FROM guests e WHERE e.table_id = t.id AND e.status IN ('invited', 'confirmed')),
+        0) AS SIGNED) AS free_seats,
+    g.id AS guest_id, g.guest_name, g.entourage, g.status AS guest_status, g.vip_tier,
+    g.expected_from AS guest_expected_from, g.expected_until AS guest_expected_until,
+    CAST((SELECT COUNT(*) FROM seats s WHERE s.guest_id = g.id) AS SIGNED) AS seats_held,
+    a.party_size, a.arrived_at
+FROM (
+    SELECT * FROM tables
+    WHERE (sqlc.narg('table_id') IS NULL OR id = sqlc.narg('table_id'))
+    ORDER BY id
+    LIMIT ?
+    OFFSET ?
+) t
+LEFT JOIN guests g ON g.table_id = t.id AND g.status IN ('invited', 'confirmed', 'arrived')
+LEFT JOIN arrivals a ON a.guest_id = g.id AND a.departed_at IS NULL
+ORDER BY t.id, g.guest_name;
+
+-- name: ListTableSpace :many
+SELECT t.id, t.zone, t.min_party, t.max_party,
+    CAST(COUNT(s.id) - COUNT(s.guest_id) AS SIGNED) AS free_seats,
+    CAST(t.occupied - IFNULL(SUM(g.status = 'arrived'), 0) AS SIGNED) AS unseated
+FROM tables t
+LEFT JOIN seats s ON s.table_id = t.id
+LEFT JOIN guests g ON g.id = s.guest_id
+GROUP BY t.id
+ORDER BY t.id;
+
+-- name: ListTables :many
+SELECT * FROM tables
+ORDER BY id;
+
 -- name: UpdateTable :exec
 UPDATE tables
 SET size = ?,
 occupied = ?
 WHERE id = ?;
 
+-- name: UpdateTableArrivalWindow :exec
+UPDATE tables
+SET expected_from = ?, expected_until = ?
+WHERE id = ?;
+
+-- name: UpdateTableLabel :exec
+UPDATE tables
+SET label = ?
+WHERE id = ?;
+
 -- name: DeleteTable :exec
 DELETE FROM tables
 WHERE id=?;
\ No newline at end of file
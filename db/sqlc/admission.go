@@ -0,0 +1,257 @@
+package db
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Names of the built-in admission policies, as given in an admission policies spec
+const (
+	PolicyTableCapacity   = "table_capacity"
+	PolicyRSVPLimit       = "rsvp_limit"
+	PolicyNonVIPEntourage = "non_vip_entourage"
+	PolicyDoorsClose      = "doors_close"
+
+	// NoTableCapacity is the spec entry turning the table_capacity policy off
+	NoTableCapacity = "no_table_capacity"
+)
+
+// Admission is what an admission policy decides on: a guest arriving with a party of PartySize at
+// their table. Guest and Table are as they were before the arrival, within its transaction.
+// FreeSeats counts the seats at the table the party could sit in, free ones and any the guest
+// already holds, and Unseated the people already at the table without a seat of their own because
+// it was overbooked.
+type Admission struct {
+	Guest     Guest
+	Table     Table
+	PartySize int32
+	FreeSeats int32
+	Unseated  int32
+	Time      time.Time
+}
+
+// AdmissionDecision is one policy's verdict on an admission, with the reason explained for the door.
+// RulesVersion is the version of the admission rules an uploaded rule's decision came from.
+type AdmissionDecision struct {
+	Policy       string `json:"policy"`
+	Allowed      bool   `json:"allowed"`
+	Reason       string `json:"reason"`
+	RulesVersion int32  `json:"rules_version,omitempty"`
+}
+
+// AdmissionPolicy is a rule an event applies to every arrival
+type AdmissionPolicy interface {
+	Admit(a Admission) AdmissionDecision
+}
+
+// AdmissionPolicies composes policies, an arrival is only admitted when every one of them allows it
+type AdmissionPolicies []AdmissionPolicy
+
+// Evaluate returns the decision of every policy, in order, and an *AdmissionDeniedError holding
+// them if any turned the arrival away
+func (policies AdmissionPolicies) Evaluate(a Admission) ([]AdmissionDecision, error) {
+	decisions := make([]AdmissionDecision, 0, len(policies))
+	denied := false
+	for _, policy := range policies {
+		decision := policy.Admit(a)
+		denied = denied || !decision.Allowed
+		decisions = append(decisions, decision)
+	}
+	if denied {
+		return decisions, &AdmissionDeniedError{Decisions: decisions}
+	}
+	return decisions, nil
+}
+
+// DefaultAdmissionPolicies only admits parties who fit in the free seats at their table
+func DefaultAdmissionPolicies() AdmissionPolicies {
+	return AdmissionPolicies{TableCapacityPolicy{}}
+}
+
+// TableCapacityPolicy admits a party who fit in the free seats at their table. Tables whose label
+// is one of Tables, or every table when there are none, may be overbooked by up to OverbookPercent
+// of their size (rounded down): the party then sit in whatever seats are free and the rest of them,
+// along with anybody else at the table without a seat, must be within the tolerance.
+type TableCapacityPolicy struct {
+	OverbookPercent int32
+	Tables          []string
+}
+
+func (p TableCapacityPolicy) Admit(a Admission) AdmissionDecision {
+	decision := AdmissionDecision{Policy: PolicyTableCapacity}
+	if a.PartySize <= a.FreeSeats {
+		decision.Allowed = true
+		decision.Reason = fmt.Sprintf("party of %d fits in the %d free seats at table %d", a.PartySize, a.FreeSeats, a.Table.ID)
+		return decision
+	}
+
+	tolerance := p.tolerance(a.Table)
+	unseated := a.Unseated + a.PartySize - a.FreeSeats
+	decision.Allowed = unseated <= tolerance
+	switch {
+	case tolerance == 0:
+		decision.Reason = fmt.Sprintf("party of %d doesn't fit in the %d free seats at table %d", a.PartySize, a.FreeSeats, a.Table.ID)
+	case decision.Allowed:
+		decision.Reason = fmt.Sprintf("party of %d overbooks table %d to %d without a seat, within its tolerance of %d", a.PartySize, a.Table.ID, unseated, tolerance)
+	default:
+		decision.Reason = fmt.Sprintf("party of %d would leave %d without a seat at table %d, over its tolerance of %d", a.PartySize, unseated, a.Table.ID, tolerance)
+	}
+	return decision
+}
+
+// tolerance is how many people may be at the table without a seat
+func (p TableCapacityPolicy) tolerance(table Table) int32 {
+	if len(p.Tables) > 0 {
+		found := false
+		for _, label := range p.Tables {
+			found = found || label == table.Label
+		}
+		if !found {
+			return 0
+		}
+	}
+	return table.Size * p.OverbookPercent / 100
+}
+
+// RSVPLimitPolicy stops a guest arriving with more than Extra people beyond the entourage on their
+// booking
+type RSVPLimitPolicy struct {
+	Extra int32
+}
+
+func (p RSVPLimitPolicy) Admit(a Admission) AdmissionDecision {
+	entourage := a.PartySize - 1
+	limit := a.Guest.Entourage + p.Extra
+	decision := AdmissionDecision{Policy: PolicyRSVPLimit, Allowed: entourage <= limit}
+	if decision.Allowed {
+		decision.Reason = fmt.Sprintf("entourage of %d is within the %d allowed by the booking", entourage, limit)
+	} else {
+		decision.Reason = fmt.Sprintf("entourage of %d is more than the %d allowed by the booking", entourage, limit)
+	}
+	return decision
+}
+
+// NonVIPEntourageLimitPolicy stops guests without a VIP tier arriving with an entourage over Max
+type NonVIPEntourageLimitPolicy struct {
+	Max int32
+}
+
+func (p NonVIPEntourageLimitPolicy) Admit(a Admission) AdmissionDecision {
+	entourage := a.PartySize - 1
+	decision := AdmissionDecision{Policy: PolicyNonVIPEntourage}
+	switch {
+	case a.Guest.VipTier != "":
+		decision.Allowed = true
+		decision.Reason = fmt.Sprintf("%s VIPs may bring any entourage", a.Guest.VipTier)
+	case entourage <= p.Max:
+		decision.Allowed = true
+		decision.Reason = fmt.Sprintf("entourage of %d is within the non-VIP limit of %d", entourage, p.Max)
+	default:
+		decision.Reason = fmt.Sprintf("entourage of %d is over the non-VIP limit of %d", entourage, p.Max)
+	}
+	return decision
+}
+
+// DoorsClosePolicy turns away every arrival, re-entries included, from At onwards
+type DoorsClosePolicy struct {
+	At time.Time
+}
+
+func (p DoorsClosePolicy) Admit(a Admission) AdmissionDecision {
+	decision := AdmissionDecision{Policy: PolicyDoorsClose, Allowed: a.Time.Before(p.At)}
+	if decision.Allowed {
+		decision.Reason = fmt.Sprintf("doors are open until %s", p.At.Format(time.RFC3339))
+	} else {
+		decision.Reason = fmt.Sprintf("doors closed at %s", p.At.Format(time.RFC3339))
+	}
+	return decision
+}
+
+// ParseAdmissionPolicies reads policies separated by commas in the form "name" or "name:argument",
+// e.g. the ADMISSION_POLICIES config value. The table_capacity policy always comes first, as in
+// DefaultAdmissionPolicies, and its entry only configures it.
+//   - table_capacity[:percent[:label|label...]] overbooks the labelled tables, or all, by percent
+//   - no_table_capacity turns table_capacity off, admitting parties whether or not they fit and
+//     seating as many as possible
+//   - rsvp_limit[:extra] allows extra people beyond the booked entourage
+//   - non_vip_entourage:max limits the entourage of guests without a VIP tier
+//   - doors_close:time turns arrivals away from the RFC 3339 time
+func ParseAdmissionPolicies(spec string) (AdmissionPolicies, error) {
+	capacity := TableCapacityPolicy{}
+	var configured, off bool
+	var policies AdmissionPolicies
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, arg := entry, ""
+		if i := strings.Index(entry, ":"); i >= 0 {
+			name, arg = entry[:i], entry[i+1:]
+		}
+
+		var policy AdmissionPolicy
+		var err error
+		switch name {
+		case PolicyTableCapacity:
+			configured = true
+			if arg != "" {
+				parts := strings.SplitN(arg, ":", 2)
+				capacity.OverbookPercent, err = parsePolicyCount(name, parts[0])
+				if len(parts) == 2 {
+					capacity.Tables = strings.Split(parts[1], "|")
+				}
+			}
+		case NoTableCapacity:
+			off = true
+			if arg != "" {
+				err = fmt.Errorf("%s takes no argument", name)
+			}
+		case PolicyRSVPLimit:
+			var p RSVPLimitPolicy
+			if arg != "" {
+				p.Extra, err = parsePolicyCount(name, arg)
+			}
+			policy = p
+		case PolicyNonVIPEntourage:
+			var p NonVIPEntourageLimitPolicy
+			p.Max, err = parsePolicyCount(name, arg)
+			policy = p
+		case PolicyDoorsClose:
+			var p DoorsClosePolicy
+			p.At, err = time.Parse(time.RFC3339, arg)
+			if err != nil {
+				err = fmt.Errorf("admission policy %s needs an RFC 3339 time: %w", name, err)
+			}
+			policy = p
+		default:
+			err = fmt.Errorf("unknown admission policy %q", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if policy != nil {
+			policies = append(policies, policy)
+		}
+	}
+
+	switch {
+	case configured && off:
+		return nil, fmt.Errorf("admission policies %s and %s can't both be given", PolicyTableCapacity, NoTableCapacity)
+	case off:
+		return append(AdmissionPolicies{}, policies...), nil
+	}
+	return append(AdmissionPolicies{capacity}, policies...), nil
+}
+
+// parsePolicyCount reads the non-negative number argument of the named policy
+func parsePolicyCount(name, arg string) (int32, error) {
+	n, err := strconv.ParseInt(arg, 10, 32)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("admission policy %s needs a non-negative number, not %q", name, arg)
+	}
+	return int32(n), nil
+}
@@ -0,0 +1,218 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/ellisp97/BE_Task_Oct20/golang/rules"
+)
+
+// ErrInvalidAdmissionRules is wrapped by the error returned when uploaded admission rules don't
+// compile
+var ErrInvalidAdmissionRules = errors.New("invalid admission rules")
+
+// AdmissionRule is one of the door rules organisers upload, written in the rules language e.g.
+// `deny if now > event.doors_close`. Reason is what the door is told when it turns a party away,
+// by default the rule itself.
+type AdmissionRule struct {
+	Name   string `json:"name"`
+	Rule   string `json:"rule"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// AdmissionRules is the document of a version of the admission rules. Event holds values, e.g. the
+// time the doors close, the rules refer to as event.<name>: whole numbers, strings, bools, and RFC
+// 3339 times given as strings.
+type AdmissionRules struct {
+	Event map[string]interface{} `json:"event,omitempty"`
+	Rules []AdmissionRule        `json:"rules"`
+}
+
+// admissionVars are the variables every admission rule may refer to, alongside event.<name>
+var admissionVars = rules.Vars{
+	"party.size":        rules.Int,
+	"party.entourage":   rules.Int,
+	"guest.name":        rules.String,
+	"guest.status":      rules.String,
+	"guest.rsvp_status": rules.String,
+	"guest.entourage":   rules.Int,
+	"guest.rsvp_size":   rules.Int,
+	"guest.tier":        rules.String,
+	"guest.vip":         rules.Bool,
+	"table.id":          rules.Int,
+	"table.label":       rules.String,
+	"table.zone":        rules.String,
+	"table.size":        rules.Int,
+	"table.occupied":    rules.Int,
+	"table.free_seats":  rules.Int,
+	"table.unseated":    rules.Int,
+	"now":               rules.Time,
+}
+
+var eventValueName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// admissionValues are the values of admissionVars for the admission
+func admissionValues(a Admission) rules.Values {
+	return rules.Values{
+		"party.size":        int64(a.PartySize),
+		"party.entourage":   int64(a.PartySize - 1),
+		"guest.name":        a.Guest.GuestName,
+		"guest.status":      a.Guest.Status,
+		"guest.rsvp_status": a.Guest.RsvpStatus,
+		"guest.entourage":   int64(a.Guest.Entourage),
+		"guest.rsvp_size":   int64(a.Guest.Entourage + 1),
+		"guest.tier":        a.Guest.VipTier,
+		"guest.vip":         a.Guest.VipTier != "",
+		"table.id":          int64(a.Table.ID),
+		"table.label":       a.Table.Label,
+		"table.zone":        a.Table.Zone,
+		"table.size":        int64(a.Table.Size),
+		"table.occupied":    int64(a.Table.Occupied),
+		"table.free_seats":  int64(a.FreeSeats),
+		"table.unseated":    int64(a.Unseated),
+		"now":               a.Time,
+	}
+}
+
+// eventValue converts a decoded JSON event value to one rules can hold
+func eventValue(name string, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case bool:
+		return v, nil
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, nil
+		}
+		return v, nil
+	case float64:
+		if v == float64(int64(v)) {
+			return int64(v), nil
+		}
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n, nil
+		}
+	}
+	return nil, fmt.Errorf("event value %s must be a whole number, string, bool or time, not %v: %w", name, value, ErrInvalidAdmissionRules)
+}
+
+// CompileAdmissionRules checks every rule of the document, returning a policy for each that denies
+// an arrival whenever the rule's condition holds. version is recorded against their decisions.
+func CompileAdmissionRules(document AdmissionRules, version int32) (AdmissionPolicies, error) {
+	vars := make(rules.Vars, len(admissionVars)+len(document.Event))
+	for name, t := range admissionVars {
+		vars[name] = t
+	}
+	event := make(rules.Values, len(document.Event))
+	for name, value := range document.Event {
+		if !eventValueName.MatchString(name) {
+			return nil, fmt.Errorf("event value name %q must be a letter or underscore followed by letters, digits or underscores: %w", name, ErrInvalidAdmissionRules)
+		}
+		v, err := eventValue(name, value)
+		if err != nil {
+			return nil, err
+		}
+		t, _ := rules.TypeOf(v)
+		vars["event."+name] = t
+		event["event."+name] = v
+	}
+
+	policies := make(AdmissionPolicies, 0, len(document.Rules))
+	names := make(map[string]bool, len(document.Rules))
+	for _, rule := range document.Rules {
+		if !eventValueName.MatchString(rule.Name) || names[rule.Name] {
+			return nil, fmt.Errorf("rule name %q must be unique and made of letters, digits or underscores: %w", rule.Name, ErrInvalidAdmissionRules)
+		}
+		names[rule.Name] = true
+
+		compiled, err := rules.Compile(rule.Rule, vars)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %v: %w", rule.Name, err, ErrInvalidAdmissionRules)
+		}
+		policies = append(policies, rulePolicy{AdmissionRule: rule, version: version, rule: compiled, event: event})
+	}
+	return policies, nil
+}
+
+// rulePolicy denies an arrival when its admission rule's condition holds, or when it can't be
+// evaluated
+type rulePolicy struct {
+	AdmissionRule
+	version int32
+	rule    *rules.Rule
+	event   rules.Values
+}
+
+func (p rulePolicy) Admit(a Admission) AdmissionDecision {
+	values := admissionValues(a)
+	for name, value := range p.event {
+		values[name] = value
+	}
+
+	decision := AdmissionDecision{Policy: "rule:" + p.Name, RulesVersion: p.version}
+	denies, err := p.rule.Denies(values)
+	switch {
+	case err != nil:
+		decision.Reason = fmt.Sprintf("rule %s could not be evaluated: %v", p.Name, err)
+	case !denies:
+		decision.Allowed = true
+		decision.Reason = fmt.Sprintf("rule %s doesn't apply", p.Name)
+	case p.Reason != "":
+		decision.Reason = p.Reason
+	default:
+		decision.Reason = fmt.Sprintf("rule %s: %s", p.Name, p.rule)
+	}
+	return decision
+}
+
+// admissionPolicies returns the event's admission policies followed by the rules of the latest
+// version of the admission rules, if any have been uploaded
+func (q *Queries) admissionPolicies(ctx context.Context, configured AdmissionPolicies) (AdmissionPolicies, error) {
+	ruleSet, err := q.GetLatestAdmissionRuleSet(ctx)
+	if err == sql.ErrNoRows {
+		return configured, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var document AdmissionRules
+	if err = json.Unmarshal(ruleSet.Document, &document); err != nil {
+		return nil, err
+	}
+	ruled, err := CompileAdmissionRules(document, ruleSet.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	policies := make(AdmissionPolicies, 0, len(configured)+len(ruled))
+	policies = append(policies, configured...)
+	return append(policies, ruled...), nil
+}
+
+// logAdmission records the decision of every policy on the guest's party arriving at the table
+func (q *Queries) logAdmission(ctx context.Context, info AuditInfo, guest Guest, tableID, partySize int32, decisions []AdmissionDecision) error {
+	for _, decision := range decisions {
+		err := q.CreateAdmissionLogEntry(ctx, CreateAdmissionLogEntryParams{
+			GuestID:      guest.ID,
+			GuestName:    guest.GuestName,
+			TableID:      tableID,
+			PartySize:    partySize,
+			Policy:       decision.Policy,
+			RulesVersion: decision.RulesVersion,
+			Allowed:      decision.Allowed,
+			Reason:       decision.Reason,
+			DecidedBy:    info.Actor,
+			RequestID:    info.RequestID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompileAdmissionRules(t *testing.T) {
+	closing := time.Date(2026, 10, 19, 23, 0, 0, 0, time.UTC)
+	document := AdmissionRules{
+		Event: map[string]interface{}{"doors_close": closing.Format(time.RFC3339), "grace": float64(2)},
+		Rules: []AdmissionRule{
+			{Name: "rsvp_growth", Rule: `deny if party.size > guest.rsvp_size + event.grace and guest.tier != "platinum"`},
+			{Name: "doors", Rule: `deny if now > event.doors_close`, Reason: "the doors have closed"},
+		},
+	}
+
+	policies, err := CompileAdmissionRules(document, 3)
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+
+	admission := Admission{Guest: Guest{Entourage: 1}, PartySize: 5, Time: closing.Add(-time.Minute)}
+	decisions, err := policies.Evaluate(admission)
+	require.ErrorIs(t, err, ErrAdmissionDenied)
+	require.Equal(t, []AdmissionDecision{
+		{Policy: "rule:rsvp_growth", Allowed: false, Reason: "rule rsvp_growth: " + document.Rules[0].Rule, RulesVersion: 3},
+		{Policy: "rule:doors", Allowed: true, Reason: "rule doors doesn't apply", RulesVersion: 3},
+	}, decisions)
+
+	admission.Guest.VipTier = "platinum"
+	admission.Time = closing.Add(time.Minute)
+	decisions, err = policies.Evaluate(admission)
+	require.ErrorIs(t, err, ErrAdmissionDenied)
+	require.True(t, decisions[0].Allowed)
+	require.Equal(t, "the doors have closed", decisions[1].Reason)
+
+	admission.Time = closing.Add(-time.Minute)
+	_, err = policies.Evaluate(admission)
+	require.NoError(t, err)
+}
+
+func TestCompileInvalidAdmissionRules(t *testing.T) {
+	testCases := []struct {
+		name     string
+		document AdmissionRules
+	}{
+		{
+			name:     "UnknownVariable",
+			document: AdmissionRules{Rules: []AdmissionRule{{Name: "typo", Rule: `deny if party.sise > 4`}}},
+		},
+		{
+			name:     "UnknownEventValue",
+			document: AdmissionRules{Rules: []AdmissionRule{{Name: "doors", Rule: `deny if now > event.doors_close`}}},
+		},
+		{
+			name: "MismatchedTypes",
+			document: AdmissionRules{
+				Event: map[string]interface{}{"doors_close": "midnight"},
+				Rules: []AdmissionRule{{Name: "doors", Rule: `deny if now > event.doors_close`}},
+			},
+		},
+		{
+			name: "FractionalEventValue",
+			document: AdmissionRules{
+				Event: map[string]interface{}{"grace": 1.5},
+				Rules: []AdmissionRule{},
+			},
+		},
+		{
+			name: "BadEventValueName",
+			document: AdmissionRules{
+				Event: map[string]interface{}{"doors close": "midnight"},
+				Rules: []AdmissionRule{},
+			},
+		},
+		{
+			name: "DuplicateName",
+			document: AdmissionRules{Rules: []AdmissionRule{
+				{Name: "size", Rule: `deny if party.size > 8`},
+				{Name: "size", Rule: `deny if party.size > 6`},
+			}},
+		},
+		{
+			name:     "NotADenyRule",
+			document: AdmissionRules{Rules: []AdmissionRule{{Name: "size", Rule: `allow if party.size < 8`}}},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := CompileAdmissionRules(tc.document, 1)
+			require.ErrorIs(t, err, ErrInvalidAdmissionRules)
+		})
+	}
+}
+
+func TestAdmissionRulesTx(t *testing.T) {
+	store := NewStore(testDB)
+	audit := AuditInfo{Actor: util.OrganiserRole, RequestID: util.RandomString(16)}
+	table := createRandomTable(t)
+
+	guest, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		Entourage: 0,
+		TableID:   table.ID,
+	})
+	require.NoError(t, err)
+
+	// The rule only applies to this guest so it can't turn away anybody else's arrivals
+	document := AdmissionRules{
+		Event: map[string]interface{}{"guest": guest.GuestName},
+		Rules: []AdmissionRule{{Name: "alone", Rule: `deny if guest.name == event.guest and party.size > 1`}},
+	}
+	ruleSet, err := store.SetAdmissionRulesTx(context.Background(), SetAdmissionRulesTxParams{AuditInfo: audit, Rules: document})
+	require.NoError(t, err)
+	require.Equal(t, audit.Actor, ruleSet.CreatedBy)
+	defer func() {
+		_, err := store.SetAdmissionRulesTx(context.Background(), SetAdmissionRulesTxParams{AuditInfo: audit, Rules: AdmissionRules{Rules: []AdmissionRule{}}})
+		require.NoError(t, err)
+	}()
+
+	var stored AdmissionRules
+	require.NoError(t, json.Unmarshal(ruleSet.Document, &stored))
+	require.Equal(t, document.Rules, stored.Rules)
+
+	latest, err := store.GetLatestAdmissionRuleSet(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, ruleSet.Version, latest.Version)
+
+	_, err = store.SetAdmissionRulesTx(context.Background(), SetAdmissionRulesTxParams{
+		AuditInfo: audit,
+		Rules:     AdmissionRules{Rules: []AdmissionRule{{Name: "typo", Rule: `deny if party.sise > 1`}}},
+	})
+	require.ErrorIs(t, err, ErrInvalidAdmissionRules)
+
+	// The denial is logged even though the arrival is rolled back
+	_, err = store.AssignTableTx(context.Background(), AssignTableTxParams{
+		AuditInfo:    audit,
+		UserID:       int64(guest.ID),
+		NewEntourage: 1,
+		TableID:      int64(table.ID),
+	})
+	var denied *AdmissionDeniedError
+	require.ErrorAs(t, err, &denied)
+	require.Equal(t, "rule:alone", denied.Decisions[len(denied.Decisions)-1].Policy)
+
+	result, err := store.AssignTableTx(context.Background(), AssignTableTxParams{
+		AuditInfo:    audit,
+		UserID:       int64(guest.ID),
+		NewEntourage: 0,
+		TableID:      int64(table.ID),
+	})
+	require.NoError(t, err)
+	require.True(t, result.Decisions[len(result.Decisions)-1].Allowed)
+
+	log, err := store.ListAdmissionLog(context.Background(), ListAdmissionLogParams{
+		GuestName: sql.NullString{String: guest.GuestName, Valid: true},
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Len(t, log, 2*len(result.Decisions))
+	require.False(t, log[len(result.Decisions)-1].Allowed)
+	require.Equal(t, ruleSet.Version, log[len(log)-1].RulesVersion)
+	require.Equal(t, audit.RequestID, log[len(log)-1].RequestID)
+}
@@ -0,0 +1,179 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmissionPolicies(t *testing.T) {
+	closing := time.Date(2026, 10, 19, 23, 0, 0, 0, time.UTC)
+	table := Table{ID: 3, Size: 10, Label: "Booth A"}
+
+	testCases := []struct {
+		name      string
+		policy    AdmissionPolicy
+		admission Admission
+		allowed   bool
+	}{
+		{
+			name:      "Fits",
+			policy:    TableCapacityPolicy{},
+			admission: Admission{Table: table, PartySize: 4, FreeSeats: 4},
+			allowed:   true,
+		},
+		{
+			name:      "DoesNotFit",
+			policy:    TableCapacityPolicy{},
+			admission: Admission{Table: table, PartySize: 5, FreeSeats: 4},
+		},
+		{
+			name:      "WithinOverbookTolerance",
+			policy:    TableCapacityPolicy{OverbookPercent: 10},
+			admission: Admission{Table: table, PartySize: 5, FreeSeats: 4},
+			allowed:   true,
+		},
+		{
+			name:      "OverbookToleranceUsedUp",
+			policy:    TableCapacityPolicy{OverbookPercent: 10},
+			admission: Admission{Table: table, PartySize: 5, FreeSeats: 4, Unseated: 1},
+		},
+		{
+			name:      "OverbookOtherTable",
+			policy:    TableCapacityPolicy{OverbookPercent: 10, Tables: []string{"Booth B"}},
+			admission: Admission{Table: table, PartySize: 5, FreeSeats: 4},
+		},
+		{
+			name:      "OverbookLabelledTable",
+			policy:    TableCapacityPolicy{OverbookPercent: 10, Tables: []string{"Booth B", "Booth A"}},
+			admission: Admission{Table: table, PartySize: 5, FreeSeats: 4},
+			allowed:   true,
+		},
+		{
+			name:      "WithinRSVP",
+			policy:    RSVPLimitPolicy{},
+			admission: Admission{Guest: Guest{Entourage: 2}, PartySize: 3},
+			allowed:   true,
+		},
+		{
+			name:      "OverRSVP",
+			policy:    RSVPLimitPolicy{},
+			admission: Admission{Guest: Guest{Entourage: 2}, PartySize: 4},
+		},
+		{
+			name:      "WithinRSVPExtra",
+			policy:    RSVPLimitPolicy{Extra: 1},
+			admission: Admission{Guest: Guest{Entourage: 2}, PartySize: 4},
+			allowed:   true,
+		},
+		{
+			name:      "NonVIPWithinLimit",
+			policy:    NonVIPEntourageLimitPolicy{Max: 2},
+			admission: Admission{PartySize: 3},
+			allowed:   true,
+		},
+		{
+			name:      "NonVIPOverLimit",
+			policy:    NonVIPEntourageLimitPolicy{Max: 2},
+			admission: Admission{PartySize: 4},
+		},
+		{
+			name:      "VIPOverLimit",
+			policy:    NonVIPEntourageLimitPolicy{Max: 2},
+			admission: Admission{Guest: Guest{VipTier: "gold"}, PartySize: 4},
+			allowed:   true,
+		},
+		{
+			name:      "DoorsOpen",
+			policy:    DoorsClosePolicy{At: closing},
+			admission: Admission{Time: closing.Add(-time.Minute)},
+			allowed:   true,
+		},
+		{
+			name:      "DoorsClosed",
+			policy:    DoorsClosePolicy{At: closing},
+			admission: Admission{Time: closing},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			decision := tc.policy.Admit(tc.admission)
+			require.Equal(t, tc.allowed, decision.Allowed)
+			require.NotEmpty(t, decision.Policy)
+			require.NotEmpty(t, decision.Reason)
+		})
+	}
+}
+
+func TestEvaluateAdmissionPolicies(t *testing.T) {
+	policies := AdmissionPolicies{TableCapacityPolicy{}, RSVPLimitPolicy{}}
+	admission := Admission{Table: Table{ID: 1, Size: 4}, Guest: Guest{Entourage: 1}, PartySize: 2, FreeSeats: 4}
+
+	decisions, err := policies.Evaluate(admission)
+	require.NoError(t, err)
+	require.Len(t, decisions, 2)
+
+	// Every decision is explained, not just the one turning the party away
+	admission.PartySize = 3
+	decisions, err = policies.Evaluate(admission)
+	require.ErrorIs(t, err, ErrAdmissionDenied)
+	require.NotErrorIs(t, err, ErrInsufficientTableSize)
+	require.Len(t, decisions, 2)
+	require.True(t, decisions[0].Allowed)
+	require.False(t, decisions[1].Allowed)
+	require.Equal(t, "admission denied: "+decisions[1].Reason, err.Error())
+
+	admission.FreeSeats = 2
+	_, err = policies.Evaluate(admission)
+	require.ErrorIs(t, err, ErrInsufficientTableSize)
+
+	var denied *AdmissionDeniedError
+	require.ErrorAs(t, err, &denied)
+	require.False(t, denied.TableCapacityOnly())
+
+	// Booked for the whole party, they're only turned away for not fitting
+	admission.Guest.Entourage = 2
+	_, err = policies.Evaluate(admission)
+	require.ErrorAs(t, err, &denied)
+	require.True(t, denied.TableCapacityOnly())
+}
+
+func TestParseAdmissionPolicies(t *testing.T) {
+	policies, err := ParseAdmissionPolicies("")
+	require.NoError(t, err)
+	require.Equal(t, DefaultAdmissionPolicies(), policies)
+
+	policies, err = ParseAdmissionPolicies("table_capacity:10:Booth A|Booth B, rsvp_limit, non_vip_entourage:2,doors_close:2026-10-19T23:00:00Z")
+	require.NoError(t, err)
+	require.Equal(t, AdmissionPolicies{
+		TableCapacityPolicy{OverbookPercent: 10, Tables: []string{"Booth A", "Booth B"}},
+		RSVPLimitPolicy{},
+		NonVIPEntourageLimitPolicy{Max: 2},
+		DoorsClosePolicy{At: time.Date(2026, 10, 19, 23, 0, 0, 0, time.UTC)},
+	}, policies)
+
+	// Leaving table_capacity out still turns away parties who don't fit
+	policies, err = ParseAdmissionPolicies("rsvp_limit:1")
+	require.NoError(t, err)
+	require.Equal(t, AdmissionPolicies{TableCapacityPolicy{}, RSVPLimitPolicy{Extra: 1}}, policies)
+
+	_, err = policies.Evaluate(Admission{Table: Table{ID: 1, Size: 4}, PartySize: 3, FreeSeats: 2})
+	require.ErrorIs(t, err, ErrInsufficientTableSize)
+
+	// Unless it's turned off
+	policies, err = ParseAdmissionPolicies("no_table_capacity, rsvp_limit:1")
+	require.NoError(t, err)
+	require.Equal(t, AdmissionPolicies{RSVPLimitPolicy{Extra: 1}}, policies)
+
+	_, err = policies.Evaluate(Admission{Table: Table{ID: 1, Size: 4}, PartySize: 2, FreeSeats: 1})
+	require.NoError(t, err)
+
+	for _, spec := range []string{"bouncer", "table_capacity:-5", "non_vip_entourage", "doors_close:11pm", "no_table_capacity:5", "table_capacity,no_table_capacity"} {
+		_, err = ParseAdmissionPolicies(spec)
+		require.Error(t, err, spec)
+	}
+}
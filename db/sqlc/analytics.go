@@ -0,0 +1,323 @@
+package db
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// EventWindow is the period of the event the analytics are computed over, From is inclusive and
+// Until exclusive
+type EventWindow struct {
+	From  time.Time `json:"from"`
+	Until time.Time `json:"until"`
+}
+
+// StartAtFirstActivity returns the window with a zero From moved up to the first arrival or change
+// of occupancy before Until, or to Until when there has been neither
+func (w EventWindow) StartAtFirstActivity(arrivals []ListEventArrivalsRow, snapshots []OccupancySnapshot) EventWindow {
+	if !w.From.IsZero() {
+		return w
+	}
+	w.From = w.Until
+	for _, arrival := range arrivals {
+		if arrival.ArrivedAt.Before(w.From) {
+			w.From = arrival.ArrivedAt
+		}
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.RecordedAt.Before(w.From) {
+			w.From = snapshot.RecordedAt
+		}
+	}
+	return w
+}
+
+// EventActivity is the arrivals and occupancy snapshots the analytics of an event are computed from
+type EventActivity struct {
+	Window    EventWindow
+	Arrivals  []ListEventArrivalsRow
+	Snapshots []OccupancySnapshot
+}
+
+// LoadEventActivity reads the arrivals and the occupancy snapshots in window, after the last
+// snapshot of every table before it for the occupancy it opened with. A zero From opens the window
+// at the first arrival or change of occupancy.
+func LoadEventActivity(ctx context.Context, q Querier, window EventWindow) (EventActivity, error) {
+	arrivals, err := q.ListEventArrivals(ctx, ListEventArrivalsParams{From: window.From, Until: window.Until})
+	if err != nil {
+		return EventActivity{}, err
+	}
+	snapshots, err := q.ListLastOccupancySnapshots(ctx, window.From)
+	if err != nil {
+		return EventActivity{}, err
+	}
+	within, err := q.ListOccupancySnapshots(ctx, ListOccupancySnapshotsParams{From: window.From, Until: window.Until})
+	if err != nil {
+		return EventActivity{}, err
+	}
+	snapshots = append(snapshots, within...)
+
+	return EventActivity{
+		Window:    window.StartAtFirstActivity(arrivals, snapshots),
+		Arrivals:  arrivals,
+		Snapshots: snapshots,
+	}, nil
+}
+
+// ArrivalBucket counts the arrivals, and the people in their parties, in the interval from Start
+type ArrivalBucket struct {
+	Start    time.Time `json:"start"`
+	Arrivals int64     `json:"arrivals"`
+	People   int64     `json:"people"`
+}
+
+// BucketArrivals counts the arrivals in every interval of the window, empty ones included. The
+// buckets are aligned to the interval, e.g. on the quarter hour for 15 minutes, so the first may
+// start before the window. Each arrival session counts once, a guest re-entering counts again.
+func BucketArrivals(arrivals []ListEventArrivalsRow, window EventWindow, interval time.Duration) []ArrivalBucket {
+	buckets := []ArrivalBucket{}
+	if interval <= 0 || !window.From.Before(window.Until) {
+		return buckets
+	}
+
+	start := window.From.Truncate(interval)
+	for at := start; at.Before(window.Until); at = at.Add(interval) {
+		buckets = append(buckets, ArrivalBucket{Start: at})
+	}
+	for _, arrival := range arrivals {
+		if arrival.ArrivedAt.Before(window.From) || !arrival.ArrivedAt.Before(window.Until) {
+			continue
+		}
+		bucket := &buckets[int(arrival.ArrivedAt.Sub(start)/interval)]
+		bucket.Arrivals++
+		bucket.People += int64(arrival.PartySize)
+	}
+	return buckets
+}
+
+// OccupancyPoint is the occupancy of the venue from At until the next point
+type OccupancyPoint struct {
+	At        time.Time `json:"at"`
+	Occupancy int32     `json:"occupancy"`
+}
+
+// OccupancySeries returns the occupancy of the venue over the window, starting with whatever it
+// was when the window opened and followed by every change within it
+func OccupancySeries(snapshots []OccupancySnapshot, window EventWindow) []OccupancyPoint {
+	series := []OccupancyPoint{{At: window.From}}
+	for _, snapshot := range snapshots {
+		switch {
+		case snapshot.RecordedAt.Before(window.From):
+			series[0].Occupancy = snapshot.VenueOccupancy
+		case snapshot.RecordedAt.Before(window.Until):
+			series = append(series, OccupancyPoint{At: snapshot.RecordedAt, Occupancy: snapshot.VenueOccupancy})
+		}
+	}
+	return series
+}
+
+// PeakOccupancy returns the first point of the series at which the venue held the most people
+func PeakOccupancy(series []OccupancyPoint) OccupancyPoint {
+	var peak OccupancyPoint
+	for i, point := range series {
+		if i == 0 || point.Occupancy > peak.Occupancy {
+			peak = point
+		}
+	}
+	return peak
+}
+
+// StaySummary is how long the parties who have left stayed, Average is 0 when nobody has
+type StaySummary struct {
+	Completed int64         `json:"completed"`
+	Average   time.Duration `json:"average"`
+}
+
+// SummariseStays averages the length of every arrival session which has ended, those still at the
+// party are left out as their stay isn't over
+func SummariseStays(arrivals []ListEventArrivalsRow) StaySummary {
+	var summary StaySummary
+	var total time.Duration
+	for _, arrival := range arrivals {
+		if !arrival.DepartedAt.Valid {
+			continue
+		}
+		summary.Completed++
+		total += arrival.DepartedAt.Time.Sub(arrival.ArrivedAt)
+	}
+	if summary.Completed > 0 {
+		summary.Average = total / time.Duration(summary.Completed)
+	}
+	return summary
+}
+
+// TableUtilisation is how much use a table got over the window. Utilisation is the share of the
+// table's seat time that was occupied, so a table of 4 with 2 people at it all night is at 0.5.
+type TableUtilisation struct {
+	TableID      int32   `json:"table_id"`
+	Label        string  `json:"label"`
+	Zone         string  `json:"zone"`
+	Size         int32   `json:"size"`
+	Arrivals     int64   `json:"arrivals"`
+	PeakOccupied int32   `json:"peak_occupied"`
+	SeatHours    float64 `json:"seat_hours"`
+	Utilisation  float64 `json:"utilisation"`
+}
+
+// UtiliseTables works out the utilisation of every table over the window from the snapshots of
+// its occupancy, carrying in whatever it was when the window opened
+func UtiliseTables(tables []Table, snapshots []OccupancySnapshot, arrivals []ListEventArrivalsRow, window EventWindow) []TableUtilisation {
+	type state struct {
+		occupied int32
+		since    time.Time
+		seatTime time.Duration
+		peak     int32
+	}
+	states := make(map[int32]*state, len(tables))
+	for _, table := range tables {
+		states[table.ID] = &state{since: window.From}
+	}
+
+	for _, snapshot := range snapshots {
+		if !snapshot.TableID.Valid || !snapshot.RecordedAt.Before(window.Until) {
+			continue
+		}
+		s, ok := states[snapshot.TableID.Int32]
+		if !ok {
+			continue
+		}
+		// Changes before the window only set what the table held when it opened
+		if !snapshot.RecordedAt.Before(window.From) {
+			s.seatTime += time.Duration(s.occupied) * snapshot.RecordedAt.Sub(s.since)
+			s.since = snapshot.RecordedAt
+			s.peak = max32(s.peak, s.occupied, snapshot.TableOccupied)
+		}
+		s.occupied = snapshot.TableOccupied
+	}
+
+	arrivalCounts := make(map[int32]int64)
+	for _, arrival := range arrivals {
+		arrivalCounts[arrival.TableID]++
+	}
+
+	length := window.Until.Sub(window.From)
+	utilisation := make([]TableUtilisation, 0, len(tables))
+	for _, table := range tables {
+		s := states[table.ID]
+		if window.Until.After(s.since) {
+			s.seatTime += time.Duration(s.occupied) * window.Until.Sub(s.since)
+		}
+		s.peak = max32(s.peak, s.occupied)
+
+		u := TableUtilisation{
+			TableID:      table.ID,
+			Label:        table.Label,
+			Zone:         table.Zone,
+			Size:         table.Size,
+			Arrivals:     arrivalCounts[table.ID],
+			PeakOccupied: s.peak,
+			SeatHours:    s.seatTime.Hours(),
+		}
+		if table.Size > 0 && length > 0 {
+			u.Utilisation = float64(s.seatTime) / (float64(table.Size) * float64(length))
+		}
+		utilisation = append(utilisation, u)
+	}
+	return utilisation
+}
+
+// NoShowSummary is how many of the guests on the list whose night is settled, those who arrived
+// at some point or were marked no-show, never turned up. Walk-ins aren't counted.
+type NoShowSummary struct {
+	Expected int64   `json:"expected"`
+	NoShows  int64   `json:"no_shows"`
+	Rate     float64 `json:"rate"`
+}
+
+// SummariseNoShows works out the no-show rate from the number of guests with each status
+func SummariseNoShows(counts []CountGuestsByStatusRow) NoShowSummary {
+	var summary NoShowSummary
+	for _, count := range counts {
+		switch count.Status {
+		case GuestArrived, GuestLeft:
+			summary.Expected += count.Guests
+		case GuestNoShow:
+			summary.Expected += count.Guests
+			summary.NoShows += count.Guests
+		}
+	}
+	if summary.Expected > 0 {
+		summary.Rate = float64(summary.NoShows) / float64(summary.Expected)
+	}
+	return summary
+}
+
+// EntourageDelta compares the party a guest was booked with, as they last RSVP'd, with the party
+// who actually came through the door
+type EntourageDelta struct {
+	GuestID   int32  `json:"guest_id"`
+	GuestName string `json:"guest_name"`
+	Booked    int32  `json:"booked_party_size"`
+	Actual    int32  `json:"actual_party_size"`
+	Delta     int32  `json:"delta"`
+}
+
+// EntourageSummary totals the booked and actual parties of every guest who arrived, Deltas lists
+// those whose party differed, biggest difference first
+type EntourageSummary struct {
+	Guests int64            `json:"guests"`
+	Booked int64            `json:"booked"`
+	Actual int64            `json:"actual"`
+	Delta  int64            `json:"delta"`
+	Deltas []EntourageDelta `json:"deltas"`
+}
+
+// CompareEntourages compares the booked and actual party of each guest on their first arrival,
+// the actual party including anybody who joined or left it during their stay. Walk-ins had no
+// booking so are left out.
+func CompareEntourages(arrivals []ListEventArrivalsRow) EntourageSummary {
+	summary := EntourageSummary{Deltas: []EntourageDelta{}}
+	seen := make(map[int32]bool)
+	for _, arrival := range arrivals {
+		if arrival.WalkIn || seen[arrival.GuestID] {
+			continue
+		}
+		seen[arrival.GuestID] = true
+
+		summary.Guests++
+		summary.Booked += int64(arrival.BookedPartySize)
+		summary.Actual += int64(arrival.PartySize)
+		if arrival.PartySize != arrival.BookedPartySize {
+			summary.Deltas = append(summary.Deltas, EntourageDelta{
+				GuestID:   arrival.GuestID,
+				GuestName: arrival.GuestName,
+				Booked:    arrival.BookedPartySize,
+				Actual:    arrival.PartySize,
+				Delta:     arrival.PartySize - arrival.BookedPartySize,
+			})
+		}
+	}
+	summary.Delta = summary.Actual - summary.Booked
+
+	sort.SliceStable(summary.Deltas, func(i, j int) bool {
+		return abs32(summary.Deltas[i].Delta) > abs32(summary.Deltas[j].Delta)
+	})
+	return summary
+}
+
+func abs32(n int32) int32 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+func max32(n int32, others ...int32) int32 {
+	for _, other := range others {
+		if other > n {
+			n = other
+		}
+	}
+	return n
+}
@@ -0,0 +1,210 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/stretchr/testify/require"
+)
+
+var eventStart = time.Date(2022, 10, 20, 19, 0, 0, 0, time.UTC)
+
+func minutesIn(minutes int) time.Time {
+	return eventStart.Add(time.Duration(minutes) * time.Minute)
+}
+
+func TestBucketArrivals(t *testing.T) {
+	arrivals := []ListEventArrivalsRow{
+		{ArrivedAt: minutesIn(5), PartySize: 2},
+		{ArrivedAt: minutesIn(14), PartySize: 1},
+		{ArrivedAt: minutesIn(15), PartySize: 4},
+		{ArrivedAt: minutesIn(50), PartySize: 3},
+	}
+	window := EventWindow{From: minutesIn(5), Until: minutesIn(60)}
+
+	buckets := BucketArrivals(arrivals, window, 15*time.Minute)
+	require.Equal(t, []ArrivalBucket{
+		{Start: minutesIn(0), Arrivals: 2, People: 3},
+		{Start: minutesIn(15), Arrivals: 1, People: 4},
+		{Start: minutesIn(30)},
+		{Start: minutesIn(45), Arrivals: 1, People: 3},
+	}, buckets)
+
+	require.Empty(t, BucketArrivals(arrivals, EventWindow{From: minutesIn(60), Until: minutesIn(60)}, 15*time.Minute))
+}
+
+func TestOccupancySeries(t *testing.T) {
+	snapshots := []OccupancySnapshot{
+		{RecordedAt: minutesIn(-10), VenueOccupancy: 2},
+		{RecordedAt: minutesIn(10), VenueOccupancy: 6},
+		{RecordedAt: minutesIn(20), VenueOccupancy: 9},
+		{RecordedAt: minutesIn(30), VenueOccupancy: 9},
+		{RecordedAt: minutesIn(40), VenueOccupancy: 4},
+		{RecordedAt: minutesIn(70), VenueOccupancy: 12},
+	}
+	window := EventWindow{From: minutesIn(0), Until: minutesIn(60)}
+
+	series := OccupancySeries(snapshots, window)
+	require.Equal(t, []OccupancyPoint{
+		{At: minutesIn(0), Occupancy: 2},
+		{At: minutesIn(10), Occupancy: 6},
+		{At: minutesIn(20), Occupancy: 9},
+		{At: minutesIn(30), Occupancy: 9},
+		{At: minutesIn(40), Occupancy: 4},
+	}, series)
+
+	// The peak is when the venue first reached its most people
+	require.Equal(t, OccupancyPoint{At: minutesIn(20), Occupancy: 9}, PeakOccupancy(series))
+}
+
+func TestSummariseStays(t *testing.T) {
+	arrivals := []ListEventArrivalsRow{
+		{ArrivedAt: minutesIn(0), DepartedAt: at(minutesIn(60))},
+		{ArrivedAt: minutesIn(30), DepartedAt: at(minutesIn(150))},
+		{ArrivedAt: minutesIn(45)},
+	}
+
+	require.Equal(t, StaySummary{Completed: 2, Average: 90 * time.Minute}, SummariseStays(arrivals))
+	require.Equal(t, StaySummary{}, SummariseStays(arrivals[2:]))
+}
+
+func TestUtiliseTables(t *testing.T) {
+	tables := []Table{
+		{ID: 1, Label: "A", Size: 4},
+		{ID: 2, Label: "B", Size: 2},
+		{ID: 3, Label: "C", Size: 6},
+	}
+	table := func(id int32) sql.NullInt32 {
+		return sql.NullInt32{Int32: id, Valid: true}
+	}
+	snapshots := []OccupancySnapshot{
+		// Table 1 already had 2 people at it when the window opened
+		{TableID: table(1), TableOccupied: 2, RecordedAt: minutesIn(-30)},
+		{TableID: table(1), TableOccupied: 4, RecordedAt: minutesIn(30)},
+		{TableID: table(2), TableOccupied: 2, RecordedAt: minutesIn(15)},
+		{TableID: table(2), TableOccupied: 0, RecordedAt: minutesIn(45)},
+		{TableOccupied: 0, RecordedAt: minutesIn(20)},
+	}
+	arrivals := []ListEventArrivalsRow{
+		{TableID: 1, ArrivedAt: minutesIn(30)},
+		{TableID: 2, ArrivedAt: minutesIn(15)},
+	}
+	window := EventWindow{From: minutesIn(0), Until: minutesIn(60)}
+
+	utilisation := UtiliseTables(tables, snapshots, arrivals, window)
+	require.Len(t, utilisation, 3)
+
+	require.Equal(t, int64(1), utilisation[0].Arrivals)
+	require.Equal(t, int32(4), utilisation[0].PeakOccupied)
+	require.InDelta(t, 3, utilisation[0].SeatHours, 1e-9)
+	require.InDelta(t, 0.75, utilisation[0].Utilisation, 1e-9)
+
+	require.Equal(t, int64(1), utilisation[1].Arrivals)
+	require.Equal(t, int32(2), utilisation[1].PeakOccupied)
+	require.InDelta(t, 1, utilisation[1].SeatHours, 1e-9)
+	require.InDelta(t, 0.5, utilisation[1].Utilisation, 1e-9)
+
+	require.Equal(t, TableUtilisation{TableID: 3, Label: "C", Size: 6}, utilisation[2])
+}
+
+func TestSummariseNoShows(t *testing.T) {
+	counts := []CountGuestsByStatusRow{
+		{Status: GuestArrived, Guests: 5},
+		{Status: GuestConfirmed, Guests: 4},
+		{Status: GuestLeft, Guests: 3},
+		{Status: GuestNoShow, Guests: 2},
+	}
+
+	require.Equal(t, NoShowSummary{Expected: 10, NoShows: 2, Rate: 0.2}, SummariseNoShows(counts))
+	require.Equal(t, NoShowSummary{}, SummariseNoShows(nil))
+}
+
+func TestCompareEntourages(t *testing.T) {
+	arrivals := []ListEventArrivalsRow{
+		{GuestID: 1, GuestName: "Guest One", BookedPartySize: 2, PartySize: 2},
+		{GuestID: 2, GuestName: "Guest Two", BookedPartySize: 4, PartySize: 1},
+		{GuestID: 3, GuestName: "Guest Three", BookedPartySize: 1, PartySize: 3},
+		{GuestID: 4, GuestName: "Walk In", BookedPartySize: 2, PartySize: 5, WalkIn: true},
+		// Only the first arrival of a guest who came back counts
+		{GuestID: 3, GuestName: "Guest Three", BookedPartySize: 3, PartySize: 3},
+	}
+
+	summary := CompareEntourages(arrivals)
+	require.Equal(t, EntourageSummary{
+		Guests: 3,
+		Booked: 7,
+		Actual: 6,
+		Delta:  -1,
+		Deltas: []EntourageDelta{
+			{GuestID: 2, GuestName: "Guest Two", Booked: 4, Actual: 1, Delta: -3},
+			{GuestID: 3, GuestName: "Guest Three", Booked: 1, Actual: 3, Delta: 2},
+		},
+	}, summary)
+}
+
+func TestOccupancySnapshots(t *testing.T) {
+	store := NewStore(testDB)
+	audit := AuditInfo{Actor: util.DoorStaffRole, RequestID: util.RandomString(16)}
+
+	table, err := store.CreateTableTx(context.Background(), CreateTableTxParams{AuditInfo: audit, Size: 4})
+	require.NoError(t, err)
+
+	guest, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		Entourage: 1,
+		TableID:   table.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = store.AssignTableTx(context.Background(), AssignTableTxParams{
+		AuditInfo:    audit,
+		UserID:       int64(guest.ID),
+		TableID:      int64(table.ID),
+		NewEntourage: 2,
+	})
+	require.NoError(t, err)
+
+	// Every change to the seating records a snapshot of the table and venue
+	snapshots, err := store.ListOccupancySnapshots(context.Background(), ListOccupancySnapshotsParams{
+		From:  time.Now().Add(-time.Hour),
+		Until: time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, snapshots)
+	last := snapshots[len(snapshots)-1]
+	require.Equal(t, sql.NullInt32{Int32: table.ID, Valid: true}, last.TableID)
+	require.Equal(t, int32(3), last.TableOccupied)
+	require.GreaterOrEqual(t, last.VenueOccupancy, int32(3))
+
+	// Before a later window only the last snapshot of each table counts, the very last holding the
+	// occupancy of the venue as it opened
+	before, err := store.ListLastOccupancySnapshots(context.Background(), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, last, before[len(before)-1])
+	tables := make(map[sql.NullInt32]bool)
+	for _, snapshot := range before {
+		require.False(t, tables[snapshot.TableID])
+		tables[snapshot.TableID] = true
+	}
+
+	// The arrival keeps the party the guest was booked with
+	arrivals, err := store.ListEventArrivals(context.Background(), ListEventArrivalsParams{
+		From:  time.Now().Add(-time.Hour),
+		Until: time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+	var found bool
+	for _, arrival := range arrivals {
+		if arrival.GuestID == guest.ID {
+			found = true
+			require.Equal(t, int32(2), arrival.BookedPartySize)
+			require.Equal(t, int32(3), arrival.PartySize)
+			require.Equal(t, guest.GuestName, arrival.GuestName)
+		}
+	}
+	require.True(t, found)
+}
@@ -12,58 +12,66 @@ const createArrival = `-- name: CreateArrival :execresult
 INSERT INTO arrivals (
     guest_id,
     table_id,
-    party_size
+    party_size,
+    booked_party_size,
+    arrived_by
 ) VALUES (
-    ?, ?, ?
+    ?, ?, ?, ?, ?
 )
 `
 
 type CreateArrivalParams struct {
-	GuestID   int32 `json:"guest_id"`
-	TableID   int32 `json:"table_id"`
-	PartySize int32 `json:"party_size"`
+	GuestID         int32  `json:"guest_id"`
+	TableID         int32  `json:"table_id"`
+	PartySize       int32  `json:"party_size"`
+	BookedPartySize int32  `json:"booked_party_size"`
+	ArrivedBy       string `json:"arrived_by"`
 }
 
 func (q *Queries) CreateArrival(ctx context.Context, arg CreateArrivalParams) (sql.Result, error) {
-	return q.db.ExecContext(ctx, createArrival, arg.GuestID, arg.TableID, arg.PartySize)
+	return q.db.ExecContext(ctx, createArrival,
+		arg.GuestID,
+		arg.TableID,
+		arg.PartySize,
+		arg.BookedPartySize,
+		arg.ArrivedBy,
+	)
 }
 
-const getArrival = `-- name: GetArrival :one
-SELECT id, guest_id, table_id, party_size from arrivals
-WHERE id =? LIMIT 1
+const endArrival = `-- name: EndArrival :exec
+UPDATE arrivals
+SET departed_at = CURRENT_TIMESTAMP
+WHERE id = ?
 `
 
-func (q *Queries) GetArrival(ctx context.Context, id int32) (Arrival, error) {
-	row := q.db.QueryRowContext(ctx, getArrival, id)
-	var i Arrival
-	err := row.Scan(
-		&i.ID,
-		&i.GuestID,
-		&i.TableID,
-		&i.PartySize,
-	)
-	return i, err
+func (q *Queries) EndArrival(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, endArrival, id)
+	return err
 }
 
-const getArrivalFromGuest = `-- name: GetArrivalFromGuest :one
-SELECT id, guest_id, table_id, party_size from arrivals
-WHERE guest_id =?
+const getArrival = `-- name: GetArrival :one
+SELECT id, guest_id, table_id, party_size, arrived_by, arrived_at, departed_at, booked_party_size from arrivals
+WHERE id =? LIMIT 1
 `
 
-func (q *Queries) GetArrivalFromGuest(ctx context.Context, guestID int32) (Arrival, error) {
-	row := q.db.QueryRowContext(ctx, getArrivalFromGuest, guestID)
+func (q *Queries) GetArrival(ctx context.Context, id int32) (Arrival, error) {
+	row := q.db.QueryRowContext(ctx, getArrival, id)
 	var i Arrival
 	err := row.Scan(
 		&i.ID,
 		&i.GuestID,
 		&i.TableID,
 		&i.PartySize,
+		&i.ArrivedBy,
+		&i.ArrivedAt,
+		&i.DepartedAt,
+		&i.BookedPartySize,
 	)
 	return i, err
 }
 
 const getArrivals = `-- name: GetArrivals :many
-SELECT id, guest_id, table_id, party_size FROM arrivals
+SELECT id, guest_id, table_id, party_size, arrived_by, arrived_at, departed_at, booked_party_size FROM arrivals
 WHERE   
     guest_id = ? OR
     table_id = ?
@@ -98,6 +106,10 @@ func (q *Queries) GetArrivals(ctx context.Context, arg GetArrivalsParams) ([]Arr
 			&i.GuestID,
 			&i.TableID,
 			&i.PartySize,
+			&i.ArrivedBy,
+			&i.ArrivedAt,
+			&i.DepartedAt,
+			&i.BookedPartySize,
 		); err != nil {
 			return nil, err
 		}
@@ -111,3 +123,58 @@ func (q *Queries) GetArrivals(ctx context.Context, arg GetArrivalsParams) ([]Arr
 	}
 	return items, nil
 }
+
+const getOpenArrivalFromGuest = `-- name: GetOpenArrivalFromGuest :one
+SELECT id, guest_id, table_id, party_size, arrived_by, arrived_at, departed_at, booked_party_size FROM arrivals
+WHERE guest_id = ? AND departed_at IS NULL
+ORDER BY id DESC
+LIMIT 1
+`
+
+func (q *Queries) GetOpenArrivalFromGuest(ctx context.Context, guestID int32) (Arrival, error) {
+	row := q.db.QueryRowContext(ctx, getOpenArrivalFromGuest, guestID)
+	var i Arrival
+	err := row.Scan(
+		&i.ID,
+		&i.GuestID,
+		&i.TableID,
+		&i.PartySize,
+		&i.ArrivedBy,
+		&i.ArrivedAt,
+		&i.DepartedAt,
+		&i.BookedPartySize,
+	)
+	return i, err
+}
+
+const updateArrivalPartySize = `-- name: UpdateArrivalPartySize :exec
+UPDATE arrivals
+SET party_size = ?
+WHERE id = ?
+`
+
+type UpdateArrivalPartySizeParams struct {
+	PartySize int32 `json:"party_size"`
+	ID        int32 `json:"id"`
+}
+
+func (q *Queries) UpdateArrivalPartySize(ctx context.Context, arg UpdateArrivalPartySizeParams) error {
+	_, err := q.db.ExecContext(ctx, updateArrivalPartySize, arg.PartySize, arg.ID)
+	return err
+}
+
+const updateArrivalTable = `-- name: UpdateArrivalTable :exec
+UPDATE arrivals
+SET table_id = ?
+WHERE id = ?
+`
+
+type UpdateArrivalTableParams struct {
+	TableID int32 `json:"table_id"`
+	ID      int32 `json:"id"`
+}
+
+func (q *Queries) UpdateArrivalTable(ctx context.Context, arg UpdateArrivalTableParams) error {
+	_, err := q.db.ExecContext(ctx, updateArrivalTable, arg.TableID, arg.ID)
+	return err
+}
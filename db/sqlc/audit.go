@@ -0,0 +1,181 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+)
+
+// Actions recorded in audit_events
+const (
+	AuditCreateGuest = "create_guest"
+	AuditArriveGuest = "arrive_guest"
+	AuditLeaveGuest  = "leave_guest"
+	AuditDeleteGuest = "delete_guest"
+	AuditRSVPGuest   = "rsvp_guest"
+	AuditUpdateParty = "update_party"
+	AuditMoveGuest   = "move_guest"
+	AuditCreateTable = "create_table"
+	AuditUpdateTable = "update_table"
+
+	AuditUpdateGuestStatus   = "update_guest_status"
+	AuditUpdateGuestProfile  = "update_guest_profile"
+	AuditUpdateArrivalWindow = "update_arrival_window"
+	// AuditMarkNoShow is recorded when a guest is marked no-show for missing their arrival window
+	AuditMarkNoShow = "mark_no_show"
+
+	AuditIssueInvitation  = "issue_invitation"
+	AuditRevokeInvitation = "revoke_invitation"
+
+	AuditAddCompanion    = "add_companion"
+	AuditUpdateCompanion = "update_companion"
+	AuditRemoveCompanion = "remove_companion"
+
+	AuditUpdateCapacity = "update_capacity"
+	AuditAdmitStanding  = "admit_standing"
+	AuditDepartStanding = "depart_standing"
+
+	AuditUpdateAdmissionRules = "update_admission_rules"
+
+	AuditBanPerson   = "ban_person"
+	AuditUnbanPerson = "unban_person"
+	// AuditBanMatch is the alert raised when a banned person is caught being booked or arriving
+	AuditBanMatch = "ban_match"
+)
+
+// AuditInfo identifies who made a change and the request it was made in, every transaction
+// which mutates guests or tables takes one and records it alongside the change
+type AuditInfo struct {
+	Actor     string `json:"actor"`
+	RequestID string `json:"request_id"`
+}
+
+// guestState is the before/after snapshot recorded for guest events
+type guestState struct {
+	Guest      Guest       `json:"guest"`
+	Arrival    *Arrival    `json:"arrival,omitempty"`
+	Companions []Companion `json:"companions,omitempty"`
+	Seats      []Seat      `json:"seats,omitempty"`
+}
+
+// auditGuest records action against a guest, before or after is nil when the guest didn't
+// exist on that side of the change
+func (q *Queries) auditGuest(ctx context.Context, info AuditInfo, action string, guest Guest, before, after *guestState) error {
+	return q.audit(ctx, info, CreateAuditEventParams{
+		Action:    action,
+		GuestID:   sql.NullInt32{Int32: guest.ID, Valid: true},
+		GuestName: guest.GuestName,
+		TableID:   sql.NullInt32{Int32: guest.TableID, Valid: true},
+	}, before, after)
+}
+
+// auditTable records action against a table, before is nil when the table was created
+func (q *Queries) auditTable(ctx context.Context, info AuditInfo, action string, before *Table, after Table) error {
+	return q.audit(ctx, info, CreateAuditEventParams{
+		Action:  action,
+		TableID: sql.NullInt32{Int32: after.ID, Valid: true},
+	}, before, after)
+}
+
+// auditInvitation records action against one of the guest's invitations, before is nil when
+// the invitation was issued
+func (q *Queries) auditInvitation(ctx context.Context, info AuditInfo, action string, guest Guest, before, after *Invitation) error {
+	return q.audit(ctx, info, CreateAuditEventParams{
+		Action:    action,
+		GuestID:   sql.NullInt32{Int32: guest.ID, Valid: true},
+		GuestName: guest.GuestName,
+	}, before, after)
+}
+
+// auditCompanion records action against one of the guest's companions
+func (q *Queries) auditCompanion(ctx context.Context, info AuditInfo, action string, guest Guest, before, after *Companion) error {
+	return q.audit(ctx, info, CreateAuditEventParams{
+		Action:    action,
+		GuestID:   sql.NullInt32{Int32: guest.ID, Valid: true},
+		GuestName: guest.GuestName,
+	}, before, after)
+}
+
+// auditCapacity records a change to the maximum occupancy of the venue or a zone, before is nil
+// when the zone had no limit
+func (q *Queries) auditCapacity(ctx context.Context, info AuditInfo, before, after *CapacityLimit) error {
+	return q.audit(ctx, info, CreateAuditEventParams{Action: AuditUpdateCapacity}, before, after)
+}
+
+// auditStanding records action against a standing admission, before is nil when it was admitted
+func (q *Queries) auditStanding(ctx context.Context, info AuditInfo, action string, before, after *StandingAdmission) error {
+	return q.audit(ctx, info, CreateAuditEventParams{
+		Action:    action,
+		GuestName: after.Name,
+	}, before, after)
+}
+
+// auditAdmissionRules records a new version of the admission rules, before is nil when none had
+// been uploaded
+func (q *Queries) auditAdmissionRules(ctx context.Context, info AuditInfo, before, after *AdmissionRuleSet) error {
+	return q.audit(ctx, info, CreateAuditEventParams{Action: AuditUpdateAdmissionRules}, before, after)
+}
+
+// auditBan records action against a person on the ban list, before is nil when they were banned
+// and after when they were unbanned
+func (q *Queries) auditBan(ctx context.Context, info AuditInfo, action string, before, after *BannedPerson) error {
+	name := ""
+	if after != nil {
+		name = after.Name
+	} else if before != nil {
+		name = before.Name
+	}
+	return q.audit(ctx, info, CreateAuditEventParams{Action: action, GuestName: name}, before, after)
+}
+
+func (q *Queries) audit(ctx context.Context, info AuditInfo, arg CreateAuditEventParams, before, after interface{}) error {
+	var err error
+	arg.Actor = info.Actor
+	arg.RequestID = info.RequestID
+	if arg.Before, err = marshalState(before); err != nil {
+		return err
+	}
+	if arg.After, err = marshalState(after); err != nil {
+		return err
+	}
+	return q.CreateAuditEvent(ctx, arg)
+}
+
+// marshalState encodes a snapshot, leaving nil pointers as SQL NULL rather than JSON null
+func marshalState(state interface{}) (json.RawMessage, error) {
+	switch s := state.(type) {
+	case *guestState:
+		if s == nil {
+			return nil, nil
+		}
+	case *Table:
+		if s == nil {
+			return nil, nil
+		}
+	case *Invitation:
+		if s == nil {
+			return nil, nil
+		}
+	case *Companion:
+		if s == nil {
+			return nil, nil
+		}
+	case *CapacityLimit:
+		if s == nil {
+			return nil, nil
+		}
+	case *StandingAdmission:
+		if s == nil {
+			return nil, nil
+		}
+	case *AdmissionRuleSet:
+		if s == nil {
+			return nil, nil
+		}
+	case *BannedPerson:
+		if s == nil {
+			return nil, nil
+		}
+	}
+	return json.Marshal(state)
+}
@@ -0,0 +1,78 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+
+	"github.com/stretchr/testify/require"
+)
+
+func createRandomAuditEvent(t *testing.T, actor string) CreateAuditEventParams {
+	arg := CreateAuditEventParams{
+		Action:    AuditCreateGuest,
+		Actor:     actor,
+		RequestID: util.RandomString(16),
+		GuestID:   sql.NullInt32{Int32: util.RandomInt(1, 1000), Valid: true},
+		GuestName: util.RandomGuestName(),
+		TableID:   sql.NullInt32{Int32: util.RandomInt(1, 1000), Valid: true},
+		After:     json.RawMessage(`{"guest":{"id":1}}`),
+	}
+
+	err := testQueries.CreateAuditEvent(context.Background(), arg)
+	require.NoError(t, err)
+	return arg
+}
+
+func TestCreateAuditEvent(t *testing.T) {
+	arg := createRandomAuditEvent(t, util.RandomString(8))
+
+	events, err := testQueries.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		GuestName: sql.NullString{String: arg.GuestName, Valid: true},
+		Limit:     5,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+
+	event := events[0]
+	require.NotZero(t, event.ID)
+	require.Equal(t, arg.Action, event.Action)
+	require.Equal(t, arg.Actor, event.Actor)
+	require.Equal(t, arg.RequestID, event.RequestID)
+	require.Equal(t, arg.GuestID, event.GuestID)
+	require.Equal(t, arg.TableID, event.TableID)
+	require.Nil(t, event.Before)
+	require.JSONEq(t, string(arg.After), string(event.After))
+	require.WithinDuration(t, time.Now(), event.CreatedAt, time.Minute)
+}
+
+func TestListAuditEvents(t *testing.T) {
+	actor := util.RandomString(8)
+	n := 3
+	for i := 0; i < n; i++ {
+		createRandomAuditEvent(t, actor)
+	}
+
+	arg := ListAuditEventsParams{
+		Actor:  sql.NullString{String: actor, Valid: true},
+		From:   sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true},
+		Limit:  5,
+		Offset: 0,
+	}
+	events, err := testQueries.ListAuditEvents(context.Background(), arg)
+	require.NoError(t, err)
+	require.Len(t, events, n)
+	for i := 1; i < n; i++ {
+		require.Less(t, events[i-1].ID, events[i].ID)
+	}
+
+	// Nothing has happened since the end of the time range
+	arg.To = sql.NullTime{Time: time.Now().Add(-time.Hour), Valid: true}
+	events, err = testQueries.ListAuditEvents(context.Background(), arg)
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
@@ -0,0 +1,184 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// How a guest matched a banned person, strongest first
+const (
+	BanMatchEmail = "email"
+	BanMatchPhone = "phone"
+	BanMatchName  = "name"
+	BanMatchFuzzy = "fuzzy_name"
+)
+
+// Where a banned person was caught
+const (
+	BanStageBooking = "booking"
+	BanStageArrival = "arrival"
+)
+
+// minBanPhoneDigits stops a phone number too short to identify anybody, e.g. an extension, from
+// matching
+const minBanPhoneDigits = 6
+
+// NormalizeName folds a name so spellings of it compare equal: accents are stripped, letters
+// lower-cased, apostrophes and full stops dropped and the words sorted, so "Seán O'Brien" and
+// "obrien, sean" are both "obrien sean"
+func NormalizeName(name string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(name) {
+		switch {
+		case unicode.Is(unicode.Mn, r), r == '\'', r == '’', r == '.':
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(' ')
+		}
+	}
+	words := strings.Fields(b.String())
+	sort.Strings(words)
+	return strings.Join(words, " ")
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// normalizePhone keeps only the digits of a phone number
+func normalizePhone(phone string) string {
+	var b strings.Builder
+	for _, r := range phone {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// maxNameDistance is how many letters two normalised names of this length may differ by and
+// still be taken for the same person, none for short names which a typo could turn into another
+func maxNameDistance(name string) int {
+	switch n := len([]rune(name)); {
+	case n < 8:
+		return 0
+	case n < 14:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// levenshtein is the number of single letter insertions, deletions and substitutions turning a
+// into b
+func levenshtein(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	prev := make([]int, len(t)+1)
+	curr := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		curr[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(t)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// MatchBan returns the banned person a guest with the name, email and phone matches and how:
+// by email or phone, either only when both sides have one, by name once normalised, or failing
+// those by the closest name within a letter or two
+func MatchBan(bans []BannedPerson, name, email, phone string) (BannedPerson, string, bool) {
+	email, phone, name = normalizeEmail(email), normalizePhone(phone), NormalizeName(name)
+
+	for _, ban := range bans {
+		if email != "" && normalizeEmail(ban.Email) == email {
+			return ban, BanMatchEmail, true
+		}
+		if len(phone) >= minBanPhoneDigits && normalizePhone(ban.Phone) == phone {
+			return ban, BanMatchPhone, true
+		}
+	}
+	if name == "" {
+		return BannedPerson{}, "", false
+	}
+
+	var closest BannedPerson
+	best := -1
+	for _, ban := range bans {
+		banned := NormalizeName(ban.Name)
+		if banned == name {
+			return ban, BanMatchName, true
+		}
+		if d := levenshtein(banned, name); d <= maxNameDistance(banned) && (best < 0 || d < best) {
+			closest, best = ban, d
+		}
+	}
+	if best < 0 {
+		return BannedPerson{}, "", false
+	}
+	return closest, BanMatchFuzzy, true
+}
+
+// checkBans returns a *BannedError if a guest with the name, email and phone is on the ban list
+func (q *Queries) checkBans(ctx context.Context, name, email, phone string) error {
+	bans, err := q.ListAllBannedPeople(ctx)
+	if err != nil {
+		return err
+	}
+	if person, match, ok := MatchBan(bans, name, email, phone); ok {
+		return &BannedError{GuestName: name, Person: person, Match: match}
+	}
+	return nil
+}
+
+// banMatch is the state recorded by the alert raised when a banned person is caught
+type banMatch struct {
+	Stage  string       `json:"stage"`
+	Match  string       `json:"match"`
+	Banned BannedPerson `json:"banned"`
+}
+
+// alertBanMatch raises an alert for the banned person caught at stage once the transaction which
+// caught them has been rolled back, returning err unless the alert can't be recorded. guest has
+// no ID when they were caught being booked.
+func (store *SQLStore) alertBanMatch(ctx context.Context, info AuditInfo, guest Guest, stage string, err error) error {
+	var banned *BannedError
+	if !errors.As(err, &banned) {
+		return err
+	}
+	alert := CreateAuditEventParams{Action: AuditBanMatch, GuestName: banned.GuestName}
+	if guest.ID != 0 {
+		alert.GuestID = sql.NullInt32{Int32: guest.ID, Valid: true}
+		alert.TableID = sql.NullInt32{Int32: guest.TableID, Valid: true}
+	}
+	state := &banMatch{Stage: stage, Match: banned.Match, Banned: banned.Person}
+	if alertErr := store.audit(ctx, info, alert, nil, state); alertErr != nil {
+		return alertErr
+	}
+	return err
+}
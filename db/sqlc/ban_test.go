@@ -0,0 +1,131 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeName(t *testing.T) {
+	require.Equal(t, "obrien sean", NormalizeName("Seán O'Brien"))
+	require.Equal(t, "obrien sean", NormalizeName("  O’BRIEN,  sean "))
+	require.Equal(t, "anne jean smith", NormalizeName("Jean-Anne Smith"))
+	require.Equal(t, "", NormalizeName(" - "))
+}
+
+func TestMatchBan(t *testing.T) {
+	bans := []BannedPerson{
+		{ID: 1, Name: "Seán O'Brien"},
+		{ID: 2, Name: "Ann Lee", Email: "Trouble@Example.com"},
+		{ID: 3, Name: "Maximilian Featherstonehaugh", Phone: "+44 (0)7700 900123"},
+	}
+
+	testCases := []struct {
+		name  string
+		guest [3]string
+		id    int32
+		match string
+	}{
+		{"ExactName", [3]string{"sean obrien", "", ""}, 1, BanMatchName},
+		{"ReorderedName", [3]string{"O'Brien, Sean", "", ""}, 1, BanMatchName},
+		{"Typo", [3]string{"Sean OBrian", "", ""}, 1, BanMatchFuzzy},
+		{"TwoTyposInLongName", [3]string{"Maximillian Featherstonhaugh", "", ""}, 3, BanMatchFuzzy},
+		{"Email", [3]string{"Someone Else", "trouble@example.com ", ""}, 2, BanMatchEmail},
+		{"Phone", [3]string{"Someone Else", "", "+44 07700 900123"}, 3, BanMatchPhone},
+		{"ShortNameNeedsExactMatch", [3]string{"Ann Lea", "", ""}, 0, ""},
+		{"TooFarOff", [3]string{"Shaun O'Brine", "", ""}, 0, ""},
+		{"ShortPhone", [3]string{"Someone Else", "", "123"}, 0, ""},
+		{"NoIdentifiersMatchNothing", [3]string{"Someone Else", "", ""}, 0, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			person, match, ok := MatchBan(bans, tc.guest[0], tc.guest[1], tc.guest[2])
+			require.Equal(t, tc.id != 0, ok)
+			require.Equal(t, tc.id, person.ID)
+			require.Equal(t, tc.match, match)
+		})
+	}
+}
+
+func TestBanList(t *testing.T) {
+	store := NewStore(testDB)
+	audit := AuditInfo{Actor: util.OrganiserRole, RequestID: util.RandomString(16)}
+	table := createRandomTable(t)
+
+	guest, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		TableID:   table.ID,
+	})
+	require.NoError(t, err)
+
+	// Banned by a unique email so the ban can't catch anybody else's guests
+	email := fmt.Sprintf("%s@example.com", util.RandomString(12))
+	person, err := store.BanPersonTx(context.Background(), BanPersonTxParams{
+		AuditInfo: audit,
+		Name:      util.RandomString(20),
+		Email:     email,
+		Reason:    "Fought the bouncers",
+	})
+	require.NoError(t, err)
+	require.Equal(t, audit.Actor, person.CreatedBy)
+	defer func() {
+		require.NoError(t, store.UnbanPersonTx(context.Background(), UnbanPersonTxParams{AuditInfo: audit, ID: person.ID}))
+		_, err := store.GetBannedPerson(context.Background(), person.ID)
+		require.ErrorIs(t, err, sql.ErrNoRows)
+	}()
+
+	name := util.RandomGuestName()
+	_, err = store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo:    audit,
+		GuestProfile: GuestProfile{Email: email},
+		GuestName:    name,
+		TableID:      table.ID,
+	})
+	var banned *BannedError
+	require.ErrorAs(t, err, &banned)
+	require.Equal(t, person.ID, banned.Person.ID)
+	require.Equal(t, BanMatchEmail, banned.Match)
+
+	_, err = store.GetGuestFromName(context.Background(), name)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// A guest booked before the ban is caught at the door
+	_, err = store.UpdateGuestProfileTx(context.Background(), UpdateGuestProfileTxParams{
+		AuditInfo: audit,
+		ID:        guest.ID,
+		Email:     sql.NullString{String: email, Valid: true},
+	})
+	require.NoError(t, err)
+	_, err = store.AssignTableTx(context.Background(), AssignTableTxParams{
+		AuditInfo:    audit,
+		UserID:       int64(guest.ID),
+		NewEntourage: 0,
+		TableID:      int64(table.ID),
+	})
+	require.ErrorIs(t, err, ErrBanned)
+
+	// Both are alerted on even though their transactions were rolled back
+	for guestName, stage := range map[string]string{name: BanStageBooking, guest.GuestName: BanStageArrival} {
+		events, err := store.ListAuditEvents(context.Background(), ListAuditEventsParams{
+			GuestName: sql.NullString{String: guestName, Valid: true},
+			Limit:     100,
+		})
+		require.NoError(t, err)
+
+		alert := events[len(events)-1]
+		require.Equal(t, AuditBanMatch, alert.Action)
+		require.Equal(t, audit.RequestID, alert.RequestID)
+
+		var state banMatch
+		require.NoError(t, json.Unmarshal(alert.After, &state))
+		require.Equal(t, stage, state.Stage)
+		require.Equal(t, person.ID, state.Banned.ID)
+	}
+}
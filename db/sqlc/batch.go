@@ -0,0 +1,206 @@
+package db
+
+import (
+	"context"
+	"strings"
+)
+
+// The sqlc MySQL engine cannot expand a slice into an IN (...) list, so the batched
+// lookups used to resolve nested data (e.g. every guest at a page of tables) are
+// written by hand below, following the same scanning conventions as the generated code.
+
+const getTablesByIDs = `SELECT id, size, occupied, created_at, created_by, label, zone, accessible, shape, min_party, max_party, expected_from, expected_until FROM tables
+WHERE id IN (%s)
+ORDER BY id`
+
+// GetTablesByIDs fetches every table whose id is in ids with a single query
+func (q *Queries) GetTablesByIDs(ctx context.Context, ids []int32) ([]Table, error) {
+	items := []Table{}
+	if len(ids) == 0 {
+		return items, nil
+	}
+
+	rows, err := q.db.QueryContext(ctx, expandIn(getTablesByIDs, len(ids)), int32Args(ids)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i Table
+		if err := rows.Scan(
+			&i.ID,
+			&i.Size,
+			&i.Occupied,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.Label,
+			&i.Zone,
+			&i.Accessible,
+			&i.Shape,
+			&i.MinParty,
+			&i.MaxParty,
+			&i.ExpectedFrom,
+			&i.ExpectedUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getGuestsByTableIDs = `SELECT id, guest_name, entourage, table_id, arrival_time, created_at, created_by, rsvp_status, rsvp_at, status, dietary, accessibility, vip_tier, email, phone, notes, expected_from, expected_until, walk_in FROM guests
+WHERE table_id IN (%s)
+ORDER BY table_id, id`
+
+// GetGuestsByTableIDs fetches every guest booked at any of the given tables with a single query
+func (q *Queries) GetGuestsByTableIDs(ctx context.Context, tableIDs []int32) ([]Guest, error) {
+	items := []Guest{}
+	if len(tableIDs) == 0 {
+		return items, nil
+	}
+
+	rows, err := q.db.QueryContext(ctx, expandIn(getGuestsByTableIDs, len(tableIDs)), int32Args(tableIDs)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i Guest
+		if err := rows.Scan(
+			&i.ID,
+			&i.GuestName,
+			&i.Entourage,
+			&i.TableID,
+			&i.ArrivalTime,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.RsvpStatus,
+			&i.RsvpAt,
+			&i.Status,
+			&i.Dietary,
+			&i.Accessibility,
+			&i.VipTier,
+			&i.Email,
+			&i.Phone,
+			&i.Notes,
+			&i.ExpectedFrom,
+			&i.ExpectedUntil,
+			&i.WalkIn,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOpenArrivalsByGuestIDs = `SELECT id, guest_id, table_id, party_size, arrived_by, arrived_at, departed_at, booked_party_size FROM arrivals
+WHERE guest_id IN (%s) AND departed_at IS NULL
+ORDER BY guest_id, id`
+
+// GetOpenArrivalsByGuestIDs fetches the open arrival sessions of every given guest with a single query
+func (q *Queries) GetOpenArrivalsByGuestIDs(ctx context.Context, guestIDs []int32) ([]Arrival, error) {
+	items := []Arrival{}
+	if len(guestIDs) == 0 {
+		return items, nil
+	}
+
+	rows, err := q.db.QueryContext(ctx, expandIn(getOpenArrivalsByGuestIDs, len(guestIDs)), int32Args(guestIDs)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i Arrival
+		if err := rows.Scan(
+			&i.ID,
+			&i.GuestID,
+			&i.TableID,
+			&i.PartySize,
+			&i.ArrivedBy,
+			&i.ArrivedAt,
+			&i.DepartedAt,
+			&i.BookedPartySize,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEmptySeatsByTableIDs = `SELECT t.id, GREATEST(
+    (SELECT COUNT(*) FROM seats s WHERE s.table_id = t.id AND s.guest_id IS NULL)
+    - (SELECT IFNULL(SUM(GREATEST(g.entourage + 1 - (SELECT COUNT(*) FROM seats h WHERE h.guest_id = g.id), 0)), 0)
+        FROM guests g WHERE g.table_id = t.id AND g.status IN ('invited', 'confirmed')),
+    0) AS seats_empty
+FROM tables t
+WHERE t.id IN (%s)
+ORDER BY t.id`
+
+// TableEmptySeats is the number of empty seats at a table, counted as GetEmptySeats does
+type TableEmptySeats struct {
+	TableID    int32 `json:"table_id"`
+	SeatsEmpty int32 `json:"seats_empty"`
+}
+
+// GetEmptySeatsByTableIDs counts the empty seats at every given table with a single query
+func (q *Queries) GetEmptySeatsByTableIDs(ctx context.Context, tableIDs []int32) ([]TableEmptySeats, error) {
+	items := []TableEmptySeats{}
+	if len(tableIDs) == 0 {
+		return items, nil
+	}
+
+	rows, err := q.db.QueryContext(ctx, expandIn(getEmptySeatsByTableIDs, len(tableIDs)), int32Args(tableIDs)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i TableEmptySeats
+		if err := rows.Scan(&i.TableID, &i.SeatsEmpty); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// expandIn substitutes n comma separated placeholders into the IN (%s) of query
+func expandIn(query string, n int) string {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+	return strings.Replace(query, "%s", placeholders, 1)
+}
+
+func int32Args(ids []int32) []interface{} {
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	return args
+}
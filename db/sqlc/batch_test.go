@@ -0,0 +1,66 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTablesByIDs(t *testing.T) {
+	table1 := createRandomTable(t)
+	table2 := createRandomTable(t)
+
+	tables, err := testQueries.GetTablesByIDs(context.Background(), []int32{table2.ID, table1.ID})
+	require.NoError(t, err)
+	require.Len(t, tables, 2)
+	require.Equal(t, table1.ID, tables[0].ID)
+	require.Equal(t, table2.ID, tables[1].ID)
+
+	tables, err = testQueries.GetTablesByIDs(context.Background(), nil)
+	require.NoError(t, err)
+	require.Empty(t, tables)
+}
+
+func TestGetGuestsByTableIDs(t *testing.T) {
+	table1 := createRandomTable(t)
+	table2 := createRandomTable(t)
+
+	guest1 := createRandomGuest(t, table1.ID)
+	guest2 := createRandomGuest(t, table2.ID)
+	guest3 := createRandomGuest(t, table2.ID)
+
+	guests, err := testQueries.GetGuestsByTableIDs(context.Background(), []int32{table1.ID, table2.ID})
+	require.NoError(t, err)
+	require.Len(t, guests, 3)
+	require.Equal(t, guest1.ID, guests[0].ID)
+	require.Equal(t, guest2.ID, guests[1].ID)
+	require.Equal(t, guest3.ID, guests[2].ID)
+}
+
+func TestGetOpenArrivalsByGuestIDs(t *testing.T) {
+	table := createRandomTable(t)
+	guest1 := createRandomGuest(t, table.ID)
+	guest2 := createRandomGuest(t, table.ID)
+
+	arrivals := make([]Arrival, 2)
+	for i, guest := range []Guest{guest1, guest2} {
+		arrivalSQL, err := testQueries.CreateArrival(context.Background(), CreateArrivalParams{
+			GuestID:   guest.ID,
+			TableID:   table.ID,
+			PartySize: guest.Entourage + 1,
+		})
+		require.NoError(t, err)
+		arrivals[i], err = testQueries.getArrivalFromSQLQuery(arrivalSQL)
+		require.NoError(t, err)
+	}
+
+	// The second guest has left, only their open session is fetched
+	require.NoError(t, testQueries.EndArrival(context.Background(), arrivals[1].ID))
+
+	open, err := testQueries.GetOpenArrivalsByGuestIDs(context.Background(), []int32{guest1.ID, guest2.ID})
+	require.NoError(t, err)
+	require.Len(t, open, 1)
+	require.Equal(t, arrivals[0].ID, open[0].ID)
+	require.False(t, open[0].DepartedAt.Valid)
+}
@@ -0,0 +1,229 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInsufficientTableSize matches (via errors.Is) every error created by InsufficientTableSizeErr
+var ErrInsufficientTableSize = errors.New("insufficient table space")
+
+// ErrGuestAlreadyArrived is returned when an arrival is attempted for a guest who already has one
+var ErrGuestAlreadyArrived = errors.New("An arrival has already been made for this guest")
+
+// ErrGuestNotArrived is returned when the party of a guest who isn't at the party is changed
+var ErrGuestNotArrived = errors.New("guest has not arrived")
+
+// ErrAlreadyAtTable is returned when a guest is moved to the table they're already at
+var ErrAlreadyAtTable = errors.New("the guest is already at this table")
+
+// ErrReentryDenied is returned when a guest who has left tries to arrive again at an event which
+// doesn't allow re-entry
+var ErrReentryDenied = errors.New("re-entry is not allowed at this event")
+
+// ErrCompanionLimit is returned when a companion is added to a guest whose whole entourage has
+// already been named
+var ErrCompanionLimit = errors.New("the guest's whole entourage has already been named")
+
+// ErrInvalidCompanions matches (via errors.Is) the errors returned when the companions arriving with
+// a guest aren't distinct companions of theirs, or don't match the size of the party
+var ErrInvalidCompanions = errors.New("invalid arriving companions")
+
+// ErrInvitationRevoked is returned when a revoked invitation is scanned
+var ErrInvitationRevoked = errors.New("invitation has been revoked")
+
+// ErrInvitationUsed is returned when an invitation which has already been used to arrive is scanned
+var ErrInvitationUsed = errors.New("invitation has already been used")
+
+// ErrTableLabelTaken is returned when a table is given a label another table already has
+var ErrTableLabelTaken = errors.New("another table already has this label")
+
+// ErrInvalidArrivalWindow is returned when a guest or table is expected to arrive in a window which
+// closes before it opens
+var ErrInvalidArrivalWindow = errors.New("expected_from must be before expected_until")
+
+// ErrNoTableAvailable is returned when a walk-in party is to be seated at whichever table fits them
+// best but no table has room for them
+var ErrNoTableAvailable = errors.New("no table has room for the party")
+
+// ErrGuestOnList is returned when a walk-in is given the name of a guest already on the guest list,
+// who should be arrived instead
+var ErrGuestOnList = errors.New("the guest is already on the guest list")
+
+// ErrInvalidPartyRange is returned when a table is created whose minimum party is bigger than its
+// maximum, or whose maximum party is bigger than the table
+var ErrInvalidPartyRange = errors.New("min_party must be at most max_party, which must be at most the table size")
+
+// ErrPartySize matches (via errors.Is) every *PartySizeError
+var ErrPartySize = errors.New("party size not allowed at table")
+
+// PartySizeError is returned when a party is seated at a table which only takes smaller or
+// bigger parties
+type PartySizeError struct {
+	TableID   int32
+	PartySize int32
+	MinParty  int32
+	MaxParty  int32
+}
+
+func (e *PartySizeError) Error() string {
+	return fmt.Sprintf("Table %d takes parties of %d to %d, not %d", e.TableID, e.MinParty, e.MaxParty, e.PartySize)
+}
+
+func (e *PartySizeError) Is(target error) bool {
+	return target == ErrPartySize
+}
+
+// ErrInvalidSeats is wrapped by the error returned when the seats requested for a party aren't
+// one distinct seat of their table for each of them
+var ErrInvalidSeats = errors.New("invalid seats")
+
+// ErrSeatTaken matches (via errors.Is) every *SeatTakenError
+var ErrSeatTaken = errors.New("the seat is held by another guest")
+
+// SeatTakenError is returned when a seat requested for a party is held by another guest
+type SeatTakenError struct {
+	TableID int32
+	Number  int32
+}
+
+func (e *SeatTakenError) Error() string {
+	return fmt.Sprintf("seat %d at table %d is held by another guest", e.Number, e.TableID)
+}
+
+func (e *SeatTakenError) Is(target error) bool {
+	return target == ErrSeatTaken
+}
+
+// ErrVenueFull matches (via errors.Is) a *CapacityError for the whole venue
+var ErrVenueFull = errors.New("the venue is at its maximum occupancy")
+
+// ErrZoneFull matches (via errors.Is) a *CapacityError for one zone of the venue
+var ErrZoneFull = errors.New("the zone is at its maximum occupancy")
+
+// CapacityError is returned when admitting a party would take the venue, or the zone (when Zone
+// isn't empty) their table is in, over its maximum occupancy even though the table has space
+type CapacityError struct {
+	Zone         string
+	MaxOccupancy int32
+	Occupancy    int64
+	PartySize    int32
+}
+
+func (e *CapacityError) Error() string {
+	if e.Zone == "" {
+		return fmt.Sprintf("the venue holds %d of its maximum occupancy of %d, a party of %d cannot be admitted", e.Occupancy, e.MaxOccupancy, e.PartySize)
+	}
+	return fmt.Sprintf("zone %s holds %d of its maximum occupancy of %d, a party of %d cannot be admitted", e.Zone, e.Occupancy, e.MaxOccupancy, e.PartySize)
+}
+
+func (e *CapacityError) Is(target error) bool {
+	if e.Zone == "" {
+		return target == ErrVenueFull
+	}
+	return target == ErrZoneFull
+}
+
+// ErrAdmissionDenied matches (via errors.Is) every *AdmissionDeniedError
+var ErrAdmissionDenied = errors.New("admission denied")
+
+// AdmissionDeniedError is returned when one of the event's admission policies turns an arrival
+// away. Decisions holds the verdict of every policy so the door can explain why. A party the
+// table_capacity policy turns away also matches ErrInsufficientTableSize.
+type AdmissionDeniedError struct {
+	Decisions []AdmissionDecision
+}
+
+func (e *AdmissionDeniedError) Error() string {
+	var reasons []string
+	for _, decision := range e.Decisions {
+		if !decision.Allowed {
+			reasons = append(reasons, decision.Reason)
+		}
+	}
+	return "admission denied: " + strings.Join(reasons, "; ")
+}
+
+func (e *AdmissionDeniedError) Is(target error) bool {
+	if target == ErrInsufficientTableSize {
+		for _, decision := range e.Decisions {
+			if decision.Policy == PolicyTableCapacity && !decision.Allowed {
+				return true
+			}
+		}
+	}
+	return target == ErrAdmissionDenied
+}
+
+// TableCapacityOnly reports whether the table_capacity policy was the only one to turn the party
+// away, i.e. the party would have been admitted had they fit at their table
+func (e *AdmissionDeniedError) TableCapacityOnly() bool {
+	only := false
+	for _, decision := range e.Decisions {
+		if decision.Allowed {
+			continue
+		}
+		if decision.Policy != PolicyTableCapacity {
+			return false
+		}
+		only = true
+	}
+	return only
+}
+
+// ErrStandingDeparted is returned when a standing admission which has already left is ended again
+var ErrStandingDeparted = errors.New("the standing admission has already left")
+
+// ErrIllegalTransition matches (via errors.Is) every *TransitionError
+var ErrIllegalTransition = errors.New("illegal guest status transition")
+
+// TransitionError is returned when a guest is asked to move to a status they can't reach from
+// their current one
+type TransitionError struct {
+	From string
+	To   string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("a guest who is %s cannot become %s", e.From, e.To)
+}
+
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrIllegalTransition
+}
+
+type insufficientTableSizeError struct {
+	tableID int
+}
+
+func (e insufficientTableSizeError) Error() string {
+	return fmt.Sprintf("Table %d has insufficient space", e.tableID)
+}
+
+func (e insufficientTableSizeError) Is(target error) bool {
+	return target == ErrInsufficientTableSize
+}
+
+func InsufficientTableSizeErr(tableID int) error {
+	return insufficientTableSizeError{tableID: tableID}
+}
+
+// ErrBanned matches (via errors.Is) every *BannedError
+var ErrBanned = errors.New("the person is on the ban list")
+
+// BannedError is returned when a guest being booked or arriving matches a person on the ban list.
+// Match is how they matched, one of the BanMatch constants.
+type BannedError struct {
+	GuestName string
+	Person    BannedPerson
+	Match     string
+}
+
+func (e *BannedError) Error() string {
+	return fmt.Sprintf("%s matches banned person %d by %s", e.GuestName, e.Person.ID, strings.ReplaceAll(e.Match, "_", " "))
+}
+
+func (e *BannedError) Is(target error) bool {
+	return target == ErrBanned
+}
@@ -6,6 +6,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 const createGuest = `-- name: CreateGuest :execresult
@@ -13,17 +14,37 @@ INSERT INTO guests(
     guest_name,
     entourage,
     table_id,
-    arrival_time
+    arrival_time,
+    created_by,
+    dietary,
+    accessibility,
+    vip_tier,
+    email,
+    phone,
+    notes,
+    expected_from,
+    expected_until,
+    walk_in
 ) VALUES (
-    ?, ?, ?, ?
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 )
 `
 
 type CreateGuestParams struct {
-	GuestName   string       `json:"guest_name"`
-	Entourage   int32        `json:"entourage"`
-	TableID     int32        `json:"table_id"`
-	ArrivalTime sql.NullTime `json:"arrival_time"`
+	GuestName     string       `json:"guest_name"`
+	Entourage     int32        `json:"entourage"`
+	TableID       int32        `json:"table_id"`
+	ArrivalTime   sql.NullTime `json:"arrival_time"`
+	CreatedBy     string       `json:"created_by"`
+	Dietary       string       `json:"dietary"`
+	Accessibility string       `json:"accessibility"`
+	VipTier       string       `json:"vip_tier"`
+	Email         string       `json:"email"`
+	Phone         string       `json:"phone"`
+	Notes         string       `json:"notes"`
+	ExpectedFrom  sql.NullTime `json:"expected_from"`
+	ExpectedUntil sql.NullTime `json:"expected_until"`
+	WalkIn        bool         `json:"walk_in"`
 }
 
 func (q *Queries) CreateGuest(ctx context.Context, arg CreateGuestParams) (sql.Result, error) {
@@ -32,6 +53,16 @@ func (q *Queries) CreateGuest(ctx context.Context, arg CreateGuestParams) (sql.R
 		arg.Entourage,
 		arg.TableID,
 		arg.ArrivalTime,
+		arg.CreatedBy,
+		arg.Dietary,
+		arg.Accessibility,
+		arg.VipTier,
+		arg.Email,
+		arg.Phone,
+		arg.Notes,
+		arg.ExpectedFrom,
+		arg.ExpectedUntil,
+		arg.WalkIn,
 	)
 }
 
@@ -46,10 +77,8 @@ func (q *Queries) DeleteGuest(ctx context.Context, id int32) error {
 }
 
 const getArrivedGuests = `-- name: GetArrivedGuests :many
-SELECT id, guest_name, entourage, table_id, arrival_time, created_at FROM guests
-WHERE id IN (
-    SELECT guest_id FROM arrivals
-)
+SELECT id, guest_name, entourage, table_id, arrival_time, created_at, created_by, rsvp_status, rsvp_at, status, dietary, accessibility, vip_tier, email, phone, notes, expected_from, expected_until, walk_in FROM guests
+WHERE status = 'arrived'
 ORDER BY arrival_time
 LIMIT ?
 OFFSET ?
@@ -76,6 +105,19 @@ func (q *Queries) GetArrivedGuests(ctx context.Context, arg GetArrivedGuestsPara
 			&i.TableID,
 			&i.ArrivalTime,
 			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.RsvpStatus,
+			&i.RsvpAt,
+			&i.Status,
+			&i.Dietary,
+			&i.Accessibility,
+			&i.VipTier,
+			&i.Email,
+			&i.Phone,
+			&i.Notes,
+			&i.ExpectedFrom,
+			&i.ExpectedUntil,
+			&i.WalkIn,
 		); err != nil {
 			return nil, err
 		}
@@ -91,7 +133,12 @@ func (q *Queries) GetArrivedGuests(ctx context.Context, arg GetArrivedGuestsPara
 }
 
 const getEmptySeats = `-- name: GetEmptySeats :one
-SELECT (SELECT IFNULL(SUM(size), 0) from tables) - (SELECT IFNULL(SUM(occupied), 0) from tables) AS seats_empty
+SELECT IFNULL(SUM(GREATEST(
+    (SELECT COUNT(*) FROM seats s WHERE s.table_id = t.id AND s.guest_id IS NULL)
+    - (SELECT IFNULL(SUM(GREATEST(g.entourage + 1 - (SELECT COUNT(*) FROM seats h WHERE h.guest_id = g.id), 0)), 0)
+        FROM guests g WHERE g.table_id = t.id AND g.status IN ('invited', 'confirmed')),
+    0)), 0) AS seats_empty
+FROM tables t
 `
 
 func (q *Queries) GetEmptySeats(ctx context.Context) (int32, error) {
@@ -102,7 +149,7 @@ func (q *Queries) GetEmptySeats(ctx context.Context) (int32, error) {
 }
 
 const getGuest = `-- name: GetGuest :one
-SELECT id, guest_name, entourage, table_id, arrival_time, created_at FROM guests
+SELECT id, guest_name, entourage, table_id, arrival_time, created_at, created_by, rsvp_status, rsvp_at, status, dietary, accessibility, vip_tier, email, phone, notes, expected_from, expected_until, walk_in FROM guests
 WHERE id = ? LIMIT 1
 `
 
@@ -116,12 +163,25 @@ func (q *Queries) GetGuest(ctx context.Context, id int32) (Guest, error) {
 		&i.TableID,
 		&i.ArrivalTime,
 		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.RsvpStatus,
+		&i.RsvpAt,
+		&i.Status,
+		&i.Dietary,
+		&i.Accessibility,
+		&i.VipTier,
+		&i.Email,
+		&i.Phone,
+		&i.Notes,
+		&i.ExpectedFrom,
+		&i.ExpectedUntil,
+		&i.WalkIn,
 	)
 	return i, err
 }
 
 const getGuestForUpdate = `-- name: GetGuestForUpdate :one
-SELECT id, guest_name, entourage, table_id, arrival_time, created_at FROM guests
+SELECT id, guest_name, entourage, table_id, arrival_time, created_at, created_by, rsvp_status, rsvp_at, status, dietary, accessibility, vip_tier, email, phone, notes, expected_from, expected_until, walk_in FROM guests
 WHERE id = ? LIMIT 1
 FOR UPDATE
 `
@@ -136,12 +196,25 @@ func (q *Queries) GetGuestForUpdate(ctx context.Context, id int32) (Guest, error
 		&i.TableID,
 		&i.ArrivalTime,
 		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.RsvpStatus,
+		&i.RsvpAt,
+		&i.Status,
+		&i.Dietary,
+		&i.Accessibility,
+		&i.VipTier,
+		&i.Email,
+		&i.Phone,
+		&i.Notes,
+		&i.ExpectedFrom,
+		&i.ExpectedUntil,
+		&i.WalkIn,
 	)
 	return i, err
 }
 
 const getGuestFromName = `-- name: GetGuestFromName :one
-SELECT id, guest_name, entourage, table_id, arrival_time, created_at FROM guests
+SELECT id, guest_name, entourage, table_id, arrival_time, created_at, created_by, rsvp_status, rsvp_at, status, dietary, accessibility, vip_tier, email, phone, notes, expected_from, expected_until, walk_in FROM guests
 WHERE guest_name = ? LIMIT 1
 `
 
@@ -155,24 +228,60 @@ func (q *Queries) GetGuestFromName(ctx context.Context, guestName string) (Guest
 		&i.TableID,
 		&i.ArrivalTime,
 		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.RsvpStatus,
+		&i.RsvpAt,
+		&i.Status,
+		&i.Dietary,
+		&i.Accessibility,
+		&i.VipTier,
+		&i.Email,
+		&i.Phone,
+		&i.Notes,
+		&i.ExpectedFrom,
+		&i.ExpectedUntil,
+		&i.WalkIn,
 	)
 	return i, err
 }
 
 const getGuests = `-- name: GetGuests :many
-SELECT id, guest_name, entourage, table_id, arrival_time, created_at FROM guests 
+SELECT id, guest_name, entourage, table_id, arrival_time, created_at, created_by, rsvp_status, rsvp_at, status, dietary, accessibility, vip_tier, email, phone, notes, expected_from, expected_until, walk_in FROM guests
+WHERE (? IS NULL OR status = ?)
+    AND (? IS NULL OR table_id = ?)
+    AND (? IS NULL OR FIND_IN_SET(?, dietary) > 0)
+    AND (? IS NULL OR vip_tier = ?)
+    AND (? IS NULL OR walk_in = ?)
 ORDER BY id
 LIMIT ?
 OFFSET ?
 `
 
 type GetGuestsParams struct {
-	Limit  int32 `json:"limit"`
-	Offset int32 `json:"offset"`
+	Status  sql.NullString `json:"status"`
+	TableID sql.NullInt32  `json:"table_id"`
+	Dietary sql.NullString `json:"dietary"`
+	VipTier sql.NullString `json:"vip_tier"`
+	WalkIn  sql.NullBool   `json:"walk_in"`
+	Limit   int32          `json:"limit"`
+	Offset  int32          `json:"offset"`
 }
 
 func (q *Queries) GetGuests(ctx context.Context, arg GetGuestsParams) ([]Guest, error) {
-	rows, err := q.db.QueryContext(ctx, getGuests, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, getGuests,
+		arg.Status,
+		arg.Status,
+		arg.TableID,
+		arg.TableID,
+		arg.Dietary,
+		arg.Dietary,
+		arg.VipTier,
+		arg.VipTier,
+		arg.WalkIn,
+		arg.WalkIn,
+		arg.Limit,
+		arg.Offset,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -187,6 +296,19 @@ func (q *Queries) GetGuests(ctx context.Context, arg GetGuestsParams) ([]Guest,
 			&i.TableID,
 			&i.ArrivalTime,
 			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.RsvpStatus,
+			&i.RsvpAt,
+			&i.Status,
+			&i.Dietary,
+			&i.Accessibility,
+			&i.VipTier,
+			&i.Email,
+			&i.Phone,
+			&i.Notes,
+			&i.ExpectedFrom,
+			&i.ExpectedUntil,
+			&i.WalkIn,
 		); err != nil {
 			return nil, err
 		}
@@ -201,6 +323,54 @@ func (q *Queries) GetGuests(ctx context.Context, arg GetGuestsParams) ([]Guest,
 	return items, nil
 }
 
+const getReservedSeats = `-- name: GetReservedSeats :one
+SELECT CAST(IFNULL(SUM(entourage + 1), 0) AS SIGNED) AS reserved_seats FROM guests
+WHERE table_id = ? AND id <> ? AND status IN ('invited', 'confirmed', 'arrived')
+`
+
+type GetReservedSeatsParams struct {
+	TableID int32 `json:"table_id"`
+	ID      int32 `json:"id"`
+}
+
+func (q *Queries) GetReservedSeats(ctx context.Context, arg GetReservedSeatsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getReservedSeats, arg.TableID, arg.ID)
+	var reserved_seats int64
+	err := row.Scan(&reserved_seats)
+	return reserved_seats, err
+}
+
+const listOverdueGuests = `-- name: ListOverdueGuests :many
+SELECT g.id FROM guests g
+JOIN tables t ON t.id = g.table_id
+WHERE g.status IN ('invited', 'confirmed')
+    AND COALESCE(g.expected_until, t.expected_until) < ?
+ORDER BY g.id
+`
+
+func (q *Queries) ListOverdueGuests(ctx context.Context, cutoff time.Time) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, listOverdueGuests, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []int32{}
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateGuestArrival = `-- name: UpdateGuestArrival :exec
 UPDATE guests
 SET entourage = ?
@@ -216,3 +386,104 @@ func (q *Queries) UpdateGuestArrival(ctx context.Context, arg UpdateGuestArrival
 	_, err := q.db.ExecContext(ctx, updateGuestArrival, arg.Entourage, arg.ID)
 	return err
 }
+
+const updateGuestArrivalWindow = `-- name: UpdateGuestArrivalWindow :exec
+UPDATE guests
+SET expected_from = ?, expected_until = ?
+WHERE id = ?
+`
+
+type UpdateGuestArrivalWindowParams struct {
+	ExpectedFrom  sql.NullTime `json:"expected_from"`
+	ExpectedUntil sql.NullTime `json:"expected_until"`
+	ID            int32        `json:"id"`
+}
+
+func (q *Queries) UpdateGuestArrivalWindow(ctx context.Context, arg UpdateGuestArrivalWindowParams) error {
+	_, err := q.db.ExecContext(ctx, updateGuestArrivalWindow, arg.ExpectedFrom, arg.ExpectedUntil, arg.ID)
+	return err
+}
+
+const updateGuestProfile = `-- name: UpdateGuestProfile :exec
+UPDATE guests
+SET
+    dietary = COALESCE(?, dietary),
+    accessibility = COALESCE(?, accessibility),
+    vip_tier = COALESCE(?, vip_tier),
+    email = COALESCE(?, email),
+    phone = COALESCE(?, phone),
+    notes = COALESCE(?, notes)
+WHERE id = ?
+`
+
+type UpdateGuestProfileParams struct {
+	Dietary       sql.NullString `json:"dietary"`
+	Accessibility sql.NullString `json:"accessibility"`
+	VipTier       sql.NullString `json:"vip_tier"`
+	Email         sql.NullString `json:"email"`
+	Phone         sql.NullString `json:"phone"`
+	Notes         sql.NullString `json:"notes"`
+	ID            int32          `json:"id"`
+}
+
+func (q *Queries) UpdateGuestProfile(ctx context.Context, arg UpdateGuestProfileParams) error {
+	_, err := q.db.ExecContext(ctx, updateGuestProfile,
+		arg.Dietary,
+		arg.Accessibility,
+		arg.VipTier,
+		arg.Email,
+		arg.Phone,
+		arg.Notes,
+		arg.ID,
+	)
+	return err
+}
+
+const updateGuestRSVP = `-- name: UpdateGuestRSVP :exec
+UPDATE guests
+SET rsvp_status = ?, entourage = ?, rsvp_at = NOW()
+WHERE id = ?
+`
+
+type UpdateGuestRSVPParams struct {
+	RsvpStatus string `json:"rsvp_status"`
+	Entourage  int32  `json:"entourage"`
+	ID         int32  `json:"id"`
+}
+
+func (q *Queries) UpdateGuestRSVP(ctx context.Context, arg UpdateGuestRSVPParams) error {
+	_, err := q.db.ExecContext(ctx, updateGuestRSVP, arg.RsvpStatus, arg.Entourage, arg.ID)
+	return err
+}
+
+const updateGuestStatus = `-- name: UpdateGuestStatus :exec
+UPDATE guests
+SET status = ?
+WHERE id = ?
+`
+
+type UpdateGuestStatusParams struct {
+	Status string `json:"status"`
+	ID     int32  `json:"id"`
+}
+
+func (q *Queries) UpdateGuestStatus(ctx context.Context, arg UpdateGuestStatusParams) error {
+	_, err := q.db.ExecContext(ctx, updateGuestStatus, arg.Status, arg.ID)
+	return err
+}
+
+const updateGuestTable = `-- name: UpdateGuestTable :exec
+UPDATE guests
+SET table_id = ?
+WHERE id = ?
+`
+
+type UpdateGuestTableParams struct {
+	TableID int32 `json:"table_id"`
+	ID      int32 `json:"id"`
+}
+
+func (q *Queries) UpdateGuestTable(ctx context.Context, arg UpdateGuestTableParams) error {
+	_, err := q.db.ExecContext(ctx, updateGuestTable, arg.TableID, arg.ID)
+	return err
+}
@@ -17,6 +17,7 @@ func createRandomGuest(t *testing.T, tableID int32) Guest {
 		Entourage:   util.RandomGuestSize(),
 		TableID:     tableID,
 		ArrivalTime: util.RandomGuestArrivalTime(),
+		CreatedBy:   util.RandomGuestName(),
 	}
 
 	guestSQL, err := testQueries.CreateGuest(context.Background(), arg)
@@ -28,6 +29,7 @@ func createRandomGuest(t *testing.T, tableID int32) Guest {
 	require.NotEmpty(t, guest)
 
 	require.Equal(t, arg.GuestName, guest.GuestName)
+	require.Equal(t, arg.CreatedBy, guest.CreatedBy)
 	require.NotZero(t, guest.ID)
 	require.GreaterOrEqual(t, int(guest.Entourage), 0)
 
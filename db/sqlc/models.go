@@ -4,27 +4,159 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
+	"time"
 )
 
+type AdmissionLog struct {
+	ID           int32     `json:"id"`
+	GuestID      int32     `json:"guest_id"`
+	GuestName    string    `json:"guest_name"`
+	TableID      int32     `json:"table_id"`
+	PartySize    int32     `json:"party_size"`
+	Policy       string    `json:"policy"`
+	RulesVersion int32     `json:"rules_version"`
+	Allowed      bool      `json:"allowed"`
+	Reason       string    `json:"reason"`
+	DecidedBy    string    `json:"decided_by"`
+	RequestID    string    `json:"request_id"`
+	DecidedAt    time.Time `json:"decided_at"`
+}
+
+type AdmissionRuleSet struct {
+	Version   int32           `json:"version"`
+	Document  json.RawMessage `json:"document"`
+	CreatedBy string          `json:"created_by"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
 type Arrival struct {
-	ID        int32 `json:"id"`
-	GuestID   int32 `json:"guest_id"`
-	TableID   int32 `json:"table_id"`
-	PartySize int32 `json:"party_size"`
+	ID              int32        `json:"id"`
+	GuestID         int32        `json:"guest_id"`
+	TableID         int32        `json:"table_id"`
+	PartySize       int32        `json:"party_size"`
+	ArrivedBy       string       `json:"arrived_by"`
+	ArrivedAt       time.Time    `json:"arrived_at"`
+	DepartedAt      sql.NullTime `json:"departed_at"`
+	BookedPartySize int32        `json:"booked_party_size"`
+}
+
+type AuditEvent struct {
+	ID        int32           `json:"id"`
+	Action    string          `json:"action"`
+	Actor     string          `json:"actor"`
+	RequestID string          `json:"request_id"`
+	GuestID   sql.NullInt32   `json:"guest_id"`
+	GuestName string          `json:"guest_name"`
+	TableID   sql.NullInt32   `json:"table_id"`
+	Before    json.RawMessage `json:"before"`
+	After     json.RawMessage `json:"after"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+type BannedPerson struct {
+	ID        int32     `json:"id"`
+	Name      string    `json:"name"`
+	Email     string    `json:"email"`
+	Phone     string    `json:"phone"`
+	Reason    string    `json:"reason"`
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CapacityLimit struct {
+	Zone         string    `json:"zone"`
+	MaxOccupancy int32     `json:"max_occupancy"`
+	UpdatedBy    string    `json:"updated_by"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+type Companion struct {
+	ID         int32           `json:"id"`
+	GuestID    int32           `json:"guest_id"`
+	Name       string          `json:"name"`
+	Attributes json.RawMessage `json:"attributes"`
+	CreatedAt  time.Time       `json:"created_at"`
 }
 
 type Guest struct {
-	ID          int32        `json:"id"`
-	GuestName   string       `json:"guest_name"`
-	Entourage   int32        `json:"entourage"`
-	TableID     int32        `json:"table_id"`
-	ArrivalTime sql.NullTime `json:"arrival_time"`
-	CreatedAt   sql.NullTime `json:"created_at"`
+	ID            int32        `json:"id"`
+	GuestName     string       `json:"guest_name"`
+	Entourage     int32        `json:"entourage"`
+	TableID       int32        `json:"table_id"`
+	ArrivalTime   sql.NullTime `json:"arrival_time"`
+	CreatedAt     sql.NullTime `json:"created_at"`
+	CreatedBy     string       `json:"created_by"`
+	RsvpStatus    string       `json:"rsvp_status"`
+	RsvpAt        sql.NullTime `json:"rsvp_at"`
+	Status        string       `json:"status"`
+	Dietary       string       `json:"dietary"`
+	Accessibility string       `json:"accessibility"`
+	VipTier       string       `json:"vip_tier"`
+	Email         string       `json:"email"`
+	Phone         string       `json:"phone"`
+	Notes         string       `json:"notes"`
+	ExpectedFrom  sql.NullTime `json:"expected_from"`
+	ExpectedUntil sql.NullTime `json:"expected_until"`
+	WalkIn        bool         `json:"walk_in"`
 }
 
-type Table struct {
+type Invitation struct {
 	ID        int32        `json:"id"`
-	Size      int32        `json:"size"`
-	Occupied  int32        `json:"occupied"`
-	CreatedAt sql.NullTime `json:"created_at"`
+	GuestID   int32        `json:"guest_id"`
+	CreatedBy string       `json:"created_by"`
+	CreatedAt time.Time    `json:"created_at"`
+	RevokedAt sql.NullTime `json:"revoked_at"`
+	UsedAt    sql.NullTime `json:"used_at"`
+}
+
+type OccupancySnapshot struct {
+	ID             int32         `json:"id"`
+	TableID        sql.NullInt32 `json:"table_id"`
+	TableOccupied  int32         `json:"table_occupied"`
+	VenueOccupancy int32         `json:"venue_occupancy"`
+	RecordedAt     time.Time     `json:"recorded_at"`
+}
+
+type PartyChange struct {
+	ID           int32     `json:"id"`
+	ArrivalID    int32     `json:"arrival_id"`
+	OldPartySize int32     `json:"old_party_size"`
+	NewPartySize int32     `json:"new_party_size"`
+	ChangedBy    string    `json:"changed_by"`
+	ChangedAt    time.Time `json:"changed_at"`
+}
+
+type Seat struct {
+	ID          int32         `json:"id"`
+	TableID     int32         `json:"table_id"`
+	Number      int32         `json:"number"`
+	GuestID     sql.NullInt32 `json:"guest_id"`
+	CompanionID sql.NullInt32 `json:"companion_id"`
+	AssignedAt  sql.NullTime  `json:"assigned_at"`
+}
+
+type StandingAdmission struct {
+	ID         int32        `json:"id"`
+	Name       string       `json:"name"`
+	PartySize  int32        `json:"party_size"`
+	AdmittedBy string       `json:"admitted_by"`
+	AdmittedAt time.Time    `json:"admitted_at"`
+	DepartedAt sql.NullTime `json:"departed_at"`
+}
+
+type Table struct {
+	ID            int32        `json:"id"`
+	Size          int32        `json:"size"`
+	Occupied      int32        `json:"occupied"`
+	CreatedAt     sql.NullTime `json:"created_at"`
+	CreatedBy     string       `json:"created_by"`
+	Label         string       `json:"label"`
+	Zone          string       `json:"zone"`
+	Accessible    bool         `json:"accessible"`
+	Shape         string       `json:"shape"`
+	MinParty      int32        `json:"min_party"`
+	MaxParty      int32        `json:"max_party"`
+	ExpectedFrom  sql.NullTime `json:"expected_from"`
+	ExpectedUntil sql.NullTime `json:"expected_until"`
 }
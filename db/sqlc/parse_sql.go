@@ -31,6 +31,42 @@ func (q *Queries) getTableFromSQLQuery(query sql.Result) (Table, error) {
 	return table, err
 }
 
+// getStandingAdmissionFromSQLQuery returns a StandingAdmission object following a CreateStandingAdmission action
+func (q *Queries) getStandingAdmissionFromSQLQuery(query sql.Result) (StandingAdmission, error) {
+	var admission StandingAdmission
+
+	id, err := query.LastInsertId()
+	if err != nil {
+		return admission, err
+	}
+	admission, err = q.GetStandingAdmission(context.Background(), int32(id))
+	return admission, err
+}
+
+// getAdmissionRuleSetFromSQLQuery returns a AdmissionRuleSet object following a CreateAdmissionRuleSet action
+func (q *Queries) getAdmissionRuleSetFromSQLQuery(query sql.Result) (AdmissionRuleSet, error) {
+	var ruleSet AdmissionRuleSet
+
+	version, err := query.LastInsertId()
+	if err != nil {
+		return ruleSet, err
+	}
+	ruleSet, err = q.GetAdmissionRuleSet(context.Background(), int32(version))
+	return ruleSet, err
+}
+
+// getBannedPersonFromSQLQuery returns a BannedPerson object following a CreateBannedPerson action
+func (q *Queries) getBannedPersonFromSQLQuery(query sql.Result) (BannedPerson, error) {
+	var person BannedPerson
+
+	id, err := query.LastInsertId()
+	if err != nil {
+		return person, err
+	}
+	person, err = q.GetBannedPerson(context.Background(), int32(id))
+	return person, err
+}
+
 // getArrivalFromSQLQuery returns a Arrival object following a CreateArrival action
 func (q *Queries) getArrivalFromSQLQuery(query sql.Result) (Arrival, error) {
 	var arrival Arrival
@@ -42,3 +78,27 @@ func (q *Queries) getArrivalFromSQLQuery(query sql.Result) (Arrival, error) {
 	arrival, err = q.GetArrival(context.Background(), int32(id))
 	return arrival, err
 }
+
+// getInvitationFromSQLQuery returns a Invitation object following a CreateInvitation action
+func (q *Queries) getInvitationFromSQLQuery(query sql.Result) (Invitation, error) {
+	var invitation Invitation
+
+	id, err := query.LastInsertId()
+	if err != nil {
+		return invitation, err
+	}
+	invitation, err = q.GetInvitation(context.Background(), int32(id))
+	return invitation, err
+}
+
+// getCompanionFromSQLQuery returns a Companion object following a CreateCompanion action
+func (q *Queries) getCompanionFromSQLQuery(query sql.Result) (Companion, error) {
+	var companion Companion
+
+	id, err := query.LastInsertId()
+	if err != nil {
+		return companion, err
+	}
+	companion, err = q.GetCompanion(context.Background(), int32(id))
+	return companion, err
+}
@@ -0,0 +1,47 @@
+package db
+
+import "strings"
+
+// VIP tiers of a guest, guests who aren't VIPs have no tier
+const (
+	VIPSilver   = "silver"
+	VIPGold     = "gold"
+	VIPPlatinum = "platinum"
+)
+
+// VIPTiers lists every VIP tier a guest can have
+var VIPTiers = []string{VIPSilver, VIPGold, VIPPlatinum}
+
+// IsVIPTier reports whether tier is one of VIPTiers
+func IsVIPTier(tier string) bool {
+	for _, t := range VIPTiers {
+		if t == tier {
+			return true
+		}
+	}
+	return false
+}
+
+// GuestProfile holds the details caterers and hosts need about a guest. Dietary is a comma
+// separated list of requirements, e.g. "vegetarian,nut_allergy".
+type GuestProfile struct {
+	Dietary       string `json:"dietary"`
+	Accessibility string `json:"accessibility"`
+	VipTier       string `json:"vip_tier"`
+	Email         string `json:"email"`
+	Phone         string `json:"phone"`
+	Notes         string `json:"notes"`
+}
+
+// NormalizeDietary lower-cases the requirements in a comma separated list and strips the space
+// around them, so each can be matched exactly by FIND_IN_SET
+func NormalizeDietary(dietary string) string {
+	var requirements []string
+	for _, requirement := range strings.Split(dietary, ",") {
+		requirement = strings.ToLower(strings.TrimSpace(requirement))
+		if requirement != "" {
+			requirements = append(requirements, requirement)
+		}
+	}
+	return strings.Join(requirements, ",")
+}
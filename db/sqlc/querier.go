@@ -5,28 +5,102 @@ package db
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
 type Querier interface {
+	AssignSeat(ctx context.Context, arg AssignSeatParams) error
+	CountCompanions(ctx context.Context, guestID int32) (int64, error)
+	CountGuestsByStatus(ctx context.Context) ([]CountGuestsByStatusRow, error)
+	CreateAdmissionLogEntry(ctx context.Context, arg CreateAdmissionLogEntryParams) error
+	CreateAdmissionRuleSet(ctx context.Context, arg CreateAdmissionRuleSetParams) (sql.Result, error)
 	CreateArrival(ctx context.Context, arg CreateArrivalParams) (sql.Result, error)
+	CreateArrivalCompanion(ctx context.Context, arg CreateArrivalCompanionParams) error
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) error
+	CreateBannedPerson(ctx context.Context, arg CreateBannedPersonParams) (sql.Result, error)
+	CreateCompanion(ctx context.Context, arg CreateCompanionParams) (sql.Result, error)
 	CreateGuest(ctx context.Context, arg CreateGuestParams) (sql.Result, error)
+	CreateInvitation(ctx context.Context, arg CreateInvitationParams) (sql.Result, error)
+	CreateOccupancySnapshot(ctx context.Context, arg CreateOccupancySnapshotParams) error
+	CreatePartyChange(ctx context.Context, arg CreatePartyChangeParams) (sql.Result, error)
+	CreateSeat(ctx context.Context, arg CreateSeatParams) error
+	CreateStandingAdmission(ctx context.Context, arg CreateStandingAdmissionParams) (sql.Result, error)
 	CreateTable(ctx context.Context, arg CreateTableParams) (sql.Result, error)
+	DeleteArrivalCompanion(ctx context.Context, arg DeleteArrivalCompanionParams) error
+	DeleteBannedPerson(ctx context.Context, id int32) error
+	DeleteCompanion(ctx context.Context, id int32) error
 	DeleteGuest(ctx context.Context, id int32) error
 	DeleteTable(ctx context.Context, id int32) error
+	EndArrival(ctx context.Context, id int32) error
+	EndStandingAdmission(ctx context.Context, id int32) error
+	GetActiveInvitationFromGuest(ctx context.Context, guestID int32) (Invitation, error)
+	GetAdmissionRuleSet(ctx context.Context, version int32) (AdmissionRuleSet, error)
 	GetArrival(ctx context.Context, id int32) (Arrival, error)
-	GetArrivalFromGuest(ctx context.Context, guestID int32) (Arrival, error)
 	GetArrivals(ctx context.Context, arg GetArrivalsParams) ([]Arrival, error)
 	GetArrivedGuests(ctx context.Context, arg GetArrivedGuestsParams) ([]Guest, error)
+	GetBannedPerson(ctx context.Context, id int32) (BannedPerson, error)
+	GetBannedPersonForUpdate(ctx context.Context, id int32) (BannedPerson, error)
+	GetCapacityLimitForUpdate(ctx context.Context, zone string) (CapacityLimit, error)
+	GetCompanion(ctx context.Context, id int32) (Companion, error)
 	GetEmptySeats(ctx context.Context) (int32, error)
 	GetGuest(ctx context.Context, id int32) (Guest, error)
 	GetGuestForUpdate(ctx context.Context, id int32) (Guest, error)
 	GetGuestFromName(ctx context.Context, guestName string) (Guest, error)
 	GetGuests(ctx context.Context, arg GetGuestsParams) ([]Guest, error)
+	GetInvitation(ctx context.Context, id int32) (Invitation, error)
+	GetInvitationForUpdate(ctx context.Context, id int32) (Invitation, error)
+	GetLatestAdmissionRuleSet(ctx context.Context) (AdmissionRuleSet, error)
+	GetOpenArrivalFromGuest(ctx context.Context, guestID int32) (Arrival, error)
+	GetReservedSeats(ctx context.Context, arg GetReservedSeatsParams) (int64, error)
+	GetStandingAdmission(ctx context.Context, id int32) (StandingAdmission, error)
+	GetStandingAdmissionForUpdate(ctx context.Context, id int32) (StandingAdmission, error)
 	GetTable(ctx context.Context, id int32) (Table, error)
 	GetTableForUpdate(ctx context.Context, id int32) (Table, error)
+	GetTableFromLabel(ctx context.Context, label string) (Table, error)
 	GetTables(ctx context.Context, arg GetTablesParams) ([]Table, error)
+	GetVenueOccupancy(ctx context.Context) (int64, error)
+	GetZoneOccupancies(ctx context.Context) ([]GetZoneOccupanciesRow, error)
+	GetZoneOccupancy(ctx context.Context, zone string) (int64, error)
+	ListAdmissionLog(ctx context.Context, arg ListAdmissionLogParams) ([]AdmissionLog, error)
+	ListAllBannedPeople(ctx context.Context) ([]BannedPerson, error)
+	ListArrivalCompanions(ctx context.Context, arrivalID int32) ([]Companion, error)
+	ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error)
+	ListBannedPeople(ctx context.Context, arg ListBannedPeopleParams) ([]BannedPerson, error)
+	ListCapacityLimits(ctx context.Context) ([]CapacityLimit, error)
+	ListCompanions(ctx context.Context, guestID int32) ([]Companion, error)
+	ListEventArrivals(ctx context.Context, arg ListEventArrivalsParams) ([]ListEventArrivalsRow, error)
+	ListEventAuditEvents(ctx context.Context, arg ListEventAuditEventsParams) ([]AuditEvent, error)
+	ListEventDeniedAdmissions(ctx context.Context, arg ListEventDeniedAdmissionsParams) ([]AdmissionLog, error)
+	ListEventStandingAdmissions(ctx context.Context, arg ListEventStandingAdmissionsParams) ([]StandingAdmission, error)
+	ListGuestSeats(ctx context.Context, guestID sql.NullInt32) ([]Seat, error)
+	ListGuestsWithStatus(ctx context.Context, status string) ([]Guest, error)
+	ListLastOccupancySnapshots(ctx context.Context, before time.Time) ([]OccupancySnapshot, error)
+	ListOccupancySnapshots(ctx context.Context, arg ListOccupancySnapshotsParams) ([]OccupancySnapshot, error)
+	ListOpenStandingAdmissions(ctx context.Context, arg ListOpenStandingAdmissionsParams) ([]StandingAdmission, error)
+	ListOverdueGuests(ctx context.Context, cutoff time.Time) ([]int32, error)
+	ListPartyChanges(ctx context.Context, arrivalID int32) ([]PartyChange, error)
+	ListSeatMap(ctx context.Context, tableID int32) ([]ListSeatMapRow, error)
+	ListSeatsForUpdate(ctx context.Context, tableID int32) ([]Seat, error)
+	ListTableRoster(ctx context.Context, arg ListTableRosterParams) ([]ListTableRosterRow, error)
+	ListTableSpace(ctx context.Context) ([]ListTableSpaceRow, error)
+	ListTables(ctx context.Context) ([]Table, error)
+	ReleaseGuestSeats(ctx context.Context, guestID sql.NullInt32) error
+	ReleaseSeat(ctx context.Context, id int32) error
+	RevokeGuestInvitations(ctx context.Context, guestID int32) (int64, error)
+	UpdateArrivalPartySize(ctx context.Context, arg UpdateArrivalPartySizeParams) error
+	UpdateArrivalTable(ctx context.Context, arg UpdateArrivalTableParams) error
+	UpdateCompanion(ctx context.Context, arg UpdateCompanionParams) error
 	UpdateGuestArrival(ctx context.Context, arg UpdateGuestArrivalParams) error
+	UpdateGuestArrivalWindow(ctx context.Context, arg UpdateGuestArrivalWindowParams) error
+	UpdateGuestProfile(ctx context.Context, arg UpdateGuestProfileParams) error
+	UpdateGuestRSVP(ctx context.Context, arg UpdateGuestRSVPParams) error
+	UpdateGuestStatus(ctx context.Context, arg UpdateGuestStatusParams) error
+	UpdateGuestTable(ctx context.Context, arg UpdateGuestTableParams) error
 	UpdateTable(ctx context.Context, arg UpdateTableParams) error
+	UpdateTableArrivalWindow(ctx context.Context, arg UpdateTableArrivalWindowParams) error
+	UpdateTableLabel(ctx context.Context, arg UpdateTableLabelParams) error
+	UpsertCapacityLimit(ctx context.Context, arg UpsertCapacityLimitParams) error
+	UseInvitation(ctx context.Context, id int32) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,88 @@
+package db
+
+import "database/sql"
+
+// TableRoster is a table as the door sees it: how many of its seats are free, reserved for the
+// parties booked by guests who haven't arrived yet or taken, who is seated there and who is still
+// expected. The free seats are counted as GetEmptySeats does, so they leave room for every party
+// still expected whether or not their seats are numbered.
+type TableRoster struct {
+	TableID       int32         `json:"table_id"`
+	Label         string        `json:"label"`
+	Zone          string        `json:"zone"`
+	Accessible    bool          `json:"accessible"`
+	Shape         string        `json:"shape"`
+	Size          int32         `json:"size"`
+	MinParty      int32         `json:"min_party"`
+	MaxParty      int32         `json:"max_party"`
+	Occupied      int32         `json:"occupied"`
+	FreeSeats     int32         `json:"free_seats"`
+	ReservedSeats int32         `json:"reserved_seats"`
+	Seated        []RosterGuest `json:"seated"`
+	Expected      []RosterGuest `json:"expected"`
+}
+
+// RosterGuest is a guest on a table's roster. The party size of a seated guest is the one they
+// arrived with, of an expected guest the one they booked.
+type RosterGuest struct {
+	GuestID   int32        `json:"guest_id"`
+	GuestName string       `json:"guest_name"`
+	Status    string       `json:"status"`
+	VipTier   string       `json:"vip_tier"`
+	PartySize int32        `json:"party_size"`
+	Seats     int32        `json:"seats"`
+	ArrivedAt sql.NullTime `json:"arrived_at"`
+	ArrivalWindow
+}
+
+// TableRosters folds the rows of ListTableRoster, one per table and guest holding seats there, into
+// a roster per table in the order the tables were listed
+func TableRosters(rows []ListTableRosterRow) []TableRoster {
+	rosters := []TableRoster{}
+	for _, row := range rows {
+		if len(rosters) == 0 || rosters[len(rosters)-1].TableID != row.ID {
+			rosters = append(rosters, TableRoster{
+				TableID:    row.ID,
+				Label:      row.Label,
+				Zone:       row.Zone,
+				Accessible: row.Accessible,
+				Shape:      row.Shape,
+				Size:       row.Size,
+				MinParty:   row.MinParty,
+				MaxParty:   row.MaxParty,
+				Occupied:   row.Occupied,
+				FreeSeats:  int32(row.FreeSeats),
+				Seated:     []RosterGuest{},
+				Expected:   []RosterGuest{},
+			})
+		}
+		if !row.GuestID.Valid {
+			continue
+		}
+
+		roster := &rosters[len(rosters)-1]
+		guest := RosterGuest{
+			GuestID:   row.GuestID.Int32,
+			GuestName: row.GuestName.String,
+			Status:    row.GuestStatus.String,
+			VipTier:   row.VipTier.String,
+			PartySize: row.Entourage.Int32 + 1,
+			Seats:     int32(row.SeatsHeld),
+			ArrivedAt: row.ArrivedAt,
+			ArrivalWindow: GuestArrivalWindow(
+				Guest{ExpectedFrom: row.GuestExpectedFrom, ExpectedUntil: row.GuestExpectedUntil},
+				Table{ExpectedFrom: row.ExpectedFrom, ExpectedUntil: row.ExpectedUntil},
+			),
+		}
+		if guest.Status != GuestArrived {
+			roster.ReservedSeats += guest.PartySize
+			roster.Expected = append(roster.Expected, guest)
+			continue
+		}
+		if row.PartySize.Valid {
+			guest.PartySize = row.PartySize.Int32
+		}
+		roster.Seated = append(roster.Seated, guest)
+	}
+	return rosters
+}
@@ -0,0 +1,69 @@
+package db
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTableRosters(t *testing.T) {
+	opens := time.Date(2026, 10, 19, 19, 0, 0, 0, time.UTC)
+	table := ListTableRosterRow{ID: 1, Size: 8, Occupied: 4, Label: "Table 1", FreeSeats: 0, ExpectedFrom: at(opens)}
+	guest := func(row ListTableRosterRow, id int32, status string, entourage int32, seats int64) ListTableRosterRow {
+		row.GuestID = sql.NullInt32{Int32: id, Valid: true}
+		row.GuestName = sql.NullString{String: string(rune('A' + id)), Valid: true}
+		row.GuestStatus = sql.NullString{String: status, Valid: true}
+		row.Entourage = sql.NullInt32{Int32: entourage, Valid: true}
+		row.SeatsHeld = seats
+		return row
+	}
+
+	seated := guest(table, 1, GuestArrived, 2, 4)
+	seated.PartySize = sql.NullInt32{Int32: 4, Valid: true}
+	seated.ArrivedAt = at(opens.Add(10 * time.Minute))
+	late := guest(table, 2, GuestConfirmed, 1, 2)
+	late.GuestExpectedUntil = at(opens.Add(time.Hour))
+	// Booked without seats, their party is reserved all the same
+	unseated := guest(table, 3, GuestInvited, 1, 0)
+	empty := ListTableRosterRow{ID: 2, Size: 4, Label: "Table 2", FreeSeats: 4}
+
+	rosters := TableRosters([]ListTableRosterRow{seated, late, unseated, empty})
+	require.Len(t, rosters, 2)
+
+	require.Equal(t, int32(1), rosters[0].TableID)
+	require.Equal(t, int32(4), rosters[0].Occupied)
+	require.Equal(t, int32(0), rosters[0].FreeSeats)
+	require.Equal(t, int32(4), rosters[0].ReservedSeats)
+
+	// The seated guest's party is the one they arrived with, bigger than they booked
+	require.Equal(t, []RosterGuest{{
+		GuestID:       1,
+		GuestName:     "B",
+		Status:        GuestArrived,
+		PartySize:     4,
+		Seats:         4,
+		ArrivedAt:     seated.ArrivedAt,
+		ArrivalWindow: ArrivalWindow{ExpectedFrom: at(opens)},
+	}}, rosters[0].Seated)
+
+	// The expected guest's window closes when their own does and opens with their table's
+	require.Equal(t, []RosterGuest{{
+		GuestID:       2,
+		GuestName:     "C",
+		Status:        GuestConfirmed,
+		PartySize:     2,
+		Seats:         2,
+		ArrivalWindow: ArrivalWindow{ExpectedFrom: at(opens), ExpectedUntil: at(opens.Add(time.Hour))},
+	}, {
+		GuestID:       3,
+		GuestName:     "D",
+		Status:        GuestInvited,
+		PartySize:     2,
+		ArrivalWindow: ArrivalWindow{ExpectedFrom: at(opens)},
+	}}, rosters[0].Expected)
+
+	require.Equal(t, TableRoster{TableID: 2, Label: "Table 2", Size: 4, FreeSeats: 4, Seated: []RosterGuest{}, Expected: []RosterGuest{}}, rosters[1])
+	require.Empty(t, TableRosters(nil))
+}
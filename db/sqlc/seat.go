@@ -0,0 +1,233 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+)
+
+// Statuses of a seat on a table's seat map
+const (
+	SeatFree     = "free"
+	SeatReserved = "reserved"
+	SeatOccupied = "occupied"
+)
+
+// SeatStatus is free if nobody holds the seat, occupied once the guest holding it has arrived and
+// reserved until then
+func SeatStatus(seat ListSeatMapRow) string {
+	switch {
+	case !seat.GuestID.Valid:
+		return SeatFree
+	case seat.GuestStatus.String == GuestArrived:
+		return SeatOccupied
+	default:
+		return SeatReserved
+	}
+}
+
+// holdsSeats reports whether a guest with status keeps the seats assigned to them
+func holdsSeats(status string) bool {
+	return status == GuestInvited || status == GuestConfirmed || status == GuestArrived
+}
+
+// pickSeats returns the numbers of the partySize seats at the table the guest's party should sit
+// in, given every seat of the table. requested seats are only checked to be the table's and free
+// or already the guest's. Otherwise the party keeps the lowest numbered of the seats it already
+// holds and is topped up with the free seats closest to them, or with the smallest run of free
+// seats it fits in when it holds none, so parties sit together wherever the table allows.
+func pickSeats(tableID int32, seats []Seat, guestID int32, partySize int32, requested []int32) ([]int32, error) {
+	var held, free []int32
+	byNumber := make(map[int32]Seat, len(seats))
+	for _, seat := range seats {
+		byNumber[seat.Number] = seat
+		switch {
+		case !seat.GuestID.Valid:
+			free = append(free, seat.Number)
+		case seat.GuestID.Int32 == guestID:
+			held = append(held, seat.Number)
+		}
+	}
+
+	if requested != nil {
+		if int32(len(requested)) != partySize {
+			return nil, fmt.Errorf("%d seats requested for a party of %d: %w", len(requested), partySize, ErrInvalidSeats)
+		}
+		picked := make(map[int32]bool, len(requested))
+		for _, number := range requested {
+			seat, ok := byNumber[number]
+			if !ok || picked[number] {
+				return nil, fmt.Errorf("seat %d isn't at table %d or is requested twice: %w", number, tableID, ErrInvalidSeats)
+			}
+			if seat.GuestID.Valid && seat.GuestID.Int32 != guestID {
+				return nil, &SeatTakenError{TableID: tableID, Number: number}
+			}
+			picked[number] = true
+		}
+		return requested, nil
+	}
+
+	if int32(len(held)) >= partySize {
+		return held[:partySize], nil
+	}
+	extra := int(partySize) - len(held)
+	if len(free) < extra {
+		return nil, InsufficientTableSizeErr(int(tableID))
+	}
+
+	picked := append(held, closestSeats(free, held, extra)...)
+	sort.Slice(picked, func(i, j int) bool { return picked[i] < picked[j] })
+	return picked, nil
+}
+
+// closestSeats returns n of the free seats, sorted by number. Without held seats to sit next to
+// they're the start of the smallest run of consecutive free seats at least n long, or when there's
+// no such run they grow out from the longest run. Otherwise they're picked one at a time as the
+// free seat nearest any held or already picked, the lowest numbered on a tie.
+func closestSeats(free, held []int32, n int) []int32 {
+	if len(held) == 0 {
+		fit, longest := -1, 0
+		fitLen, longestLen := 0, 0
+		for start := 0; start < len(free); {
+			end := start + 1
+			for end < len(free) && free[end] == free[end-1]+1 {
+				end++
+			}
+			length := end - start
+			if length >= n && (fit < 0 || length < fitLen) {
+				fit, fitLen = start, length
+			}
+			if length > longestLen {
+				longest, longestLen = start, length
+			}
+			start = end
+		}
+		if fit >= 0 {
+			return free[fit : fit+n]
+		}
+		held = []int32{free[longest]}
+	}
+
+	taken := append([]int32(nil), held...)
+	remaining := append([]int32(nil), free...)
+	picked := make([]int32, 0, n)
+	for len(picked) < n {
+		best, bestDistance := 0, int32(-1)
+		for i, number := range remaining {
+			for _, t := range taken {
+				d := number - t
+				if d < 0 {
+					d = -d
+				}
+				if bestDistance < 0 || d < bestDistance {
+					best, bestDistance = i, d
+				}
+			}
+		}
+		picked = append(picked, remaining[best])
+		taken = append(taken, remaining[best])
+		remaining = append(remaining[:best], remaining[best+1:]...)
+	}
+	sort.Slice(picked, func(i, j int) bool { return picked[i] < picked[j] })
+	return picked
+}
+
+// seatParty gives the guest's party of partySize the seats at the table chosen by pickSeats,
+// releasing any others the guest held there, and returns every seat the guest holds. The first
+// seat is the guest's own, companionIDs take the next in order and any left over are for the rest
+// of the entourage. When companionIDs is nil the seats kept keep whoever was sat in them. The
+// caller must hold the lock on the table.
+func (q *Queries) seatParty(ctx context.Context, tableID, guestID, partySize int32, companionIDs, requested []int32) ([]Seat, error) {
+	seats, err := q.ListSeatsForUpdate(ctx, tableID)
+	if err != nil {
+		return nil, err
+	}
+
+	numbers, err := pickSeats(tableID, seats, guestID, partySize, requested)
+	if err != nil {
+		return nil, err
+	}
+
+	occupants := make(map[int32]sql.NullInt32, len(numbers))
+	for i, number := range numbers {
+		var companion sql.NullInt32
+		if i > 0 && i <= len(companionIDs) {
+			companion = sql.NullInt32{Int32: companionIDs[i-1], Valid: true}
+		}
+		occupants[number] = companion
+	}
+
+	guest := sql.NullInt32{Int32: guestID, Valid: true}
+	for _, seat := range seats {
+		companion, picked := occupants[seat.Number]
+		held := seat.GuestID == guest
+		switch {
+		case held && !picked:
+			err = q.ReleaseSeat(ctx, seat.ID)
+		case picked && (!held || (companionIDs != nil && seat.CompanionID != companion)):
+			err = q.AssignSeat(ctx, AssignSeatParams{GuestID: guest, CompanionID: companion, ID: seat.ID})
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return q.ListGuestSeats(ctx, guest)
+}
+
+// tableSeating returns how many seats at the table the guest's party could sit in, those free and
+// those the guest already holds, and how many people are at the table without a seat of their own
+// because it was overbooked. The caller must hold the lock on the table.
+func (q *Queries) tableSeating(ctx context.Context, table Table, guestID int32) (free, unseated int32, err error) {
+	seats, err := q.ListSeatMap(ctx, table.ID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	seatedArrivals := int32(0)
+	for _, seat := range seats {
+		switch {
+		case !seat.GuestID.Valid, seat.GuestID.Int32 == guestID:
+			free++
+		case seat.GuestStatus.String == GuestArrived:
+			seatedArrivals++
+		}
+	}
+	return free, table.Occupied - seatedArrivals, nil
+}
+
+// releaseSeats frees every seat the guest holds
+func (q *Queries) releaseSeats(ctx context.Context, guestID int32) error {
+	return q.ReleaseGuestSeats(ctx, sql.NullInt32{Int32: guestID, Valid: true})
+}
+
+// updateOccupancy sets how many people are sat at the table and snapshots the occupancy of the
+// table and venue for the event analytics. Every change to who is seated goes through it, or calls
+// snapshotOccupancy itself once several tables have changed. The caller must hold the lock on the
+// table.
+func (q *Queries) updateOccupancy(ctx context.Context, table Table, occupied int32) error {
+	err := q.UpdateTable(ctx, UpdateTableParams{
+		ID:       table.ID,
+		Size:     table.Size,
+		Occupied: occupied,
+	})
+	if err != nil {
+		return err
+	}
+	return q.snapshotOccupancy(ctx, sql.NullInt32{Int32: table.ID, Valid: true}, occupied)
+}
+
+// snapshotOccupancy records the occupancy of the venue once the table has occupied people at it,
+// tableID isn't valid when a standing party came or went
+func (q *Queries) snapshotOccupancy(ctx context.Context, tableID sql.NullInt32, occupied int32) error {
+	venue, err := q.GetVenueOccupancy(ctx)
+	if err != nil {
+		return err
+	}
+	return q.CreateOccupancySnapshot(ctx, CreateOccupancySnapshotParams{
+		TableID:        tableID,
+		TableOccupied:  occupied,
+		VenueOccupancy: int32(venue),
+	})
+}
@@ -0,0 +1,193 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/stretchr/testify/require"
+)
+
+// tableSeats returns the seats of a table of size, holders maps seat numbers to the guest holding them
+func tableSeats(size int32, holders map[int32]int32) []Seat {
+	seats := make([]Seat, size)
+	for i := range seats {
+		number := int32(i) + 1
+		seats[i] = Seat{ID: number, TableID: 1, Number: number}
+		if guestID, ok := holders[number]; ok {
+			seats[i].GuestID = sql.NullInt32{Int32: guestID, Valid: true}
+		}
+	}
+	return seats
+}
+
+func TestPickSeats(t *testing.T) {
+	const guestID = 7
+
+	testCases := []struct {
+		name      string
+		size      int32
+		holders   map[int32]int32
+		partySize int32
+		requested []int32
+		want      []int32
+		err       error
+	}{
+		{
+			name:      "EmptyTable",
+			size:      8,
+			partySize: 3,
+			want:      []int32{1, 2, 3},
+		},
+		{
+			name:      "SmallestRunThatFits",
+			size:      10,
+			holders:   map[int32]int32{1: 2, 6: 3, 9: 4},
+			partySize: 2,
+			want:      []int32{7, 8},
+		},
+		{
+			name:      "NoRunLongEnough",
+			size:      8,
+			holders:   map[int32]int32{3: 2, 6: 3},
+			partySize: 4,
+			want:      []int32{1, 2, 4, 5},
+		},
+		{
+			name:      "TopsUpHeldSeats",
+			size:      8,
+			holders:   map[int32]int32{4: guestID, 5: guestID, 3: 2},
+			partySize: 4,
+			want:      []int32{4, 5, 6, 7},
+		},
+		{
+			name:      "KeepsLowestHeldSeats",
+			size:      8,
+			holders:   map[int32]int32{2: guestID, 3: guestID, 4: guestID},
+			partySize: 2,
+			want:      []int32{2, 3},
+		},
+		{
+			name:      "NotEnoughFree",
+			size:      4,
+			holders:   map[int32]int32{1: 2, 2: 2, 3: 3},
+			partySize: 2,
+			err:       ErrInsufficientTableSize,
+		},
+		{
+			name:      "Requested",
+			size:      8,
+			holders:   map[int32]int32{5: guestID, 1: 2},
+			partySize: 2,
+			requested: []int32{5, 3},
+			want:      []int32{5, 3},
+		},
+		{
+			name:      "RequestedTaken",
+			size:      8,
+			holders:   map[int32]int32{1: 2},
+			partySize: 2,
+			requested: []int32{1, 2},
+			err:       ErrSeatTaken,
+		},
+		{
+			name:      "RequestedNotAtTable",
+			size:      4,
+			partySize: 1,
+			requested: []int32{5},
+			err:       ErrInvalidSeats,
+		},
+		{
+			name:      "RequestedWrongCount",
+			size:      4,
+			partySize: 2,
+			requested: []int32{1},
+			err:       ErrInvalidSeats,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := pickSeats(1, tableSeats(tc.size, tc.holders), guestID, tc.partySize, tc.requested)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestSeatPartyTx(t *testing.T) {
+	store := NewStore(testDB)
+	audit := AuditInfo{Actor: util.DoorStaffRole, RequestID: util.RandomString(16)}
+
+	table, err := store.CreateTableTx(context.Background(), CreateTableTxParams{AuditInfo: audit, Size: 6})
+	require.NoError(t, err)
+
+	// The first guest reserves seats 2 and 3 at booking
+	booked, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		Entourage: 1,
+		TableID:   table.ID,
+		Seats:     []int32{2, 3},
+	})
+	require.NoError(t, err)
+
+	walkUp, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		Entourage: 2,
+		TableID:   table.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = store.AssignTableTx(context.Background(), AssignTableTxParams{
+		AuditInfo:    audit,
+		UserID:       int64(walkUp.ID),
+		NewEntourage: 0,
+		TableID:      int64(table.ID),
+		Seats:        []int32{3},
+	})
+	require.ErrorIs(t, err, ErrSeatTaken)
+
+	// Seats 4 to 6 are the only run the party fits in
+	result, err := store.AssignTableTx(context.Background(), AssignTableTxParams{
+		AuditInfo:    audit,
+		UserID:       int64(walkUp.ID),
+		NewEntourage: 2,
+		TableID:      int64(table.ID),
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Seats, 3)
+	for i, seat := range result.Seats {
+		require.Equal(t, int32(i)+4, seat.Number)
+	}
+
+	// The booked guest keeps their seats and the newcomer to their party takes seat 1
+	result, err = store.AssignTableTx(context.Background(), AssignTableTxParams{
+		AuditInfo:    audit,
+		UserID:       int64(booked.ID),
+		NewEntourage: 2,
+		TableID:      int64(table.ID),
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Seats, 3)
+	require.Equal(t, int32(1), result.Seats[0].Number)
+
+	seatMap, err := store.ListSeatMap(context.Background(), table.ID)
+	require.NoError(t, err)
+	for _, seat := range seatMap {
+		require.Equal(t, SeatOccupied, SeatStatus(seat))
+	}
+
+	_, err = store.LeaveGuestTx(context.Background(), LeaveGuestTxParams{AuditInfo: audit, ID: walkUp.ID})
+	require.NoError(t, err)
+
+	seats, err := store.ListGuestSeats(context.Background(), sql.NullInt32{Int32: walkUp.ID, Valid: true})
+	require.NoError(t, err)
+	require.Empty(t, seats)
+}
@@ -0,0 +1,63 @@
+package db
+
+// Statuses of a guest, every guest starts off invited
+const (
+	GuestInvited   = "invited"
+	GuestConfirmed = "confirmed"
+	GuestDeclined  = "declined"
+	GuestCancelled = "cancelled"
+	GuestArrived   = "arrived"
+	GuestLeft      = "left"
+	GuestNoShow    = "no_show"
+)
+
+// GuestStatuses lists every status a guest can have
+var GuestStatuses = []string{
+	GuestInvited,
+	GuestConfirmed,
+	GuestDeclined,
+	GuestCancelled,
+	GuestArrived,
+	GuestLeft,
+	GuestNoShow,
+}
+
+// IsGuestStatus reports whether status is one of GuestStatuses
+func IsGuestStatus(status string) bool {
+	for _, s := range GuestStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// guestTransitions maps each status to those a guest may move to from it. Confirmed and declined
+// guests may repeat their RSVP, a guest who left may re-enter and a no-show may still turn up late.
+// A cancelled guest is off the list for good.
+var guestTransitions = map[string][]string{
+	GuestInvited:   {GuestConfirmed, GuestDeclined, GuestCancelled, GuestArrived, GuestNoShow},
+	GuestConfirmed: {GuestConfirmed, GuestDeclined, GuestCancelled, GuestArrived, GuestNoShow},
+	GuestDeclined:  {GuestDeclined, GuestConfirmed, GuestCancelled},
+	GuestArrived:   {GuestLeft},
+	GuestLeft:      {GuestArrived},
+	GuestNoShow:    {GuestArrived, GuestCancelled},
+}
+
+// CanTransition reports whether a guest may move from one status to another
+func CanTransition(from, to string) bool {
+	for _, status := range guestTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// checkTransition returns a *TransitionError unless a guest may move from one status to another
+func checkTransition(from, to string) error {
+	if !CanTransition(from, to) {
+		return &TransitionError{From: from, To: to}
+	}
+	return nil
+}
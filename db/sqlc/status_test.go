@@ -0,0 +1,43 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanTransition(t *testing.T) {
+	testCases := []struct {
+		from string
+		to   string
+		ok   bool
+	}{
+		{GuestInvited, GuestConfirmed, true},
+		{GuestInvited, GuestArrived, true},
+		{GuestConfirmed, GuestNoShow, true},
+		{GuestDeclined, GuestConfirmed, true},
+		{GuestDeclined, GuestArrived, false},
+		{GuestArrived, GuestLeft, true},
+		{GuestArrived, GuestArrived, false},
+		{GuestLeft, GuestArrived, true},
+		{GuestNoShow, GuestArrived, true},
+		{GuestCancelled, GuestConfirmed, false},
+		{GuestCancelled, GuestArrived, false},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, tc.ok, CanTransition(tc.from, tc.to), "%s -> %s", tc.from, tc.to)
+
+		err := checkTransition(tc.from, tc.to)
+		if tc.ok {
+			require.NoError(t, err)
+			continue
+		}
+		require.ErrorIs(t, err, ErrIllegalTransition)
+
+		var transitionErr *TransitionError
+		require.ErrorAs(t, err, &transitionErr)
+		require.Equal(t, tc.from, transitionErr.From)
+		require.Equal(t, tc.to, transitionErr.To)
+	}
+}
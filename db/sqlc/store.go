@@ -3,26 +3,82 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 )
 
 type Store interface {
 	Querier
+	GetTablesByIDs(ctx context.Context, ids []int32) ([]Table, error)
+	GetGuestsByTableIDs(ctx context.Context, tableIDs []int32) ([]Guest, error)
+	GetOpenArrivalsByGuestIDs(ctx context.Context, guestIDs []int32) ([]Arrival, error)
+	GetEmptySeatsByTableIDs(ctx context.Context, tableIDs []int32) ([]TableEmptySeats, error)
+	CreateGuestTx(ctx context.Context, arg CreateGuestTxParams) (Guest, error)
+	CreateTableTx(ctx context.Context, arg CreateTableTxParams) (Table, error)
 	AssignTableTx(ctx context.Context, arg AssignTableTxParams) (AssignTableTxResult, error)
-	DeleteGuestTx(ctx context.Context, id int32) error
+	ArriveByInvitationTx(ctx context.Context, arg ArriveByInvitationTxParams) (AssignTableTxResult, error)
+	WalkInTx(ctx context.Context, arg WalkInTxParams) (AssignTableTxResult, error)
+	IssueInvitationTx(ctx context.Context, arg IssueInvitationTxParams) (Invitation, error)
+	RevokeInvitationTx(ctx context.Context, arg RevokeInvitationTxParams) error
+	RSVPTx(ctx context.Context, arg RSVPTxParams) (Guest, error)
+	DeleteGuestTx(ctx context.Context, arg DeleteGuestTxParams) error
+	LeaveGuestTx(ctx context.Context, arg LeaveGuestTxParams) (Guest, error)
+	UpdatePartyTx(ctx context.Context, arg UpdatePartyTxParams) (UpdatePartyTxResult, error)
+	MoveGuestTx(ctx context.Context, arg MoveGuestTxParams) (MoveGuestTxResult, error)
+	TransitionGuestTx(ctx context.Context, arg TransitionGuestTxParams) (Guest, error)
+	UpdateGuestProfileTx(ctx context.Context, arg UpdateGuestProfileTxParams) (Guest, error)
+	SetGuestArrivalWindowTx(ctx context.Context, arg SetGuestArrivalWindowTxParams) (Guest, error)
+	SetTableArrivalWindowTx(ctx context.Context, arg SetTableArrivalWindowTxParams) (Table, error)
+	MarkNoShowsTx(ctx context.Context, arg MarkNoShowsTxParams) ([]Guest, error)
+	SetCapacityLimitTx(ctx context.Context, arg SetCapacityLimitTxParams) (CapacityLimit, error)
+	SetAdmissionRulesTx(ctx context.Context, arg SetAdmissionRulesTxParams) (AdmissionRuleSet, error)
+	BanPersonTx(ctx context.Context, arg BanPersonTxParams) (BannedPerson, error)
+	UnbanPersonTx(ctx context.Context, arg UnbanPersonTxParams) error
+	AdmitStandingTx(ctx context.Context, arg AdmitStandingTxParams) (StandingAdmission, error)
+	DepartStandingTx(ctx context.Context, arg DepartStandingTxParams) (StandingAdmission, error)
+	AddCompanionTx(ctx context.Context, arg AddCompanionTxParams) (Companion, error)
+	UpdateCompanionTx(ctx context.Context, arg UpdateCompanionTxParams) (Companion, error)
+	RemoveCompanionTx(ctx context.Context, arg RemoveCompanionTxParams) error
+	ListAuditEvents(ctx context.Context, arg ListAuditEventsParams) ([]AuditEvent, error)
 }
 
 // Store provides all functions to execute db queries and transactions
 type SQLStore struct {
 	*Queries
-	db *sql.DB
+	db          *sql.DB
+	denyReentry bool
+	admission   AdmissionPolicies
 }
 
-func NewStore(db *sql.DB) *SQLStore {
-	return &SQLStore{
-		db:      db,
-		Queries: New(db),
+// StoreOption configures the policies a SQLStore applies to the event
+type StoreOption func(*SQLStore)
+
+// WithReentryDenied stops guests who have left from arriving again when denied is true
+func WithReentryDenied(denied bool) StoreOption {
+	return func(store *SQLStore) {
+		store.denyReentry = denied
+	}
+}
+
+// WithAdmissionPolicies replaces the default admission policies every arrival must pass
+func WithAdmissionPolicies(policies AdmissionPolicies) StoreOption {
+	return func(store *SQLStore) {
+		store.admission = policies
+	}
+}
+
+func NewStore(db *sql.DB, opts ...StoreOption) *SQLStore {
+	store := &SQLStore{
+		db:        db,
+		Queries:   New(db),
+		admission: DefaultAdmissionPolicies(),
+	}
+	for _, opt := range opts {
+		opt(store)
 	}
+	return store
 }
 
 // execTx executes function within a db transaction
@@ -43,138 +99,1944 @@ func (store *SQLStore) execTx(ctx context.Context, fn func(*Queries) error) erro
 	return tx.Commit()
 }
 
-// AssignTableParams contains input parameters of the transaction assigning a guest to a table
-type AssignTableTxParams struct {
-	UserID       int64 `json:"user_id"`
-	NewEntourage int64 `json:"new_entourage"`
-	TableID      int64 `json:"table_id"`
+// CreateGuestTxParams contains input parameters of the transaction adding a guest to the guest list.
+// Seats optionally reserves the numbered seats at the table for the party, one for each of them.
+type CreateGuestTxParams struct {
+	AuditInfo
+	GuestProfile
+	ArrivalWindow
+	GuestName string  `json:"guest_name"`
+	Entourage int32   `json:"entourage"`
+	TableID   int32   `json:"table_id"`
+	Seats     []int32 `json:"seats"`
 }
 
-// AssignTableTxResult contains result of the assign table transaction
-type AssignTableTxResult struct {
-	Arrival  Arrival `json:"arrival"`
-	Table    Table   `json:"table"`
-	Guest    Guest   `json:"guest"`
-	OldTable Table   `json:"old_table"`
+// CreateGuestTx adds a guest to the guest list, recording the actor as its creator, and issues
+// their invitation. A guest matching somebody on the ban list is refused with a *BannedError.
+func (store *SQLStore) CreateGuestTx(ctx context.Context, arg CreateGuestTxParams) (Guest, error) {
+	var guest Guest
+
+	if err := arg.ArrivalWindow.Validate(); err != nil {
+		return guest, err
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.checkBans(ctx, arg.GuestName, arg.Email, arg.Phone); err != nil {
+			return err
+		}
+
+		result, err := q.CreateGuest(ctx, CreateGuestParams{
+			GuestName:     arg.GuestName,
+			Entourage:     arg.Entourage,
+			TableID:       arg.TableID,
+			CreatedBy:     arg.Actor,
+			Dietary:       NormalizeDietary(arg.Dietary),
+			Accessibility: arg.Accessibility,
+			VipTier:       arg.VipTier,
+			Email:         arg.Email,
+			Phone:         arg.Phone,
+			Notes:         arg.Notes,
+			ExpectedFrom:  arg.ExpectedFrom,
+			ExpectedUntil: arg.ExpectedUntil,
+		})
+		if err != nil {
+			return err
+		}
+
+		guest, err = q.getGuestFromSQLQuery(result)
+		if err != nil {
+			return err
+		}
+
+		if arg.Seats != nil {
+			if _, err = q.GetTableForUpdate(ctx, guest.TableID); err != nil {
+				return err
+			}
+			if _, err = q.seatParty(ctx, guest.TableID, guest.ID, guest.Entourage+1, nil, arg.Seats); err != nil {
+				return err
+			}
+		}
+
+		err = q.auditGuest(ctx, arg.AuditInfo, AuditCreateGuest, guest, nil, &guestState{Guest: guest})
+		if err != nil {
+			return err
+		}
+
+		// Every guest on the list is sent an invitation to scan on arrival
+		_, err = q.issueInvitation(ctx, arg.AuditInfo, guest)
+		return err
+	})
+	return guest, store.alertBanMatch(ctx, arg.AuditInfo, Guest{GuestName: arg.GuestName}, BanStageBooking, err)
+}
+
+// CreateTableTxParams contains input parameters of the transaction creating a table. A table without
+// a label is labelled after its ID, without a shape is round, and without a minimum or maximum party
+// takes any party from 1 to its size.
+type CreateTableTxParams struct {
+	AuditInfo
+	ArrivalWindow
+	Size       int32  `json:"size"`
+	Label      string `json:"label"`
+	Zone       string `json:"zone"`
+	Accessible bool   `json:"accessible"`
+	Shape      string `json:"shape"`
+	MinParty   int32  `json:"min_party"`
+	MaxParty   int32  `json:"max_party"`
+}
+
+// CreateTableTx creates an empty table with its numbered seats, recording the actor as its creator
+func (store *SQLStore) CreateTableTx(ctx context.Context, arg CreateTableTxParams) (Table, error) {
+	var table Table
+
+	if arg.Shape == "" {
+		arg.Shape = ShapeRound
+	}
+	if arg.MinParty == 0 {
+		arg.MinParty = 1
+	}
+	if arg.MaxParty == 0 {
+		arg.MaxParty = arg.Size
+	}
+	if arg.MinParty > arg.MaxParty || arg.MaxParty > arg.Size {
+		return table, ErrInvalidPartyRange
+	}
+	if err := arg.ArrivalWindow.Validate(); err != nil {
+		return table, err
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.checkTableLabelFree(ctx, arg.Label); err != nil {
+			return err
+		}
+
+		result, err := q.CreateTable(ctx, CreateTableParams{
+			Size:          arg.Size,
+			CreatedBy:     arg.Actor,
+			Label:         arg.Label,
+			Zone:          arg.Zone,
+			Accessible:    arg.Accessible,
+			Shape:         arg.Shape,
+			MinParty:      arg.MinParty,
+			MaxParty:      arg.MaxParty,
+			ExpectedFrom:  arg.ExpectedFrom,
+			ExpectedUntil: arg.ExpectedUntil,
+		})
+		if err != nil {
+			return err
+		}
+
+		table, err = q.getTableFromSQLQuery(result)
+		if err != nil {
+			return err
+		}
+
+		for number := int32(1); number <= table.Size; number++ {
+			err = q.CreateSeat(ctx, CreateSeatParams{TableID: table.ID, Number: number})
+			if err != nil {
+				return err
+			}
+		}
+
+		// The ID isn't known until the table is inserted, the empty label is only ever seen by
+		// this transaction
+		if table.Label == "" {
+			table.Label = defaultTableLabel(table.ID)
+			if err = q.checkTableLabelFree(ctx, table.Label); err != nil {
+				return err
+			}
+			err = q.UpdateTableLabel(ctx, UpdateTableLabelParams{Label: table.Label, ID: table.ID})
+			if err != nil {
+				return err
+			}
+		}
+
+		return q.auditTable(ctx, arg.AuditInfo, AuditCreateTable, nil, table)
+	})
+	return table, err
+}
+
+// checkTableLabelFree returns ErrTableLabelTaken if another table already has label
+func (q *Queries) checkTableLabelFree(ctx context.Context, label string) error {
+	if label == "" {
+		return nil
+	}
+
+	_, err := q.GetTableFromLabel(ctx, label)
+	if err == nil {
+		return ErrTableLabelTaken
+	}
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	return err
 }
 
-var txKey = struct{}{}
+// AssignTableParams contains input parameters of the transaction assigning a guest to a table,
+// the actor is recorded as whoever let the party in
+// CompanionIDs optionally names who the arriving entourage are, there must then be exactly
+// NewEntourage of them. Seats optionally gives the numbered seats the party sit in, the guest's
+// first followed by those of the companions, otherwise they're picked by pickSeats.
+type AssignTableTxParams struct {
+	AuditInfo
+	UserID       int64   `json:"user_id"`
+	NewEntourage int64   `json:"new_entourage"`
+	TableID      int64   `json:"table_id"`
+	CompanionIDs []int32 `json:"companion_ids"`
+	Seats        []int32 `json:"seats"`
+}
 
-func InsufficientTableSizeErr(tableID int) error {
-	return fmt.Errorf("Table %d has insufficient space", tableID)
+// AssignTableTxResult contains result of the assign table transaction
+type AssignTableTxResult struct {
+	Arrival    Arrival     `json:"arrival"`
+	Table      Table       `json:"table"`
+	Guest      Guest       `json:"guest"`
+	OldTable   Table       `json:"old_table"`
+	Companions []Companion `json:"companions"`
+	Seats      []Seat      `json:"seats"`
+	// Decisions explains why each of the event's admission policies let the party in
+	Decisions []AdmissionDecision `json:"decisions"`
 }
 
-// AssignTableTx assigns a guest alongwith their entourage to a table, and will return an error if the party size is bigger than the table size
+// AssignTableTx assigns a guest alongwith their entourage to a table, and will return an
+// *AdmissionDeniedError if any of the event's admission policies turn them away, or a
+// *BannedError if the guest is on the ban list
 func (store *SQLStore) AssignTableTx(ctx context.Context, arg AssignTableTxParams) (AssignTableTxResult, error) {
 	var result AssignTableTxResult
 
 	err := store.execTx(ctx, func(q *Queries) error {
-		var err error
+		return store.assignTable(ctx, q, arg, &result)
+	})
+	return result, store.logDeniedAdmission(ctx, arg, &result, err)
+}
 
-		result.Guest, err = q.GetGuestForUpdate(ctx, int32(arg.UserID))
-		if err != nil {
+// logDeniedAdmission records the decisions which turned a party away, or raises the alert for a
+// banned guest, once the transaction of their arrival has been rolled back, returning err unless
+// the log can't be written
+func (store *SQLStore) logDeniedAdmission(ctx context.Context, arg AssignTableTxParams, result *AssignTableTxResult, err error) error {
+	if errors.Is(err, ErrBanned) {
+		return store.alertBanMatch(ctx, arg.AuditInfo, result.Guest, BanStageArrival, err)
+	}
+	var denied *AdmissionDeniedError
+	if !errors.As(err, &denied) {
+		return err
+	}
+	if logErr := store.logAdmission(ctx, arg.AuditInfo, result.Guest, result.Table.ID, int32(arg.NewEntourage)+1, denied.Decisions); logErr != nil {
+		return logErr
+	}
+	return err
+}
+
+// assignTable arrives the guest within the transaction of q, opening a new arrival session. result
+// is filled in as far as it gets so callers can report the state of the guest and table on failure.
+// A guest re-entering after leaving is checked for space exactly like a first arrival.
+func (store *SQLStore) assignTable(ctx context.Context, q *Queries, arg AssignTableTxParams, result *AssignTableTxResult) error {
+	var err error
+
+	result.Guest, err = q.GetGuestForUpdate(ctx, int32(arg.UserID))
+	if err != nil {
+		return err
+	}
+	oldGuest := result.Guest
+
+	if oldGuest.Status == GuestArrived {
+		return ErrGuestAlreadyArrived
+	}
+	if oldGuest.Status == GuestLeft && store.denyReentry {
+		return ErrReentryDenied
+	}
+	// The ban list may have grown since the guest was booked
+	if err = q.checkBans(ctx, oldGuest.GuestName, oldGuest.Email, oldGuest.Phone); err != nil {
+		return err
+	}
+	if err = checkTransition(oldGuest.Status, GuestArrived); err != nil {
+		return err
+	}
+
+	result.Table, err = q.GetTableForUpdate(ctx, int32(arg.TableID))
+	if err != nil {
+		return err
+	}
+	result.OldTable = result.Table
+
+	if arg.CompanionIDs != nil {
+		if err = q.checkArrivingCompanions(ctx, oldGuest.ID, arg.CompanionIDs, arg.NewEntourage); err != nil {
 			return err
 		}
+	}
 
-		// Must also check that the guest is not already arrived by accessing the arrivals table
-		_, err = q.GetArrivalFromGuest(ctx, int32(arg.UserID))
-		if err == nil {
-			return fmt.Errorf("An arrival has already been made for this guest")
-		}
+	if err = CheckPartySize(result.Table, int32(arg.NewEntourage)+1); err != nil {
+		return err
+	}
+
+	// Whether the party fits is down to the admission policies, an overbooked party is given
+	// whatever seats are free for them, counting any they reserved
+	partySize := int32(arg.NewEntourage) + 1
+	freeSeats, unseated, err := q.tableSeating(ctx, result.Table, oldGuest.ID)
+	if err != nil {
+		return err
+	}
+	policies, err := q.admissionPolicies(ctx, store.admission)
+	if err != nil {
+		return err
+	}
+	result.Decisions, err = policies.Evaluate(Admission{
+		Guest:     oldGuest,
+		Table:     result.Table,
+		PartySize: partySize,
+		FreeSeats: freeSeats,
+		Unseated:  unseated,
+		Time:      time.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	if err = q.logAdmission(ctx, arg.AuditInfo, oldGuest, result.Table.ID, partySize, result.Decisions); err != nil {
+		return err
+	}
+
+	seated := partySize
+	if arg.Seats == nil && seated > freeSeats {
+		seated = freeSeats
+	}
+	result.Seats, err = q.seatParty(ctx, result.Table.ID, oldGuest.ID, seated, arg.CompanionIDs, arg.Seats)
+	if err != nil {
+		return err
+	}
+
+	if err = q.checkCapacity(ctx, result.Table.Zone, int32(arg.NewEntourage)+1); err != nil {
+		return err
+	}
+
+	arrivalSQL, err := q.CreateArrival(ctx, CreateArrivalParams{
+		GuestID:         int32(arg.UserID),
+		TableID:         int32(arg.TableID),
+		PartySize:       int32(arg.NewEntourage) + 1,
+		BookedPartySize: oldGuest.Entourage + 1,
+		ArrivedBy:       arg.Actor,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	// Update Original guest record with new entourage value
+	err = q.UpdateGuestArrival(ctx, UpdateGuestArrivalParams{
+		ID:        int32(arg.UserID),
+		Entourage: int32(arg.NewEntourage),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	err = q.UpdateGuestStatus(ctx, UpdateGuestStatusParams{
+		ID:     int32(arg.UserID),
+		Status: GuestArrived,
+	})
+
+	if err != nil {
+		return err
+	}
 
-		result.Table, err = q.GetTableForUpdate(ctx, int32(arg.TableID))
+	err = q.updateOccupancy(ctx, result.Table, result.Table.Occupied+int32(arg.NewEntourage)+1)
+
+	if err != nil {
+		return err
+	}
+
+	// Reading the Arrival object here due to the no Returning property of MySQL
+	// This will be added to the table when the transaction is committed,
+	// and this can only happen if there are no errors beforehand
+	result.Arrival, err = q.getArrivalFromSQLQuery(arrivalSQL)
+	if err != nil {
+		return err
+	}
+
+	for _, companionID := range arg.CompanionIDs {
+		err = q.CreateArrivalCompanion(ctx, CreateArrivalCompanionParams{
+			ArrivalID:   result.Arrival.ID,
+			CompanionID: companionID,
+		})
 		if err != nil {
 			return err
 		}
-		result.OldTable = result.Table
+	}
+	result.Companions, err = q.ListArrivalCompanions(ctx, result.Arrival.ID)
+	if err != nil {
+		return err
+	}
+
+	result.Guest, err = q.GetGuest(ctx, int32(arg.UserID))
+	if err != nil {
+		return err
+	}
+
+	result.Table, err = q.GetTable(ctx, int32(arg.TableID))
+	if err != nil {
+		return err
+	}
+
+	err = q.auditGuest(ctx, arg.AuditInfo, AuditArriveGuest, result.Guest,
+		&guestState{Guest: oldGuest},
+		&guestState{Guest: result.Guest, Arrival: &result.Arrival, Companions: result.Companions})
+	if err != nil {
+		return err
+	}
+	return q.auditTable(ctx, arg.AuditInfo, AuditUpdateTable, &result.OldTable, result.Table)
+}
+
+// checkArrivingCompanions returns an error wrapping ErrInvalidCompanions unless companionIDs are
+// entourage distinct companions of the guest
+func (q *Queries) checkArrivingCompanions(ctx context.Context, guestID int32, companionIDs []int32, entourage int64) error {
+	if int64(len(companionIDs)) != entourage {
+		return fmt.Errorf("%d companions named for an entourage of %d: %w", len(companionIDs), entourage, ErrInvalidCompanions)
+	}
+
+	companions, err := q.ListCompanions(ctx, guestID)
+	if err != nil {
+		return err
+	}
+	unused := make(map[int32]bool, len(companions))
+	for _, companion := range companions {
+		unused[companion.ID] = true
+	}
 
-		if result.Table.Occupied+int32(arg.NewEntourage)+1 > result.Table.Size {
-			return InsufficientTableSizeErr(int(result.Table.ID))
+	for _, id := range companionIDs {
+		if !unused[id] {
+			return fmt.Errorf("companion %d isn't one of the guest's or is listed twice: %w", id, ErrInvalidCompanions)
 		}
+		unused[id] = false
+	}
+	return nil
+}
 
-		arrivalSQL, err := q.CreateArrival(ctx, CreateArrivalParams{
-			GuestID:   int32(arg.UserID),
-			TableID:   int32(arg.TableID),
-			PartySize: int32(arg.NewEntourage) + 1,
-		})
+// ArriveByInvitationTxParams contains input parameters of the transaction arriving a guest
+// from a scanned invitation
+type ArriveByInvitationTxParams struct {
+	AuditInfo
+	InvitationID int32 `json:"invitation_id"`
+	GuestID      int32 `json:"guest_id"`
+	NewEntourage int64 `json:"new_entourage"`
+}
+
+// ArriveByInvitationTx uses up the invitation and arrives its guest at their table exactly as
+// AssignTableTx does. The invitation is only marked as used if the arrival succeeds.
+func (store *SQLStore) ArriveByInvitationTx(ctx context.Context, arg ArriveByInvitationTxParams) (AssignTableTxResult, error) {
+	var result AssignTableTxResult
 
+	assign := AssignTableTxParams{
+		AuditInfo:    arg.AuditInfo,
+		NewEntourage: arg.NewEntourage,
+	}
+	err := store.execTx(ctx, func(q *Queries) error {
+		invitation, err := q.getUsableInvitation(ctx, arg.InvitationID, arg.GuestID)
 		if err != nil {
 			return err
 		}
 
-		// Update Original guest record with new entourage value
-		err = q.UpdateGuestArrival(ctx, UpdateGuestArrivalParams{
-			ID:        int32(arg.UserID),
-			Entourage: int32(arg.NewEntourage),
-		})
+		guest, err := q.GetGuest(ctx, invitation.GuestID)
+		if err != nil {
+			return err
+		}
 
+		assign.UserID = int64(guest.ID)
+		assign.TableID = int64(guest.TableID)
+		err = store.assignTable(ctx, q, assign, &result)
 		if err != nil {
 			return err
 		}
 
-		err = q.UpdateTable(ctx, UpdateTableParams{
-			ID:       result.Table.ID,
-			Size:     result.Table.Size,
-			Occupied: result.Table.Occupied + int32(arg.NewEntourage) + 1,
-		})
+		return q.UseInvitation(ctx, invitation.ID)
+	})
+	return result, store.logDeniedAdmission(ctx, assign, &result, err)
+}
 
-		if err != nil {
+// WalkInTxParams contains input parameters of the transaction letting in a party who aren't on the
+// guest list. TableID is 0 to seat them at whichever table fits them best. Seats optionally gives
+// the numbered seats the party sit in, the guest's first.
+type WalkInTxParams struct {
+	AuditInfo
+	GuestProfile
+	GuestName string  `json:"guest_name"`
+	Entourage int32   `json:"entourage"`
+	TableID   int32   `json:"table_id"`
+	Seats     []int32 `json:"seats"`
+}
+
+// WalkInTx adds the walk-in to the guest list, tagged as a walk-in, and arrives them at the table
+// in the same transaction, so a party turned away leaves no trace on the guest list. Their arrival
+// is checked exactly as AssignTableTx checks any other. No invitation is issued as they're already
+// in. A name already on the guest list returns ErrGuestOnList.
+func (store *SQLStore) WalkInTx(ctx context.Context, arg WalkInTxParams) (AssignTableTxResult, error) {
+	var result AssignTableTxResult
+
+	assign := AssignTableTxParams{
+		AuditInfo:    arg.AuditInfo,
+		NewEntourage: int64(arg.Entourage),
+		Seats:        arg.Seats,
+	}
+	err := store.execTx(ctx, func(q *Queries) error {
+		_, err := q.GetGuestFromName(ctx, arg.GuestName)
+		if err == nil {
+			return ErrGuestOnList
+		}
+		if err != sql.ErrNoRows {
 			return err
 		}
 
-		// Reading the Arrival object here due to the no Returning property of MySQL
-		// This will be added to the table when the transaction is committed,
-		// and this can only happen if there are no errors beforehand
-		result.Arrival, err = q.getArrivalFromSQLQuery(arrivalSQL)
+		// The table is locked before the guest is added to it, as it would be for any arrival
+		var table Table
+		if arg.TableID == 0 {
+			table, err = q.pickWalkInTable(ctx, arg.Entourage+1)
+		} else {
+			table, err = q.GetTableForUpdate(ctx, arg.TableID)
+		}
 		if err != nil {
 			return err
 		}
 
-		result.Guest, err = q.GetGuest(ctx, int32(arg.UserID))
+		created, err := q.CreateGuest(ctx, CreateGuestParams{
+			GuestName:     arg.GuestName,
+			Entourage:     arg.Entourage,
+			TableID:       table.ID,
+			CreatedBy:     arg.Actor,
+			Dietary:       NormalizeDietary(arg.Dietary),
+			Accessibility: arg.Accessibility,
+			VipTier:       arg.VipTier,
+			Email:         arg.Email,
+			Phone:         arg.Phone,
+			Notes:         arg.Notes,
+			WalkIn:        true,
+		})
 		if err != nil {
 			return err
 		}
 
-		result.Table, err = q.GetTable(ctx, int32(arg.TableID))
+		guest, err := q.getGuestFromSQLQuery(created)
 		if err != nil {
 			return err
 		}
+		if err = q.auditGuest(ctx, arg.AuditInfo, AuditCreateGuest, guest, nil, &guestState{Guest: guest}); err != nil {
+			return err
+		}
 
-		return nil
+		assign.UserID = int64(guest.ID)
+		assign.TableID = int64(table.ID)
+		return store.assignTable(ctx, q, assign, &result)
 	})
-	return result, err
+	if err != nil {
+		// The walk-in was rolled back with the rest of the transaction
+		result.Guest.ID = 0
+	}
+	return result, store.logDeniedAdmission(ctx, assign, &result, err)
 }
 
-// DeleteGuestTx deletes a guest from the guests table while also freeing up their table space
-// if they've already arrived
-func (store *SQLStore) DeleteGuestTx(ctx context.Context, id int32) error {
+// getUsableInvitation locks the invitation, returning an error unless it belongs to the guest and
+// can still be used
+func (q *Queries) getUsableInvitation(ctx context.Context, invitationID, guestID int32) (Invitation, error) {
+	invitation, err := q.GetInvitationForUpdate(ctx, invitationID)
+	if err != nil {
+		return invitation, err
+	}
+	if invitation.GuestID != guestID {
+		return invitation, sql.ErrNoRows
+	}
+	if invitation.RevokedAt.Valid {
+		return invitation, ErrInvitationRevoked
+	}
+	if invitation.UsedAt.Valid {
+		return invitation, ErrInvitationUsed
+	}
+	return invitation, nil
+}
 
-	err := store.execTx(ctx, func(q *Queries) error {
+// RSVP statuses of a guest, every guest starts off pending
+const (
+	RSVPPending  = "pending"
+	RSVPAccepted = "accepted"
+	RSVPDeclined = "declined"
+)
+
+// RSVPTxParams contains input parameters of the transaction recording a guest's RSVP
+type RSVPTxParams struct {
+	AuditInfo
+	InvitationID int32  `json:"invitation_id"`
+	GuestID      int32  `json:"guest_id"`
+	Status       string `json:"status"`
+	Entourage    int32  `json:"entourage"`
+}
 
-		guest, err := q.GetGuest(ctx, id)
+// RSVPTx records the guest accepting or declining their invitation along with the entourage they
+// expect to bring, confirming or declining the guest. An accepted party must fit at the guest's
+// table alongside the seats reserved by every other guest there who hasn't declined, and a changed
+// one must be in the table's party range, so surprises are caught before the event rather than at
+// the door.
+func (store *SQLStore) RSVPTx(ctx context.Context, arg RSVPTxParams) (Guest, error) {
+	var guest Guest
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		invitation, err := q.getUsableInvitation(ctx, arg.InvitationID, arg.GuestID)
 		if err != nil {
 			return err
 		}
 
-		err = q.DeleteGuest(ctx, id)
+		oldGuest, err := q.GetGuestForUpdate(ctx, invitation.GuestID)
 		if err != nil {
 			return err
 		}
 
-		table, errTable := q.GetTableForUpdate(ctx, guest.TableID)
-		// Swallow any errors here as it's acceptable that a guest hasn't yet arrived successfully at a table
-		if errTable == nil {
-			err = q.UpdateTable(ctx, UpdateTableParams{
-				ID:       table.ID,
-				Size:     table.Size,
-				Occupied: table.Occupied - (guest.Entourage + 1),
-			})
+		// Once the guest has arrived their party is settled at the door
+		if oldGuest.Status == GuestArrived {
+			return ErrGuestAlreadyArrived
+		}
+
+		status := GuestDeclined
+		if arg.Status == RSVPAccepted {
+			status = GuestConfirmed
+		}
+		if err = checkTransition(oldGuest.Status, status); err != nil {
+			return err
+		}
 
+		if arg.Status == RSVPAccepted {
+			// Lock the table so concurrent RSVPs to it can't both take the last seats
+			table, err := q.GetTableForUpdate(ctx, oldGuest.TableID)
 			if err != nil {
 				return err
 			}
-		}
-		return nil
+
+			if arg.Entourage != oldGuest.Entourage {
+				if err = CheckPartySize(table, arg.Entourage+1); err != nil {
+					return err
+				}
+			}
+
+			reserved, err := q.GetReservedSeats(ctx, GetReservedSeatsParams{
+				TableID: table.ID,
+				ID:      oldGuest.ID,
+			})
+			if err != nil {
+				return err
+			}
+
+			if reserved+int64(arg.Entourage)+1 > int64(table.Size) {
+				return InsufficientTableSizeErr(int(table.ID))
+			}
+		}
+
+		err = q.UpdateGuestRSVP(ctx, UpdateGuestRSVPParams{
+			RsvpStatus: arg.Status,
+			Entourage:  arg.Entourage,
+			ID:         oldGuest.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.UpdateGuestStatus(ctx, UpdateGuestStatusParams{
+			Status: status,
+			ID:     oldGuest.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		if !holdsSeats(status) {
+			if err = q.releaseSeats(ctx, oldGuest.ID); err != nil {
+				return err
+			}
+		}
+
+		guest, err = q.GetGuest(ctx, oldGuest.ID)
+		if err != nil {
+			return err
+		}
+
+		return q.auditGuest(ctx, arg.AuditInfo, AuditRSVPGuest, guest, &guestState{Guest: oldGuest}, &guestState{Guest: guest})
+	})
+	return guest, err
+}
+
+// IssueInvitationTxParams contains input parameters of the transaction issuing a guest's invitation
+type IssueInvitationTxParams struct {
+	AuditInfo
+	GuestID int32 `json:"guest_id"`
+}
+
+// IssueInvitationTx issues a new invitation to the guest, revoking any they already hold
+func (store *SQLStore) IssueInvitationTx(ctx context.Context, arg IssueInvitationTxParams) (Invitation, error) {
+	var invitation Invitation
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		guest, err := q.GetGuestForUpdate(ctx, arg.GuestID)
+		if err != nil {
+			return err
+		}
+
+		if _, err = q.RevokeGuestInvitations(ctx, guest.ID); err != nil {
+			return err
+		}
+
+		invitation, err = q.issueInvitation(ctx, arg.AuditInfo, guest)
+		return err
+	})
+	return invitation, err
+}
+
+// RevokeInvitationTxParams contains input parameters of the transaction revoking a guest's invitation
+type RevokeInvitationTxParams struct {
+	AuditInfo
+	GuestID int32 `json:"guest_id"`
+}
+
+// RevokeInvitationTx revokes the guest's invitation so it can no longer be scanned, it returns
+// sql.ErrNoRows if the guest holds no unused invitation
+func (store *SQLStore) RevokeInvitationTx(ctx context.Context, arg RevokeInvitationTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		guest, err := q.GetGuestForUpdate(ctx, arg.GuestID)
+		if err != nil {
+			return err
+		}
+
+		invitation, err := q.GetActiveInvitationFromGuest(ctx, guest.ID)
+		if err != nil {
+			return err
+		}
+
+		if _, err = q.RevokeGuestInvitations(ctx, guest.ID); err != nil {
+			return err
+		}
+
+		revoked, err := q.GetInvitation(ctx, invitation.ID)
+		if err != nil {
+			return err
+		}
+		return q.auditInvitation(ctx, arg.AuditInfo, AuditRevokeInvitation, guest, &invitation, &revoked)
+	})
+}
+
+// issueInvitation creates an invitation for the guest within a transaction
+func (q *Queries) issueInvitation(ctx context.Context, info AuditInfo, guest Guest) (Invitation, error) {
+	result, err := q.CreateInvitation(ctx, CreateInvitationParams{
+		GuestID:   guest.ID,
+		CreatedBy: info.Actor,
+	})
+	if err != nil {
+		return Invitation{}, err
+	}
+
+	invitation, err := q.getInvitationFromSQLQuery(result)
+	if err != nil {
+		return Invitation{}, err
+	}
+	return invitation, q.auditInvitation(ctx, info, AuditIssueInvitation, guest, nil, &invitation)
+}
+
+// LeaveGuestTxParams contains input parameters of the transaction recording a guest leaving
+type LeaveGuestTxParams struct {
+	AuditInfo
+	ID int32 `json:"id"`
+}
+
+// LeaveGuestTx records an arrived guest leaving, freeing the seats their party occupied. The guest
+// stays on the list and may re-enter through AssignTableTx.
+func (store *SQLStore) LeaveGuestTx(ctx context.Context, arg LeaveGuestTxParams) (Guest, error) {
+	var guest Guest
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		oldGuest, err := q.GetGuestForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if err = checkTransition(oldGuest.Status, GuestLeft); err != nil {
+			return err
+		}
+
+		arrival, err := q.GetOpenArrivalFromGuest(ctx, oldGuest.ID)
+		if err != nil {
+			return err
+		}
+
+		table, err := q.GetTableForUpdate(ctx, arrival.TableID)
+		if err != nil {
+			return err
+		}
+
+		err = q.updateOccupancy(ctx, table, table.Occupied-arrival.PartySize)
+		if err != nil {
+			return err
+		}
+
+		// Closing the session rather than deleting it keeps the guest's history of visits
+		err = q.EndArrival(ctx, arrival.ID)
+		if err != nil {
+			return err
+		}
+
+		err = q.releaseSeats(ctx, oldGuest.ID)
+		if err != nil {
+			return err
+		}
+
+		err = q.UpdateGuestStatus(ctx, UpdateGuestStatusParams{
+			Status: GuestLeft,
+			ID:     oldGuest.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		guest, err = q.GetGuest(ctx, oldGuest.ID)
+		if err != nil {
+			return err
+		}
+		updated, err := q.GetTable(ctx, table.ID)
+		if err != nil {
+			return err
+		}
+		ended, err := q.GetArrival(ctx, arrival.ID)
+		if err != nil {
+			return err
+		}
+
+		err = q.auditGuest(ctx, arg.AuditInfo, AuditLeaveGuest, guest,
+			&guestState{Guest: oldGuest, Arrival: &arrival},
+			&guestState{Guest: guest, Arrival: &ended})
+		if err != nil {
+			return err
+		}
+		return q.auditTable(ctx, arg.AuditInfo, AuditUpdateTable, &table, updated)
+	})
+	return guest, err
+}
+
+// UpdatePartyTxParams contains input parameters of the transaction changing the size of a
+// seated party
+type UpdatePartyTxParams struct {
+	AuditInfo
+	ID           int32 `json:"id"`
+	NewEntourage int32 `json:"new_entourage"`
+}
+
+// UpdatePartyTxResult contains result of the update party transaction
+type UpdatePartyTxResult struct {
+	Guest   Guest         `json:"guest"`
+	Arrival Arrival       `json:"arrival"`
+	Table   Table         `json:"table"`
+	Seats   []Seat        `json:"seats"`
+	History []PartyChange `json:"history"`
+}
+
+// UpdatePartyTx grows or shrinks the party of an arrived guest, e.g. when a latecomer joins them or
+// some of them leave early. The new party must be within the sizes the table takes, and a bigger
+// one is admitted as if it were arriving, by the admission policies against the booking it arrived
+// with and within the maximum occupancy of the venue and zone, taking the free seats nearest theirs.
+// A smaller one frees up its highest numbered seats and the companions it no longer has, and every
+// change is kept against the arrival.
+func (store *SQLStore) UpdatePartyTx(ctx context.Context, arg UpdatePartyTxParams) (UpdatePartyTxResult, error) {
+	var result UpdatePartyTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		oldGuest, err := q.GetGuestForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if oldGuest.Status != GuestArrived {
+			return ErrGuestNotArrived
+		}
+
+		arrival, err := q.GetOpenArrivalFromGuest(ctx, oldGuest.ID)
+		if err != nil {
+			return err
+		}
+
+		table, err := q.GetTableForUpdate(ctx, arrival.TableID)
+		if err != nil {
+			return err
+		}
+
+		newPartySize := arg.NewEntourage + 1
+		if err = CheckPartySize(table, newPartySize); err != nil {
+			return err
+		}
+
+		// Anybody in an overbooked party who is without a seat stays without one, only the
+		// latecomers need to fit in the free seats
+		held, err := q.ListGuestSeats(ctx, sql.NullInt32{Int32: oldGuest.ID, Valid: true})
+		if err != nil {
+			return err
+		}
+		occupied := table.Occupied - arrival.PartySize + newPartySize
+		seated := int32(len(held))
+		if latecomers := newPartySize - arrival.PartySize; latecomers > 0 {
+			// The table as it would be without the party, for them to arrive at anew
+			others := table
+			others.Occupied -= arrival.PartySize
+			freeSeats, unseated, err := q.tableSeating(ctx, others, oldGuest.ID)
+			if err != nil {
+				return err
+			}
+			policies, err := q.admissionPolicies(ctx, store.admission)
+			if err != nil {
+				return err
+			}
+			booked := oldGuest
+			booked.Entourage = arrival.BookedPartySize - 1
+			_, err = policies.Evaluate(Admission{
+				Guest:     booked,
+				Table:     others,
+				PartySize: newPartySize,
+				FreeSeats: freeSeats,
+				Unseated:  unseated,
+				Time:      time.Now(),
+			})
+			if err != nil {
+				return err
+			}
+			if err = q.checkCapacity(ctx, table.Zone, latecomers); err != nil {
+				return err
+			}
+
+			seated += latecomers
+			if seated > freeSeats {
+				seated = freeSeats
+			}
+		}
+		if seated > newPartySize {
+			seated = newPartySize
+		}
+
+		// Companions beyond the new entourage have left, the seats kept are given to those who stay
+		var companionIDs []int32
+		companions, err := q.ListArrivalCompanions(ctx, arrival.ID)
+		if err != nil {
+			return err
+		}
+		if int32(len(companions)) > arg.NewEntourage {
+			companionIDs = make([]int32, 0, arg.NewEntourage)
+			for i, companion := range companions {
+				if int32(i) < arg.NewEntourage {
+					companionIDs = append(companionIDs, companion.ID)
+					continue
+				}
+				err = q.DeleteArrivalCompanion(ctx, DeleteArrivalCompanionParams{ArrivalID: arrival.ID, CompanionID: companion.ID})
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		result.Seats, err = q.seatParty(ctx, table.ID, oldGuest.ID, seated, companionIDs, nil)
+		if err != nil {
+			return err
+		}
+
+		err = q.updateOccupancy(ctx, table, occupied)
+		if err != nil {
+			return err
+		}
+
+		err = q.UpdateArrivalPartySize(ctx, UpdateArrivalPartySizeParams{
+			PartySize: newPartySize,
+			ID:        arrival.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		_, err = q.CreatePartyChange(ctx, CreatePartyChangeParams{
+			ArrivalID:    arrival.ID,
+			OldPartySize: arrival.PartySize,
+			NewPartySize: newPartySize,
+			ChangedBy:    arg.Actor,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.UpdateGuestArrival(ctx, UpdateGuestArrivalParams{
+			Entourage: arg.NewEntourage,
+			ID:        oldGuest.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Guest, err = q.GetGuest(ctx, oldGuest.ID)
+		if err != nil {
+			return err
+		}
+		result.Arrival, err = q.GetArrival(ctx, arrival.ID)
+		if err != nil {
+			return err
+		}
+		result.Table, err = q.GetTable(ctx, table.ID)
+		if err != nil {
+			return err
+		}
+		result.History, err = q.ListPartyChanges(ctx, arrival.ID)
+		if err != nil {
+			return err
+		}
+
+		err = q.auditGuest(ctx, arg.AuditInfo, AuditUpdateParty, result.Guest,
+			&guestState{Guest: oldGuest, Arrival: &arrival},
+			&guestState{Guest: result.Guest, Arrival: &result.Arrival})
+		if err != nil {
+			return err
+		}
+		return q.auditTable(ctx, arg.AuditInfo, AuditUpdateTable, &table, result.Table)
+	})
+	return result, err
+}
+
+// MoveGuestTxParams contains input parameters of the transaction moving a guest to another table
+type MoveGuestTxParams struct {
+	AuditInfo
+	ID      int32 `json:"id"`
+	TableID int32 `json:"table_id"`
+}
+
+// MoveGuestTxResult contains result of the move guest transaction, Arrival is only set for a guest
+// who was moved while seated
+type MoveGuestTxResult struct {
+	Guest    Guest    `json:"guest"`
+	Arrival  *Arrival `json:"arrival,omitempty"`
+	OldTable Table    `json:"old_table"`
+	Table    Table    `json:"table"`
+	Seats    []Seat   `json:"seats"`
+}
+
+// MoveGuestTx moves the guest to another table. A seated party must fit in the free seats at the
+// new table, and within its zone's maximum occupancy when that's another zone, and take their open
+// arrival with them, the old table's seats being freed. A guest yet to arrive has their reservation
+// moved instead, which must fit alongside every other reservation at the new table exactly as an
+// RSVP must, along with any numbered seats they were holding. Both tables are locked in ID order so
+// two moves in opposite directions can't deadlock.
+func (store *SQLStore) MoveGuestTx(ctx context.Context, arg MoveGuestTxParams) (MoveGuestTxResult, error) {
+	var result MoveGuestTxResult
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		oldGuest, err := q.GetGuestForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if oldGuest.TableID == arg.TableID {
+			return ErrAlreadyAtTable
+		}
+
+		var oldArrival *Arrival
+		fromID := oldGuest.TableID
+		if oldGuest.Status == GuestArrived {
+			arrival, err := q.GetOpenArrivalFromGuest(ctx, oldGuest.ID)
+			if err != nil {
+				return err
+			}
+			oldArrival = &arrival
+			fromID = arrival.TableID
+		}
+
+		from, to, err := q.lockTablePair(ctx, fromID, arg.TableID)
+		if err != nil {
+			return err
+		}
+
+		held, err := q.ListGuestSeats(ctx, sql.NullInt32{Int32: oldGuest.ID, Valid: true})
+		if err != nil {
+			return err
+		}
+
+		if oldArrival != nil {
+			if err = q.moveArrival(ctx, oldGuest, *oldArrival, from, to); err != nil {
+				return err
+			}
+		} else {
+			if err = q.moveReservation(ctx, oldGuest, to, int32(len(held))); err != nil {
+				return err
+			}
+		}
+
+		err = q.UpdateGuestTable(ctx, UpdateGuestTableParams{
+			TableID: to.ID,
+			ID:      oldGuest.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		result.Guest, err = q.GetGuest(ctx, oldGuest.ID)
+		if err != nil {
+			return err
+		}
+		result.OldTable, err = q.GetTable(ctx, from.ID)
+		if err != nil {
+			return err
+		}
+		result.Table, err = q.GetTable(ctx, to.ID)
+		if err != nil {
+			return err
+		}
+		result.Seats, err = q.ListGuestSeats(ctx, sql.NullInt32{Int32: oldGuest.ID, Valid: true})
+		if err != nil {
+			return err
+		}
+
+		before := &guestState{Guest: oldGuest, Arrival: oldArrival, Seats: held}
+		after := &guestState{Guest: result.Guest, Seats: result.Seats}
+		if oldArrival != nil {
+			arrival, err := q.GetArrival(ctx, oldArrival.ID)
+			if err != nil {
+				return err
+			}
+			result.Arrival = &arrival
+			after.Arrival = &arrival
+		}
+
+		if err = q.auditGuest(ctx, arg.AuditInfo, AuditMoveGuest, result.Guest, before, after); err != nil {
+			return err
+		}
+		if err = q.auditTable(ctx, arg.AuditInfo, AuditUpdateTable, &from, result.OldTable); err != nil {
+			return err
+		}
+		return q.auditTable(ctx, arg.AuditInfo, AuditUpdateTable, &to, result.Table)
+	})
+	return result, err
+}
+
+// lockTablePair locks the tables a guest is moving from and to, lowest ID first, returning them
+// in the order they were asked for
+func (q *Queries) lockTablePair(ctx context.Context, fromID, toID int32) (from, to Table, err error) {
+	first, second := fromID, toID
+	if second < first {
+		first, second = second, first
+	}
+
+	locked := make(map[int32]Table, 2)
+	for _, id := range []int32{first, second} {
+		table, err := q.GetTableForUpdate(ctx, id)
+		if err != nil {
+			return from, to, err
+		}
+		locked[id] = table
+	}
+	return locked[fromID], locked[toID], nil
+}
+
+// moveArrival moves the guest's seated party and their open arrival from one table to the other,
+// the party must fit at the new table without anybody going unseated. The caller must hold the
+// locks on both tables.
+func (q *Queries) moveArrival(ctx context.Context, guest Guest, arrival Arrival, from, to Table) error {
+	if err := CheckPartySize(to, arrival.PartySize); err != nil {
+		return err
+	}
+	free, unseated, err := q.tableSeating(ctx, to, guest.ID)
+	if err != nil {
+		return err
+	}
+	if free-unseated < arrival.PartySize {
+		return InsufficientTableSizeErr(int(to.ID))
+	}
+	if to.Zone != from.Zone {
+		if err = q.checkZoneCapacity(ctx, to.Zone, arrival.PartySize); err != nil {
+			return err
+		}
+	}
+
+	// The named companions keep a seat of their own next to the guest
+	companions, err := q.ListArrivalCompanions(ctx, arrival.ID)
+	if err != nil {
+		return err
+	}
+	companionIDs := make([]int32, len(companions))
+	for i, companion := range companions {
+		companionIDs[i] = companion.ID
+	}
+
+	if err = q.releaseSeats(ctx, guest.ID); err != nil {
+		return err
+	}
+	if _, err = q.seatParty(ctx, to.ID, guest.ID, arrival.PartySize, companionIDs, nil); err != nil {
+		return err
+	}
+
+	// Both tables are updated before either is snapshot, so the venue never looks to have lost
+	// the party in between
+	moves := []struct {
+		table    Table
+		occupied int32
+	}{{from, from.Occupied - arrival.PartySize}, {to, to.Occupied + arrival.PartySize}}
+	for _, move := range moves {
+		err = q.UpdateTable(ctx, UpdateTableParams{
+			ID:       move.table.ID,
+			Size:     move.table.Size,
+			Occupied: move.occupied,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	for _, move := range moves {
+		if err = q.snapshotOccupancy(ctx, sql.NullInt32{Int32: move.table.ID, Valid: true}, move.occupied); err != nil {
+			return err
+		}
+	}
+
+	return q.UpdateArrivalTable(ctx, UpdateArrivalTableParams{
+		TableID: to.ID,
+		ID:      arrival.ID,
+	})
+}
+
+// moveReservation checks the reservation of a guest yet to arrive fits at the new table and moves
+// the seatsHeld numbered seats they were holding there. Guests whose status doesn't hold a place
+// at their table, e.g. those who declined, are moved without any check. The caller must hold the
+// lock on the new table.
+func (q *Queries) moveReservation(ctx context.Context, guest Guest, to Table, seatsHeld int32) error {
+	if !holdsSeats(guest.Status) {
+		return nil
+	}
+
+	partySize := guest.Entourage + 1
+	if err := CheckPartySize(to, partySize); err != nil {
+		return err
+	}
+	reserved, err := q.GetReservedSeats(ctx, GetReservedSeatsParams{
+		TableID: to.ID,
+		ID:      guest.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if reserved+int64(partySize) > int64(to.Size) {
+		return InsufficientTableSizeErr(int(to.ID))
+	}
+
+	if seatsHeld == 0 {
+		return nil
+	}
+	if err = q.releaseSeats(ctx, guest.ID); err != nil {
+		return err
+	}
+	_, err = q.seatParty(ctx, to.ID, guest.ID, seatsHeld, nil, nil)
+	return err
+}
+
+// TransitionGuestTxParams contains input parameters of the transaction changing a guest's status
+type TransitionGuestTxParams struct {
+	AuditInfo
+	ID     int32  `json:"id"`
+	Status string `json:"status"`
+}
+
+// TransitionGuestTx moves the guest to a status which doesn't change who is seated, i.e. confirmed,
+// declined, cancelled or no_show. Arriving and leaving take and free seats so must go through
+// AssignTableTx and LeaveGuestTx.
+func (store *SQLStore) TransitionGuestTx(ctx context.Context, arg TransitionGuestTxParams) (Guest, error) {
+	var guest Guest
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		oldGuest, err := q.GetGuestForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if arg.Status == GuestArrived || arg.Status == GuestLeft {
+			return fmt.Errorf("guests must arrive or leave through their own transactions, not become %s directly", arg.Status)
+		}
+		if err = checkTransition(oldGuest.Status, arg.Status); err != nil {
+			return err
+		}
+
+		err = q.UpdateGuestStatus(ctx, UpdateGuestStatusParams{
+			Status: arg.Status,
+			ID:     oldGuest.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		// Seats reserved at booking go back to the table once the guest won't be coming
+		if !holdsSeats(arg.Status) {
+			if err = q.releaseSeats(ctx, oldGuest.ID); err != nil {
+				return err
+			}
+		}
+
+		guest, err = q.GetGuest(ctx, oldGuest.ID)
+		if err != nil {
+			return err
+		}
+
+		return q.auditGuest(ctx, arg.AuditInfo, AuditUpdateGuestStatus, guest, &guestState{Guest: oldGuest}, &guestState{Guest: guest})
+	})
+	return guest, err
+}
+
+// UpdateGuestProfileTxParams contains input parameters of the transaction updating a guest's
+// profile, only the fields which are Valid are changed
+type UpdateGuestProfileTxParams struct {
+	AuditInfo
+	ID            int32          `json:"id"`
+	Dietary       sql.NullString `json:"dietary"`
+	Accessibility sql.NullString `json:"accessibility"`
+	VipTier       sql.NullString `json:"vip_tier"`
+	Email         sql.NullString `json:"email"`
+	Phone         sql.NullString `json:"phone"`
+	Notes         sql.NullString `json:"notes"`
+}
+
+// UpdateGuestProfileTx changes the dietary requirements, accessibility needs, VIP tier, contact
+// details or notes of a guest
+func (store *SQLStore) UpdateGuestProfileTx(ctx context.Context, arg UpdateGuestProfileTxParams) (Guest, error) {
+	var guest Guest
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		oldGuest, err := q.GetGuestForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		dietary := arg.Dietary
+		if dietary.Valid {
+			dietary.String = NormalizeDietary(dietary.String)
+		}
+
+		err = q.UpdateGuestProfile(ctx, UpdateGuestProfileParams{
+			Dietary:       dietary,
+			Accessibility: arg.Accessibility,
+			VipTier:       arg.VipTier,
+			Email:         arg.Email,
+			Phone:         arg.Phone,
+			Notes:         arg.Notes,
+			ID:            oldGuest.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		guest, err = q.GetGuest(ctx, oldGuest.ID)
+		if err != nil {
+			return err
+		}
+
+		return q.auditGuest(ctx, arg.AuditInfo, AuditUpdateGuestProfile, guest, &guestState{Guest: oldGuest}, &guestState{Guest: guest})
+	})
+	return guest, err
+}
+
+// SetGuestArrivalWindowTxParams contains input parameters of the transaction changing when a guest
+// is expected to arrive, an open end falls back to their table's window
+type SetGuestArrivalWindowTxParams struct {
+	AuditInfo
+	ArrivalWindow
+	ID int32 `json:"id"`
+}
+
+// SetGuestArrivalWindowTx changes when the guest is expected to arrive
+func (store *SQLStore) SetGuestArrivalWindowTx(ctx context.Context, arg SetGuestArrivalWindowTxParams) (Guest, error) {
+	var guest Guest
+
+	if err := arg.ArrivalWindow.Validate(); err != nil {
+		return guest, err
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		oldGuest, err := q.GetGuestForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		err = q.UpdateGuestArrivalWindow(ctx, UpdateGuestArrivalWindowParams{
+			ExpectedFrom:  arg.ExpectedFrom,
+			ExpectedUntil: arg.ExpectedUntil,
+			ID:            oldGuest.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		guest, err = q.GetGuest(ctx, oldGuest.ID)
+		if err != nil {
+			return err
+		}
+
+		return q.auditGuest(ctx, arg.AuditInfo, AuditUpdateArrivalWindow, guest, &guestState{Guest: oldGuest}, &guestState{Guest: guest})
+	})
+	return guest, err
+}
+
+// SetTableArrivalWindowTxParams contains input parameters of the transaction changing when the
+// guests at a table are expected to arrive
+type SetTableArrivalWindowTxParams struct {
+	AuditInfo
+	ArrivalWindow
+	ID int32 `json:"id"`
+}
+
+// SetTableArrivalWindowTx changes when the guests at the table are expected to arrive, guests with
+// their own window keep it
+func (store *SQLStore) SetTableArrivalWindowTx(ctx context.Context, arg SetTableArrivalWindowTxParams) (Table, error) {
+	var table Table
+
+	if err := arg.ArrivalWindow.Validate(); err != nil {
+		return table, err
+	}
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		oldTable, err := q.GetTableForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		err = q.UpdateTableArrivalWindow(ctx, UpdateTableArrivalWindowParams{
+			ExpectedFrom:  arg.ExpectedFrom,
+			ExpectedUntil: arg.ExpectedUntil,
+			ID:            oldTable.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		table, err = q.GetTable(ctx, oldTable.ID)
+		if err != nil {
+			return err
+		}
+
+		return q.auditTable(ctx, arg.AuditInfo, AuditUpdateArrivalWindow, &oldTable, table)
+	})
+	return table, err
+}
+
+// MarkNoShowsTxParams contains input parameters of marking the guests who missed their arrival
+// window no-show. Now is passed in rather than read from the clock so callers control time.
+type MarkNoShowsTxParams struct {
+	AuditInfo
+	Now   time.Time     `json:"now"`
+	Grace time.Duration `json:"grace"`
+}
+
+// MarkNoShowsTx marks every guest still expected whose arrival window closed more than the grace
+// period before now as no_show, releasing the seats reserved for them. Each guest is marked in a
+// transaction of their own, and is checked again once locked in case they arrived in the meantime.
+// The guests marked are returned, along with those marked before any error.
+func (store *SQLStore) MarkNoShowsTx(ctx context.Context, arg MarkNoShowsTxParams) ([]Guest, error) {
+	marked := []Guest{}
+
+	ids, err := store.ListOverdueGuests(ctx, arg.Now.Add(-arg.Grace))
+	if err != nil {
+		return marked, err
+	}
+
+	for _, id := range ids {
+		var guest Guest
+		err = store.execTx(ctx, func(q *Queries) error {
+			oldGuest, err := q.GetGuestForUpdate(ctx, id)
+			if err != nil {
+				return err
+			}
+			table, err := q.GetTable(ctx, oldGuest.TableID)
+			if err != nil {
+				return err
+			}
+			if !IsNoShow(oldGuest, table, arg.Now, arg.Grace) {
+				return nil
+			}
+
+			held, err := q.ListGuestSeats(ctx, sql.NullInt32{Int32: oldGuest.ID, Valid: true})
+			if err != nil {
+				return err
+			}
+
+			err = q.UpdateGuestStatus(ctx, UpdateGuestStatusParams{Status: GuestNoShow, ID: oldGuest.ID})
+			if err != nil {
+				return err
+			}
+			if err = q.releaseSeats(ctx, oldGuest.ID); err != nil {
+				return err
+			}
+
+			guest, err = q.GetGuest(ctx, oldGuest.ID)
+			if err != nil {
+				return err
+			}
+
+			return q.auditGuest(ctx, arg.AuditInfo, AuditMarkNoShow, guest, &guestState{Guest: oldGuest, Seats: held}, &guestState{Guest: guest})
+		})
+		if err != nil {
+			return marked, err
+		}
+		if guest.ID != 0 {
+			marked = append(marked, guest)
+		}
+	}
+	return marked, nil
+}
+
+// AddCompanionTxParams contains input parameters of the transaction naming one of a guest's
+// companions
+type AddCompanionTxParams struct {
+	AuditInfo
+	GuestID    int32           `json:"guest_id"`
+	Name       string          `json:"name"`
+	Attributes json.RawMessage `json:"attributes"`
+}
+
+// AddCompanionTx names one of the guest's entourage, a guest can't have more companions than
+// their entourage
+func (store *SQLStore) AddCompanionTx(ctx context.Context, arg AddCompanionTxParams) (Companion, error) {
+	var companion Companion
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		guest, err := q.GetGuestForUpdate(ctx, arg.GuestID)
+		if err != nil {
+			return err
+		}
+
+		count, err := q.CountCompanions(ctx, guest.ID)
+		if err != nil {
+			return err
+		}
+		if count >= int64(guest.Entourage) {
+			return ErrCompanionLimit
+		}
+
+		companionSQL, err := q.CreateCompanion(ctx, CreateCompanionParams{
+			GuestID:    guest.ID,
+			Name:       arg.Name,
+			Attributes: arg.Attributes,
+		})
+		if err != nil {
+			return err
+		}
+
+		companion, err = q.getCompanionFromSQLQuery(companionSQL)
+		if err != nil {
+			return err
+		}
+
+		return q.auditCompanion(ctx, arg.AuditInfo, AuditAddCompanion, guest, nil, &companion)
+	})
+	return companion, err
+}
+
+// UpdateCompanionTxParams contains input parameters of the transaction renaming a companion,
+// their attributes are kept if Attributes is nil
+type UpdateCompanionTxParams struct {
+	AuditInfo
+	ID         int32           `json:"id"`
+	GuestID    int32           `json:"guest_id"`
+	Name       string          `json:"name"`
+	Attributes json.RawMessage `json:"attributes"`
+}
+
+// UpdateCompanionTx renames one of the guest's companions and optionally replaces their attributes
+func (store *SQLStore) UpdateCompanionTx(ctx context.Context, arg UpdateCompanionTxParams) (Companion, error) {
+	var companion Companion
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		guest, oldCompanion, err := q.getGuestCompanionForUpdate(ctx, arg.GuestID, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		attributes := arg.Attributes
+		if attributes == nil {
+			attributes = oldCompanion.Attributes
+		}
+
+		err = q.UpdateCompanion(ctx, UpdateCompanionParams{
+			Name:       arg.Name,
+			Attributes: attributes,
+			ID:         oldCompanion.ID,
+		})
+		if err != nil {
+			return err
+		}
+
+		companion, err = q.GetCompanion(ctx, oldCompanion.ID)
+		if err != nil {
+			return err
+		}
+
+		return q.auditCompanion(ctx, arg.AuditInfo, AuditUpdateCompanion, guest, &oldCompanion, &companion)
+	})
+	return companion, err
+}
+
+// RemoveCompanionTxParams contains input parameters of the transaction removing a companion
+type RemoveCompanionTxParams struct {
+	AuditInfo
+	ID      int32 `json:"id"`
+	GuestID int32 `json:"guest_id"`
+}
+
+// RemoveCompanionTx removes one of the guest's companions. The guest's entourage is unchanged,
+// the seat just goes back to being anonymous.
+func (store *SQLStore) RemoveCompanionTx(ctx context.Context, arg RemoveCompanionTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		guest, companion, err := q.getGuestCompanionForUpdate(ctx, arg.GuestID, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteCompanion(ctx, companion.ID)
+		if err != nil {
+			return err
+		}
+
+		return q.auditCompanion(ctx, arg.AuditInfo, AuditRemoveCompanion, guest, &companion, nil)
+	})
+}
+
+// getGuestCompanionForUpdate locks the guest and returns them with the companion, which is
+// sql.ErrNoRows unless it's one of theirs
+func (q *Queries) getGuestCompanionForUpdate(ctx context.Context, guestID, companionID int32) (Guest, Companion, error) {
+	guest, err := q.GetGuestForUpdate(ctx, guestID)
+	if err != nil {
+		return guest, Companion{}, err
+	}
+
+	companion, err := q.GetCompanion(ctx, companionID)
+	if err != nil {
+		return guest, companion, err
+	}
+	if companion.GuestID != guest.ID {
+		return guest, companion, sql.ErrNoRows
+	}
+	return guest, companion, nil
+}
+
+// checkCapacity returns a *CapacityError if partySize more people would take the venue, or zone
+// when it has a limit of its own, over its maximum occupancy. It locks the venue's limit first so
+// every admission is serialised on it and the headcount can't change before the caller commits.
+// Callers must already hold the lock on the table being seated at.
+func (q *Queries) checkCapacity(ctx context.Context, zone string, partySize int32) error {
+	venue, err := q.GetCapacityLimitForUpdate(ctx, "")
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	if err == nil && venue.MaxOccupancy > 0 {
+		occupancy, err := q.GetVenueOccupancy(ctx)
+		if err != nil {
+			return err
+		}
+		if occupancy+int64(partySize) > int64(venue.MaxOccupancy) {
+			return &CapacityError{MaxOccupancy: venue.MaxOccupancy, Occupancy: occupancy, PartySize: partySize}
+		}
+	}
+
+	return q.checkZoneCapacity(ctx, zone, partySize)
+}
+
+// checkZoneCapacity returns a *CapacityError if partySize more people would take zone over its
+// maximum occupancy, leaving the venue's limit alone for people moving between zones. The venue as
+// a whole has no zone of its own so is never checked here.
+func (q *Queries) checkZoneCapacity(ctx context.Context, zone string, partySize int32) error {
+	if zone == "" {
+		return nil
+	}
+	limit, err := q.GetCapacityLimitForUpdate(ctx, zone)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if limit.MaxOccupancy > 0 {
+		occupancy, err := q.GetZoneOccupancy(ctx, zone)
+		if err != nil {
+			return err
+		}
+		if occupancy+int64(partySize) > int64(limit.MaxOccupancy) {
+			return &CapacityError{Zone: zone, MaxOccupancy: limit.MaxOccupancy, Occupancy: occupancy, PartySize: partySize}
+		}
+	}
+	return nil
+}
+
+// SetCapacityLimitTxParams contains input parameters of the transaction setting a maximum
+// occupancy, Zone is empty for the venue as a whole
+type SetCapacityLimitTxParams struct {
+	AuditInfo
+	Zone         string `json:"zone"`
+	MaxOccupancy int32  `json:"max_occupancy"`
+}
+
+// SetCapacityLimitTx sets the maximum occupancy of the venue or one of its zones, 0 removes the
+// limit. Lowering it below the current headcount doesn't remove anybody, it only stops admissions
+// until enough people leave.
+func (store *SQLStore) SetCapacityLimitTx(ctx context.Context, arg SetCapacityLimitTxParams) (CapacityLimit, error) {
+	var limit CapacityLimit
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		var before *CapacityLimit
+		old, err := q.GetCapacityLimitForUpdate(ctx, arg.Zone)
+		if err == nil {
+			before = &old
+		} else if err != sql.ErrNoRows {
+			return err
+		}
+
+		err = q.UpsertCapacityLimit(ctx, UpsertCapacityLimitParams{
+			Zone:         arg.Zone,
+			MaxOccupancy: arg.MaxOccupancy,
+			UpdatedBy:    arg.Actor,
+		})
+		if err != nil {
+			return err
+		}
+
+		limit, err = q.GetCapacityLimitForUpdate(ctx, arg.Zone)
+		if err != nil {
+			return err
+		}
+
+		return q.auditCapacity(ctx, arg.AuditInfo, before, &limit)
+	})
+	return limit, err
+}
+
+// SetAdmissionRulesTxParams contains input parameters of the transaction uploading a new version
+// of the admission rules
+type SetAdmissionRulesTxParams struct {
+	AuditInfo
+	Rules AdmissionRules `json:"rules"`
+}
+
+// SetAdmissionRulesTx checks every rule compiles and stores them as the next version of the
+// admission rules, which every arrival from then on must pass alongside the configured admission
+// policies. Uploading no rules removes them.
+func (store *SQLStore) SetAdmissionRulesTx(ctx context.Context, arg SetAdmissionRulesTxParams) (AdmissionRuleSet, error) {
+	var ruleSet AdmissionRuleSet
+
+	if _, err := CompileAdmissionRules(arg.Rules, 0); err != nil {
+		return ruleSet, err
+	}
+	document, err := json.Marshal(arg.Rules)
+	if err != nil {
+		return ruleSet, err
+	}
+
+	err = store.execTx(ctx, func(q *Queries) error {
+		var before *AdmissionRuleSet
+		old, err := q.GetLatestAdmissionRuleSet(ctx)
+		if err == nil {
+			before = &old
+		} else if err != sql.ErrNoRows {
+			return err
+		}
+
+		ruleSetSQL, err := q.CreateAdmissionRuleSet(ctx, CreateAdmissionRuleSetParams{
+			Document:  document,
+			CreatedBy: arg.Actor,
+		})
+		if err != nil {
+			return err
+		}
+		ruleSet, err = q.getAdmissionRuleSetFromSQLQuery(ruleSetSQL)
+		if err != nil {
+			return err
+		}
+
+		return q.auditAdmissionRules(ctx, arg.AuditInfo, before, &ruleSet)
+	})
+	return ruleSet, err
+}
+
+// BanPersonTxParams contains input parameters of the transaction adding a person to the ban list.
+// Email and phone are optional, and match guests by them as well as by name.
+type BanPersonTxParams struct {
+	AuditInfo
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Phone  string `json:"phone"`
+	Reason string `json:"reason"`
+}
+
+// BanPersonTx adds a person to the ban list, guests matching them can no longer be booked or arrive
+func (store *SQLStore) BanPersonTx(ctx context.Context, arg BanPersonTxParams) (BannedPerson, error) {
+	var person BannedPerson
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		personSQL, err := q.CreateBannedPerson(ctx, CreateBannedPersonParams{
+			Name:      arg.Name,
+			Email:     arg.Email,
+			Phone:     arg.Phone,
+			Reason:    arg.Reason,
+			CreatedBy: arg.Actor,
+		})
+		if err != nil {
+			return err
+		}
+		person, err = q.getBannedPersonFromSQLQuery(personSQL)
+		if err != nil {
+			return err
+		}
+
+		return q.auditBan(ctx, arg.AuditInfo, AuditBanPerson, nil, &person)
+	})
+	return person, err
+}
+
+// UnbanPersonTxParams contains input parameters of the transaction removing a person from the ban
+// list
+type UnbanPersonTxParams struct {
+	AuditInfo
+	ID int32 `json:"id"`
+}
+
+// UnbanPersonTx removes a person from the ban list
+func (store *SQLStore) UnbanPersonTx(ctx context.Context, arg UnbanPersonTxParams) error {
+	return store.execTx(ctx, func(q *Queries) error {
+		person, err := q.GetBannedPersonForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		if err = q.DeleteBannedPerson(ctx, person.ID); err != nil {
+			return err
+		}
+
+		return q.auditBan(ctx, arg.AuditInfo, AuditUnbanPerson, &person, nil)
+	})
+}
+
+// AdmitStandingTxParams contains input parameters of the transaction admitting a standing party
+type AdmitStandingTxParams struct {
+	AuditInfo
+	Name      string `json:"name"`
+	PartySize int32  `json:"party_size"`
+}
+
+// AdmitStandingTx lets a party in without a table, e.g. to the bar, as long as the venue has room
+// for them. They count towards the venue's headcount until DepartStandingTx.
+func (store *SQLStore) AdmitStandingTx(ctx context.Context, arg AdmitStandingTxParams) (StandingAdmission, error) {
+	var admission StandingAdmission
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		if err := q.checkCapacity(ctx, "", arg.PartySize); err != nil {
+			return err
+		}
+
+		admissionSQL, err := q.CreateStandingAdmission(ctx, CreateStandingAdmissionParams{
+			Name:       arg.Name,
+			PartySize:  arg.PartySize,
+			AdmittedBy: arg.Actor,
+		})
+		if err != nil {
+			return err
+		}
+
+		admission, err = q.getStandingAdmissionFromSQLQuery(admissionSQL)
+		if err != nil {
+			return err
+		}
+		if err = q.snapshotOccupancy(ctx, sql.NullInt32{}, 0); err != nil {
+			return err
+		}
+
+		return q.auditStanding(ctx, arg.AuditInfo, AuditAdmitStanding, nil, &admission)
+	})
+	return admission, err
+}
+
+// DepartStandingTxParams contains input parameters of the transaction ending a standing admission
+type DepartStandingTxParams struct {
+	AuditInfo
+	ID int32 `json:"id"`
+}
+
+// DepartStandingTx records a standing party leaving, freeing their share of the venue's capacity
+func (store *SQLStore) DepartStandingTx(ctx context.Context, arg DepartStandingTxParams) (StandingAdmission, error) {
+	var admission StandingAdmission
+
+	err := store.execTx(ctx, func(q *Queries) error {
+		old, err := q.GetStandingAdmissionForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+		if old.DepartedAt.Valid {
+			return ErrStandingDeparted
+		}
+
+		err = q.EndStandingAdmission(ctx, old.ID)
+		if err != nil {
+			return err
+		}
+		if err = q.snapshotOccupancy(ctx, sql.NullInt32{}, 0); err != nil {
+			return err
+		}
+
+		admission, err = q.GetStandingAdmission(ctx, old.ID)
+		if err != nil {
+			return err
+		}
+
+		return q.auditStanding(ctx, arg.AuditInfo, AuditDepartStanding, &old, &admission)
+	})
+	return admission, err
+}
+
+// DeleteGuestTxParams contains input parameters of the transaction deleting a guest
+type DeleteGuestTxParams struct {
+	AuditInfo
+	ID int32 `json:"id"`
+}
+
+// DeleteGuestTx deletes a guest from the guests table while also freeing up their table space
+// if they've already arrived. The arrival is recorded as a leave before the guest is deleted,
+// as the arrivals row cascades away with the guest.
+func (store *SQLStore) DeleteGuestTx(ctx context.Context, arg DeleteGuestTxParams) error {
+
+	err := store.execTx(ctx, func(q *Queries) error {
+
+		guest, err := q.GetGuestForUpdate(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		// Only a guest who has arrived occupies seats, there is nothing to free otherwise
+		arrival, err := q.GetOpenArrivalFromGuest(ctx, arg.ID)
+		if err == nil {
+			table, err := q.GetTableForUpdate(ctx, arrival.TableID)
+			if err != nil {
+				return err
+			}
+
+			err = q.updateOccupancy(ctx, table, table.Occupied-arrival.PartySize)
+			if err != nil {
+				return err
+			}
+
+			updated, err := q.GetTable(ctx, table.ID)
+			if err != nil {
+				return err
+			}
+
+			err = q.auditGuest(ctx, arg.AuditInfo, AuditLeaveGuest, guest,
+				&guestState{Guest: guest, Arrival: &arrival},
+				&guestState{Guest: guest})
+			if err != nil {
+				return err
+			}
+			err = q.auditTable(ctx, arg.AuditInfo, AuditUpdateTable, &table, updated)
+			if err != nil {
+				return err
+			}
+		} else if err != sql.ErrNoRows {
+			return err
+		}
+
+		err = q.releaseSeats(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteGuest(ctx, arg.ID)
+		if err != nil {
+			return err
+		}
+
+		return q.auditGuest(ctx, arg.AuditInfo, AuditDeleteGuest, guest, &guestState{Guest: guest}, nil)
 	})
 	return err
 }
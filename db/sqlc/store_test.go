@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -31,10 +32,8 @@ func TestAssignTableTx(t *testing.T) {
 		table := differentTables[util.RandomInt(0, int32(variations)-1)]
 		guest := createRandomGuest(t, table.ID)
 
-		txName := fmt.Sprintf("tx %d", i+1)
 		go func() {
-			ctx := context.WithValue(context.Background(), txKey, txName)
-			result, err := store.AssignTableTx(ctx, AssignTableTxParams{
+			result, err := store.AssignTableTx(context.Background(), AssignTableTxParams{
 				UserID:       int64(guest.ID),
 				TableID:      int64(table.ID),
 				NewEntourage: int64(guest.Entourage),
@@ -62,8 +61,7 @@ func TestAssignTableTx(t *testing.T) {
 		// If the party size was more than the table size we want to check we return the expected error
 		// then exit any subsequent checks
 		if err != nil && guest.Entourage+table.Occupied+1 > table.Size {
-			require.Error(t, err)
-			require.EqualError(t, InsufficientTableSizeErr(int(table.ID)), err.Error())
+			require.ErrorIs(t, err, ErrInsufficientTableSize)
 			continue
 		}
 
@@ -111,22 +109,26 @@ func TestDeleteGuestTx(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, guest)
 
+	audit := AuditInfo{Actor: util.DoorStaffRole, RequestID: util.RandomString(16)}
+
 	assignTableTxResult, err := store.AssignTableTx(context.Background(), AssignTableTxParams{
 		UserID:       int64(guest.ID),
 		TableID:      int64(guest.TableID),
 		NewEntourage: int64(guest.Entourage),
+		AuditInfo:    audit,
 	})
 	require.NoError(t, err)
 	require.NotEmpty(t, assignTableTxResult)
 
 	arrival := assignTableTxResult.Arrival
 	require.NotEmpty(t, arrival)
+	require.Equal(t, util.DoorStaffRole, arrival.ArrivedBy)
 
 	newTable := assignTableTxResult.Table
 	require.NotEmpty(t, newTable)
 	require.Equal(t, newTable.Size, arrival.PartySize)
 
-	err = store.DeleteGuestTx(context.Background(), guest.ID)
+	err = store.DeleteGuestTx(context.Background(), DeleteGuestTxParams{ID: guest.ID, AuditInfo: audit})
 	require.NoError(t, err)
 
 	guest2, err := testQueries.GetGuest(context.Background(), guest.ID)
@@ -138,4 +140,896 @@ func TestDeleteGuestTx(t *testing.T) {
 	table, err = testQueries.GetTable(context.Background(), table.ID)
 	require.NoError(t, err)
 	require.Equal(t, int(table.Occupied), 0)
+
+	// The whole history survives the guest being deleted
+	events, err := store.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		TableID: sql.NullInt32{Int32: table.ID, Valid: true},
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 5)
+
+	actions := []string{AuditArriveGuest, AuditUpdateTable, AuditLeaveGuest, AuditUpdateTable, AuditDeleteGuest}
+	for i, event := range events {
+		require.Equal(t, actions[i], event.Action)
+		require.Equal(t, audit.Actor, event.Actor)
+		require.Equal(t, audit.RequestID, event.RequestID)
+		require.Equal(t, table.ID, event.TableID.Int32)
+	}
+	require.Nil(t, events[4].After)
+
+	var before guestState
+	require.NoError(t, json.Unmarshal(events[4].Before, &before))
+	require.Equal(t, guest.ID, before.Guest.ID)
+}
+
+func TestCreateGuestTx(t *testing.T) {
+	store := NewStore(testDB)
+	table := createRandomTable(t)
+	audit := AuditInfo{Actor: util.RandomString(8), RequestID: util.RandomString(16)}
+
+	guest, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		Entourage: table.Size - 1,
+		TableID:   table.ID,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, guest.ID)
+	require.Equal(t, audit.Actor, guest.CreatedBy)
+
+	events, err := store.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		Actor: sql.NullString{String: audit.Actor, Valid: true},
+		Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, AuditCreateGuest, events[0].Action)
+	require.Equal(t, guest.GuestName, events[0].GuestName)
+	require.Equal(t, guest.ID, events[0].GuestID.Int32)
+	require.Nil(t, events[0].Before)
+
+	var after guestState
+	require.NoError(t, json.Unmarshal(events[0].After, &after))
+	require.Equal(t, guest.ID, after.Guest.ID)
+	require.Nil(t, after.Arrival)
+
+	// The guest is sent an invitation along with their place on the list
+	invitation, err := store.GetActiveInvitationFromGuest(context.Background(), guest.ID)
+	require.NoError(t, err)
+	require.Equal(t, audit.Actor, invitation.CreatedBy)
+	require.Equal(t, AuditIssueInvitation, events[1].Action)
+}
+
+func TestCreateTableTx(t *testing.T) {
+	store := NewStore(testDB)
+	audit := AuditInfo{Actor: util.RandomString(8), RequestID: util.RandomString(16)}
+
+	table, err := store.CreateTableTx(context.Background(), CreateTableTxParams{
+		AuditInfo: audit,
+		Size:      util.RandomTableSize(),
+	})
+	require.NoError(t, err)
+	require.NotZero(t, table.ID)
+	require.Equal(t, audit.Actor, table.CreatedBy)
+
+	events, err := store.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		TableID: sql.NullInt32{Int32: table.ID, Valid: true},
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, AuditCreateTable, events[0].Action)
+	require.Equal(t, audit.RequestID, events[0].RequestID)
+	require.False(t, events[0].GuestID.Valid)
+
+	var after Table
+	require.NoError(t, json.Unmarshal(events[0].After, &after))
+	require.Equal(t, table.Size, after.Size)
+
+	// Without any details the table is labelled after its ID and takes any party it can seat
+	require.Equal(t, fmt.Sprintf("Table %d", table.ID), table.Label)
+	require.Equal(t, table.Label, after.Label)
+	require.Equal(t, ShapeRound, table.Shape)
+	require.Equal(t, int32(1), table.MinParty)
+	require.Equal(t, table.Size, table.MaxParty)
+
+	booth, err := store.CreateTableTx(context.Background(), CreateTableTxParams{
+		AuditInfo:  audit,
+		Size:       6,
+		Label:      "Booth " + util.RandomString(8),
+		Zone:       "Terrace",
+		Accessible: true,
+		Shape:      ShapeBooth,
+		MinParty:   4,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(4), booth.MinParty)
+	require.Equal(t, int32(6), booth.MaxParty)
+
+	byLabel, err := store.GetTableFromLabel(context.Background(), booth.Label)
+	require.NoError(t, err)
+	require.Equal(t, booth.ID, byLabel.ID)
+
+	_, err = store.CreateTableTx(context.Background(), CreateTableTxParams{AuditInfo: audit, Size: 4, Label: booth.Label})
+	require.ErrorIs(t, err, ErrTableLabelTaken)
+
+	_, err = store.CreateTableTx(context.Background(), CreateTableTxParams{AuditInfo: audit, Size: 4, MaxParty: 5})
+	require.ErrorIs(t, err, ErrInvalidPartyRange)
+}
+
+func TestAssignTablePartySize(t *testing.T) {
+	store := NewStore(testDB)
+	audit := AuditInfo{Actor: util.DoorStaffRole, RequestID: util.RandomString(16)}
+
+	booth, err := store.CreateTableTx(context.Background(), CreateTableTxParams{
+		AuditInfo: audit,
+		Size:      8,
+		MinParty:  2,
+		MaxParty:  4,
+	})
+	require.NoError(t, err)
+
+	guest, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		Entourage: 1,
+		TableID:   booth.ID,
+	})
+	require.NoError(t, err)
+
+	// The table has the seats but only takes parties of 2 to 4
+	for _, entourage := range []int64{0, 4} {
+		_, err = store.AssignTableTx(context.Background(), AssignTableTxParams{
+			AuditInfo:    audit,
+			UserID:       int64(guest.ID),
+			NewEntourage: entourage,
+			TableID:      int64(booth.ID),
+		})
+		var partySizeErr *PartySizeError
+		require.ErrorAs(t, err, &partySizeErr)
+		require.Equal(t, int32(entourage)+1, partySizeErr.PartySize)
+	}
+
+	result, err := store.AssignTableTx(context.Background(), AssignTableTxParams{
+		AuditInfo:    audit,
+		UserID:       int64(guest.ID),
+		NewEntourage: 3,
+		TableID:      int64(booth.ID),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(4), result.Table.Occupied)
+}
+
+func TestArriveByInvitationTx(t *testing.T) {
+	store := NewStore(testDB)
+	table := createRandomTable(t)
+	audit := AuditInfo{Actor: util.RandomString(8), RequestID: util.RandomString(16)}
+
+	guest, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		Entourage: 0,
+		TableID:   table.ID,
+	})
+	require.NoError(t, err)
+	invitation, err := store.GetActiveInvitationFromGuest(context.Background(), guest.ID)
+	require.NoError(t, err)
+
+	arg := ArriveByInvitationTxParams{
+		AuditInfo:    audit,
+		InvitationID: invitation.ID,
+		GuestID:      guest.ID,
+		NewEntourage: int64(table.Size),
+	}
+
+	// A party too big for the table doesn't use up the invitation
+	_, err = store.ArriveByInvitationTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInsufficientTableSize)
+	_, err = store.GetActiveInvitationFromGuest(context.Background(), guest.ID)
+	require.NoError(t, err)
+
+	// The invitation can't be claimed by another guest
+	wrongGuest := arg
+	wrongGuest.GuestID = guest.ID + 1
+	_, err = store.ArriveByInvitationTx(context.Background(), wrongGuest)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	arg.NewEntourage = 0
+	result, err := store.ArriveByInvitationTx(context.Background(), arg)
+	require.NoError(t, err)
+	require.Equal(t, guest.ID, result.Guest.ID)
+	require.Equal(t, table.Occupied+1, result.Table.Occupied)
+
+	used, err := store.GetInvitation(context.Background(), invitation.ID)
+	require.NoError(t, err)
+	require.True(t, used.UsedAt.Valid)
+
+	_, err = store.ArriveByInvitationTx(context.Background(), arg)
+	require.ErrorIs(t, err, ErrInvitationUsed)
+}
+
+func TestIssueAndRevokeInvitationTx(t *testing.T) {
+	store := NewStore(testDB)
+	table := createRandomTable(t)
+	audit := AuditInfo{Actor: util.RandomString(8), RequestID: util.RandomString(16)}
+
+	guest, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		Entourage: 0,
+		TableID:   table.ID,
+	})
+	require.NoError(t, err)
+	original, err := store.GetActiveInvitationFromGuest(context.Background(), guest.ID)
+	require.NoError(t, err)
+
+	// Reissuing revokes the original
+	reissued, err := store.IssueInvitationTx(context.Background(), IssueInvitationTxParams{AuditInfo: audit, GuestID: guest.ID})
+	require.NoError(t, err)
+	require.NotEqual(t, original.ID, reissued.ID)
+
+	_, err = store.ArriveByInvitationTx(context.Background(), ArriveByInvitationTxParams{
+		AuditInfo:    audit,
+		InvitationID: original.ID,
+		GuestID:      guest.ID,
+	})
+	require.ErrorIs(t, err, ErrInvitationRevoked)
+
+	err = store.RevokeInvitationTx(context.Background(), RevokeInvitationTxParams{AuditInfo: audit, GuestID: guest.ID})
+	require.NoError(t, err)
+	_, err = store.GetActiveInvitationFromGuest(context.Background(), guest.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = store.RevokeInvitationTx(context.Background(), RevokeInvitationTxParams{AuditInfo: audit, GuestID: guest.ID})
+	require.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestRSVPTx(t *testing.T) {
+	store := NewStore(testDB)
+	table := createRandomTable(t)
+	audit := AuditInfo{Actor: util.RandomString(8), RequestID: util.RandomString(16)}
+
+	rsvp := func(guest Guest, status string, entourage int32) (Guest, error) {
+		invitation, err := store.GetActiveInvitationFromGuest(context.Background(), guest.ID)
+		require.NoError(t, err)
+		return store.RSVPTx(context.Background(), RSVPTxParams{
+			AuditInfo:    audit,
+			InvitationID: invitation.ID,
+			GuestID:      guest.ID,
+			Status:       status,
+			Entourage:    entourage,
+		})
+	}
+
+	guests := make([]Guest, 2)
+	for i := range guests {
+		guest, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+			AuditInfo: audit,
+			GuestName: util.RandomGuestName(),
+			Entourage: 0,
+			TableID:   table.ID,
+		})
+		require.NoError(t, err)
+		require.Equal(t, RSVPPending, guest.RsvpStatus)
+		guests[i] = guest
+	}
+
+	// A party bigger than the table takes is turned down before counting the seats
+	_, err := rsvp(guests[0], RSVPAccepted, table.MaxParty)
+	require.ErrorIs(t, err, ErrPartySize)
+
+	// The first guest takes every seat but the one the second has reserved
+	accepted, err := rsvp(guests[0], RSVPAccepted, table.Size-2)
+	require.NoError(t, err)
+	require.Equal(t, RSVPAccepted, accepted.RsvpStatus)
+	require.Equal(t, table.Size-2, accepted.Entourage)
+	require.True(t, accepted.RsvpAt.Valid)
+
+	_, err = rsvp(guests[1], RSVPAccepted, 1)
+	require.ErrorIs(t, err, ErrInsufficientTableSize)
+
+	// Declining frees the second guest's seat
+	declined, err := rsvp(guests[1], RSVPDeclined, 0)
+	require.NoError(t, err)
+	require.Equal(t, RSVPDeclined, declined.RsvpStatus)
+
+	accepted, err = rsvp(guests[0], RSVPAccepted, table.Size-1)
+	require.NoError(t, err)
+	require.Equal(t, table.Size-1, accepted.Entourage)
+
+	// RSVPs close once the guest has arrived
+	_, err = store.AssignTableTx(context.Background(), AssignTableTxParams{
+		AuditInfo:    audit,
+		UserID:       int64(accepted.ID),
+		TableID:      int64(table.ID),
+		NewEntourage: int64(accepted.Entourage),
+	})
+	require.NoError(t, err)
+	_, err = rsvp(guests[0], RSVPDeclined, 0)
+	require.ErrorIs(t, err, ErrGuestAlreadyArrived)
+}
+
+func TestLeaveGuestTx(t *testing.T) {
+	store := NewStore(testDB)
+	table := createRandomTable(t)
+	audit := AuditInfo{Actor: util.DoorStaffRole, RequestID: util.RandomString(16)}
+
+	guest, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		Entourage: 1,
+		TableID:   table.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, GuestInvited, guest.Status)
+
+	// Only guests who have arrived can leave
+	_, err = store.LeaveGuestTx(context.Background(), LeaveGuestTxParams{AuditInfo: audit, ID: guest.ID})
+	require.ErrorIs(t, err, ErrIllegalTransition)
+
+	arrive := func() {
+		result, err := store.AssignTableTx(context.Background(), AssignTableTxParams{
+			AuditInfo:    audit,
+			UserID:       int64(guest.ID),
+			TableID:      int64(table.ID),
+			NewEntourage: int64(guest.Entourage),
+		})
+		require.NoError(t, err)
+		require.Equal(t, GuestArrived, result.Guest.Status)
+		require.Equal(t, guest.Entourage+1, result.Table.Occupied)
+	}
+	arrive()
+
+	left, err := store.LeaveGuestTx(context.Background(), LeaveGuestTxParams{AuditInfo: audit, ID: guest.ID})
+	require.NoError(t, err)
+	require.Equal(t, GuestLeft, left.Status)
+
+	updated, err := store.GetTable(context.Background(), table.ID)
+	require.NoError(t, err)
+	require.Zero(t, updated.Occupied)
+
+	// A guest who left is kept on the list and may come back in
+	arrive()
+}
+
+func TestTransitionGuestTx(t *testing.T) {
+	store := NewStore(testDB)
+	table := createRandomTable(t)
+	audit := AuditInfo{Actor: util.OrganiserRole, RequestID: util.RandomString(16)}
+
+	guest, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		Entourage: 0,
+		TableID:   table.ID,
+	})
+	require.NoError(t, err)
+
+	noShow, err := store.TransitionGuestTx(context.Background(), TransitionGuestTxParams{
+		AuditInfo: audit,
+		ID:        guest.ID,
+		Status:    GuestNoShow,
+	})
+	require.NoError(t, err)
+	require.Equal(t, GuestNoShow, noShow.Status)
+
+	_, err = store.TransitionGuestTx(context.Background(), TransitionGuestTxParams{
+		AuditInfo: audit,
+		ID:        guest.ID,
+		Status:    GuestConfirmed,
+	})
+	require.ErrorIs(t, err, ErrIllegalTransition)
+
+	// Arriving is left to AssignTableTx so the table is updated with the guest
+	_, err = store.TransitionGuestTx(context.Background(), TransitionGuestTxParams{
+		AuditInfo: audit,
+		ID:        guest.ID,
+		Status:    GuestArrived,
+	})
+	require.Error(t, err)
+
+	events, err := store.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		GuestName: sql.NullString{String: guest.GuestName, Valid: true},
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AuditUpdateGuestStatus, events[len(events)-1].Action)
+}
+
+func TestGuestProfileTx(t *testing.T) {
+	store := NewStore(testDB)
+	table := createRandomTable(t)
+	audit := AuditInfo{Actor: util.OrganiserRole, RequestID: util.RandomString(16)}
+
+	guest, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestProfile: GuestProfile{
+			Dietary: " Vegetarian ,Nut_Allergy,",
+			VipTier: VIPGold,
+			Email:   "guest@example.com",
+		},
+		GuestName: util.RandomGuestName(),
+		Entourage: 0,
+		TableID:   table.ID,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "vegetarian,nut_allergy", guest.Dietary)
+	require.Equal(t, VIPGold, guest.VipTier)
+	require.Equal(t, "guest@example.com", guest.Email)
+
+	// Each requirement is matched on its own
+	guests, err := store.GetGuests(context.Background(), GetGuestsParams{
+		TableID: sql.NullInt32{Int32: table.ID, Valid: true},
+		Dietary: sql.NullString{String: "nut_allergy", Valid: true},
+		VipTier: sql.NullString{String: VIPGold, Valid: true},
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Len(t, guests, 1)
+	require.Equal(t, guest.ID, guests[0].ID)
+
+	// Only the given fields change
+	updated, err := store.UpdateGuestProfileTx(context.Background(), UpdateGuestProfileTxParams{
+		AuditInfo: audit,
+		ID:        guest.ID,
+		Dietary:   sql.NullString{String: "Vegan", Valid: true},
+		VipTier:   sql.NullString{String: "", Valid: true},
+		Notes:     sql.NullString{String: "Seat near the stage", Valid: true},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "vegan", updated.Dietary)
+	require.Empty(t, updated.VipTier)
+	require.Equal(t, "Seat near the stage", updated.Notes)
+	require.Equal(t, guest.Email, updated.Email)
+
+	guests, err = store.GetGuests(context.Background(), GetGuestsParams{
+		TableID: sql.NullInt32{Int32: table.ID, Valid: true},
+		Dietary: sql.NullString{String: "nut_allergy", Valid: true},
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Empty(t, guests)
+
+	events, err := store.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		GuestName: sql.NullString{String: guest.GuestName, Valid: true},
+		Limit:     10,
+	})
+	require.NoError(t, err)
+	require.Equal(t, AuditUpdateGuestProfile, events[len(events)-1].Action)
+}
+
+func TestUpdatePartyTx(t *testing.T) {
+	store := NewStore(testDB)
+	table := createRandomTable(t)
+	audit := AuditInfo{Actor: util.DoorStaffRole, RequestID: util.RandomString(16)}
+
+	guest, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		Entourage: 1,
+		TableID:   table.ID,
+	})
+	require.NoError(t, err)
+
+	update := func(entourage int32) (UpdatePartyTxResult, error) {
+		return store.UpdatePartyTx(context.Background(), UpdatePartyTxParams{
+			AuditInfo:    audit,
+			ID:           guest.ID,
+			NewEntourage: entourage,
+		})
+	}
+
+	_, err = update(0)
+	require.ErrorIs(t, err, ErrGuestNotArrived)
+
+	_, err = store.AssignTableTx(context.Background(), AssignTableTxParams{
+		AuditInfo:    audit,
+		UserID:       int64(guest.ID),
+		TableID:      int64(table.ID),
+		NewEntourage: int64(guest.Entourage),
+	})
+	require.NoError(t, err)
+
+	// A latecomer fills the table, nobody else fits
+	full, err := update(table.Size - 1)
+	require.NoError(t, err)
+	require.Equal(t, table.Size, full.Table.Occupied)
+	require.Equal(t, table.Size, full.Arrival.PartySize)
+	require.Equal(t, table.Size-1, full.Guest.Entourage)
+
+	// The table takes no bigger party than its size
+	_, err = update(table.Size)
+	require.ErrorIs(t, err, ErrPartySize)
+
+	// Everyone but the guest leaves early
+	alone, err := update(0)
+	require.NoError(t, err)
+	require.Equal(t, int32(1), alone.Table.Occupied)
+	require.Equal(t, int32(1), alone.Arrival.PartySize)
+
+	require.Len(t, alone.History, 2)
+	require.Equal(t, int32(2), alone.History[0].OldPartySize)
+	require.Equal(t, table.Size, alone.History[0].NewPartySize)
+	require.Equal(t, table.Size, alone.History[1].OldPartySize)
+	require.Equal(t, int32(1), alone.History[1].NewPartySize)
+	require.Equal(t, audit.Actor, alone.History[1].ChangedBy)
+}
+
+func TestUpdatePartyTxChecks(t *testing.T) {
+	store := NewStore(testDB, WithAdmissionPolicies(AdmissionPolicies{TableCapacityPolicy{}, RSVPLimitPolicy{Extra: 1}}))
+	audit := AuditInfo{Actor: util.DoorStaffRole, RequestID: util.RandomString(16)}
+
+	table, err := store.CreateTableTx(context.Background(), CreateTableTxParams{
+		AuditInfo: audit,
+		Size:      8,
+		MinParty:  2,
+		MaxParty:  6,
+	})
+	require.NoError(t, err)
+
+	guest, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		Entourage: 2,
+		TableID:   table.ID,
+	})
+	require.NoError(t, err)
+
+	companions := make([]Companion, guest.Entourage)
+	for i := range companions {
+		companions[i], err = store.AddCompanionTx(context.Background(), AddCompanionTxParams{
+			AuditInfo: audit,
+			GuestID:   guest.ID,
+			Name:      util.RandomGuestName(),
+		})
+		require.NoError(t, err)
+	}
+
+	_, err = store.AssignTableTx(context.Background(), AssignTableTxParams{
+		AuditInfo:    audit,
+		UserID:       int64(guest.ID),
+		TableID:      int64(table.ID),
+		NewEntourage: int64(guest.Entourage),
+		CompanionIDs: []int32{companions[0].ID, companions[1].ID},
+	})
+	require.NoError(t, err)
+
+	update := func(entourage int32) (UpdatePartyTxResult, error) {
+		return store.UpdatePartyTx(context.Background(), UpdatePartyTxParams{
+			AuditInfo:    audit,
+			ID:           guest.ID,
+			NewEntourage: entourage,
+		})
+	}
+
+	// The table only takes parties of 2 to 6
+	_, err = update(0)
+	var partySizeErr *PartySizeError
+	require.ErrorAs(t, err, &partySizeErr)
+
+	// One more than booked is within the RSVP limit, two more isn't, and the seats are untouched
+	_, err = update(4)
+	var denied *AdmissionDeniedError
+	require.ErrorAs(t, err, &denied)
+	require.Equal(t, PolicyRSVPLimit, denied.Decisions[1].Policy)
+	require.False(t, denied.Decisions[1].Allowed)
+
+	seats, err := store.ListGuestSeats(context.Background(), sql.NullInt32{Int32: guest.ID, Valid: true})
+	require.NoError(t, err)
+	require.Len(t, seats, 3)
+
+	grown, err := update(3)
+	require.NoError(t, err)
+	require.Equal(t, int32(4), grown.Arrival.PartySize)
+	require.Len(t, grown.Seats, 4)
+
+	// Shrinking to the guest and one companion leaves the other companion out
+	shrunk, err := update(1)
+	require.NoError(t, err)
+	require.Len(t, shrunk.Seats, 2)
+
+	arrived, err := store.ListArrivalCompanions(context.Background(), shrunk.Arrival.ID)
+	require.NoError(t, err)
+	require.Len(t, arrived, 1)
+	require.Equal(t, companions[0].ID, arrived[0].ID)
+	require.Equal(t, companions[0].ID, shrunk.Seats[1].CompanionID.Int32)
+}
+
+func TestMoveGuestTx(t *testing.T) {
+	store := NewStore(testDB)
+	audit := AuditInfo{Actor: util.DoorStaffRole, RequestID: util.RandomString(16)}
+
+	createTable := func(size int32) Table {
+		table, err := store.CreateTableTx(context.Background(), CreateTableTxParams{AuditInfo: audit, Size: size})
+		require.NoError(t, err)
+		return table
+	}
+	from, to, small := createTable(4), createTable(4), createTable(1)
+
+	guest, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		Entourage: 1,
+		TableID:   from.ID,
+		Seats:     []int32{1, 2},
+	})
+	require.NoError(t, err)
+
+	move := func(tableID int32) (MoveGuestTxResult, error) {
+		return store.MoveGuestTx(context.Background(), MoveGuestTxParams{
+			AuditInfo: audit,
+			ID:        guest.ID,
+			TableID:   tableID,
+		})
+	}
+
+	_, err = move(from.ID)
+	require.ErrorIs(t, err, ErrAlreadyAtTable)
+
+	_, err = move(small.ID)
+	require.ErrorIs(t, err, ErrInsufficientTableSize)
+
+	// The reservation moves along with the seats it was holding
+	reserved, err := move(to.ID)
+	require.NoError(t, err)
+	require.Nil(t, reserved.Arrival)
+	require.Equal(t, to.ID, reserved.Guest.TableID)
+	require.Equal(t, from.ID, reserved.OldTable.ID)
+	require.Len(t, reserved.Seats, 2)
+	for _, seat := range reserved.Seats {
+		require.Equal(t, to.ID, seat.TableID)
+	}
+
+	arrived, err := store.AssignTableTx(context.Background(), AssignTableTxParams{
+		AuditInfo:    audit,
+		UserID:       int64(guest.ID),
+		TableID:      int64(to.ID),
+		NewEntourage: int64(guest.Entourage),
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), arrived.Table.Occupied)
+
+	// Once seated the party takes their arrival back to the first table
+	seated, err := move(from.ID)
+	require.NoError(t, err)
+	require.NotNil(t, seated.Arrival)
+	require.Equal(t, arrived.Arrival.ID, seated.Arrival.ID)
+	require.Equal(t, from.ID, seated.Arrival.TableID)
+	require.Equal(t, from.ID, seated.Guest.TableID)
+	require.Equal(t, GuestArrived, seated.Guest.Status)
+	require.Equal(t, int32(0), seated.OldTable.Occupied)
+	require.Equal(t, int32(2), seated.Table.Occupied)
+	require.Len(t, seated.Seats, 2)
+	for _, seat := range seated.Seats {
+		require.Equal(t, from.ID, seat.TableID)
+	}
+
+	_, err = move(small.ID)
+	require.ErrorIs(t, err, ErrInsufficientTableSize)
+}
+
+func TestReentry(t *testing.T) {
+	audit := AuditInfo{Actor: util.DoorStaffRole, RequestID: util.RandomString(16)}
+
+	testCases := []struct {
+		name        string
+		denyReentry bool
+	}{
+		{name: "Allowed", denyReentry: false},
+		{name: "Denied", denyReentry: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			store := NewStore(testDB, WithReentryDenied(tc.denyReentry))
+			table := createRandomTable(t)
+
+			guest, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+				AuditInfo: audit,
+				GuestName: util.RandomGuestName(),
+				Entourage: 0,
+				TableID:   table.ID,
+			})
+			require.NoError(t, err)
+
+			arrive := func() (AssignTableTxResult, error) {
+				return store.AssignTableTx(context.Background(), AssignTableTxParams{
+					AuditInfo:    audit,
+					UserID:       int64(guest.ID),
+					TableID:      int64(table.ID),
+					NewEntourage: 0,
+				})
+			}
+
+			first, err := arrive()
+			require.NoError(t, err)
+			require.False(t, first.Arrival.DepartedAt.Valid)
+
+			_, err = store.LeaveGuestTx(context.Background(), LeaveGuestTxParams{AuditInfo: audit, ID: guest.ID})
+			require.NoError(t, err)
+
+			_, err = store.GetOpenArrivalFromGuest(context.Background(), guest.ID)
+			require.ErrorIs(t, err, sql.ErrNoRows)
+
+			ended, err := store.GetArrival(context.Background(), first.Arrival.ID)
+			require.NoError(t, err)
+			require.True(t, ended.DepartedAt.Valid)
+
+			second, err := arrive()
+			if tc.denyReentry {
+				require.ErrorIs(t, err, ErrReentryDenied)
+				return
+			}
+			require.NoError(t, err)
+			require.NotEqual(t, first.Arrival.ID, second.Arrival.ID)
+			require.Equal(t, int32(1), second.Table.Occupied)
+
+			open, err := store.GetOpenArrivalFromGuest(context.Background(), guest.ID)
+			require.NoError(t, err)
+			require.Equal(t, second.Arrival.ID, open.ID)
+		})
+	}
+}
+
+func TestCompanionsTx(t *testing.T) {
+	store := NewStore(testDB)
+	table := createRandomTable(t)
+	audit := AuditInfo{Actor: util.OrganiserRole, RequestID: util.RandomString(16)}
+
+	guest, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		Entourage: 2,
+		TableID:   table.ID,
+	})
+	require.NoError(t, err)
+
+	companions := make([]Companion, guest.Entourage)
+	for i := range companions {
+		companions[i], err = store.AddCompanionTx(context.Background(), AddCompanionTxParams{
+			AuditInfo:  audit,
+			GuestID:    guest.ID,
+			Name:       util.RandomGuestName(),
+			Attributes: json.RawMessage(`{"dietary": "vegan"}`),
+		})
+		require.NoError(t, err)
+		require.Equal(t, guest.ID, companions[i].GuestID)
+	}
+
+	// Every seat of the entourage has been named
+	_, err = store.AddCompanionTx(context.Background(), AddCompanionTxParams{AuditInfo: audit, GuestID: guest.ID})
+	require.ErrorIs(t, err, ErrCompanionLimit)
+
+	// Renaming keeps the attributes unless they're replaced
+	renamed, err := store.UpdateCompanionTx(context.Background(), UpdateCompanionTxParams{
+		AuditInfo: audit,
+		ID:        companions[0].ID,
+		GuestID:   guest.ID,
+		Name:      "Renamed Companion",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Renamed Companion", renamed.Name)
+	require.JSONEq(t, `{"dietary": "vegan"}`, string(renamed.Attributes))
+
+	arrive := func(entourage int64, companionIDs []int32) (AssignTableTxResult, error) {
+		return store.AssignTableTx(context.Background(), AssignTableTxParams{
+			AuditInfo:    audit,
+			UserID:       int64(guest.ID),
+			TableID:      int64(table.ID),
+			NewEntourage: entourage,
+			CompanionIDs: companionIDs,
+		})
+	}
+
+	_, err = arrive(2, []int32{companions[0].ID})
+	require.ErrorIs(t, err, ErrInvalidCompanions)
+	_, err = arrive(2, []int32{companions[0].ID, companions[0].ID})
+	require.ErrorIs(t, err, ErrInvalidCompanions)
+
+	// Only one of the companions turns up, the seat maths follow the list
+	result, err := arrive(1, []int32{companions[1].ID})
+	require.NoError(t, err)
+	require.Equal(t, int32(2), result.Table.Occupied)
+	require.Len(t, result.Companions, 1)
+	require.Equal(t, companions[1].ID, result.Companions[0].ID)
+
+	err = store.RemoveCompanionTx(context.Background(), RemoveCompanionTxParams{
+		AuditInfo: audit,
+		ID:        companions[0].ID,
+		GuestID:   guest.ID,
+	})
+	require.NoError(t, err)
+
+	remaining, err := store.ListCompanions(context.Background(), guest.ID)
+	require.NoError(t, err)
+	require.Len(t, remaining, 1)
+}
+
+func TestCapacityLimits(t *testing.T) {
+	store := NewStore(testDB)
+	audit := AuditInfo{Actor: util.DoorStaffRole, RequestID: util.RandomString(16)}
+	zone := util.RandomString(10)
+
+	table, err := store.CreateTableTx(context.Background(), CreateTableTxParams{
+		AuditInfo: audit,
+		Size:      10,
+		Zone:      zone,
+	})
+	require.NoError(t, err)
+
+	limit, err := store.SetCapacityLimitTx(context.Background(), SetCapacityLimitTxParams{
+		AuditInfo:    audit,
+		Zone:         zone,
+		MaxOccupancy: 3,
+	})
+	require.NoError(t, err)
+	require.Equal(t, int32(3), limit.MaxOccupancy)
+	require.Equal(t, audit.Actor, limit.UpdatedBy)
+
+	guest, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		Entourage: 3,
+		TableID:   table.ID,
+	})
+	require.NoError(t, err)
+
+	// The table has the seats but the zone doesn't
+	_, err = store.AssignTableTx(context.Background(), AssignTableTxParams{
+		AuditInfo:    audit,
+		UserID:       int64(guest.ID),
+		NewEntourage: 3,
+		TableID:      int64(table.ID),
+	})
+	require.ErrorIs(t, err, ErrZoneFull)
+	require.NotErrorIs(t, err, ErrVenueFull)
+
+	_, err = store.AssignTableTx(context.Background(), AssignTableTxParams{
+		AuditInfo:    audit,
+		UserID:       int64(guest.ID),
+		NewEntourage: 2,
+		TableID:      int64(table.ID),
+	})
+	require.NoError(t, err)
+
+	_, err = store.UpdatePartyTx(context.Background(), UpdatePartyTxParams{AuditInfo: audit, ID: guest.ID, NewEntourage: 3})
+	var capacityErr *CapacityError
+	require.ErrorAs(t, err, &capacityErr)
+	require.Equal(t, zone, capacityErr.Zone)
+	require.Equal(t, int64(3), capacityErr.Occupancy)
+
+	// Standing admissions only count towards the venue
+	occupancy, err := store.GetVenueOccupancy(context.Background())
+	require.NoError(t, err)
+	_, err = store.SetCapacityLimitTx(context.Background(), SetCapacityLimitTxParams{
+		AuditInfo:    audit,
+		MaxOccupancy: int32(occupancy) + 2,
+	})
+	require.NoError(t, err)
+	defer func() {
+		_, err := store.SetCapacityLimitTx(context.Background(), SetCapacityLimitTxParams{AuditInfo: audit})
+		require.NoError(t, err)
+	}()
+
+	admission, err := store.AdmitStandingTx(context.Background(), AdmitStandingTxParams{
+		AuditInfo: audit,
+		Name:      util.RandomGuestName(),
+		PartySize: 2,
+	})
+	require.NoError(t, err)
+	require.False(t, admission.DepartedAt.Valid)
+
+	_, err = store.AdmitStandingTx(context.Background(), AdmitStandingTxParams{AuditInfo: audit, PartySize: 1})
+	require.ErrorIs(t, err, ErrVenueFull)
+
+	admission, err = store.DepartStandingTx(context.Background(), DepartStandingTxParams{AuditInfo: audit, ID: admission.ID})
+	require.NoError(t, err)
+	require.True(t, admission.DepartedAt.Valid)
+
+	_, err = store.DepartStandingTx(context.Background(), DepartStandingTxParams{AuditInfo: audit, ID: admission.ID})
+	require.ErrorIs(t, err, ErrStandingDeparted)
+
+	_, err = store.AdmitStandingTx(context.Background(), AdmitStandingTxParams{AuditInfo: audit, PartySize: 1})
+	require.NoError(t, err)
 }
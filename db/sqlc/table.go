@@ -0,0 +1,38 @@
+package db
+
+import "fmt"
+
+// Shapes of a table on the floor plan
+const (
+	ShapeRound       = "round"
+	ShapeRectangular = "rectangular"
+	ShapeSquare      = "square"
+	ShapeBooth       = "booth"
+)
+
+// TableShapes lists every shape a table can have
+var TableShapes = []string{ShapeRound, ShapeRectangular, ShapeSquare, ShapeBooth}
+
+// IsTableShape reports whether shape is one of TableShapes
+func IsTableShape(shape string) bool {
+	for _, s := range TableShapes {
+		if s == shape {
+			return true
+		}
+	}
+	return false
+}
+
+// defaultTableLabel is the label given to a table created without one
+func defaultTableLabel(id int32) string {
+	return fmt.Sprintf("Table %d", id)
+}
+
+// CheckPartySize returns a *PartySizeError if a party of partySize, the guest and their entourage,
+// is smaller or bigger than the table allows. It doesn't check the table has the seats free.
+func CheckPartySize(table Table, partySize int32) error {
+	if partySize < table.MinParty || (table.MaxParty > 0 && partySize > table.MaxParty) {
+		return &PartySizeError{TableID: table.ID, PartySize: partySize, MinParty: table.MinParty, MaxParty: table.MaxParty}
+	}
+	return nil
+}
@@ -11,19 +11,49 @@ import (
 const createTable = `-- name: CreateTable :execresult
 INSERT INTO tables(
     size,
-    occupied
+    occupied,
+    created_by,
+    label,
+    zone,
+    accessible,
+    shape,
+    min_party,
+    max_party,
+    expected_from,
+    expected_until
 ) VALUES (
-    ?, ?
+    ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?
 )
 `
 
 type CreateTableParams struct {
-	Size     int32 `json:"size"`
-	Occupied int32 `json:"occupied"`
+	Size          int32        `json:"size"`
+	Occupied      int32        `json:"occupied"`
+	CreatedBy     string       `json:"created_by"`
+	Label         string       `json:"label"`
+	Zone          string       `json:"zone"`
+	Accessible    bool         `json:"accessible"`
+	Shape         string       `json:"shape"`
+	MinParty      int32        `json:"min_party"`
+	MaxParty      int32        `json:"max_party"`
+	ExpectedFrom  sql.NullTime `json:"expected_from"`
+	ExpectedUntil sql.NullTime `json:"expected_until"`
 }
 
 func (q *Queries) CreateTable(ctx context.Context, arg CreateTableParams) (sql.Result, error) {
-	return q.db.ExecContext(ctx, createTable, arg.Size, arg.Occupied)
+	return q.db.ExecContext(ctx, createTable,
+		arg.Size,
+		arg.Occupied,
+		arg.CreatedBy,
+		arg.Label,
+		arg.Zone,
+		arg.Accessible,
+		arg.Shape,
+		arg.MinParty,
+		arg.MaxParty,
+		arg.ExpectedFrom,
+		arg.ExpectedUntil,
+	)
 }
 
 const deleteTable = `-- name: DeleteTable :exec
@@ -37,7 +67,7 @@ func (q *Queries) DeleteTable(ctx context.Context, id int32) error {
 }
 
 const getTable = `-- name: GetTable :one
-SELECT id, size, occupied, created_at FROM tables
+SELECT id, size, occupied, created_at, created_by, label, zone, accessible, shape, min_party, max_party, expected_from, expected_until FROM tables
 WHERE id = ? LIMIT 1
 `
 
@@ -49,12 +79,21 @@ func (q *Queries) GetTable(ctx context.Context, id int32) (Table, error) {
 		&i.Size,
 		&i.Occupied,
 		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.Label,
+		&i.Zone,
+		&i.Accessible,
+		&i.Shape,
+		&i.MinParty,
+		&i.MaxParty,
+		&i.ExpectedFrom,
+		&i.ExpectedUntil,
 	)
 	return i, err
 }
 
 const getTableForUpdate = `-- name: GetTableForUpdate :one
-SELECT id, size, occupied, created_at FROM tables
+SELECT id, size, occupied, created_at, created_by, label, zone, accessible, shape, min_party, max_party, expected_from, expected_until FROM tables
 WHERE id = ? LIMIT 1
 FOR UPDATE
 `
@@ -67,12 +106,47 @@ func (q *Queries) GetTableForUpdate(ctx context.Context, id int32) (Table, error
 		&i.Size,
 		&i.Occupied,
 		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.Label,
+		&i.Zone,
+		&i.Accessible,
+		&i.Shape,
+		&i.MinParty,
+		&i.MaxParty,
+		&i.ExpectedFrom,
+		&i.ExpectedUntil,
+	)
+	return i, err
+}
+
+const getTableFromLabel = `-- name: GetTableFromLabel :one
+SELECT id, size, occupied, created_at, created_by, label, zone, accessible, shape, min_party, max_party, expected_from, expected_until FROM tables
+WHERE label = ? LIMIT 1
+`
+
+func (q *Queries) GetTableFromLabel(ctx context.Context, label string) (Table, error) {
+	row := q.db.QueryRowContext(ctx, getTableFromLabel, label)
+	var i Table
+	err := row.Scan(
+		&i.ID,
+		&i.Size,
+		&i.Occupied,
+		&i.CreatedAt,
+		&i.CreatedBy,
+		&i.Label,
+		&i.Zone,
+		&i.Accessible,
+		&i.Shape,
+		&i.MinParty,
+		&i.MaxParty,
+		&i.ExpectedFrom,
+		&i.ExpectedUntil,
 	)
 	return i, err
 }
 
 const getTables = `-- name: GetTables :many
-SELECT id, size, occupied, created_at FROM tables 
+SELECT id, size, occupied, created_at, created_by, label, zone, accessible, shape, min_party, max_party, expected_from, expected_until FROM tables 
 ORDER BY id
 LIMIT ?
 OFFSET ?
@@ -97,6 +171,213 @@ func (q *Queries) GetTables(ctx context.Context, arg GetTablesParams) ([]Table,
 			&i.Size,
 			&i.Occupied,
 			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.Label,
+			&i.Zone,
+			&i.Accessible,
+			&i.Shape,
+			&i.MinParty,
+			&i.MaxParty,
+			&i.ExpectedFrom,
+			&i.ExpectedUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTableRoster = `-- name: ListTableRoster :many
+SELECT t.id, t.size, t.occupied, t.label, t.zone, t.accessible, t.shape, t.min_party, t.max_party,
+    t.expected_from, t.expected_until,
+    CAST(GREATEST(
+        (SELECT COUNT(*) FROM seats s WHERE s.table_id = t.id AND s.guest_id IS NULL)
+        - (SELECT IFNULL(SUM(GREATEST(e.entourage + 1 - (SELECT COUNT(*) FROM seats h WHERE h.guest_id = e.id), 0)), 0)
+            FROM guests e WHERE e.table_id = t.id AND e.status IN ('invited', 'confirmed')),
+        0) AS SIGNED) AS free_seats,
+    g.id AS guest_id, g.guest_name, g.entourage, g.status AS guest_status, g.vip_tier,
+    g.expected_from AS guest_expected_from, g.expected_until AS guest_expected_until,
+    CAST((SELECT COUNT(*) FROM seats s WHERE s.guest_id = g.id) AS SIGNED) AS seats_held,
+    a.party_size, a.arrived_at
+FROM (
+    SELECT id, size, occupied, created_at, created_by, label, zone, accessible, shape, min_party, max_party, expected_from, expected_until FROM tables
+    WHERE (? IS NULL OR id = ?)
+    ORDER BY id
+    LIMIT ?
+    OFFSET ?
+) t
+LEFT JOIN guests g ON g.table_id = t.id AND g.status IN ('invited', 'confirmed', 'arrived')
+LEFT JOIN arrivals a ON a.guest_id = g.id AND a.departed_at IS NULL
+ORDER BY t.id, g.guest_name
+`
+
+type ListTableRosterParams struct {
+	TableID sql.NullInt32 `json:"table_id"`
+	Limit   int32         `json:"limit"`
+	Offset  int32         `json:"offset"`
+}
+
+type ListTableRosterRow struct {
+	ID                 int32          `json:"id"`
+	Size               int32          `json:"size"`
+	Occupied           int32          `json:"occupied"`
+	Label              string         `json:"label"`
+	Zone               string         `json:"zone"`
+	Accessible         bool           `json:"accessible"`
+	Shape              string         `json:"shape"`
+	MinParty           int32          `json:"min_party"`
+	MaxParty           int32          `json:"max_party"`
+	ExpectedFrom       sql.NullTime   `json:"expected_from"`
+	ExpectedUntil      sql.NullTime   `json:"expected_until"`
+	FreeSeats          int64          `json:"free_seats"`
+	GuestID            sql.NullInt32  `json:"guest_id"`
+	GuestName          sql.NullString `json:"guest_name"`
+	Entourage          sql.NullInt32  `json:"entourage"`
+	GuestStatus        sql.NullString `json:"guest_status"`
+	VipTier            sql.NullString `json:"vip_tier"`
+	GuestExpectedFrom  sql.NullTime   `json:"guest_expected_from"`
+	GuestExpectedUntil sql.NullTime   `json:"guest_expected_until"`
+	SeatsHeld          int64          `json:"seats_held"`
+	PartySize          sql.NullInt32  `json:"party_size"`
+	ArrivedAt          sql.NullTime   `json:"arrived_at"`
+}
+
+func (q *Queries) ListTableRoster(ctx context.Context, arg ListTableRosterParams) ([]ListTableRosterRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTableRoster,
+		arg.TableID,
+		arg.TableID,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTableRosterRow{}
+	for rows.Next() {
+		var i ListTableRosterRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Size,
+			&i.Occupied,
+			&i.Label,
+			&i.Zone,
+			&i.Accessible,
+			&i.Shape,
+			&i.MinParty,
+			&i.MaxParty,
+			&i.ExpectedFrom,
+			&i.ExpectedUntil,
+			&i.FreeSeats,
+			&i.GuestID,
+			&i.GuestName,
+			&i.Entourage,
+			&i.GuestStatus,
+			&i.VipTier,
+			&i.GuestExpectedFrom,
+			&i.GuestExpectedUntil,
+			&i.SeatsHeld,
+			&i.PartySize,
+			&i.ArrivedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTableSpace = `-- name: ListTableSpace :many
+SELECT t.id, t.zone, t.min_party, t.max_party,
+    CAST(COUNT(s.id) - COUNT(s.guest_id) AS SIGNED) AS free_seats,
+    CAST(t.occupied - IFNULL(SUM(g.status = 'arrived'), 0) AS SIGNED) AS unseated
+FROM tables t
+LEFT JOIN seats s ON s.table_id = t.id
+LEFT JOIN guests g ON g.id = s.guest_id
+GROUP BY t.id
+ORDER BY t.id
+`
+
+type ListTableSpaceRow struct {
+	ID        int32  `json:"id"`
+	Zone      string `json:"zone"`
+	MinParty  int32  `json:"min_party"`
+	MaxParty  int32  `json:"max_party"`
+	FreeSeats int64  `json:"free_seats"`
+	Unseated  int64  `json:"unseated"`
+}
+
+func (q *Queries) ListTableSpace(ctx context.Context) ([]ListTableSpaceRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTableSpace)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTableSpaceRow{}
+	for rows.Next() {
+		var i ListTableSpaceRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Zone,
+			&i.MinParty,
+			&i.MaxParty,
+			&i.FreeSeats,
+			&i.Unseated,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTables = `-- name: ListTables :many
+SELECT id, size, occupied, created_at, created_by, label, zone, accessible, shape, min_party, max_party, expected_from, expected_until FROM tables
+ORDER BY id
+`
+
+func (q *Queries) ListTables(ctx context.Context) ([]Table, error) {
+	rows, err := q.db.QueryContext(ctx, listTables)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Table{}
+	for rows.Next() {
+		var i Table
+		if err := rows.Scan(
+			&i.ID,
+			&i.Size,
+			&i.Occupied,
+			&i.CreatedAt,
+			&i.CreatedBy,
+			&i.Label,
+			&i.Zone,
+			&i.Accessible,
+			&i.Shape,
+			&i.MinParty,
+			&i.MaxParty,
+			&i.ExpectedFrom,
+			&i.ExpectedUntil,
 		); err != nil {
 			return nil, err
 		}
@@ -128,3 +409,36 @@ func (q *Queries) UpdateTable(ctx context.Context, arg UpdateTableParams) error
 	_, err := q.db.ExecContext(ctx, updateTable, arg.Size, arg.Occupied, arg.ID)
 	return err
 }
+
+const updateTableArrivalWindow = `-- name: UpdateTableArrivalWindow :exec
+UPDATE tables
+SET expected_from = ?, expected_until = ?
+WHERE id = ?
+`
+
+type UpdateTableArrivalWindowParams struct {
+	ExpectedFrom  sql.NullTime `json:"expected_from"`
+	ExpectedUntil sql.NullTime `json:"expected_until"`
+	ID            int32        `json:"id"`
+}
+
+func (q *Queries) UpdateTableArrivalWindow(ctx context.Context, arg UpdateTableArrivalWindowParams) error {
+	_, err := q.db.ExecContext(ctx, updateTableArrivalWindow, arg.ExpectedFrom, arg.ExpectedUntil, arg.ID)
+	return err
+}
+
+const updateTableLabel = `-- name: UpdateTableLabel :exec
+UPDATE tables
+SET label = ?
+WHERE id = ?
+`
+
+type UpdateTableLabelParams struct {
+	Label string `json:"label"`
+	ID    int32  `json:"id"`
+}
+
+func (q *Queries) UpdateTableLabel(ctx context.Context, arg UpdateTableLabelParams) error {
+	_, err := q.db.ExecContext(ctx, updateTableLabel, arg.Label, arg.ID)
+	return err
+}
@@ -12,9 +12,15 @@ import (
 )
 
 func createRandomTable(t *testing.T) Table {
+	size := util.RandomTableSize()
 	arg := CreateTableParams{
-		Size:     util.RandomTableSize(),
-		Occupied: 0,
+		Size:      size,
+		Occupied:  0,
+		CreatedBy: util.RandomGuestName(),
+		Label:     util.RandomString(12),
+		Shape:     ShapeRound,
+		MinParty:  1,
+		MaxParty:  size,
 	}
 
 	tableSQL, err := testQueries.CreateTable(context.Background(), arg)
@@ -26,9 +32,16 @@ func createRandomTable(t *testing.T) Table {
 	require.NotEmpty(t, tableSQL)
 
 	require.Equal(t, arg.Size, table.Size)
+	require.Equal(t, arg.CreatedBy, table.CreatedBy)
+	require.Equal(t, arg.Label, table.Label)
 	require.NotZero(t, table.ID)
 	require.Zero(t, table.Occupied)
 
+	for number := int32(1); number <= table.Size; number++ {
+		err = testQueries.CreateSeat(context.Background(), CreateSeatParams{TableID: table.ID, Number: number})
+		require.NoError(t, err)
+	}
+
 	return table
 }
 
@@ -104,23 +117,103 @@ func TestListTables(t *testing.T) {
 }
 
 func TestGetEmptySeats(t *testing.T) {
-	count, err := testQueries.GetEmptySeats(context.Background())
+	store := NewStore(testDB)
+	audit := AuditInfo{Actor: util.OrganiserRole, RequestID: util.RandomString(16)}
+
+	before, err := store.GetEmptySeats(context.Background())
 	require.NoError(t, err)
-	require.NotNil(t, count)
 
-	tableSize := 0
-	tableOccupied := 0
-	tables, err := testQueries.GetTables(context.Background(), GetTablesParams{
-		Limit:  1000,
-		Offset: 0,
+	table, err := store.CreateTableTx(context.Background(), CreateTableTxParams{AuditInfo: audit, Size: 4})
+	require.NoError(t, err)
+
+	created, err := store.GetEmptySeats(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, before+table.Size, created)
+
+	// A party booked without seats still takes theirs out of the empty ones
+	_, err = store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		Entourage: 1,
+		TableID:   table.ID,
 	})
 	require.NoError(t, err)
 
-	for _, table := range tables {
-		tableSize += int(table.Size)
-		tableOccupied += int(table.Occupied)
+	booked, err := store.GetEmptySeats(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, created-2, booked)
+
+	counts, err := store.GetEmptySeatsByTableIDs(context.Background(), []int32{table.ID})
+	require.NoError(t, err)
+	require.Equal(t, []TableEmptySeats{{TableID: table.ID, SeatsEmpty: 2}}, counts)
+}
+
+func TestGetEmptySeatsHeld(t *testing.T) {
+	store := NewStore(testDB)
+	audit := AuditInfo{Actor: util.OrganiserRole, RequestID: util.RandomString(16)}
+
+	before, err := store.GetEmptySeats(context.Background())
+	require.NoError(t, err)
+
+	table, err := store.CreateTableTx(context.Background(), CreateTableTxParams{AuditInfo: audit, Size: 4})
+	require.NoError(t, err)
+
+	// Seats held for a guest who hasn't arrived yet aren't empty, nor are they once the party sits in them
+	guest, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		Entourage: 1,
+		TableID:   table.ID,
+		Seats:     []int32{1, 2},
+	})
+	require.NoError(t, err)
+
+	held, err := store.GetEmptySeats(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, before+table.Size-guest.Entourage-1, held)
+
+	_, err = store.AssignTableTx(context.Background(), AssignTableTxParams{
+		AuditInfo:    audit,
+		UserID:       int64(guest.ID),
+		TableID:      int64(table.ID),
+		NewEntourage: int64(guest.Entourage),
+	})
+	require.NoError(t, err)
+
+	arrived, err := store.GetEmptySeats(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, held, arrived)
+}
+
+func TestListTableRoster(t *testing.T) {
+	table := createRandomTable(t)
+	guest := createRandomGuest(t, table.ID)
+
+	rows, err := testQueries.ListTableRoster(context.Background(), ListTableRosterParams{
+		TableID: sql.NullInt32{Int32: table.ID, Valid: true},
+		Limit:   1,
+	})
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	require.Equal(t, table.ID, rows[0].ID)
+	require.Equal(t, guest.ID, rows[0].GuestID.Int32)
+	require.Zero(t, rows[0].SeatsHeld)
+	require.False(t, rows[0].PartySize.Valid)
+
+	// The guest was booked without seats, their party is still reserved and not free
+	free := int64(table.Size - guest.Entourage - 1)
+	if free < 0 {
+		free = 0
 	}
+	require.Equal(t, free, rows[0].FreeSeats)
+	roster := TableRosters(rows)[0]
+	require.Equal(t, guest.Entourage+1, roster.ReservedSeats)
+
+	empty, err := testQueries.GetEmptySeatsByTableIDs(context.Background(), []int32{table.ID})
+	require.NoError(t, err)
+	require.Equal(t, []TableEmptySeats{{TableID: table.ID, SeatsEmpty: roster.FreeSeats}}, empty)
 
+	rows, err = testQueries.ListTableRoster(context.Background(), ListTableRosterParams{Limit: 5})
 	require.NoError(t, err)
-	require.Equal(t, count, int32(tableSize-tableOccupied))
+	require.NotEmpty(t, rows)
 }
@@ -0,0 +1,124 @@
+package db
+
+import (
+	"context"
+	"sort"
+)
+
+// RankWalkInTables returns the IDs of the tables with room for a walk-in party of partySize, best
+// fit first. The best fit is the table left with the fewest spare seats once the party sits down,
+// so bigger tables are kept free for bigger parties, ties going to the lowest ID. A table only has
+// room when it takes parties of that size and has enough free seats left over once anybody
+// already at it without a seat of their own is counted.
+func RankWalkInTables(spaces []ListTableSpaceRow, partySize int32) []int32 {
+	type fit struct {
+		id    int32
+		spare int64
+	}
+	var fits []fit
+	for _, space := range spaces {
+		if partySize < space.MinParty || (space.MaxParty > 0 && partySize > space.MaxParty) {
+			continue
+		}
+		spare := space.FreeSeats - space.Unseated - int64(partySize)
+		if spare < 0 {
+			continue
+		}
+		fits = append(fits, fit{id: space.ID, spare: spare})
+	}
+
+	sort.SliceStable(fits, func(i, j int) bool {
+		if fits[i].spare != fits[j].spare {
+			return fits[i].spare < fits[j].spare
+		}
+		return fits[i].id < fits[j].id
+	})
+
+	ids := make([]int32, len(fits))
+	for i, fit := range fits {
+		ids[i] = fit.id
+	}
+	return ids
+}
+
+// pickWalkInTable locks and returns the best fitting table for a walk-in party of partySize.
+// Candidates are ranked without locks, passing over those in a zone already at its maximum
+// occupancy, so the chosen table is checked again once locked. Tables are only ever locked lowest
+// ID first, as lockTablePair does, so one that has filled up since is passed over for the next
+// best with a higher ID, leaving it locked without risking a deadlock against other arrivals. A
+// full venue is returned straight away as a *CapacityError, and ErrNoTableAvailable when no table
+// has room.
+func (q *Queries) pickWalkInTable(ctx context.Context, partySize int32) (Table, error) {
+	spaces, err := q.ListTableSpace(ctx)
+	if err != nil {
+		return Table{}, err
+	}
+	full, err := q.fullZones(ctx, partySize)
+	if err != nil {
+		return Table{}, err
+	}
+	open := make([]ListTableSpaceRow, 0, len(spaces))
+	for _, space := range spaces {
+		if !full[space.Zone] {
+			open = append(open, space)
+		}
+	}
+
+	var locked int32
+	for _, id := range RankWalkInTables(open, partySize) {
+		if id <= locked {
+			continue
+		}
+		table, err := q.GetTableForUpdate(ctx, id)
+		if err != nil {
+			return Table{}, err
+		}
+		locked = id
+
+		free, unseated, err := q.tableSeating(ctx, table, 0)
+		if err != nil {
+			return Table{}, err
+		}
+		if CheckPartySize(table, partySize) != nil || free-unseated < partySize {
+			continue
+		}
+		if err := q.checkCapacity(ctx, table.Zone, partySize); err != nil {
+			return Table{}, err
+		}
+		return table, nil
+	}
+	return Table{}, ErrNoTableAvailable
+}
+
+// fullZones reads, without locking them, which zones have no room for partySize more people.
+// A venue without room is returned as a *CapacityError instead.
+func (q *Queries) fullZones(ctx context.Context, partySize int32) (map[string]bool, error) {
+	limits, err := q.ListCapacityLimits(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	full := map[string]bool{}
+	for _, limit := range limits {
+		if limit.MaxOccupancy <= 0 {
+			continue
+		}
+		var occupancy int64
+		if limit.Zone == "" {
+			occupancy, err = q.GetVenueOccupancy(ctx)
+		} else {
+			occupancy, err = q.GetZoneOccupancy(ctx, limit.Zone)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if occupancy+int64(partySize) <= int64(limit.MaxOccupancy) {
+			continue
+		}
+		if limit.Zone == "" {
+			return nil, &CapacityError{MaxOccupancy: limit.MaxOccupancy, Occupancy: occupancy, PartySize: partySize}
+		}
+		full[limit.Zone] = true
+	}
+	return full, nil
+}
@@ -0,0 +1,146 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankWalkInTables(t *testing.T) {
+	spaces := []ListTableSpaceRow{
+		{ID: 1, MinParty: 1, MaxParty: 8, FreeSeats: 8},
+		{ID: 2, MinParty: 1, MaxParty: 4, FreeSeats: 4},
+		{ID: 3, MinParty: 1, MaxParty: 4, FreeSeats: 2},
+		{ID: 4, MinParty: 4, MaxParty: 6, FreeSeats: 6},
+		{ID: 5, MinParty: 1, MaxParty: 4, FreeSeats: 3, Unseated: 1},
+		{ID: 6, MinParty: 1, MaxParty: 4, FreeSeats: 3},
+	}
+
+	testCases := []struct {
+		name      string
+		partySize int32
+		ranked    []int32
+	}{
+		{"Couple", 2, []int32{3, 5, 6, 2, 1}},
+		{"Three", 3, []int32{6, 2, 1}},
+		{"TooSmallForSomeTables", 4, []int32{2, 4, 1}},
+		{"OnlyTheBiggestTable", 7, []int32{1}},
+		{"NoRoom", 9, []int32{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.ranked, RankWalkInTables(spaces, tc.partySize))
+		})
+	}
+}
+
+func TestWalkInTx(t *testing.T) {
+	store := NewStore(testDB)
+	audit := AuditInfo{Actor: util.DoorStaffRole, RequestID: util.RandomString(16)}
+
+	table, err := store.CreateTableTx(context.Background(), CreateTableTxParams{AuditInfo: audit, Size: 3})
+	require.NoError(t, err)
+
+	result, err := store.WalkInTx(context.Background(), WalkInTxParams{
+		AuditInfo:    audit,
+		GuestProfile: GuestProfile{Dietary: "Vegan"},
+		GuestName:    util.RandomGuestName(),
+		Entourage:    1,
+		TableID:      table.ID,
+		Seats:        []int32{2, 3},
+	})
+	require.NoError(t, err)
+	require.True(t, result.Guest.WalkIn)
+	require.Equal(t, GuestArrived, result.Guest.Status)
+	require.Equal(t, "vegan", result.Guest.Dietary)
+	require.Equal(t, table.ID, result.Table.ID)
+	require.Equal(t, table.Occupied+2, result.Table.Occupied)
+	require.Len(t, result.Seats, 2)
+	require.Equal(t, int32(2), result.Seats[0].Number)
+
+	// Walk-ins don't need an invitation to get in
+	_, err = store.GetActiveInvitationFromGuest(context.Background(), result.Guest.ID)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	walkIns, err := store.GetGuests(context.Background(), GetGuestsParams{
+		TableID: sql.NullInt32{Int32: table.ID, Valid: true},
+		WalkIn:  sql.NullBool{Bool: true, Valid: true},
+		Limit:   10,
+	})
+	require.NoError(t, err)
+	require.Len(t, walkIns, 1)
+	require.Equal(t, result.Guest.ID, walkIns[0].ID)
+
+	// Somebody already on the guest list is arrived, not walked in
+	_, err = store.WalkInTx(context.Background(), WalkInTxParams{
+		AuditInfo: audit,
+		GuestName: result.Guest.GuestName,
+		TableID:   table.ID,
+	})
+	require.ErrorIs(t, err, ErrGuestOnList)
+
+	// A party too big for the table leaves no trace on the guest list
+	name := util.RandomGuestName()
+	_, err = store.WalkInTx(context.Background(), WalkInTxParams{
+		AuditInfo: audit,
+		GuestName: name,
+		Entourage: 3,
+		TableID:   table.ID,
+	})
+	require.Error(t, err)
+	_, err = store.GetGuestFromName(context.Background(), name)
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	// Without a table the party is seated wherever there's room, and turned away when there's none
+	result, err = store.WalkInTx(context.Background(), WalkInTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+	})
+	require.NoError(t, err)
+	require.True(t, result.Guest.WalkIn)
+	require.Equal(t, result.Table.ID, result.Guest.TableID)
+	require.Len(t, result.Seats, 1)
+
+	_, err = store.WalkInTx(context.Background(), WalkInTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		Entourage: 10000,
+	})
+	require.ErrorIs(t, err, ErrNoTableAvailable)
+}
+
+func TestWalkInTxConcurrent(t *testing.T) {
+	store := NewStore(testDB)
+	audit := AuditInfo{Actor: util.DoorStaffRole, RequestID: util.RandomString(16)}
+
+	for i := 0; i < 3; i++ {
+		_, err := store.CreateTableTx(context.Background(), CreateTableTxParams{AuditInfo: audit, Size: 2})
+		require.NoError(t, err)
+	}
+
+	// Walk-ins racing for the same best fitting tables only ever lock them lowest ID first, so
+	// none of them deadlock whichever tables they end up passing over
+	n := 6
+	errs := make(chan error)
+	for i := 0; i < n; i++ {
+		go func() {
+			_, err := store.WalkInTx(context.Background(), WalkInTxParams{
+				AuditInfo: audit,
+				GuestName: util.RandomGuestName(),
+				Entourage: 1,
+			})
+			errs <- err
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		err := <-errs
+		if err != nil {
+			require.ErrorIs(t, err, ErrNoTableAvailable)
+		}
+	}
+}
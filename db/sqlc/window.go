@@ -0,0 +1,44 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+)
+
+// ArrivalWindow is when a guest, or the guests at a table, are expected to arrive. Either end may
+// be left open, only a window which closes can make a guest a no-show.
+type ArrivalWindow struct {
+	ExpectedFrom  sql.NullTime `json:"expected_from"`
+	ExpectedUntil sql.NullTime `json:"expected_until"`
+}
+
+// Validate returns ErrInvalidArrivalWindow if the window closes before it opens
+func (w ArrivalWindow) Validate() error {
+	if w.ExpectedFrom.Valid && w.ExpectedUntil.Valid && !w.ExpectedFrom.Time.Before(w.ExpectedUntil.Time) {
+		return ErrInvalidArrivalWindow
+	}
+	return nil
+}
+
+// GuestArrivalWindow returns the window the guest is expected in, each end their own if they have
+// one and otherwise their table's
+func GuestArrivalWindow(guest Guest, table Table) ArrivalWindow {
+	window := ArrivalWindow{ExpectedFrom: guest.ExpectedFrom, ExpectedUntil: guest.ExpectedUntil}
+	if !window.ExpectedFrom.Valid {
+		window.ExpectedFrom = table.ExpectedFrom
+	}
+	if !window.ExpectedUntil.Valid {
+		window.ExpectedUntil = table.ExpectedUntil
+	}
+	return window
+}
+
+// IsNoShow reports whether the guest, still expected, hasn't arrived by now although their window
+// closed more than grace ago
+func IsNoShow(guest Guest, table Table, now time.Time, grace time.Duration) bool {
+	if guest.Status != GuestInvited && guest.Status != GuestConfirmed {
+		return false
+	}
+	window := GuestArrivalWindow(guest, table)
+	return window.ExpectedUntil.Valid && now.After(window.ExpectedUntil.Time.Add(grace))
+}
@@ -0,0 +1,150 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/stretchr/testify/require"
+)
+
+func at(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: true}
+}
+
+func TestIsNoShow(t *testing.T) {
+	closes := time.Date(2026, 10, 19, 20, 0, 0, 0, time.UTC)
+	grace := 15 * time.Minute
+	table := Table{ExpectedFrom: at(closes.Add(-time.Hour)), ExpectedUntil: at(closes)}
+
+	testCases := []struct {
+		name   string
+		guest  Guest
+		now    time.Time
+		noShow bool
+	}{
+		{"WithinGrace", Guest{Status: GuestConfirmed}, closes.Add(grace), false},
+		{"TableWindowMissed", Guest{Status: GuestConfirmed}, closes.Add(grace + time.Second), true},
+		{"InvitedGuest", Guest{Status: GuestInvited}, closes.Add(time.Hour), true},
+		{"OwnWindowOverridesTable", Guest{Status: GuestInvited, ExpectedUntil: at(closes.Add(time.Hour))}, closes.Add(time.Hour), false},
+		{"Arrived", Guest{Status: GuestArrived}, closes.Add(time.Hour), false},
+		{"Declined", Guest{Status: GuestDeclined}, closes.Add(time.Hour), false},
+		{"AlreadyNoShow", Guest{Status: GuestNoShow}, closes.Add(time.Hour), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.noShow, IsNoShow(tc.guest, table, tc.now, grace))
+		})
+	}
+
+	// Without a window closing anywhere nobody is ever a no-show
+	require.False(t, IsNoShow(Guest{Status: GuestInvited}, Table{ExpectedFrom: at(closes)}, closes.Add(24*time.Hour), grace))
+}
+
+func TestGuestArrivalWindow(t *testing.T) {
+	opens := time.Date(2026, 10, 19, 19, 0, 0, 0, time.UTC)
+	table := Table{ExpectedFrom: at(opens), ExpectedUntil: at(opens.Add(time.Hour))}
+	guest := Guest{ExpectedUntil: at(opens.Add(2 * time.Hour))}
+
+	window := GuestArrivalWindow(guest, table)
+	require.Equal(t, table.ExpectedFrom, window.ExpectedFrom)
+	require.Equal(t, guest.ExpectedUntil, window.ExpectedUntil)
+
+	require.NoError(t, window.Validate())
+	require.NoError(t, ArrivalWindow{ExpectedUntil: at(opens)}.Validate())
+	require.ErrorIs(t, ArrivalWindow{ExpectedFrom: at(opens), ExpectedUntil: at(opens)}.Validate(), ErrInvalidArrivalWindow)
+}
+
+func TestMarkNoShowsTx(t *testing.T) {
+	store := NewStore(testDB)
+	audit := AuditInfo{Actor: util.OrganiserRole, RequestID: util.RandomString(16)}
+	now := time.Now().UTC().Truncate(time.Second)
+	grace := 15 * time.Minute
+
+	table, err := store.CreateTableTx(context.Background(), CreateTableTxParams{
+		AuditInfo:     audit,
+		ArrivalWindow: ArrivalWindow{ExpectedFrom: at(now.Add(-2 * time.Hour)), ExpectedUntil: at(now.Add(-time.Hour))},
+		Size:          4,
+	})
+	require.NoError(t, err)
+	require.True(t, table.ExpectedUntil.Valid)
+
+	late, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		Entourage: 1,
+		TableID:   table.ID,
+		Seats:     []int32{1, 2},
+	})
+	require.NoError(t, err)
+
+	// Expected later than the rest of the table
+	onTime, err := store.CreateGuestTx(context.Background(), CreateGuestTxParams{
+		AuditInfo: audit,
+		GuestName: util.RandomGuestName(),
+		TableID:   table.ID,
+	})
+	require.NoError(t, err)
+	onTime, err = store.SetGuestArrivalWindowTx(context.Background(), SetGuestArrivalWindowTxParams{
+		AuditInfo:     audit,
+		ArrivalWindow: ArrivalWindow{ExpectedUntil: at(now.Add(time.Hour))},
+		ID:            onTime.ID,
+	})
+	require.NoError(t, err)
+
+	_, err = store.SetGuestArrivalWindowTx(context.Background(), SetGuestArrivalWindowTxParams{
+		AuditInfo:     audit,
+		ArrivalWindow: ArrivalWindow{ExpectedFrom: at(now), ExpectedUntil: at(now.Add(-time.Hour))},
+		ID:            onTime.ID,
+	})
+	require.ErrorIs(t, err, ErrInvalidArrivalWindow)
+
+	marked, err := store.MarkNoShowsTx(context.Background(), MarkNoShowsTxParams{AuditInfo: audit, Now: now, Grace: grace})
+	require.NoError(t, err)
+
+	markedIDs := map[int32]bool{}
+	for _, guest := range marked {
+		markedIDs[guest.ID] = true
+	}
+	require.True(t, markedIDs[late.ID])
+	require.False(t, markedIDs[onTime.ID])
+
+	late, err = store.GetGuest(context.Background(), late.ID)
+	require.NoError(t, err)
+	require.Equal(t, GuestNoShow, late.Status)
+	held, err := store.ListGuestSeats(context.Background(), sql.NullInt32{Int32: late.ID, Valid: true})
+	require.NoError(t, err)
+	require.Empty(t, held)
+
+	events, err := store.ListAuditEvents(context.Background(), ListAuditEventsParams{
+		GuestName: sql.NullString{String: late.GuestName, Valid: true},
+		Limit:     100,
+	})
+	require.NoError(t, err)
+	event := events[len(events)-1]
+	require.Equal(t, AuditMarkNoShow, event.Action)
+
+	var before guestState
+	require.NoError(t, json.Unmarshal(event.Before, &before))
+	require.Len(t, before.Seats, 2)
+
+	// Once marked, a guest isn't marked again
+	marked, err = store.MarkNoShowsTx(context.Background(), MarkNoShowsTxParams{AuditInfo: audit, Now: now, Grace: grace})
+	require.NoError(t, err)
+	for _, guest := range marked {
+		require.NotEqual(t, late.ID, guest.ID)
+	}
+
+	// Moving the table's window later gives its guests until then
+	table, err = store.SetTableArrivalWindowTx(context.Background(), SetTableArrivalWindowTxParams{
+		AuditInfo:     audit,
+		ArrivalWindow: ArrivalWindow{ExpectedUntil: at(now.Add(2 * time.Hour))},
+		ID:            table.ID,
+	})
+	require.NoError(t, err)
+	require.False(t, table.ExpectedFrom.Valid)
+}
@@ -23,22 +23,41 @@ var doc = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/guest/{name}": {
-            "delete": {
-                "description": "Checks there is a valid record based on the name value then performs a DELETE action.",
+        "/admission_log": {
+            "get": {
+                "description": "Fetches an array of admission decisions ([]AdmissionLog) oldest first, one for every admission policy and rule evaluated on every arrival, including those which turned the party away. Decisions can be filtered by guest name and whether they allowed the arrival, and are paginated with a minimum page_id of 1 and page_size of 5-10.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Deletes a guest based on their Guest Name value.",
+                "summary": "returns the decisions made on arrivals",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Guest Name",
-                        "name": "name",
-                        "in": "path",
+                        "name": "guest_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only decisions which allowed, or denied, the arrival",
+                        "name": "allowed",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page ID",
+                        "name": "page_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page Size",
+                        "name": "page_size",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -48,327 +67,489 @@ var doc = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/db.Guest"
+                                "$ref": "#/definitions/db.AdmissionLog"
                             }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HTTPError"
+                            "$ref": "#/definitions/api.httpError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HTTPError"
+                            "$ref": "#/definitions/api.httpError"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HTTPError"
+                            "$ref": "#/definitions/api.httpError"
                         }
                     }
                 }
             }
         },
-        "/guest_list/": {
+        "/admission_rules": {
             "get": {
-                "description": "Fetches an array of guest object ([]Guest), the requests are paginated with a minimum page_id of 1 and page_size of 5-20. Running a make test will generate some default data via the mysql unit tests.",
+                "description": "Fetches the admission rules in force, or the version given, with who uploaded them and when.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "returns all guests on the guest_list",
+                "summary": "returns a version of the admission rules",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Page ID",
-                        "name": "page_id",
-                        "in": "query",
-                        "required": true
+                        "description": "Version, the latest when left out",
+                        "name": "version",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.AdmissionRuleSet"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Compiles every rule, e.g. ` + "`" + `deny if party.size \u003e guest.rsvp_size + 2 and not guest.vip` + "`" + `, and stores them as the next version of the admission rules which every arrival must pass from then on, alongside the policies configured for the event. event holds values the rules refer to as event.\u003cname\u003e, RFC 3339 strings are times. A rule which doesn't compile is rejected with the column it fails at. Uploading no rules removes them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Uploads a new version of the admission rules",
+                "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page Size",
-                        "name": "page_size",
-                        "in": "query",
-                        "required": true
+                        "description": "Event values and rules",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.setAdmissionRulesRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.Guest"
-                            }
+                            "$ref": "#/definitions/db.AdmissionRuleSet"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HTTPError"
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HTTPError"
+                            "$ref": "#/definitions/api.httpError"
                         }
                     }
                 }
             }
         },
-        "/guest_list/{name}": {
-            "post": {
-                "description": "Executes a POST request preceeding the check to see if the table is big enough for the party (1 + entourage).",
+        "/analytics/arrivals": {
+            "get": {
+                "description": "Counts the arrivals, and the people in their parties, in every interval of the window of the event, 15 minutes by default. Intervals are aligned to their length, e.g. on the quarter hour, and empty ones are included.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Creates a guest according to the name, table, and entourage arguments.",
+                "summary": "returns the number of arrivals in each interval of the event",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Guest Name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "Start of the window (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
                     },
                     {
-                        "description": "Entourage",
-                        "name": "entourage",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
+                        "type": "string",
+                        "description": "End of the window (RFC 3339)",
+                        "name": "until",
+                        "in": "query"
                     },
                     {
-                        "description": "Table ID - unique identifier of the table (see getTables)",
-                        "name": "table_id",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
+                        "type": "integer",
+                        "description": "Length of each interval in minutes",
+                        "name": "interval_minutes",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/api.arrivalAnalyticsResponse"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HTTPError"
+                            "$ref": "#/definitions/api.httpError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HTTPError"
+                            "$ref": "#/definitions/api.httpError"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HTTPError"
+                            "$ref": "#/definitions/api.httpError"
                         }
                     }
                 }
             }
         },
-        "/guests/": {
+        "/analytics/occupancy": {
             "get": {
-                "description": "Fetches an array of guest object ([]Guest), who have already undergone an arrival event. The requests are paginated with a minimum page_id of 1 and page_size of 5-20. Running a make test will generate some default data via the mysql unit tests.",
+                "description": "Returns the time-series of the venue's occupancy, seated and standing, over the window of the event, starting with the occupancy when the window opened followed by every change within it, along with the peak and when it was first reached.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "returns all guests already arrived",
+                "summary": "returns the occupancy of the venue over the event",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Page ID",
-                        "name": "page_id",
-                        "in": "query",
-                        "required": true
+                        "type": "string",
+                        "description": "Start of the window (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "description": "Page Size",
-                        "name": "page_size",
-                        "in": "query",
-                        "required": true
+                        "type": "string",
+                        "description": "End of the window (RFC 3339)",
+                        "name": "until",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/db.Guest"
-                            }
+                            "$ref": "#/definitions/api.occupancyAnalyticsResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HTTPError"
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HTTPError"
+                            "$ref": "#/definitions/api.httpError"
                         }
                     }
                 }
             }
         },
-        "/guests/{name}": {
+        "/analytics/summary": {
             "get": {
-                "description": "Fetches a guest object (Guest)",
+                "description": "Computes, over the window of the event, the number of arrivals and people let in, the peak occupancy of the venue and when it was reached, the average stay of the parties who have left, the no-show rate of the guest list and how the parties who arrived compared with what they were booked for. The window runs from the first arrival or change of occupancy until now unless from or until are given.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "returns a guest based on their GuestName value.",
+                "summary": "returns the headline analytics of the event",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Guest Name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "Start of the window (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the window (RFC 3339)",
+                        "name": "until",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/db.Guest"
+                            "$ref": "#/definitions/api.analyticsSummaryResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HTTPError"
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HTTPError"
+                            "$ref": "#/definitions/api.httpError"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Performs a PUT action to the arrivals table to record an arrival of the guest and their party.",
+            }
+        },
+        "/analytics/tables": {
+            "get": {
+                "description": "Returns, for every table over the window of the event, the number of arrivals at it, the most people sat at it at once, the seat hours it was occupied for and its utilisation, the share of its seat time that was occupied.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Arrives the guest into the party",
+                "summary": "returns the utilisation of every table over the event",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Guest Name",
-                        "name": "name",
-                        "in": "path",
-                        "required": true
+                        "description": "Start of the window (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
                     },
                     {
-                        "description": "Entourage (May be different to original)",
-                        "name": "entourage",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "integer"
-                        }
+                        "type": "string",
+                        "description": "End of the window (RFC 3339)",
+                        "name": "until",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api.tableAnalyticsResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HTTPError"
+                            "$ref": "#/definitions/api.httpError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HTTPError"
+                            "$ref": "#/definitions/api.httpError"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HTTPError"
+                            "$ref": "#/definitions/api.httpError"
                         }
                     }
                 }
             }
         },
-        "/seats_empty": {
+        "/audit": {
             "get": {
-                "description": "The empty seats are calculated from the difference between the Size and Occupied values in the table.",
+                "description": "Fetches an array of audit events ([]AuditEvent) oldest first, each holding the actor, action, request ID and the before/after state of the guest or table. Events can be filtered by guest name, table ID, actor and a [from, to) time range, and are paginated with a minimum page_id of 1 and page_size of 5-10.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Gets all the empty seats",
+                "summary": "returns the audit trail of changes to guests and tables",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest Name",
+                        "name": "guest_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Table ID",
+                        "name": "table_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Username of whoever made the change",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Earliest event time (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Time events must precede (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page ID",
+                        "name": "page_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page Size",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "integer"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.AuditEvent"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HTTPError"
+                            "$ref": "#/definitions/api.httpError"
                         }
                     }
                 }
             }
         },
-        "/tables/": {
+        "/bans": {
             "get": {
-                "description": "Fetches an array of table object ([]Table), the requests are paginated with a minimum page_id of 1 and page_size of 5-20. Running a make test will generate some default data via the mysql unit tests.",
+                "description": "Fetches an array of banned people ([]BannedPerson) in the order they were banned, paginated with a minimum page_id of 1 and page_size of 5-10.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "returns all tables",
+                "summary": "returns the ban list",
                 "parameters": [
                     {
                         "type": "integer",
@@ -391,116 +572,4185 @@ var doc = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/db.Table"
+                                "$ref": "#/definitions/db.BannedPerson"
                             }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HTTPError"
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HTTPError"
+                            "$ref": "#/definitions/api.httpError"
                         }
                     }
                 }
             },
             "post": {
-                "description": "Executes a POST request adding the table object to the db..",
+                "description": "Guests matching the person can no longer be added to the guest list or arrive, both are refused with a 403 and raise a ban_match alert in the audit log. Guests are matched by email or phone when the person has them, and otherwise by name: exactly once accents, case, punctuation and the order of the words are ignored, or within a letter or two for longer names.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
-                "summary": "Creates a table according to the table size.",
+                "summary": "Adds a person to the ban list",
                 "parameters": [
                     {
-                        "description": "Table Size - minimum value is 1",
-                        "name": "size",
+                        "description": "Name, optionally email and phone, and the reason for the ban",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "integer"
+                            "$ref": "#/definitions/api.banPersonRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
-                        "schema": {}
+                        "schema": {
+                            "$ref": "#/definitions/db.BannedPerson"
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HTTPError"
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/httputil.HTTPError"
+                            "$ref": "#/definitions/api.httpError"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "db.Guest": {
-            "type": "object",
-            "properties": {
-                "arrival_time": {
-                    "$ref": "#/definitions/sql.NullTime"
-                },
-                "created_at": {
-                    "$ref": "#/definitions/sql.NullTime"
-                },
-                "entourage": {
-                    "type": "integer"
-                },
+        },
+        "/bans/{id}": {
+            "delete": {
+                "description": "Guests matching the person can be added to the guest list and arrive again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Removes a person from the ban list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Banned person ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/capacity": {
+            "get": {
+                "description": "Fetches the headcount of the venue, seated guests and their entourage plus standing admissions, and of each zone with seated guests or a limit of its own, against their maximum occupancy. A max_occupancy of 0 means there is no limit.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "returns the occupancy of the venue and its zones",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.capacityResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Sets the legal headcount limit of the venue, or of the zone given, which arrivals, party changes and standing admissions are checked against alongside the table's own size. A max_occupancy of 0 removes the limit. Lowering a limit below the current headcount doesn't remove anybody, it only stops admissions until enough people leave.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Sets the maximum occupancy of the venue or a zone",
+                "parameters": [
+                    {
+                        "description": "Zone and maximum occupancy",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.setCapacityRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.CapacityLimit"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/checkin/scan": {
+            "post": {
+                "description": "Verifies the scanned token and arrives its guest and their party at their table, as PUT /guests/{name} does. Each invitation can only be used to arrive once.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Arrives the holder of a scanned invitation into the party",
+                "parameters": [
+                    {
+                        "description": "Scanned token and entourage (May be different to original)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.scanInvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.Guest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.admissionError"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/guest_list": {
+            "get": {
+                "description": "Fetches an array of guest object ([]Guest), the requests are paginated with a minimum page_id of 1 and page_size of 5-10. The guests' email and phone are left out for viewers. Running a make test will generate some default data via the mysql unit tests.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "returns all guests on the guest_list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page ID",
+                        "name": "page_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page Size",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only guests with this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only guests at this table",
+                        "name": "table_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only guests at the table with this label",
+                        "name": "table_label",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only guests with this dietary requirement",
+                        "name": "dietary",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only guests of this VIP tier",
+                        "name": "vip_tier",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only walk-ins, or only guests who were on the list",
+                        "name": "walk_in",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.guestResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/guest_list/{name}": {
+            "post": {
+                "description": "Executes a POST request preceeding the check to see if the table is big enough for the party (1 + entourage) and takes parties of that size. The table is given by either its ID or label. Numbered seats can optionally be reserved for the party, one for each of them, a seat already held by another guest is rejected with a 409. A guest matching somebody on the ban list by name, email or phone is refused with a 403 and an alert is raised. An arrival window can be given for the guest, see PUT /guests/{name}/arrival_window.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Creates a guest according to the name, table, and entourage arguments.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Entourage, Table ID - unique identifier of the table (see getTables) - or label, and optionally the guest's profile",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.createGuestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Deletes the guest, freeing any seats they hold and recording an arrived party leaving first. The removal is kept in the audit trail and listed as a correction in the report. Requires the organiser role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Removes a guest from the guest list.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/guests": {
+            "get": {
+                "description": "Fetches an array of guest object ([]Guest), who have already undergone an arrival event. The requests are paginated with a minimum page_id of 1 and page_size of 5-10. The guests' email and phone are left out for viewers. Running a make test will generate some default data via the mysql unit tests.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "returns all guests already arrived",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page ID",
+                        "name": "page_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page Size",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.guestResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/guests/{name}": {
+            "get": {
+                "description": "Fetches a guest object (Guest), the guest's email and phone are left out for viewers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "returns a guest based on their GuestName value.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.guestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Performs a PUT action to the arrivals table to record an arrival of the guest and their party. The party is given either as an entourage count or as the IDs of the named companions arriving. A guest who has left may re-enter in a new arrival session unless the event denies re-entry. The party must pass every one of the event's admission policies, by default that they fit in the free seats at the table, and a denial is a 409 explaining the decision of each, unless the only policy turning them away is that they don't fit, which is a 400. The venue, and the zone of the guest's table, must be under their maximum occupancy. The party can be given numbered seats, the guest's first followed by the companions', otherwise they keep any seats reserved at booking and are given the free seats closest together. A guest who has since been put on the ban list is refused with a 403 and an alert is raised.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Arrives the guest into the party",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Entourage (May be different to original) or arriving companions",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.arriveGuestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.admissionError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Frees the seats the guest's party occupied, the guest stays on the list with the status left and may re-enter.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Records an arrived guest leaving.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/guests/{name}/arrival_window": {
+            "put": {
+                "description": "Replaces the guest's arrival window, an end left out falls back to their table's window. A guest who hasn't arrived once their window closes, plus the NO_SHOW_GRACE period, is marked no_show and their reserved seats are released.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Sets when a guest is expected to arrive",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Start and end of the window, RFC 3339",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.arrivalWindowRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.Guest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/guests/{name}/companions": {
+            "get": {
+                "description": "Fetches the companions named for the guest's entourage, marking those who came in with the guest's current arrival.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "returns the named companions of the guest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/api.companionResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a companion to the guest, the name and attributes are optional. A guest can't have more companions than their entourage.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Names one of the guest's companions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Name and attributes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.companionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.Companion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/guests/{name}/companions/{id}": {
+            "put": {
+                "description": "Replaces the companion's name, and their attributes if given.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Renames one of the guest's companions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Companion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Name and attributes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.companionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.Companion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "The guest's entourage is unchanged, the seat just goes back to being anonymous.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Removes one of the guest's companions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Companion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/guests/{name}/invitation": {
+            "get": {
+                "description": "Fetches the token of the guest's unused, unrevoked invitation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "returns the guest's current invitation token.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.invitationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Every guest is sent an invitation when they're added to the guest list, this replaces it (e.g. if the QR code was lost), revoking the old one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Issues a new invitation to the guest.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.invitationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "The guest's current invitation can no longer be scanned to arrive, until a new one is issued.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Revokes the guest's invitation.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/guests/{name}/invitation/qr": {
+            "get": {
+                "description": "Renders the token of the guest's unused, unrevoked invitation as a PNG QR code to be sent to the guest and scanned on arrival.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "image/png"
+                ],
+                "summary": "returns the guest's current invitation as a QR code.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/guests/{name}/move": {
+            "post": {
+                "description": "Moves a seated guest, or the reservation of a guest yet to arrive, to another table. A seated party must fit in the free seats at the new table, and within the maximum occupancy of its zone when it's in another one, and their arrival moves with them, freeing their seats at the old table. A reservation must fit alongside every other reservation at the new table, along with any numbered seats the guest was holding. The old and new tables are returned as they are after the move.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Moves a guest to a different table",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Table ID or label to move to",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.moveGuestRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.MoveGuestTxResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/guests/{name}/party": {
+            "patch": {
+                "description": "Changes the entourage of a guest who is already seated, e.g. when a latecomer joins them or some of their party leave early. The party must be within the sizes the table takes. A bigger party must pass the event's admission policies, against the entourage the guest was booked with, and be within the maximum occupancy of the venue and zone, a smaller one frees up its seats and the named companions beyond the new entourage. Every change is kept in the history of the arrival.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Grows or shrinks the party of an arrived guest",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New entourage",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.updatePartyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.UpdatePartyTxResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/guests/{name}/profile": {
+            "patch": {
+                "description": "Updates any of the dietary requirements, accessibility needs, VIP tier, contact email and phone or host notes of a guest, fields which aren't given are left unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Changes the profile of a guest.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Profile fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.updateGuestProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.Guest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/guests/{name}/status": {
+            "put": {
+                "description": "Moves the guest to a status which doesn't change who is seated (confirmed, declined, cancelled or no_show), arriving and leaving are done with PUT and DELETE /guests/{name}. Transitions the guest's current status doesn't allow are rejected with a 409.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Changes the status of a guest.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Guest Name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.updateGuestStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.Guest"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/report": {
+            "get": {
+                "description": "Builds the report of the event over its window: the totals, every party turned away at the door with the reasons, the guests marked no-show within the window, how full each table was, a timeline of every arrival and departure, any periods a table, zone or the venue was over capacity, and the corrections made by hand. The window runs from the first arrival or change of occupancy until now unless from or until are given. With format=html the report is a self-contained HTML document.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/html"
+                ],
+                "summary": "returns the end-of-event report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start of the window (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the window (RFC 3339)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "json or html",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/report.Report"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/roster": {
+            "get": {
+                "description": "Fetches an array of tables as GET /tables/{id} returns them, paginated like GET /tables with a minimum page_id of 1 and page_size of 5-10. Each page is read with a single query.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "returns a page of tables with their live occupancy and guest rosters",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page ID",
+                        "name": "page_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page Size",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.TableRoster"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/rsvp/{token}": {
+            "get": {
+                "description": "Guest facing, authenticated by the invitation token rather than a staff credential.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "returns the RSVP of the invitation holder.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invitation token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.rsvpResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Guest facing, authenticated by the invitation token rather than a staff credential. An accepted party must fit at the guest's table alongside every other guest there who hasn't declined, and a changed party must be one the table takes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Accepts or declines the invitation.",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invitation token",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status and expected entourage",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.rsvpRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.rsvpResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "410": {
+                        "description": "Gone",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/seats_empty": {
+            "get": {
+                "description": "The empty seats are the seats at each table nobody holds, less those still to be given to the guests booked there who haven't arrived, so reserved seats are never empty.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Gets all the empty seats",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/standing": {
+            "get": {
+                "description": "Fetches an array of standing admissions ([]StandingAdmission) which haven't left, oldest first. The requests are paginated with a minimum page_id of 1 and page_size of 5-10.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "returns the standing parties still in the venue",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page ID",
+                        "name": "page_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page Size",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.StandingAdmission"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Lets a standing party in, e.g. to the bar, who count towards the venue's maximum occupancy until they leave. They don't count towards any zone, which only hold seated guests.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Admits a party without a table",
+                "parameters": [
+                    {
+                        "description": "Name and party size",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.admitStandingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.StandingAdmission"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/standing/{id}": {
+            "delete": {
+                "description": "Ends the standing admission, freeing its share of the venue's maximum occupancy.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Records a standing party leaving",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Standing admission ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.StandingAdmission"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tables": {
+            "get": {
+                "description": "Fetches an array of table object ([]Table), the requests are paginated with a minimum page_id of 1 and page_size of 5-10. Running a make test will generate some default data via the mysql unit tests.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "returns all tables",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page ID",
+                        "name": "page_id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page Size",
+                        "name": "page_size",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/db.Table"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Executes a POST request adding the table object to the db. Labels are unique, a table without one is labelled after its ID. An arrival window can be given for the table's guests, see PUT /tables/{id}/arrival_window.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Creates a table according to the table size.",
+                "parameters": [
+                    {
+                        "description": "Table Size - minimum value is 1 - and optionally its label, zone, accessibility, shape and the smallest and biggest party it takes",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.createTableRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.Table"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tables/{id}": {
+            "get": {
+                "description": "Fetches the table with how many of its seats are free, reserved for guests who haven't arrived yet and taken, the guests seated there with the party they arrived with and the guests still expected with the party they booked and when they're expected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "returns a table with its live occupancy and guest roster",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Table ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.TableRoster"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tables/{id}/arrival_window": {
+            "put": {
+                "description": "Replaces the table's arrival window, which applies to each of its guests without a window of their own. A guest who hasn't arrived once their window closes, plus the NO_SHOW_GRACE period, is marked no_show and their reserved seats are released.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Sets when the guests at a table are expected to arrive",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Table ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Start and end of the window, RFC 3339",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.arrivalWindowRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/db.Table"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tables/{id}/seats": {
+            "get": {
+                "description": "Fetches every numbered seat of the table with its status, free, reserved for a guest who hasn't arrived yet or occupied, and the guest or named companion holding it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "returns the seat map of a table",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Table ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.seatMapResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/tokens": {
+            "post": {
+                "description": "Organisers issue JWTs to door staff and viewers (e.g. for a shift), the token expires after the configured ACCESS_TOKEN_DURATION.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Issues an access token for a member of staff.",
+                "parameters": [
+                    {
+                        "description": "Username and role of the token holder",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.createTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.createTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        },
+        "/walkins": {
+            "post": {
+                "description": "Adds the guest to the guest list, tagged as a walk-in, and arrives them with their party in one go. Without a table they're seated at the table with room for them that has the fewest seats to spare, keeping bigger tables for bigger parties, and a 409 is returned when no table has room. Their arrival must pass the event's admission policies and the maximum occupancy of the venue and zone exactly as any other arrival, and a walk-in matching somebody on the ban list is refused with a 403 and an alert is raised. A name already on the guest list is rejected with a 409, that guest should be arrived instead.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "summary": "Lets in a party who aren't on the guest list",
+                "parameters": [
+                    {
+                        "description": "Guest name, entourage, optionally the table and seats, and the guest's profile",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api.walkInRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.walkInResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/api.admissionError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/api.httpError"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "api.admissionError": {
+            "type": "object",
+            "properties": {
+                "decisions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.AdmissionDecision"
+                    }
+                },
+                "error": {
+                    "type": "string",
+                    "example": "admission denied: doors closed at 2026-10-19T23:00:00Z"
+                }
+            }
+        },
+        "api.admissionRule": {
+            "type": "object",
+            "required": [
+                "name",
+                "rule"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "rule": {
+                    "type": "string",
+                    "maxLength": 1024
+                }
+            }
+        },
+        "api.admitStandingRequest": {
+            "type": "object",
+            "required": [
+                "party_size"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "party_size": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "api.analyticsSummaryResponse": {
+            "type": "object",
+            "properties": {
+                "arrivals": {
+                    "type": "integer"
+                },
+                "average_stay_minutes": {
+                    "type": "number"
+                },
+                "completed_stays": {
+                    "type": "integer"
+                },
+                "entourage": {
+                    "$ref": "#/definitions/db.EntourageSummary"
+                },
+                "no_shows": {
+                    "$ref": "#/definitions/db.NoShowSummary"
+                },
+                "peak_occupancy": {
+                    "$ref": "#/definitions/db.OccupancyPoint"
+                },
+                "people": {
+                    "type": "integer"
+                },
+                "window": {
+                    "$ref": "#/definitions/db.EventWindow"
+                }
+            }
+        },
+        "api.arrivalAnalyticsResponse": {
+            "type": "object",
+            "properties": {
+                "buckets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.ArrivalBucket"
+                    }
+                },
+                "interval_minutes": {
+                    "type": "integer"
+                },
+                "window": {
+                    "$ref": "#/definitions/db.EventWindow"
+                }
+            }
+        },
+        "api.arrivalWindowRequest": {
+            "type": "object",
+            "properties": {
+                "expected_from": {
+                    "type": "string"
+                },
+                "expected_until": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.arriveGuestRequest": {
+            "type": "object",
+            "properties": {
+                "companions": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "entourage": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "seats": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "api.banPersonRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "reason"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 5
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1024
+                }
+            }
+        },
+        "api.capacityResponse": {
+            "type": "object",
+            "properties": {
+                "venue": {
+                    "$ref": "#/definitions/api.capacityUsage"
+                },
+                "zones": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.capacityUsage"
+                    }
+                }
+            }
+        },
+        "api.capacityUsage": {
+            "type": "object",
+            "properties": {
+                "max_occupancy": {
+                    "type": "integer"
+                },
+                "occupancy": {
+                    "type": "integer"
+                },
+                "zone": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.companionRequest": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "api.companionResponse": {
+            "type": "object",
+            "properties": {
+                "arrived": {
+                    "description": "Arrived is true when the companion came in with the guest's current arrival",
+                    "type": "boolean"
+                },
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "guest_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.createGuestRequest": {
+            "type": "object",
+            "properties": {
+                "accessibility": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "dietary": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "entourage": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "expected_from": {
+                    "type": "string"
+                },
+                "expected_until": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1024
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "seats": {
+                    "description": "Seats optionally reserves numbered seats at the table, one for each of the party",
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "table_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "table_label": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "vip_tier": {
+                    "type": "string",
+                    "enum": [
+                        "silver",
+                        "gold",
+                        "platinum"
+                    ]
+                }
+            }
+        },
+        "api.createTableRequest": {
+            "type": "object",
+            "required": [
+                "size"
+            ],
+            "properties": {
+                "accessible": {
+                    "type": "boolean"
+                },
+                "expected_from": {
+                    "type": "string"
+                },
+                "expected_until": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "max_party": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "min_party": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "shape": {
+                    "type": "string",
+                    "enum": [
+                        "round",
+                        "rectangular",
+                        "square",
+                        "booth"
+                    ]
+                },
+                "size": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "zone": {
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
+        "api.createTokenRequest": {
+            "type": "object",
+            "required": [
+                "role",
+                "username"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "organiser",
+                        "door_staff",
+                        "viewer"
+                    ]
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.createTokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "access_token_expires_at": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.guestResponse": {
+            "type": "object",
+            "properties": {
+                "accessibility": {
+                    "type": "string"
+                },
+                "arrival_time": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "created_at": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "dietary": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "entourage": {
+                    "type": "integer"
+                },
+                "expected_from": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "expected_until": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "guest_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "rsvp_at": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "rsvp_status": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "table_id": {
+                    "type": "integer"
+                },
+                "vip_tier": {
+                    "type": "string"
+                },
+                "walk_in": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "api.httpError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string",
+                    "example": "record not found"
+                }
+            }
+        },
+        "api.invitationResponse": {
+            "type": "object",
+            "properties": {
+                "guest_name": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.moveGuestRequest": {
+            "type": "object",
+            "properties": {
+                "table_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "table_label": {
+                    "type": "string",
+                    "maxLength": 64
+                }
+            }
+        },
+        "api.occupancyAnalyticsResponse": {
+            "type": "object",
+            "properties": {
+                "peak": {
+                    "$ref": "#/definitions/db.OccupancyPoint"
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.OccupancyPoint"
+                    }
+                },
+                "window": {
+                    "$ref": "#/definitions/db.EventWindow"
+                }
+            }
+        },
+        "api.rsvpRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "entourage": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "accepted",
+                        "declined"
+                    ]
+                }
+            }
+        },
+        "api.rsvpResponse": {
+            "type": "object",
+            "properties": {
+                "entourage": {
+                    "type": "integer"
+                },
+                "guest_name": {
+                    "type": "string"
+                },
+                "rsvp_status": {
+                    "type": "string"
+                },
+                "table_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.scanInvitationRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "entourage": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.seatMapResponse": {
+            "type": "object",
+            "properties": {
+                "label": {
+                    "type": "string"
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.seatResponse"
+                    }
+                },
+                "table_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "api.seatResponse": {
+            "type": "object",
+            "properties": {
+                "companion_name": {
+                    "type": "string"
+                },
+                "guest_name": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "api.setAdmissionRulesRequest": {
+            "type": "object",
+            "required": [
+                "rules"
+            ],
+            "properties": {
+                "event": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api.admissionRule"
+                    }
+                }
+            }
+        },
+        "api.setCapacityRequest": {
+            "type": "object",
+            "properties": {
+                "max_occupancy": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "zone": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "api.tableAnalyticsResponse": {
+            "type": "object",
+            "properties": {
+                "tables": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.TableUtilisation"
+                    }
+                },
+                "window": {
+                    "$ref": "#/definitions/db.EventWindow"
+                }
+            }
+        },
+        "api.updateGuestProfileRequest": {
+            "type": "object",
+            "properties": {
+                "accessibility": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "dietary": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1024
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "vip_tier": {
+                    "type": "string",
+                    "enum": [
+                        "",
+                        "silver",
+                        "gold",
+                        "platinum"
+                    ]
+                }
+            }
+        },
+        "api.updateGuestStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "confirmed",
+                        "declined",
+                        "cancelled",
+                        "no_show"
+                    ]
+                }
+            }
+        },
+        "api.updatePartyRequest": {
+            "type": "object",
+            "properties": {
+                "entourage": {
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "api.walkInRequest": {
+            "type": "object",
+            "required": [
+                "guest_name"
+            ],
+            "properties": {
+                "accessibility": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "dietary": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "entourage": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "guest_name": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 5
+                },
+                "notes": {
+                    "type": "string",
+                    "maxLength": 1024
+                },
+                "phone": {
+                    "type": "string",
+                    "maxLength": 32
+                },
+                "seats": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "table_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "table_label": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "vip_tier": {
+                    "type": "string",
+                    "enum": [
+                        "silver",
+                        "gold",
+                        "platinum"
+                    ]
+                }
+            }
+        },
+        "api.walkInResponse": {
+            "type": "object",
+            "properties": {
+                "guest": {
+                    "$ref": "#/definitions/db.Guest"
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.Seat"
+                    }
+                },
+                "table": {
+                    "$ref": "#/definitions/db.Table"
+                }
+            }
+        },
+        "db.AdmissionDecision": {
+            "type": "object",
+            "properties": {
+                "allowed": {
+                    "type": "boolean"
+                },
+                "policy": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "rules_version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.AdmissionLog": {
+            "type": "object",
+            "properties": {
+                "allowed": {
+                    "type": "boolean"
+                },
+                "decided_at": {
+                    "type": "string"
+                },
+                "decided_by": {
+                    "type": "string"
+                },
+                "guest_id": {
+                    "type": "integer"
+                },
+                "guest_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "party_size": {
+                    "type": "integer"
+                },
+                "policy": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "rules_version": {
+                    "type": "integer"
+                },
+                "table_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.AdmissionRuleSet": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "document": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.Arrival": {
+            "type": "object",
+            "properties": {
+                "arrived_at": {
+                    "type": "string"
+                },
+                "arrived_by": {
+                    "type": "string"
+                },
+                "booked_party_size": {
+                    "type": "integer"
+                },
+                "departed_at": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "guest_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "party_size": {
+                    "type": "integer"
+                },
+                "table_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.ArrivalBucket": {
+            "type": "object",
+            "properties": {
+                "arrivals": {
+                    "type": "integer"
+                },
+                "people": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.AuditEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "before": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "guest_id": {
+                    "$ref": "#/definitions/sql.NullInt32"
+                },
+                "guest_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "table_id": {
+                    "$ref": "#/definitions/sql.NullInt32"
+                }
+            }
+        },
+        "db.BannedPerson": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.CapacityLimit": {
+            "type": "object",
+            "properties": {
+                "max_occupancy": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                },
+                "zone": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.Companion": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "guest_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.EntourageDelta": {
+            "type": "object",
+            "properties": {
+                "actual_party_size": {
+                    "type": "integer"
+                },
+                "booked_party_size": {
+                    "type": "integer"
+                },
+                "delta": {
+                    "type": "integer"
+                },
+                "guest_id": {
+                    "type": "integer"
+                },
+                "guest_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.EntourageSummary": {
+            "type": "object",
+            "properties": {
+                "actual": {
+                    "type": "integer"
+                },
+                "booked": {
+                    "type": "integer"
+                },
+                "delta": {
+                    "type": "integer"
+                },
+                "deltas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.EntourageDelta"
+                    }
+                },
+                "guests": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.EventWindow": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "until": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.Guest": {
+            "type": "object",
+            "properties": {
+                "accessibility": {
+                    "type": "string"
+                },
+                "arrival_time": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "created_at": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "dietary": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "entourage": {
+                    "type": "integer"
+                },
+                "expected_from": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "expected_until": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "guest_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "rsvp_at": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "rsvp_status": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "table_id": {
+                    "type": "integer"
+                },
+                "vip_tier": {
+                    "type": "string"
+                },
+                "walk_in": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "db.MoveGuestTxResult": {
+            "type": "object",
+            "properties": {
+                "arrival": {
+                    "$ref": "#/definitions/db.Arrival"
+                },
+                "guest": {
+                    "$ref": "#/definitions/db.Guest"
+                },
+                "old_table": {
+                    "$ref": "#/definitions/db.Table"
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.Seat"
+                    }
+                },
+                "table": {
+                    "$ref": "#/definitions/db.Table"
+                }
+            }
+        },
+        "db.NoShowSummary": {
+            "type": "object",
+            "properties": {
+                "expected": {
+                    "type": "integer"
+                },
+                "no_shows": {
+                    "type": "integer"
+                },
+                "rate": {
+                    "type": "number"
+                }
+            }
+        },
+        "db.OccupancyPoint": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "occupancy": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.PartyChange": {
+            "type": "object",
+            "properties": {
+                "arrival_id": {
+                    "type": "integer"
+                },
+                "changed_at": {
+                    "type": "string"
+                },
+                "changed_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "new_party_size": {
+                    "type": "integer"
+                },
+                "old_party_size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "db.RosterGuest": {
+            "type": "object",
+            "properties": {
+                "arrived_at": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "expected_from": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "expected_until": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "guest_id": {
+                    "type": "integer"
+                },
                 "guest_name": {
                     "type": "string"
                 },
+                "party_size": {
+                    "type": "integer"
+                },
+                "seats": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "vip_tier": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.Seat": {
+            "type": "object",
+            "properties": {
+                "assigned_at": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "companion_id": {
+                    "$ref": "#/definitions/sql.NullInt32"
+                },
+                "guest_id": {
+                    "$ref": "#/definitions/sql.NullInt32"
+                },
                 "id": {
                     "type": "integer"
                 },
+                "number": {
+                    "type": "integer"
+                },
                 "table_id": {
                     "type": "integer"
                 }
             }
         },
+        "db.StandingAdmission": {
+            "type": "object",
+            "properties": {
+                "admitted_at": {
+                    "type": "string"
+                },
+                "admitted_by": {
+                    "type": "string"
+                },
+                "departed_at": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "party_size": {
+                    "type": "integer"
+                }
+            }
+        },
         "db.Table": {
             "type": "object",
             "properties": {
+                "accessible": {
+                    "type": "boolean"
+                },
                 "created_at": {
                     "$ref": "#/definitions/sql.NullTime"
                 },
+                "created_by": {
+                    "type": "string"
+                },
+                "expected_from": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "expected_until": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
                 "id": {
                     "type": "integer"
                 },
+                "label": {
+                    "type": "string"
+                },
+                "max_party": {
+                    "type": "integer"
+                },
+                "min_party": {
+                    "type": "integer"
+                },
                 "occupied": {
                     "type": "integer"
                 },
+                "shape": {
+                    "type": "string"
+                },
                 "size": {
                     "type": "integer"
+                },
+                "zone": {
+                    "type": "string"
                 }
             }
         },
-        "httputil.HTTPError": {
+        "db.TableRoster": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer",
-                    "example": 400
+                "accessible": {
+                    "type": "boolean"
                 },
-                "message": {
-                    "type": "string",
-                    "example": "status bad request"
+                "expected": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.RosterGuest"
+                    }
+                },
+                "free_seats": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "max_party": {
+                    "type": "integer"
+                },
+                "min_party": {
+                    "type": "integer"
+                },
+                "occupied": {
+                    "type": "integer"
+                },
+                "reserved_seats": {
+                    "type": "integer"
+                },
+                "seated": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.RosterGuest"
+                    }
+                },
+                "shape": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "table_id": {
+                    "type": "integer"
+                },
+                "zone": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.TableUtilisation": {
+            "type": "object",
+            "properties": {
+                "arrivals": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "peak_occupied": {
+                    "type": "integer"
+                },
+                "seat_hours": {
+                    "type": "number"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "table_id": {
+                    "type": "integer"
+                },
+                "utilisation": {
+                    "type": "number"
+                },
+                "zone": {
+                    "type": "string"
+                }
+            }
+        },
+        "db.UpdatePartyTxResult": {
+            "type": "object",
+            "properties": {
+                "arrival": {
+                    "$ref": "#/definitions/db.Arrival"
+                },
+                "guest": {
+                    "$ref": "#/definitions/db.Guest"
+                },
+                "history": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.PartyChange"
+                    }
+                },
+                "seats": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.Seat"
+                    }
+                },
+                "table": {
+                    "$ref": "#/definitions/db.Table"
+                }
+            }
+        },
+        "report.CapacityViolation": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "peak": {
+                    "type": "integer"
+                },
+                "scope": {
+                    "type": "string"
+                },
+                "table_id": {
+                    "type": "integer"
+                },
+                "until": {
+                    "$ref": "#/definitions/sql.NullTime"
+                }
+            }
+        },
+        "report.Correction": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "at": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "guest_name": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "table_id": {
+                    "$ref": "#/definitions/sql.NullInt32"
+                }
+            }
+        },
+        "report.NoShow": {
+            "type": "object",
+            "properties": {
+                "expected_until": {
+                    "$ref": "#/definitions/sql.NullTime"
+                },
+                "guest_id": {
+                    "type": "integer"
+                },
+                "guest_name": {
+                    "type": "string"
+                },
+                "party_size": {
+                    "type": "integer"
+                },
+                "table_id": {
+                    "type": "integer"
+                },
+                "marked_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "report.Report": {
+            "type": "object",
+            "properties": {
+                "capacity_violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/report.CapacityViolation"
+                    }
+                },
+                "corrections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/report.Correction"
+                    }
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "no_shows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/report.NoShow"
+                    }
+                },
+                "tables": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/db.TableUtilisation"
+                    }
+                },
+                "timeline": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/report.TimelineEntry"
+                    }
+                },
+                "totals": {
+                    "$ref": "#/definitions/report.Totals"
+                },
+                "turned_away": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/report.TurnedAway"
+                    }
+                },
+                "window": {
+                    "$ref": "#/definitions/db.EventWindow"
+                }
+            }
+        },
+        "report.TimelineEntry": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "guest_name": {
+                    "type": "string"
+                },
+                "party_size": {
+                    "type": "integer"
+                },
+                "standing": {
+                    "type": "boolean"
+                },
+                "table_id": {
+                    "type": "integer"
+                },
+                "walk_in": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "report.Totals": {
+            "type": "object",
+            "properties": {
+                "arrivals": {
+                    "type": "integer"
+                },
+                "average_stay_minutes": {
+                    "type": "number"
+                },
+                "capacity_violations": {
+                    "type": "integer"
+                },
+                "corrections": {
+                    "type": "integer"
+                },
+                "departures": {
+                    "type": "integer"
+                },
+                "guest_list": {
+                    "type": "integer"
+                },
+                "no_shows": {
+                    "$ref": "#/definitions/db.NoShowSummary"
+                },
+                "peak_occupancy": {
+                    "$ref": "#/definitions/db.OccupancyPoint"
+                },
+                "people": {
+                    "type": "integer"
+                },
+                "standing_parties": {
+                    "type": "integer"
+                },
+                "turned_away": {
+                    "type": "integer"
+                },
+                "walk_ins": {
+                    "type": "integer"
+                }
+            }
+        },
+        "report.TurnedAway": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "decided_by": {
+                    "type": "string"
+                },
+                "guest_name": {
+                    "type": "string"
+                },
+                "party_size": {
+                    "type": "integer"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "table_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "sql.NullInt32": {
+            "type": "object",
+            "properties": {
+                "int32": {
+                    "type": "integer"
+                },
+                "valid": {
+                    "description": "Valid is true if Int32 is not NULL",
+                    "type": "boolean"
                 }
             }
         },
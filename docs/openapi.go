@@ -0,0 +1,8 @@
+package docs
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI 3 description of the HTTP API, it is the source of truth for
+// request validation whereas swagger.json only backs the swagger UI
+//go:embed openapi.yaml
+var OpenAPI []byte
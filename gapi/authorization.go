@@ -0,0 +1,87 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/token"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationHeader = "authorization"
+	authorizationBearer = "bearer"
+	apiKeyHeader        = "x-api-key"
+	requestIDHeader     = "x-request-id"
+)
+
+// authorizeUser authenticates the caller from the bearer token or API key in the request
+// metadata, the same credentials the HTTP API accepts, and checks their role is one of roles
+func (server *Server) authorizeUser(ctx context.Context, roles ...string) (*token.Payload, error) {
+	payload, err := server.authenticate(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "unauthenticated: %s", err)
+	}
+
+	for _, role := range roles {
+		if payload.Role == role {
+			return payload, nil
+		}
+	}
+	return nil, status.Errorf(codes.PermissionDenied, "role %s is not permitted to call this method", payload.Role)
+}
+
+func (server *Server) authenticate(ctx context.Context) (*token.Payload, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, fmt.Errorf("missing metadata")
+	}
+
+	if values := md.Get(apiKeyHeader); len(values) > 0 {
+		return server.apiKeys.Verify(values[0])
+	}
+
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 {
+		return nil, fmt.Errorf("missing authorization header")
+	}
+
+	fields := strings.Fields(values[0])
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("invalid authorization header format")
+	}
+
+	authType := strings.ToLower(fields[0])
+	if authType != authorizationBearer {
+		return nil, fmt.Errorf("unsupported authorization type: %s", authType)
+	}
+
+	return server.tokenMaker.VerifyToken(fields[1])
+}
+
+// auditInfo attributes a mutation to the caller. The request ID is taken from the x-request-id
+// metadata when the client sets it and generated otherwise, either way it is sent back in the
+// response header.
+func auditInfo(ctx context.Context, payload *token.Payload) db.AuditInfo {
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDHeader); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	requestID = util.RequestID(requestID)
+
+	// Fails only when there is no transport stream, e.g. when a handler is called directly in tests
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, requestID))
+
+	return db.AuditInfo{
+		Actor:     payload.Username,
+		RequestID: requestID,
+	}
+}
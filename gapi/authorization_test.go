@@ -0,0 +1,161 @@
+package gapi
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	"github.com/ellisp97/BE_Task_Oct20/golang/pb"
+	"github.com/ellisp97/BE_Task_Oct20/golang/token"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	testUsername  = "test-user"
+	testAPIKey    = "test-api-key"
+	testRequestID = "test-request"
+)
+
+func newTestServer(t *testing.T, store *mockdb.MockStore) *Server {
+	config := util.Config{
+		TokenSymmetricKey: util.RandomString(32),
+		APIKeys:           fmt.Sprintf("dashboard:%s:%s", util.ViewerRole, testAPIKey),
+	}
+
+	server, err := NewServer(config, store)
+	require.NoError(t, err)
+	return server
+}
+
+// newContextWithBearerToken returns an incoming context authenticated as testUsername with role,
+// carrying testRequestID as its request ID
+func newContextWithBearerToken(t *testing.T, tokenMaker token.Maker, role string) context.Context {
+	accessToken, _, err := tokenMaker.CreateToken(testUsername, role, time.Minute)
+	require.NoError(t, err)
+
+	md := metadata.MD{
+		authorizationHeader: []string{fmt.Sprintf("%s %s", authorizationBearer, accessToken)},
+		requestIDHeader:     []string{testRequestID},
+	}
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+func TestAuthorizeUser(t *testing.T) {
+	testCases := []struct {
+		name       string
+		buildCtx   func(t *testing.T, server *Server) context.Context
+		buildStubs func(store *mockdb.MockStore)
+		code       codes.Code
+	}{
+		{
+			name: "OrganiserToken",
+			buildCtx: func(t *testing.T, server *Server) context.Context {
+				return newContextWithBearerToken(t, server.tokenMaker, util.OrganiserRole)
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEmptySeats(gomock.Any()).Times(1).Return(int32(3), nil)
+			},
+			code: codes.OK,
+		},
+		{
+			name: "ViewerAPIKey",
+			buildCtx: func(t *testing.T, server *Server) context.Context {
+				return metadata.NewIncomingContext(context.Background(), metadata.MD{apiKeyHeader: []string{testAPIKey}})
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEmptySeats(gomock.Any()).Times(1).Return(int32(3), nil)
+			},
+			code: codes.OK,
+		},
+		{
+			name: "NoMetadata",
+			buildCtx: func(t *testing.T, server *Server) context.Context {
+				return context.Background()
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEmptySeats(gomock.Any()).Times(0)
+			},
+			code: codes.Unauthenticated,
+		},
+		{
+			name: "UnknownAPIKey",
+			buildCtx: func(t *testing.T, server *Server) context.Context {
+				return metadata.NewIncomingContext(context.Background(), metadata.MD{apiKeyHeader: []string{"unknown"}})
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEmptySeats(gomock.Any()).Times(0)
+			},
+			code: codes.Unauthenticated,
+		},
+		{
+			name: "UnsupportedAuthorizationType",
+			buildCtx: func(t *testing.T, server *Server) context.Context {
+				return metadata.NewIncomingContext(context.Background(), metadata.MD{authorizationHeader: []string{"basic abc"}})
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetEmptySeats(gomock.Any()).Times(0)
+			},
+			code: codes.Unauthenticated,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			_, err := server.GetEmptySeats(tc.buildCtx(t, server), &pb.GetEmptySeatsRequest{})
+			if tc.code == codes.OK {
+				require.NoError(t, err)
+				return
+			}
+			requireStatusCode(t, err, tc.code)
+		})
+	}
+}
+
+func TestRolePermissions(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().GetTable(gomock.Any(), gomock.Any()).Times(0)
+	store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Any()).Times(0)
+	server := newTestServer(t, store)
+
+	// Door staff can't book guests
+	ctx := newContextWithBearerToken(t, server.tokenMaker, util.DoorStaffRole)
+	_, err := server.CreateGuest(ctx, &pb.CreateGuestRequest{GuestName: util.RandomGuestName(), TableId: 1})
+	requireStatusCode(t, err, codes.PermissionDenied)
+
+	// Viewers can't arrive guests
+	ctx = newContextWithBearerToken(t, server.tokenMaker, util.ViewerRole)
+	_, err = server.ArriveGuest(ctx, &pb.ArriveGuestRequest{GuestName: util.RandomGuestName()})
+	requireStatusCode(t, err, codes.PermissionDenied)
+}
+
+func TestAuditInfo(t *testing.T) {
+	payload := &token.Payload{Username: testUsername, Role: util.DoorStaffRole}
+
+	md := metadata.Pairs(requestIDHeader, testRequestID)
+	info := auditInfo(metadata.NewIncomingContext(context.Background(), md), payload)
+	require.Equal(t, testUsername, info.Actor)
+	require.Equal(t, testRequestID, info.RequestID)
+
+	// Calls without a request ID are given a fresh one
+	first := auditInfo(context.Background(), payload)
+	second := auditInfo(context.Background(), payload)
+	require.NotEmpty(t, first.RequestID)
+	require.NotEqual(t, first.RequestID, second.RequestID)
+}
@@ -0,0 +1,91 @@
+package gapi
+
+import (
+	"database/sql"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/pb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func convertGuest(guest db.Guest) *pb.Guest {
+	return &pb.Guest{
+		Id:            guest.ID,
+		GuestName:     guest.GuestName,
+		Entourage:     guest.Entourage,
+		TableId:       guest.TableID,
+		ArrivalTime:   convertNullTime(guest.ArrivalTime),
+		CreatedAt:     convertNullTime(guest.CreatedAt),
+		RsvpStatus:    guest.RsvpStatus,
+		Status:        guest.Status,
+		Dietary:       guest.Dietary,
+		Accessibility: guest.Accessibility,
+		VipTier:       guest.VipTier,
+		Email:         guest.Email,
+		Phone:         guest.Phone,
+		Notes:         guest.Notes,
+	}
+}
+
+func convertGuests(guests []db.Guest) []*pb.Guest {
+	result := make([]*pb.Guest, len(guests))
+	for i, guest := range guests {
+		result[i] = convertGuest(guest)
+	}
+	return result
+}
+
+func convertTable(table db.Table) *pb.Table {
+	return &pb.Table{
+		Id:         table.ID,
+		Size:       table.Size,
+		Occupied:   table.Occupied,
+		CreatedAt:  convertNullTime(table.CreatedAt),
+		Label:      table.Label,
+		Zone:       table.Zone,
+		Accessible: table.Accessible,
+		Shape:      table.Shape,
+		MinParty:   table.MinParty,
+		MaxParty:   table.MaxParty,
+	}
+}
+
+func convertTables(tables []db.Table) []*pb.Table {
+	result := make([]*pb.Table, len(tables))
+	for i, table := range tables {
+		result[i] = convertTable(table)
+	}
+	return result
+}
+
+func convertArrival(arrival db.Arrival) *pb.Arrival {
+	return &pb.Arrival{
+		Id:         arrival.ID,
+		GuestId:    arrival.GuestID,
+		TableId:    arrival.TableID,
+		PartySize:  arrival.PartySize,
+		ArrivedAt:  timestamppb.New(arrival.ArrivedAt),
+		DepartedAt: convertNullTime(arrival.DepartedAt),
+	}
+}
+
+func convertAdmissionDecisions(decisions []db.AdmissionDecision) []*pb.AdmissionDecision {
+	result := make([]*pb.AdmissionDecision, len(decisions))
+	for i, decision := range decisions {
+		result[i] = &pb.AdmissionDecision{
+			Policy:       decision.Policy,
+			Allowed:      decision.Allowed,
+			Reason:       decision.Reason,
+			RulesVersion: decision.RulesVersion,
+		}
+	}
+	return result
+}
+
+// convertNullTime leaves the timestamp unset when the column is NULL
+func convertNullTime(t sql.NullTime) *timestamppb.Timestamp {
+	if !t.Valid {
+		return nil
+	}
+	return timestamppb.New(t.Time)
+}
@@ -0,0 +1,63 @@
+package gapi
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/token"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// toStatusError maps the domain errors returned by the store onto gRPC status codes, in line with
+// the HTTP status the REST API answers them with: a bad request is INVALID_ARGUMENT, a conflict with
+// the state of the guest, table or invitation FAILED_PRECONDITION (ALREADY_EXISTS when it already
+// exists) and a full venue RESOURCE_EXHAUSTED
+func toStatusError(err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, db.ErrBanned):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, db.ErrAdmissionDenied), errors.Is(err, db.ErrInsufficientTableSize), errors.Is(err, db.ErrPartySize):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, db.ErrVenueFull), errors.Is(err, db.ErrZoneFull), errors.Is(err, db.ErrNoTableAvailable):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, db.ErrInvalidPartyRange), errors.Is(err, db.ErrInvalidCompanions), errors.Is(err, db.ErrInvalidSeats),
+		errors.Is(err, db.ErrInvalidArrivalWindow), errors.Is(err, db.ErrInvalidAdmissionRules), errors.Is(err, token.ErrInvalidInvitation):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, db.ErrTableLabelTaken), errors.Is(err, db.ErrGuestAlreadyArrived), errors.Is(err, db.ErrGuestOnList):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, db.ErrIllegalTransition), errors.Is(err, db.ErrReentryDenied), errors.Is(err, db.ErrGuestNotArrived),
+		errors.Is(err, db.ErrAlreadyAtTable), errors.Is(err, db.ErrSeatTaken), errors.Is(err, db.ErrCompanionLimit),
+		errors.Is(err, db.ErrStandingDeparted), errors.Is(err, db.ErrInvitationUsed), errors.Is(err, db.ErrInvitationRevoked):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func invalidArgumentError(format string, args ...interface{}) error {
+	return status.Error(codes.InvalidArgument, fmt.Sprintf(format, args...))
+}
+
+// validateGuestName mirrors the min=5 binding applied to guest names by the HTTP API
+func validateGuestName(name string) error {
+	if len(name) < 5 {
+		return invalidArgumentError("guest_name must be at least 5 characters")
+	}
+	return nil
+}
+
+// validatePagination mirrors the page_id/page_size bindings applied by the HTTP API
+func validatePagination(pageID, pageSize int32) error {
+	if pageID < 1 {
+		return invalidArgumentError("page_id must be at least 1")
+	}
+	if pageSize < 5 || pageSize > 10 {
+		return invalidArgumentError("page_size must be between 5 and 10")
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package gapi
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/token"
+	"google.golang.org/grpc/codes"
+)
+
+func TestToStatusError(t *testing.T) {
+	testCases := []struct {
+		err  error
+		code codes.Code
+	}{
+		{sql.ErrNoRows, codes.NotFound},
+		{&db.BannedError{}, codes.PermissionDenied},
+		{&db.AdmissionDeniedError{}, codes.FailedPrecondition},
+		{db.InsufficientTableSizeErr(1), codes.FailedPrecondition},
+		{&db.PartySizeError{}, codes.FailedPrecondition},
+		{&db.CapacityError{}, codes.ResourceExhausted},
+		{&db.CapacityError{Zone: "Garden"}, codes.ResourceExhausted},
+		{db.ErrNoTableAvailable, codes.ResourceExhausted},
+		{db.ErrInvalidPartyRange, codes.InvalidArgument},
+		{fmt.Errorf("%w: two companions named Basil", db.ErrInvalidCompanions), codes.InvalidArgument},
+		{fmt.Errorf("%w: seat 9 isn't at the table", db.ErrInvalidSeats), codes.InvalidArgument},
+		{db.ErrInvalidArrivalWindow, codes.InvalidArgument},
+		{db.ErrInvalidAdmissionRules, codes.InvalidArgument},
+		{token.ErrInvalidInvitation, codes.InvalidArgument},
+		{db.ErrTableLabelTaken, codes.AlreadyExists},
+		{db.ErrGuestAlreadyArrived, codes.AlreadyExists},
+		{db.ErrGuestOnList, codes.AlreadyExists},
+		{&db.TransitionError{From: db.GuestLeft, To: db.GuestArrived}, codes.FailedPrecondition},
+		{db.ErrReentryDenied, codes.FailedPrecondition},
+		{db.ErrGuestNotArrived, codes.FailedPrecondition},
+		{db.ErrAlreadyAtTable, codes.FailedPrecondition},
+		{&db.SeatTakenError{TableID: 1, Number: 2}, codes.FailedPrecondition},
+		{db.ErrCompanionLimit, codes.FailedPrecondition},
+		{db.ErrStandingDeparted, codes.FailedPrecondition},
+		{db.ErrInvitationUsed, codes.FailedPrecondition},
+		{db.ErrInvitationRevoked, codes.FailedPrecondition},
+		{errors.New("connection reset"), codes.Internal},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.err.Error(), func(t *testing.T) {
+			requireStatusCode(t, toStatusError(tc.err), tc.code)
+		})
+	}
+}
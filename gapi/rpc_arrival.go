@@ -0,0 +1,98 @@
+package gapi
+
+import (
+	"context"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/pb"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+)
+
+// ArriveGuest arrives a guest and their (possibly changed) entourage at their table
+func (server *Server) ArriveGuest(ctx context.Context, req *pb.ArriveGuestRequest) (*pb.ArriveGuestResponse, error) {
+	payload, err := server.authorizeUser(ctx, util.OrganiserRole, util.DoorStaffRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateGuestName(req.GetGuestName()); err != nil {
+		return nil, err
+	}
+	if req.GetEntourage() < 0 {
+		return nil, invalidArgumentError("entourage must not be negative")
+	}
+
+	guest, err := server.store.GetGuestFromName(ctx, req.GetGuestName())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	result, err := server.store.AssignTableTx(ctx, db.AssignTableTxParams{
+		UserID:       int64(guest.ID),
+		TableID:      int64(guest.TableID),
+		NewEntourage: int64(req.GetEntourage()),
+		AuditInfo:    auditInfo(ctx, payload),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.ArriveGuestResponse{
+		Guest:     convertGuest(result.Guest),
+		Table:     convertTable(result.Table),
+		Arrival:   convertArrival(result.Arrival),
+		Decisions: convertAdmissionDecisions(result.Decisions),
+	}, nil
+}
+
+// LeaveGuest records an arrived guest leaving, freeing the seats their party occupied. The guest
+// stays on the list and may re-enter.
+func (server *Server) LeaveGuest(ctx context.Context, req *pb.LeaveGuestRequest) (*pb.LeaveGuestResponse, error) {
+	payload, err := server.authorizeUser(ctx, util.OrganiserRole, util.DoorStaffRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateGuestName(req.GetGuestName()); err != nil {
+		return nil, err
+	}
+
+	guest, err := server.store.GetGuestFromName(ctx, req.GetGuestName())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	guest, err = server.store.LeaveGuestTx(ctx, db.LeaveGuestTxParams{
+		AuditInfo: auditInfo(ctx, payload),
+		ID:        guest.ID,
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.LeaveGuestResponse{Guest: convertGuest(guest)}, nil
+}
+
+// ListArrivedGuests returns a page of the guests who have already arrived
+func (server *Server) ListArrivedGuests(ctx context.Context, req *pb.ListGuestsRequest) (*pb.ListGuestsResponse, error) {
+	if _, err := server.authorizeUser(ctx, util.OrganiserRole, util.DoorStaffRole); err != nil {
+		return nil, err
+	}
+
+	if err := validatePagination(req.GetPageId(), req.GetPageSize()); err != nil {
+		return nil, err
+	}
+	if req.GetStatus() != "" || req.GetTableId() != 0 || req.GetDietary() != "" || req.GetVipTier() != "" {
+		return nil, invalidArgumentError("filters cannot be set when listing arrived guests")
+	}
+
+	guests, err := server.store.GetArrivedGuests(ctx, db.GetArrivedGuestsParams{
+		Limit:  req.GetPageSize(),
+		Offset: (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.ListGuestsResponse{Guests: convertGuests(guests)}, nil
+}
@@ -0,0 +1,228 @@
+package gapi
+
+import (
+	"database/sql"
+	"testing"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/pb"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+func TestArriveGuestRPC(t *testing.T) {
+	table := randomTable()
+	table.Occupied = 0
+
+	guest := randomGuest()
+	guest.TableID = table.ID
+	guest.Entourage = table.Size - 1
+
+	arg := db.AssignTableTxParams{
+		UserID:       int64(guest.ID),
+		TableID:      int64(guest.TableID),
+		NewEntourage: int64(guest.Entourage),
+		AuditInfo:    db.AuditInfo{Actor: testUsername, RequestID: testRequestID},
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.ArriveGuestRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.ArriveGuestResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.ArriveGuestRequest{GuestName: guest.GuestName, Entourage: guest.Entourage},
+			buildStubs: func(store *mockdb.MockStore) {
+				occupied := table
+				occupied.Occupied = table.Size
+				gomock.InOrder(
+					store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil),
+					store.EXPECT().AssignTableTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(db.AssignTableTxResult{
+						Guest:    guest,
+						Table:    occupied,
+						OldTable: table,
+						Arrival:  db.Arrival{ID: 1, GuestID: guest.ID, TableID: table.ID, PartySize: guest.Entourage + 1},
+						Decisions: []db.AdmissionDecision{
+							{Policy: db.PolicyTableCapacity, Allowed: true, Reason: "party fits"},
+						},
+					}, nil),
+				)
+			},
+			checkResponse: func(t *testing.T, res *pb.ArriveGuestResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, guest.GuestName, res.GetGuest().GetGuestName())
+				require.Equal(t, table.Size, res.GetTable().GetOccupied())
+				require.Equal(t, guest.Entourage+1, res.GetArrival().GetPartySize())
+				require.Len(t, res.GetDecisions(), 1)
+				require.Equal(t, db.PolicyTableCapacity, res.GetDecisions()[0].GetPolicy())
+			},
+		},
+		{
+			name: "InsufficientSpace",
+			req:  &pb.ArriveGuestRequest{GuestName: guest.GuestName, Entourage: guest.Entourage},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().AssignTableTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.AssignTableTxResult{}, db.InsufficientTableSizeErr(int(table.ID)))
+			},
+			checkResponse: func(t *testing.T, res *pb.ArriveGuestResponse, err error) {
+				requireStatusCode(t, err, codes.FailedPrecondition)
+			},
+		},
+		{
+			name: "AlreadyArrived",
+			req:  &pb.ArriveGuestRequest{GuestName: guest.GuestName, Entourage: guest.Entourage},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().AssignTableTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.AssignTableTxResult{}, db.ErrGuestAlreadyArrived)
+			},
+			checkResponse: func(t *testing.T, res *pb.ArriveGuestResponse, err error) {
+				requireStatusCode(t, err, codes.AlreadyExists)
+			},
+		},
+		{
+			name: "GuestNotFound",
+			req:  &pb.ArriveGuestRequest{GuestName: guest.GuestName, Entourage: guest.Entourage},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(db.Guest{}, sql.ErrNoRows)
+				store.EXPECT().AssignTableTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ArriveGuestResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+			},
+		},
+		{
+			name: "NegativeEntourage",
+			req:  &pb.ArriveGuestRequest{GuestName: guest.GuestName, Entourage: -1},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ArriveGuestResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, util.OrganiserRole)
+			res, err := server.ArriveGuest(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestLeaveGuestRPC(t *testing.T) {
+	guest := randomGuest()
+	guest.Status = db.GuestArrived
+
+	left := guest
+	left.Status = db.GuestLeft
+
+	arg := db.LeaveGuestTxParams{
+		AuditInfo: db.AuditInfo{Actor: testUsername, RequestID: testRequestID},
+		ID:        guest.ID,
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.LeaveGuestRequest
+		role          string
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.LeaveGuestResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.LeaveGuestRequest{GuestName: guest.GuestName},
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				gomock.InOrder(
+					store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil),
+					store.EXPECT().LeaveGuestTx(gomock.Any(), gomock.Eq(arg)).Times(1).Return(left, nil),
+				)
+			},
+			checkResponse: func(t *testing.T, res *pb.LeaveGuestResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, guest.GuestName, res.GetGuest().GetGuestName())
+				require.Equal(t, db.GuestLeft, res.GetGuest().GetStatus())
+			},
+		},
+		{
+			name: "NotArrived",
+			req:  &pb.LeaveGuestRequest{GuestName: guest.GuestName},
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().LeaveGuestTx(gomock.Any(), gomock.Eq(arg)).Times(1).
+					Return(db.Guest{}, &db.TransitionError{From: db.GuestConfirmed, To: db.GuestLeft})
+			},
+			checkResponse: func(t *testing.T, res *pb.LeaveGuestResponse, err error) {
+				requireStatusCode(t, err, codes.FailedPrecondition)
+			},
+		},
+		{
+			name: "GuestNotFound",
+			req:  &pb.LeaveGuestRequest{GuestName: guest.GuestName},
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(db.Guest{}, sql.ErrNoRows)
+				store.EXPECT().LeaveGuestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LeaveGuestResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+			},
+		},
+		{
+			name: "InvalidName",
+			req:  &pb.LeaveGuestRequest{GuestName: "Al"},
+			role: util.DoorStaffRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LeaveGuestResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name: "ViewerDenied",
+			req:  &pb.LeaveGuestRequest{GuestName: guest.GuestName},
+			role: util.ViewerRole,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.LeaveGuestResponse, err error) {
+				requireStatusCode(t, err, codes.PermissionDenied)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, tc.role)
+			res, err := server.LeaveGuest(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
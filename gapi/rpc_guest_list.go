@@ -0,0 +1,148 @@
+package gapi
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/pb"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+)
+
+// CreateGuest adds a guest to the guest list once their table is confirmed to be big enough for the party
+func (server *Server) CreateGuest(ctx context.Context, req *pb.CreateGuestRequest) (*pb.CreateGuestResponse, error) {
+	payload, err := server.authorizeUser(ctx, util.OrganiserRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateGuestName(req.GetGuestName()); err != nil {
+		return nil, err
+	}
+	if req.GetEntourage() < 0 {
+		return nil, invalidArgumentError("entourage must not be negative")
+	}
+	if req.GetVipTier() != "" && !db.IsVIPTier(req.GetVipTier()) {
+		return nil, invalidArgumentError("vip_tier must be one of %s", strings.Join(db.VIPTiers, ", "))
+	}
+
+	if req.GetTableId() != 0 && req.GetTableLabel() != "" {
+		return nil, invalidArgumentError("only one of table_id and table_label can be set")
+	}
+
+	var table db.Table
+	if req.GetTableLabel() != "" {
+		table, err = server.store.GetTableFromLabel(ctx, req.GetTableLabel())
+	} else {
+		table, err = server.store.GetTable(ctx, req.GetTableId())
+	}
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	if table.Size < req.GetEntourage()+1 {
+		return nil, toStatusError(db.InsufficientTableSizeErr(int(table.ID)))
+	}
+	if err := db.CheckPartySize(table, req.GetEntourage()+1); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	guest, err := server.store.CreateGuestTx(ctx, db.CreateGuestTxParams{
+		AuditInfo: auditInfo(ctx, payload),
+		GuestName: req.GetGuestName(),
+		Entourage: req.GetEntourage(),
+		TableID:   table.ID,
+		GuestProfile: db.GuestProfile{
+			Dietary:       req.GetDietary(),
+			Accessibility: req.GetAccessibility(),
+			VipTier:       req.GetVipTier(),
+			Email:         req.GetEmail(),
+			Phone:         req.GetPhone(),
+			Notes:         req.GetNotes(),
+		},
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.CreateGuestResponse{Guest: convertGuest(guest)}, nil
+}
+
+// GetGuest returns a guest based on their name
+func (server *Server) GetGuest(ctx context.Context, req *pb.GetGuestRequest) (*pb.GetGuestResponse, error) {
+	if _, err := server.authorizeUser(ctx, util.OrganiserRole, util.DoorStaffRole); err != nil {
+		return nil, err
+	}
+
+	if err := validateGuestName(req.GetGuestName()); err != nil {
+		return nil, err
+	}
+
+	guest, err := server.store.GetGuestFromName(ctx, req.GetGuestName())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.GetGuestResponse{Guest: convertGuest(guest)}, nil
+}
+
+// ListGuests returns a page of the guest list
+func (server *Server) ListGuests(ctx context.Context, req *pb.ListGuestsRequest) (*pb.ListGuestsResponse, error) {
+	if _, err := server.authorizeUser(ctx, util.OrganiserRole, util.DoorStaffRole); err != nil {
+		return nil, err
+	}
+
+	if err := validatePagination(req.GetPageId(), req.GetPageSize()); err != nil {
+		return nil, err
+	}
+
+	if req.GetStatus() != "" && !db.IsGuestStatus(req.GetStatus()) {
+		return nil, invalidArgumentError("status must be one of %s", strings.Join(db.GuestStatuses, ", "))
+	}
+	if req.GetVipTier() != "" && !db.IsVIPTier(req.GetVipTier()) {
+		return nil, invalidArgumentError("vip_tier must be one of %s", strings.Join(db.VIPTiers, ", "))
+	}
+	if strings.Contains(req.GetDietary(), ",") {
+		return nil, invalidArgumentError("dietary must be a single requirement")
+	}
+
+	dietary := db.NormalizeDietary(req.GetDietary())
+	guests, err := server.store.GetGuests(ctx, db.GetGuestsParams{
+		Status:  sql.NullString{String: req.GetStatus(), Valid: req.GetStatus() != ""},
+		TableID: sql.NullInt32{Int32: req.GetTableId(), Valid: req.GetTableId() != 0},
+		Dietary: sql.NullString{String: dietary, Valid: dietary != ""},
+		VipTier: sql.NullString{String: req.GetVipTier(), Valid: req.GetVipTier() != ""},
+		Limit:   req.GetPageSize(),
+		Offset:  (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.ListGuestsResponse{Guests: convertGuests(guests)}, nil
+}
+
+// DeleteGuest records an arrived guest leaving, freeing their seats
+func (server *Server) DeleteGuest(ctx context.Context, req *pb.DeleteGuestRequest) (*pb.DeleteGuestResponse, error) {
+	payload, err := server.authorizeUser(ctx, util.OrganiserRole, util.DoorStaffRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateGuestName(req.GetGuestName()); err != nil {
+		return nil, err
+	}
+
+	guest, err := server.store.GetGuestFromName(ctx, req.GetGuestName())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	_, err = server.store.LeaveGuestTx(ctx, db.LeaveGuestTxParams{ID: guest.ID, AuditInfo: auditInfo(ctx, payload)})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.DeleteGuestResponse{GuestName: guest.GuestName}, nil
+}
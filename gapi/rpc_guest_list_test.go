@@ -0,0 +1,336 @@
+package gapi
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/pb"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateGuestRPC(t *testing.T) {
+	table := randomTable()
+	guest := randomGuest()
+	guest.TableID = table.ID
+	guest.Entourage = table.Size - 1
+
+	testCases := []struct {
+		name          string
+		req           *pb.CreateGuestRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.CreateGuestResponse, err error)
+	}{
+		{
+			name: "OK",
+			req: &pb.CreateGuestRequest{
+				GuestName: guest.GuestName,
+				Entourage: guest.Entourage,
+				TableId:   table.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				gomock.InOrder(
+					store.EXPECT().GetTable(gomock.Any(), gomock.Eq(table.ID)).Times(1).Return(table, nil),
+					store.EXPECT().CreateGuestTx(gomock.Any(), gomock.Eq(db.CreateGuestTxParams{
+						AuditInfo: db.AuditInfo{Actor: testUsername, RequestID: testRequestID},
+						GuestName: guest.GuestName,
+						Entourage: guest.Entourage,
+						TableID:   table.ID,
+					})).Times(1).Return(guest, nil),
+				)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateGuestResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, guest.GuestName, res.GetGuest().GetGuestName())
+				require.Equal(t, guest.TableID, res.GetGuest().GetTableId())
+			},
+		},
+		{
+			name: "ByTableLabel",
+			req: &pb.CreateGuestRequest{
+				GuestName:  guest.GuestName,
+				Entourage:  guest.Entourage,
+				TableLabel: table.Label,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTableFromLabel(gomock.Any(), gomock.Eq(table.Label)).Times(1).Return(table, nil)
+				store.EXPECT().CreateGuestTx(gomock.Any(), gomock.Eq(db.CreateGuestTxParams{
+					AuditInfo: db.AuditInfo{Actor: testUsername, RequestID: testRequestID},
+					GuestName: guest.GuestName,
+					Entourage: guest.Entourage,
+					TableID:   table.ID,
+				})).Times(1).Return(guest, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateGuestResponse, err error) {
+				require.NoError(t, err)
+				require.Equal(t, table.ID, res.GetGuest().GetTableId())
+			},
+		},
+		{
+			name: "PartyAboveTableMaximum",
+			req: &pb.CreateGuestRequest{
+				GuestName: guest.GuestName,
+				Entourage: guest.Entourage,
+				TableId:   table.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				small := table
+				small.MaxParty = guest.Entourage
+				store.EXPECT().GetTable(gomock.Any(), gomock.Eq(table.ID)).Times(1).Return(small, nil)
+				store.EXPECT().CreateGuestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateGuestResponse, err error) {
+				requireStatusCode(t, err, codes.FailedPrecondition)
+			},
+		},
+		{
+			name: "TableTooSmall",
+			req: &pb.CreateGuestRequest{
+				GuestName: guest.GuestName,
+				Entourage: table.Size,
+				TableId:   table.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTable(gomock.Any(), gomock.Eq(table.ID)).Times(1).Return(table, nil)
+				store.EXPECT().CreateGuestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateGuestResponse, err error) {
+				requireStatusCode(t, err, codes.FailedPrecondition)
+			},
+		},
+		{
+			name: "TableNotFound",
+			req: &pb.CreateGuestRequest{
+				GuestName: guest.GuestName,
+				Entourage: guest.Entourage,
+				TableId:   table.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTable(gomock.Any(), gomock.Eq(table.ID)).Times(1).Return(db.Table{}, sql.ErrNoRows)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateGuestResponse, err error) {
+				requireStatusCode(t, err, codes.NotFound)
+			},
+		},
+		{
+			name: "InvalidName",
+			req: &pb.CreateGuestRequest{
+				GuestName: "a",
+				Entourage: guest.Entourage,
+				TableId:   table.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTable(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateGuestResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name: "InternalError",
+			req: &pb.CreateGuestRequest{
+				GuestName: guest.GuestName,
+				Entourage: guest.Entourage,
+				TableId:   table.ID,
+			},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTable(gomock.Any(), gomock.Eq(table.ID)).Times(1).Return(table, nil)
+				store.EXPECT().CreateGuestTx(gomock.Any(), gomock.Any()).Times(1).Return(db.Guest{}, sql.ErrConnDone)
+			},
+			checkResponse: func(t *testing.T, res *pb.CreateGuestResponse, err error) {
+				requireStatusCode(t, err, codes.Internal)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, util.OrganiserRole)
+			res, err := server.CreateGuest(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestListGuestsRPC(t *testing.T) {
+	n := 5
+	guests := make([]db.Guest, n)
+	for i := 0; i < n; i++ {
+		guests[i] = randomGuest()
+	}
+
+	testCases := []struct {
+		name          string
+		req           *pb.ListGuestsRequest
+		buildStubs    func(store *mockdb.MockStore)
+		checkResponse func(t *testing.T, res *pb.ListGuestsResponse, err error)
+	}{
+		{
+			name: "OK",
+			req:  &pb.ListGuestsRequest{PageId: 2, PageSize: int32(n)},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().
+					GetGuests(gomock.Any(), gomock.Eq(db.GetGuestsParams{Limit: int32(n), Offset: int32(n)})).
+					Times(1).
+					Return(guests, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListGuestsResponse, err error) {
+				require.NoError(t, err)
+				require.Len(t, res.GetGuests(), n)
+				for i, guest := range res.GetGuests() {
+					require.Equal(t, guests[i].GuestName, guest.GetGuestName())
+				}
+			},
+		},
+		{
+			name: "FilterByStatus",
+			req:  &pb.ListGuestsRequest{PageId: 1, PageSize: int32(n), Status: db.GuestNoShow},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.GetGuestsParams{
+					Status: sql.NullString{String: db.GuestNoShow, Valid: true},
+					Limit:  int32(n),
+				}
+				store.EXPECT().
+					GetGuests(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(guests, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListGuestsResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "FilterByProfile",
+			req:  &pb.ListGuestsRequest{PageId: 1, PageSize: int32(n), TableId: 4, Dietary: "Vegan", VipTier: db.VIPPlatinum},
+			buildStubs: func(store *mockdb.MockStore) {
+				arg := db.GetGuestsParams{
+					TableID: sql.NullInt32{Int32: 4, Valid: true},
+					Dietary: sql.NullString{String: "vegan", Valid: true},
+					VipTier: sql.NullString{String: db.VIPPlatinum, Valid: true},
+					Limit:   int32(n),
+				}
+				store.EXPECT().
+					GetGuests(gomock.Any(), gomock.Eq(arg)).
+					Times(1).
+					Return(guests, nil)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListGuestsResponse, err error) {
+				require.NoError(t, err)
+			},
+		},
+		{
+			name: "InvalidVIPTier",
+			req:  &pb.ListGuestsRequest{PageId: 1, PageSize: int32(n), VipTier: "diamond"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuests(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListGuestsResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name: "InvalidStatus",
+			req:  &pb.ListGuestsRequest{PageId: 1, PageSize: int32(n), Status: "partying"},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuests(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListGuestsResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+		{
+			name: "InvalidPagination",
+			req:  &pb.ListGuestsRequest{PageId: 0, PageSize: 100},
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuests(gomock.Any(), gomock.Any()).Times(0)
+			},
+			checkResponse: func(t *testing.T, res *pb.ListGuestsResponse, err error) {
+				requireStatusCode(t, err, codes.InvalidArgument)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			server := newTestServer(t, store)
+			ctx := newContextWithBearerToken(t, server.tokenMaker, util.OrganiserRole)
+			res, err := server.ListGuests(ctx, tc.req)
+			tc.checkResponse(t, res, err)
+		})
+	}
+}
+
+func TestDeleteGuestRPC(t *testing.T) {
+	guest := randomGuest()
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mockdb.NewMockStore(controller)
+	gomock.InOrder(
+		store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil),
+		store.EXPECT().LeaveGuestTx(gomock.Any(), gomock.Eq(db.LeaveGuestTxParams{
+			AuditInfo: db.AuditInfo{Actor: testUsername, RequestID: testRequestID},
+			ID:        guest.ID,
+		})).Times(1).Return(guest, nil),
+	)
+
+	server := newTestServer(t, store)
+	ctx := newContextWithBearerToken(t, server.tokenMaker, util.OrganiserRole)
+	res, err := server.DeleteGuest(ctx, &pb.DeleteGuestRequest{GuestName: guest.GuestName})
+	require.NoError(t, err)
+	require.Equal(t, guest.GuestName, res.GetGuestName())
+}
+
+func randomGuest() db.Guest {
+	return db.Guest{
+		ID:          util.RandomInt(1, 1000),
+		GuestName:   util.RandomGuestName(),
+		Entourage:   util.RandomGuestSize(),
+		TableID:     util.RandomInt(1, 20),
+		ArrivalTime: util.RandomGuestArrivalTime(),
+	}
+}
+
+func randomTable() db.Table {
+	size := util.RandomTableSize()
+	id := util.RandomInt(1, 1000)
+	return db.Table{
+		ID:       id,
+		Size:     size,
+		Occupied: size - util.RandomInt(1, size),
+		Label:    fmt.Sprintf("Table %d", id),
+		Shape:    db.ShapeRound,
+		MinParty: 1,
+		MaxParty: size,
+	}
+}
+
+// requireStatusCode requires the returned error to be a gRPC status with the expected code
+func requireStatusCode(t *testing.T, err error, code codes.Code) {
+	require.Error(t, err)
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, code, st.Code())
+}
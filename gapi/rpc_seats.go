@@ -0,0 +1,129 @@
+package gapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/ellisp97/BE_Task_Oct20/golang/pb"
+	"github.com/ellisp97/BE_Task_Oct20/golang/scheduler"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const defaultOccupancyPollInterval = 2 * time.Second
+
+// GetEmptySeats returns the number of empty seats across every table
+func (server *Server) GetEmptySeats(ctx context.Context, req *pb.GetEmptySeatsRequest) (*pb.GetEmptySeatsResponse, error) {
+	if _, err := server.authorizeUser(ctx, util.OrganiserRole, util.DoorStaffRole, util.ViewerRole); err != nil {
+		return nil, err
+	}
+
+	count, err := server.store.GetEmptySeats(ctx)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.GetEmptySeatsResponse{SeatsEmpty: count}, nil
+}
+
+// WatchOccupancy streams an update whenever the occupancy of the venue (or a single table) changes.
+// The store is polled so that changes made through the HTTP API are picked up as well as gRPC ones,
+// the current state is always sent first. A guest being marked no-show is sent straight away, with
+// the seats it released, rather than at the next poll.
+func (server *Server) WatchOccupancy(req *pb.WatchOccupancyRequest, stream pb.Seats_WatchOccupancyServer) error {
+	if _, err := server.authorizeUser(stream.Context(), util.OrganiserRole, util.DoorStaffRole, util.ViewerRole); err != nil {
+		return err
+	}
+
+	ctx := stream.Context()
+
+	interval := server.config.OccupancyPollInterval
+	if interval <= 0 {
+		interval = defaultOccupancyPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var noShows <-chan scheduler.NoShow
+	if server.noShows != nil {
+		var cancel func()
+		noShows, cancel = server.noShows.Subscribe()
+		defer cancel()
+	}
+
+	var last *pb.OccupancyUpdate
+	var noShow *pb.NoShow
+	for {
+		update, err := server.occupancy(ctx, req.GetTableId())
+		if err != nil {
+			return toStatusError(err)
+		}
+
+		if last == nil || noShow != nil || occupancyChanged(last, update) {
+			update.NoShow = noShow
+			if err := stream.Send(update); err != nil {
+				return err
+			}
+			last = update
+		}
+
+		noShow = nil
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case event := <-noShows:
+			// A no-show at another table is only a reason to poll early
+			if req.GetTableId() == 0 || event.Guest.TableID == req.GetTableId() {
+				noShow = convertNoShow(event)
+			}
+		}
+	}
+}
+
+func convertNoShow(event scheduler.NoShow) *pb.NoShow {
+	return &pb.NoShow{
+		GuestName: event.Guest.GuestName,
+		TableId:   event.Guest.TableID,
+		MarkedAt:  timestamppb.New(event.MarkedAt),
+	}
+}
+
+// occupancy reads the current occupancy of a table, or of the whole venue when tableID is 0
+func (server *Server) occupancy(ctx context.Context, tableID int32) (*pb.OccupancyUpdate, error) {
+	if tableID == 0 {
+		count, err := server.store.GetEmptySeats(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return &pb.OccupancyUpdate{
+			SeatsEmpty: count,
+			ObservedAt: timestamppb.Now(),
+		}, nil
+	}
+
+	table, err := server.store.GetTable(ctx, tableID)
+	if err != nil {
+		return nil, err
+	}
+	empty, err := server.store.GetEmptySeatsByTableIDs(ctx, []int32{table.ID})
+	if err != nil {
+		return nil, err
+	}
+	update := &pb.OccupancyUpdate{
+		TableId:    table.ID,
+		Size:       table.Size,
+		Occupied:   table.Occupied,
+		ObservedAt: timestamppb.Now(),
+	}
+	if len(empty) > 0 {
+		update.SeatsEmpty = empty[0].SeatsEmpty
+	}
+	return update, nil
+}
+
+func occupancyChanged(previous, current *pb.OccupancyUpdate) bool {
+	return previous.GetSize() != current.GetSize() ||
+		previous.GetOccupied() != current.GetOccupied() ||
+		previous.GetSeatsEmpty() != current.GetSeatsEmpty()
+}
@@ -0,0 +1,133 @@
+package gapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/pb"
+	"github.com/ellisp97/BE_Task_Oct20/golang/scheduler"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// occupancyStream is an in-memory pb.Seats_WatchOccupancyServer which collects every update sent
+type occupancyStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	updates chan *pb.OccupancyUpdate
+}
+
+func (s *occupancyStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *occupancyStream) Send(update *pb.OccupancyUpdate) error {
+	s.updates <- update
+	return nil
+}
+
+func TestWatchOccupancyRPC(t *testing.T) {
+	table := randomTable()
+	table.Occupied = 0
+	seated := table
+	seated.Occupied = 1
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	// The first two polls see an empty table, every following one sees a guest seated
+	store := mockdb.NewMockStore(controller)
+	gomock.InOrder(
+		store.EXPECT().GetTable(gomock.Any(), gomock.Eq(table.ID)).Times(2).Return(table, nil),
+		store.EXPECT().GetTable(gomock.Any(), gomock.Eq(table.ID)).AnyTimes().Return(seated, nil),
+	)
+	gomock.InOrder(
+		store.EXPECT().GetEmptySeatsByTableIDs(gomock.Any(), gomock.Eq([]int32{table.ID})).Times(2).
+			Return([]db.TableEmptySeats{{TableID: table.ID, SeatsEmpty: table.Size}}, nil),
+		store.EXPECT().GetEmptySeatsByTableIDs(gomock.Any(), gomock.Eq([]int32{table.ID})).AnyTimes().
+			Return([]db.TableEmptySeats{{TableID: table.ID, SeatsEmpty: table.Size - 1}}, nil),
+	)
+
+	server := newTestServer(t, store)
+	server.config.OccupancyPollInterval = time.Millisecond
+
+	ctx, cancel := context.WithCancel(newContextWithBearerToken(t, server.tokenMaker, util.ViewerRole))
+	defer cancel()
+	stream := &occupancyStream{ctx: ctx, updates: make(chan *pb.OccupancyUpdate, 10)}
+
+	done := make(chan error)
+	go func() {
+		done <- server.WatchOccupancy(&pb.WatchOccupancyRequest{TableId: table.ID}, stream)
+	}()
+
+	first := <-stream.updates
+	require.Equal(t, table.ID, first.GetTableId())
+	require.Equal(t, int32(0), first.GetOccupied())
+	require.Equal(t, table.Size, first.GetSeatsEmpty())
+
+	// The unchanged second poll must not be streamed
+	second := <-stream.updates
+	require.Equal(t, int32(1), second.GetOccupied())
+	require.Equal(t, table.Size-1, second.GetSeatsEmpty())
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+// noShowFeed is a NoShowSource publishing whatever the test sends on it
+type noShowFeed chan scheduler.NoShow
+
+func (feed noShowFeed) Subscribe() (<-chan scheduler.NoShow, func()) {
+	return feed, func() {}
+}
+
+func TestWatchOccupancyNoShowRPC(t *testing.T) {
+	table := randomTable()
+	markedAt := time.Date(2026, 10, 19, 21, 30, 0, 0, time.UTC)
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().GetTable(gomock.Any(), gomock.Eq(table.ID)).Times(3).Return(table, nil)
+	store.EXPECT().GetEmptySeatsByTableIDs(gomock.Any(), gomock.Eq([]int32{table.ID})).Times(3).
+		Return([]db.TableEmptySeats{{TableID: table.ID, SeatsEmpty: table.Size - table.Occupied}}, nil)
+
+	feed := make(noShowFeed)
+	server := newTestServer(t, store)
+	WithNoShows(feed)(server)
+	// Polling is left to the no-shows
+	server.config.OccupancyPollInterval = time.Hour
+
+	ctx, cancel := context.WithCancel(newContextWithBearerToken(t, server.tokenMaker, util.ViewerRole))
+	defer cancel()
+	stream := &occupancyStream{ctx: ctx, updates: make(chan *pb.OccupancyUpdate, 10)}
+
+	done := make(chan error)
+	go func() {
+		done <- server.WatchOccupancy(&pb.WatchOccupancyRequest{TableId: table.ID}, stream)
+	}()
+
+	first := <-stream.updates
+	require.Nil(t, first.GetNoShow())
+
+	// A no-show at another table doesn't concern the watcher, one at theirs is sent with the
+	// occupancy unchanged
+	feed <- scheduler.NoShow{Guest: db.Guest{GuestName: "Elsewhere", TableID: table.ID + 1}, MarkedAt: markedAt}
+	feed <- scheduler.NoShow{Guest: db.Guest{GuestName: "Late Guest", TableID: table.ID}, MarkedAt: markedAt}
+
+	update := <-stream.updates
+	require.Equal(t, first.GetOccupied(), update.GetOccupied())
+	require.Equal(t, "Late Guest", update.GetNoShow().GetGuestName())
+	require.Equal(t, table.ID, update.GetNoShow().GetTableId())
+	require.True(t, markedAt.Equal(update.GetNoShow().GetMarkedAt().AsTime()))
+
+	cancel()
+	require.NoError(t, <-done)
+	require.Empty(t, stream.updates)
+}
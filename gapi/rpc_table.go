@@ -0,0 +1,65 @@
+package gapi
+
+import (
+	"context"
+	"strings"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/pb"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+)
+
+// CreateTable adds an empty table of the requested size
+func (server *Server) CreateTable(ctx context.Context, req *pb.CreateTableRequest) (*pb.CreateTableResponse, error) {
+	payload, err := server.authorizeUser(ctx, util.OrganiserRole)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetSize() < 1 {
+		return nil, invalidArgumentError("size must be at least 1")
+	}
+	if req.GetShape() != "" && !db.IsTableShape(req.GetShape()) {
+		return nil, invalidArgumentError("shape must be one of %s", strings.Join(db.TableShapes, ", "))
+	}
+	if req.GetMinParty() < 0 || req.GetMaxParty() < 0 {
+		return nil, invalidArgumentError("min_party and max_party must not be negative")
+	}
+
+	table, err := server.store.CreateTableTx(ctx, db.CreateTableTxParams{
+		AuditInfo:  auditInfo(ctx, payload),
+		Size:       req.GetSize(),
+		Label:      req.GetLabel(),
+		Zone:       req.GetZone(),
+		Accessible: req.GetAccessible(),
+		Shape:      req.GetShape(),
+		MinParty:   req.GetMinParty(),
+		MaxParty:   req.GetMaxParty(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.CreateTableResponse{Table: convertTable(table)}, nil
+}
+
+// ListTables returns a page of tables
+func (server *Server) ListTables(ctx context.Context, req *pb.ListTablesRequest) (*pb.ListTablesResponse, error) {
+	if _, err := server.authorizeUser(ctx, util.OrganiserRole, util.DoorStaffRole, util.ViewerRole); err != nil {
+		return nil, err
+	}
+
+	if err := validatePagination(req.GetPageId(), req.GetPageSize()); err != nil {
+		return nil, err
+	}
+
+	tables, err := server.store.GetTables(ctx, db.GetTablesParams{
+		Limit:  req.GetPageSize(),
+		Offset: (req.GetPageId() - 1) * req.GetPageSize(),
+	})
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.ListTablesResponse{Tables: convertTables(tables)}, nil
+}
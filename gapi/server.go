@@ -0,0 +1,63 @@
+package gapi
+
+import (
+	"fmt"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/pb"
+	"github.com/ellisp97/BE_Task_Oct20/golang/scheduler"
+	"github.com/ellisp97/BE_Task_Oct20/golang/token"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+)
+
+// Server serves gRPC requests for the guestlist service
+type Server struct {
+	pb.UnimplementedGuestListServer
+	pb.UnimplementedArrivalsServer
+	pb.UnimplementedTablesServer
+	pb.UnimplementedSeatsServer
+	config     util.Config
+	store      db.Store
+	tokenMaker token.Maker
+	apiKeys    *token.APIKeys
+	noShows    NoShowSource
+}
+
+// NoShowSource publishes the guests marked no-show, e.g. the *scheduler.NoShowScheduler
+type NoShowSource interface {
+	Subscribe() (<-chan scheduler.NoShow, func())
+}
+
+// ServerOption configures a Server
+type ServerOption func(*Server)
+
+// WithNoShows has WatchOccupancy send an update as soon as source marks a guest no-show
+func WithNoShows(source NoShowSource) ServerOption {
+	return func(server *Server) {
+		server.noShows = source
+	}
+}
+
+// NewServer creates a new gRPC server backed by the same store and credentials as the HTTP API
+func NewServer(config util.Config, store db.Store, options ...ServerOption) (*Server, error) {
+	tokenMaker, err := token.NewJWTMaker(config.TokenSymmetricKey)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create token maker: %w", err)
+	}
+
+	apiKeys, err := token.ParseAPIKeys(config.APIKeys)
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse api keys: %w", err)
+	}
+
+	server := &Server{
+		config:     config,
+		store:      store,
+		tokenMaker: tokenMaker,
+		apiKeys:    apiKeys,
+	}
+	for _, option := range options {
+		option(server)
+	}
+	return server, nil
+}
@@ -0,0 +1,35 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/token"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+)
+
+// authorize returns the caller's payload if their role is one of roles. The HTTP API only
+// lets door staff and organisers reach the handler, mutations narrow that down further.
+func authorize(ctx context.Context, roles ...string) (*token.Payload, error) {
+	payload, ok := token.FromContext(ctx)
+	if !ok {
+		return nil, errors.New("unauthenticated")
+	}
+
+	for _, role := range roles {
+		if payload.Role == role {
+			return payload, nil
+		}
+	}
+	return nil, fmt.Errorf("role %s is not permitted to perform this mutation", payload.Role)
+}
+
+// auditInfo attributes a mutation to the caller and the HTTP request it arrived in
+func auditInfo(ctx context.Context, payload *token.Payload) db.AuditInfo {
+	return db.AuditInfo{
+		Actor:     payload.Username,
+		RequestID: util.RequestIDFromContext(ctx),
+	}
+}
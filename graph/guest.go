@@ -0,0 +1,128 @@
+package graph
+
+import (
+	"context"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+type guestResolver struct {
+	guest db.Guest
+}
+
+// newGuestResolvers queues the arrivals and tables of the guests so they are each fetched in one query
+func newGuestResolvers(ctx context.Context, guests []db.Guest) []*guestResolver {
+	l := loadersFrom(ctx)
+	resolvers := make([]*guestResolver, len(guests))
+	guestIDs := make([]int32, len(guests))
+	tableIDs := make([]int32, len(guests))
+	for i, guest := range guests {
+		guestIDs[i] = guest.ID
+		tableIDs[i] = guest.TableID
+		resolvers[i] = &guestResolver{guest: guest}
+	}
+	l.arrivalByGuest.prime(guestIDs...)
+	l.tables.prime(tableIDs...)
+	return resolvers
+}
+
+func (r *guestResolver) ID() int32 {
+	return r.guest.ID
+}
+
+func (r *guestResolver) Name() string {
+	return r.guest.GuestName
+}
+
+func (r *guestResolver) Entourage() int32 {
+	return r.guest.Entourage
+}
+
+func (r *guestResolver) TableID() int32 {
+	return r.guest.TableID
+}
+
+func (r *guestResolver) Table(ctx context.Context) (*tableResolver, error) {
+	table, err := loadersFrom(ctx).table(ctx, r.guest.TableID)
+	if err != nil || table == nil {
+		return nil, err
+	}
+	return newTableResolvers(ctx, []db.Table{*table})[0], nil
+}
+
+func (r *guestResolver) Arrival(ctx context.Context) (*arrivalResolver, error) {
+	arrival, err := loadersFrom(ctx).arrival(ctx, r.guest.ID)
+	if err != nil || arrival == nil {
+		return nil, err
+	}
+	return &arrivalResolver{arrival: *arrival}, nil
+}
+
+func (r *guestResolver) ArrivalTime() *graphql.Time {
+	return nullTime(r.guest.ArrivalTime)
+}
+
+func (r *guestResolver) CreatedAt() *graphql.Time {
+	return nullTime(r.guest.CreatedAt)
+}
+
+func (r *guestResolver) RsvpStatus() string {
+	return r.guest.RsvpStatus
+}
+
+func (r *guestResolver) Status() string {
+	return r.guest.Status
+}
+
+func (r *guestResolver) Dietary() string {
+	return r.guest.Dietary
+}
+
+func (r *guestResolver) Accessibility() string {
+	return r.guest.Accessibility
+}
+
+func (r *guestResolver) VipTier() string {
+	return r.guest.VipTier
+}
+
+func (r *guestResolver) Email() string {
+	return r.guest.Email
+}
+
+func (r *guestResolver) Phone() string {
+	return r.guest.Phone
+}
+
+func (r *guestResolver) Notes() string {
+	return r.guest.Notes
+}
+
+type arrivalResolver struct {
+	arrival db.Arrival
+}
+
+func (r *arrivalResolver) ID() int32 {
+	return r.arrival.ID
+}
+
+func (r *arrivalResolver) GuestID() int32 {
+	return r.arrival.GuestID
+}
+
+func (r *arrivalResolver) TableID() int32 {
+	return r.arrival.TableID
+}
+
+func (r *arrivalResolver) PartySize() int32 {
+	return r.arrival.PartySize
+}
+
+func (r *arrivalResolver) ArrivedAt() graphql.Time {
+	return graphql.Time{Time: r.arrival.ArrivedAt}
+}
+
+func (r *arrivalResolver) DepartedAt() *graphql.Time {
+	return nullTime(r.arrival.DepartedAt)
+}
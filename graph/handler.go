@@ -0,0 +1,49 @@
+package graph
+
+import (
+	_ "embed"
+	"encoding/json"
+	"net/http"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+//go:embed schema.graphql
+var schemaString string
+
+// Handler serves GraphQL queries over HTTP, each request gets its own set of batch loaders
+type Handler struct {
+	schema *graphql.Schema
+	store  db.Store
+}
+
+type graphqlRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// NewHandler parses the schema and binds it to resolvers backed by store
+func NewHandler(store db.Store) *Handler {
+	return &Handler{
+		schema: graphql.MustParseSchema(schemaString, &Resolver{store: store}),
+		store:  store,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req graphqlRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx := withLoaders(r.Context(), h.store)
+	response := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,268 @@
+package graph
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/token"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRequestID = "test-request"
+
+var (
+	organiser = &token.Payload{Username: "organiser", Role: util.OrganiserRole}
+	doorStaff = &token.Payload{Username: "door-1", Role: util.DoorStaffRole}
+)
+
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func TestNestedTablesQueryIsBatched(t *testing.T) {
+	n := 5
+	tables := make([]db.Table, n)
+	tableIDs := make([]int32, n)
+	var guests []db.Guest
+	var guestIDs []int32
+	var arrivals []db.Arrival
+	for i := 0; i < n; i++ {
+		tables[i] = db.Table{ID: int32(i + 1), Size: 10, Occupied: 2}
+		tableIDs[i] = tables[i].ID
+		for j := 0; j < 2; j++ {
+			guest := db.Guest{ID: int32(i*2 + j + 1), GuestName: util.RandomGuestName(), Entourage: 0, TableID: tables[i].ID}
+			guests = append(guests, guest)
+			guestIDs = append(guestIDs, guest.ID)
+			arrivals = append(arrivals, db.Arrival{ID: guest.ID, GuestID: guest.ID, TableID: guest.TableID, PartySize: 1})
+		}
+	}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	// However many tables and guests are requested, each level is resolved with exactly one query.
+	// Resolvers run on their own goroutines so the ids are checked with assert rather than require.
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().GetTables(gomock.Any(), gomock.Eq(db.GetTablesParams{Limit: int32(n), Offset: 0})).Times(1).Return(tables, nil)
+	store.EXPECT().GetGuestsByTableIDs(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, ids []int32) ([]db.Guest, error) {
+			assert.ElementsMatch(t, tableIDs, ids)
+			return guests, nil
+		})
+	store.EXPECT().GetOpenArrivalsByGuestIDs(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, ids []int32) ([]db.Arrival, error) {
+			assert.ElementsMatch(t, guestIDs, ids)
+			return arrivals, nil
+		})
+	store.EXPECT().GetEmptySeatsByTableIDs(gomock.Any(), gomock.Any()).Times(1).
+		DoAndReturn(func(_ context.Context, ids []int32) ([]db.TableEmptySeats, error) {
+			assert.ElementsMatch(t, tableIDs, ids)
+			counts := make([]db.TableEmptySeats, len(ids))
+			for i, id := range ids {
+				counts[i] = db.TableEmptySeats{TableID: id, SeatsEmpty: 8}
+			}
+			return counts, nil
+		})
+	store.EXPECT().GetTablesByIDs(gomock.Any(), gomock.Any()).Times(0)
+
+	res := execute(t, store, doorStaff, `{ tables(pageId: 1, pageSize: 5) { id seatsEmpty guests { name table { id } arrival { partySize } } } }`, nil)
+	require.Empty(t, res.Errors)
+
+	var data struct {
+		Tables []struct {
+			ID         int32 `json:"id"`
+			SeatsEmpty int32 `json:"seatsEmpty"`
+			Guests     []struct {
+				Name  string `json:"name"`
+				Table struct {
+					ID int32 `json:"id"`
+				} `json:"table"`
+				Arrival *struct {
+					PartySize int32 `json:"partySize"`
+				} `json:"arrival"`
+			} `json:"guests"`
+		} `json:"tables"`
+	}
+	require.NoError(t, json.Unmarshal(res.Data, &data))
+	require.Len(t, data.Tables, n)
+	for i, table := range data.Tables {
+		require.Equal(t, tables[i].ID, table.ID)
+		require.Equal(t, int32(8), table.SeatsEmpty)
+		require.Len(t, table.Guests, 2)
+		for _, guest := range table.Guests {
+			require.Equal(t, table.ID, guest.Table.ID)
+			require.NotNil(t, guest.Arrival)
+			require.Equal(t, int32(1), guest.Arrival.PartySize)
+		}
+	}
+}
+
+func TestArriveGuestMutation(t *testing.T) {
+	table := db.Table{ID: 1, Size: 10, Occupied: 0}
+	guest := db.Guest{ID: 7, GuestName: util.RandomGuestName(), Entourage: 2, TableID: table.ID}
+
+	testCases := []struct {
+		name       string
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, res graphqlResponse)
+	}{
+		{
+			name: "OK",
+			buildStubs: func(store *mockdb.MockStore) {
+				seated := table
+				seated.Occupied = 4
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().AssignTableTx(gomock.Any(), gomock.Eq(db.AssignTableTxParams{
+					UserID:       int64(guest.ID),
+					TableID:      int64(table.ID),
+					NewEntourage: 3,
+					AuditInfo:    db.AuditInfo{Actor: doorStaff.Username, RequestID: testRequestID},
+				})).Times(1).Return(db.AssignTableTxResult{
+					Guest:   db.Guest{ID: guest.ID, GuestName: guest.GuestName, Entourage: 3, TableID: table.ID},
+					Table:   seated,
+					Arrival: db.Arrival{ID: 1, GuestID: guest.ID, TableID: table.ID, PartySize: 4},
+				}, nil)
+			},
+			check: func(t *testing.T, res graphqlResponse) {
+				require.Empty(t, res.Errors)
+				require.JSONEq(t, `{"arriveGuest":{"entourage":3,"table":{"occupied":4},"arrival":{"partySize":4}}}`, string(res.Data))
+			},
+		},
+		{
+			name: "InsufficientSpace",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(guest, nil)
+				store.EXPECT().AssignTableTx(gomock.Any(), gomock.Any()).Times(1).
+					Return(db.AssignTableTxResult{}, db.InsufficientTableSizeErr(int(table.ID)))
+			},
+			check: func(t *testing.T, res graphqlResponse) {
+				require.Len(t, res.Errors, 1)
+				require.Equal(t, db.InsufficientTableSizeErr(int(table.ID)).Error(), res.Errors[0].Message)
+			},
+		},
+		{
+			name: "NotFound",
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetGuestFromName(gomock.Any(), gomock.Eq(guest.GuestName)).Times(1).Return(db.Guest{}, sql.ErrNoRows)
+				store.EXPECT().AssignTableTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res graphqlResponse) {
+				require.Len(t, res.Errors, 1)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			res := execute(t, store, doorStaff, `mutation($name: String!) { arriveGuest(name: $name, entourage: 3) { entourage table { occupied } arrival { partySize } } }`,
+				map[string]interface{}{"name": guest.GuestName})
+			tc.check(t, res)
+		})
+	}
+}
+
+func TestBookGuestMutation(t *testing.T) {
+	table := db.Table{ID: 1, Size: 10, Occupied: 0}
+	guest := db.Guest{ID: 7, GuestName: util.RandomGuestName(), Entourage: 2, TableID: table.ID, CreatedBy: organiser.Username}
+
+	testCases := []struct {
+		name       string
+		payload    *token.Payload
+		buildStubs func(store *mockdb.MockStore)
+		check      func(t *testing.T, res graphqlResponse)
+	}{
+		{
+			name:    "OK",
+			payload: organiser,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().GetTable(gomock.Any(), gomock.Eq(table.ID)).Times(1).Return(table, nil)
+				store.EXPECT().CreateGuestTx(gomock.Any(), gomock.Eq(db.CreateGuestTxParams{
+					AuditInfo: db.AuditInfo{Actor: organiser.Username, RequestID: testRequestID},
+					GuestName: guest.GuestName,
+					Entourage: guest.Entourage,
+					TableID:   table.ID,
+				})).Times(1).Return(guest, nil)
+			},
+			check: func(t *testing.T, res graphqlResponse) {
+				require.Empty(t, res.Errors)
+			},
+		},
+		{
+			name:    "DoorStaffForbidden",
+			payload: doorStaff,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGuestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res graphqlResponse) {
+				require.Len(t, res.Errors, 1)
+			},
+		},
+		{
+			name:    "Unauthenticated",
+			payload: nil,
+			buildStubs: func(store *mockdb.MockStore) {
+				store.EXPECT().CreateGuestTx(gomock.Any(), gomock.Any()).Times(0)
+			},
+			check: func(t *testing.T, res graphqlResponse) {
+				require.Len(t, res.Errors, 1)
+				require.Equal(t, "unauthenticated", res.Errors[0].Message)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			store := mockdb.NewMockStore(controller)
+			tc.buildStubs(store)
+
+			res := execute(t, store, tc.payload, `mutation($name: String!) { bookGuest(name: $name, tableId: 1, entourage: 2) { name } }`,
+				map[string]interface{}{"name": guest.GuestName})
+			tc.check(t, res)
+		})
+	}
+}
+
+// execute posts a query to the handler as the caller described by payload and decodes the GraphQL response
+func execute(t *testing.T, store db.Store, payload *token.Payload, query string, variables map[string]interface{}) graphqlResponse {
+	data, err := json.Marshal(graphqlRequest{Query: query, Variables: variables})
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodPost, "/graphql", bytes.NewReader(data))
+	require.NoError(t, err)
+	req = req.WithContext(util.NewRequestIDContext(req.Context(), testRequestID))
+	if payload != nil {
+		req = req.WithContext(token.NewContext(req.Context(), payload))
+	}
+
+	recorder := httptest.NewRecorder()
+	NewHandler(store).ServeHTTP(recorder, req)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var res graphqlResponse
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&res))
+	return res
+}
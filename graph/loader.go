@@ -0,0 +1,183 @@
+package graph
+
+import (
+	"context"
+	"sync"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+)
+
+type loadersKey struct{}
+
+// loader batches lookups keyed by an id. Resolvers which already know which ids will be
+// requested (e.g. every table on a page) queue them with prime, the first load then fetches
+// every queued id in a single query and later loads are served from the cache.
+type loader struct {
+	mu      sync.Mutex
+	pending map[int32]struct{}
+	loaded  map[int32]interface{}
+	fetch   func(ctx context.Context, ids []int32) (map[int32]interface{}, error)
+}
+
+func newLoader(fetch func(ctx context.Context, ids []int32) (map[int32]interface{}, error)) *loader {
+	return &loader{
+		pending: map[int32]struct{}{},
+		loaded:  map[int32]interface{}{},
+		fetch:   fetch,
+	}
+}
+
+// prime queues ids to be fetched by the next load
+func (l *loader) prime(ids ...int32) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, id := range ids {
+		if _, ok := l.loaded[id]; !ok {
+			l.pending[id] = struct{}{}
+		}
+	}
+}
+
+// store caches a value which has already been read elsewhere
+func (l *loader) store(id int32, value interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.loaded[id] = value
+	delete(l.pending, id)
+}
+
+// load returns the value for id, fetching it along with every pending id if it isn't cached.
+// Ids the fetch doesn't return are cached as nil so they are not queried again.
+func (l *loader) load(ctx context.Context, id int32) (interface{}, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if value, ok := l.loaded[id]; ok {
+		return value, nil
+	}
+
+	l.pending[id] = struct{}{}
+	ids := make([]int32, 0, len(l.pending))
+	for pendingID := range l.pending {
+		ids = append(ids, pendingID)
+	}
+
+	values, err := l.fetch(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, pendingID := range ids {
+		l.loaded[pendingID] = values[pendingID]
+		delete(l.pending, pendingID)
+	}
+	return l.loaded[id], nil
+}
+
+// loaders holds the per request batch loaders used by the resolvers
+type loaders struct {
+	tables         *loader
+	guestsByTable  *loader
+	arrivalByGuest *loader
+	emptySeats     *loader
+}
+
+func newLoaders(store db.Store) *loaders {
+	l := &loaders{}
+	l.tables = newLoader(func(ctx context.Context, ids []int32) (map[int32]interface{}, error) {
+		tables, err := store.GetTablesByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[int32]interface{}, len(tables))
+		for _, table := range tables {
+			values[table.ID] = table
+		}
+		return values, nil
+	})
+	l.guestsByTable = newLoader(func(ctx context.Context, ids []int32) (map[int32]interface{}, error) {
+		guests, err := store.GetGuestsByTableIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		// Queue the arrivals of every fetched guest now rather than per table,
+		// so they are all resolved by a single query as well
+		grouped := make(map[int32][]db.Guest, len(ids))
+		guestIDs := make([]int32, len(guests))
+		for i, guest := range guests {
+			grouped[guest.TableID] = append(grouped[guest.TableID], guest)
+			guestIDs[i] = guest.ID
+		}
+		l.arrivalByGuest.prime(guestIDs...)
+		values := make(map[int32]interface{}, len(ids))
+		for _, id := range ids {
+			values[id] = grouped[id]
+		}
+		return values, nil
+	})
+	l.arrivalByGuest = newLoader(func(ctx context.Context, ids []int32) (map[int32]interface{}, error) {
+		arrivals, err := store.GetOpenArrivalsByGuestIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		// Arrivals are ordered by id so the latest one for each guest wins
+		values := make(map[int32]interface{}, len(arrivals))
+		for _, arrival := range arrivals {
+			values[arrival.GuestID] = arrival
+		}
+		return values, nil
+	})
+	l.emptySeats = newLoader(func(ctx context.Context, ids []int32) (map[int32]interface{}, error) {
+		counts, err := store.GetEmptySeatsByTableIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[int32]interface{}, len(counts))
+		for _, count := range counts {
+			values[count.TableID] = count.SeatsEmpty
+		}
+		return values, nil
+	})
+	return l
+}
+
+func withLoaders(ctx context.Context, store db.Store) context.Context {
+	return context.WithValue(ctx, loadersKey{}, newLoaders(store))
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
+
+func (l *loaders) table(ctx context.Context, id int32) (*db.Table, error) {
+	value, err := l.tables.load(ctx, id)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	table := value.(db.Table)
+	return &table, nil
+}
+
+func (l *loaders) guestsAtTable(ctx context.Context, tableID int32) ([]db.Guest, error) {
+	value, err := l.guestsByTable.load(ctx, tableID)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	return value.([]db.Guest), nil
+}
+
+func (l *loaders) arrival(ctx context.Context, guestID int32) (*db.Arrival, error) {
+	value, err := l.arrivalByGuest.load(ctx, guestID)
+	if err != nil || value == nil {
+		return nil, err
+	}
+	arrival := value.(db.Arrival)
+	return &arrival, nil
+}
+
+func (l *loaders) seatsEmpty(ctx context.Context, tableID int32) (int32, error) {
+	value, err := l.emptySeats.load(ctx, tableID)
+	if err != nil || value == nil {
+		return 0, err
+	}
+	return value.(int32), nil
+}
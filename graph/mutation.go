@@ -0,0 +1,122 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+)
+
+type bookGuestArgs struct {
+	Name       string
+	TableID    *int32
+	TableLabel *string
+	Entourage  int32
+}
+
+// BookGuest adds a guest to the guest list once their table is confirmed to be big enough for the party
+func (r *Resolver) BookGuest(ctx context.Context, args bookGuestArgs) (*guestResolver, error) {
+	payload, err := authorize(ctx, util.OrganiserRole)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateGuestName(args.Name); err != nil {
+		return nil, err
+	}
+	if args.Entourage < 0 {
+		return nil, fmt.Errorf("entourage must not be negative")
+	}
+
+	if (args.TableID == nil) == (args.TableLabel == nil) {
+		return nil, fmt.Errorf("exactly one of tableId and tableLabel must be given")
+	}
+
+	var table db.Table
+	if args.TableLabel != nil {
+		table, err = r.store.GetTableFromLabel(ctx, *args.TableLabel)
+	} else {
+		table, err = r.store.GetTable(ctx, *args.TableID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if table.Size < args.Entourage+1 {
+		return nil, db.InsufficientTableSizeErr(int(table.ID))
+	}
+	if err := db.CheckPartySize(table, args.Entourage+1); err != nil {
+		return nil, err
+	}
+
+	guest, err := r.store.CreateGuestTx(ctx, db.CreateGuestTxParams{
+		AuditInfo: auditInfo(ctx, payload),
+		GuestName: args.Name,
+		Entourage: args.Entourage,
+		TableID:   table.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newGuestResolvers(ctx, []db.Guest{guest})[0], nil
+}
+
+type arriveGuestArgs struct {
+	Name      string
+	Entourage int32
+}
+
+// ArriveGuest arrives a guest and their (possibly changed) entourage at their table
+func (r *Resolver) ArriveGuest(ctx context.Context, args arriveGuestArgs) (*guestResolver, error) {
+	payload, err := authorize(ctx, util.OrganiserRole, util.DoorStaffRole)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateGuestName(args.Name); err != nil {
+		return nil, err
+	}
+	if args.Entourage < 0 {
+		return nil, fmt.Errorf("entourage must not be negative")
+	}
+
+	guest, err := r.store.GetGuestFromName(ctx, args.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.store.AssignTableTx(ctx, db.AssignTableTxParams{
+		UserID:       int64(guest.ID),
+		TableID:      int64(guest.TableID),
+		NewEntourage: int64(args.Entourage),
+		AuditInfo:    auditInfo(ctx, payload),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	l := loadersFrom(ctx)
+	l.tables.store(result.Table.ID, result.Table)
+	l.arrivalByGuest.store(result.Guest.ID, result.Arrival)
+	return newGuestResolvers(ctx, []db.Guest{result.Guest})[0], nil
+}
+
+// LeaveGuest records a guest and their entourage leaving the party, freeing up their seats
+func (r *Resolver) LeaveGuest(ctx context.Context, args struct{ Name string }) (string, error) {
+	payload, err := authorize(ctx, util.OrganiserRole, util.DoorStaffRole)
+	if err != nil {
+		return "", err
+	}
+	if err := validateGuestName(args.Name); err != nil {
+		return "", err
+	}
+
+	guest, err := r.store.GetGuestFromName(ctx, args.Name)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := r.store.LeaveGuestTx(ctx, db.LeaveGuestTxParams{ID: guest.ID, AuditInfo: auditInfo(ctx, payload)}); err != nil {
+		return "", err
+	}
+	return guest.GuestName, nil
+}
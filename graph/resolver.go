@@ -0,0 +1,175 @@
+package graph
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// Resolver is the root resolver for the dashboard queries and mutations
+type Resolver struct {
+	store db.Store
+}
+
+type pageArgs struct {
+	PageID   int32
+	PageSize int32
+}
+
+func (args pageArgs) validate() error {
+	if args.PageID < 1 {
+		return fmt.Errorf("pageId must be at least 1")
+	}
+	if args.PageSize < 5 || args.PageSize > 10 {
+		return fmt.Errorf("pageSize must be between 5 and 10")
+	}
+	return nil
+}
+
+func (args pageArgs) offset() int32 {
+	return (args.PageID - 1) * args.PageSize
+}
+
+func validateGuestName(name string) error {
+	if len(name) < 5 {
+		return fmt.Errorf("name must be at least 5 characters")
+	}
+	return nil
+}
+
+func (r *Resolver) Tables(ctx context.Context, args pageArgs) ([]*tableResolver, error) {
+	if err := args.validate(); err != nil {
+		return nil, err
+	}
+
+	tables, err := r.store.GetTables(ctx, db.GetTablesParams{
+		Limit:  args.PageSize,
+		Offset: args.offset(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newTableResolvers(ctx, tables), nil
+}
+
+func (r *Resolver) Table(ctx context.Context, args struct{ ID int32 }) (*tableResolver, error) {
+	table, err := loadersFrom(ctx).table(ctx, args.ID)
+	if err != nil || table == nil {
+		return nil, err
+	}
+	return newTableResolvers(ctx, []db.Table{*table})[0], nil
+}
+
+// guestsArgs is a page of the guest list, optionally only the guests with a given status, at a
+// given table, with a dietary requirement or of a VIP tier
+type guestsArgs struct {
+	PageID   int32
+	PageSize int32
+	Status   *string
+	TableID  *int32
+	Dietary  *string
+	VipTier  *string
+}
+
+func (r *Resolver) Guests(ctx context.Context, args guestsArgs) ([]*guestResolver, error) {
+	page := pageArgs{PageID: args.PageID, PageSize: args.PageSize}
+	if err := page.validate(); err != nil {
+		return nil, err
+	}
+
+	var status sql.NullString
+	if args.Status != nil {
+		if !db.IsGuestStatus(*args.Status) {
+			return nil, fmt.Errorf("status must be one of %s", strings.Join(db.GuestStatuses, ", "))
+		}
+		status = sql.NullString{String: *args.Status, Valid: true}
+	}
+
+	var tableID sql.NullInt32
+	if args.TableID != nil {
+		tableID = sql.NullInt32{Int32: *args.TableID, Valid: true}
+	}
+
+	var dietary sql.NullString
+	if args.Dietary != nil {
+		if strings.Contains(*args.Dietary, ",") {
+			return nil, fmt.Errorf("dietary must be a single requirement")
+		}
+		dietary = sql.NullString{String: db.NormalizeDietary(*args.Dietary), Valid: true}
+	}
+
+	var vipTier sql.NullString
+	if args.VipTier != nil {
+		if !db.IsVIPTier(*args.VipTier) {
+			return nil, fmt.Errorf("vipTier must be one of %s", strings.Join(db.VIPTiers, ", "))
+		}
+		vipTier = sql.NullString{String: *args.VipTier, Valid: true}
+	}
+
+	guests, err := r.store.GetGuests(ctx, db.GetGuestsParams{
+		Status:  status,
+		TableID: tableID,
+		Dietary: dietary,
+		VipTier: vipTier,
+		Limit:   page.PageSize,
+		Offset:  page.offset(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newGuestResolvers(ctx, guests), nil
+}
+
+func (r *Resolver) ArrivedGuests(ctx context.Context, args pageArgs) ([]*guestResolver, error) {
+	if err := args.validate(); err != nil {
+		return nil, err
+	}
+
+	guests, err := r.store.GetArrivedGuests(ctx, db.GetArrivedGuestsParams{
+		Limit:  args.PageSize,
+		Offset: args.offset(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newGuestResolvers(ctx, guests), nil
+}
+
+func (r *Resolver) Guest(ctx context.Context, args struct{ Name string }) (*guestResolver, error) {
+	guest, err := r.store.GetGuestFromName(ctx, args.Name)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return newGuestResolvers(ctx, []db.Guest{guest})[0], nil
+}
+
+func (r *Resolver) Seats(ctx context.Context) (*seatsResolver, error) {
+	count, err := r.store.GetEmptySeats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &seatsResolver{empty: count}, nil
+}
+
+type seatsResolver struct {
+	empty int32
+}
+
+func (r *seatsResolver) Empty() int32 {
+	return r.empty
+}
+
+func nullTime(t sql.NullTime) *graphql.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &graphql.Time{Time: t.Time}
+}
@@ -0,0 +1,80 @@
+package graph
+
+import (
+	"context"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+type tableResolver struct {
+	table db.Table
+}
+
+// newTableResolvers caches the tables and queues their guests so that resolving
+// the guests of every table on a page only takes one query
+func newTableResolvers(ctx context.Context, tables []db.Table) []*tableResolver {
+	l := loadersFrom(ctx)
+	resolvers := make([]*tableResolver, len(tables))
+	ids := make([]int32, len(tables))
+	for i, table := range tables {
+		l.tables.store(table.ID, table)
+		ids[i] = table.ID
+		resolvers[i] = &tableResolver{table: table}
+	}
+	l.guestsByTable.prime(ids...)
+	l.emptySeats.prime(ids...)
+	return resolvers
+}
+
+func (r *tableResolver) ID() int32 {
+	return r.table.ID
+}
+
+func (r *tableResolver) Size() int32 {
+	return r.table.Size
+}
+
+func (r *tableResolver) Occupied() int32 {
+	return r.table.Occupied
+}
+
+func (r *tableResolver) SeatsEmpty(ctx context.Context) (int32, error) {
+	return loadersFrom(ctx).seatsEmpty(ctx, r.table.ID)
+}
+
+func (r *tableResolver) CreatedAt() *graphql.Time {
+	return nullTime(r.table.CreatedAt)
+}
+
+func (r *tableResolver) Label() string {
+	return r.table.Label
+}
+
+func (r *tableResolver) Zone() string {
+	return r.table.Zone
+}
+
+func (r *tableResolver) Accessible() bool {
+	return r.table.Accessible
+}
+
+func (r *tableResolver) Shape() string {
+	return r.table.Shape
+}
+
+func (r *tableResolver) MinParty() int32 {
+	return r.table.MinParty
+}
+
+func (r *tableResolver) MaxParty() int32 {
+	return r.table.MaxParty
+}
+
+func (r *tableResolver) Guests(ctx context.Context) ([]*guestResolver, error) {
+	guests, err := loadersFrom(ctx).guestsAtTable(ctx, r.table.ID)
+	if err != nil {
+		return nil, err
+	}
+	return newGuestResolvers(ctx, guests), nil
+}
@@ -1,12 +1,19 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"log"
+	"net"
 
 	"github.com/ellisp97/BE_Task_Oct20/golang/api"
 	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/gapi"
+	"github.com/ellisp97/BE_Task_Oct20/golang/pb"
+	"github.com/ellisp97/BE_Task_Oct20/golang/scheduler"
 	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 
 	_ "github.com/go-sql-driver/mysql"
 	_ "github.com/golang/mock/mockgen/model"
@@ -23,11 +30,58 @@ func main() {
 		log.Fatal("Cannot connect to the mysql database: ", err)
 	}
 
-	store := db.NewStore(connection)
-	server := api.NewServer(store)
+	admission, err := db.ParseAdmissionPolicies(config.AdmissionPolicies)
+	if err != nil {
+		log.Fatal("Cannot parse admission policies: ", err)
+	}
+
+	store := db.NewStore(connection,
+		db.WithReentryDenied(config.DenyReentry),
+		db.WithAdmissionPolicies(admission),
+	)
+	var grpcOptions []gapi.ServerOption
+	if config.NoShowCheckInterval > 0 {
+		noShows := scheduler.NewNoShowScheduler(store, scheduler.RealClock(), config.NoShowCheckInterval, config.NoShowGrace)
+		go noShows.Run(context.Background())
+		grpcOptions = append(grpcOptions, gapi.WithNoShows(noShows))
+	}
+	go runGrpcServer(config, store, grpcOptions...)
+	runGinServer(config, store)
+}
+
+func runGinServer(config util.Config, store db.Store) {
+	server, err := api.NewServer(config, store)
+	if err != nil {
+		log.Fatal("Cannot create server: ", err)
+	}
 
 	err = server.Start(config.SeverAddress)
 	if err != nil {
 		log.Fatal("Server failed to start")
 	}
 }
+
+func runGrpcServer(config util.Config, store db.Store, options ...gapi.ServerOption) {
+	server, err := gapi.NewServer(config, store, options...)
+	if err != nil {
+		log.Fatal("Cannot create gRPC server: ", err)
+	}
+
+	grpcServer := grpc.NewServer()
+	pb.RegisterGuestListServer(grpcServer, server)
+	pb.RegisterArrivalsServer(grpcServer, server)
+	pb.RegisterTablesServer(grpcServer, server)
+	pb.RegisterSeatsServer(grpcServer, server)
+	reflection.Register(grpcServer)
+
+	listener, err := net.Listen("tcp", config.GRPCServerAddress)
+	if err != nil {
+		log.Fatal("Cannot create gRPC listener: ", err)
+	}
+
+	log.Printf("Starting gRPC server at %s", listener.Addr().String())
+	err = grpcServer.Serve(listener)
+	if err != nil {
+		log.Fatal("gRPC server failed to start: ", err)
+	}
+}
@@ -0,0 +1,484 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.19.1
+// source: rpc_arrival.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ArriveGuestRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GuestName string `protobuf:"bytes,1,opt,name=guest_name,json=guestName,proto3" json:"guest_name,omitempty"`
+	Entourage int32  `protobuf:"varint,2,opt,name=entourage,proto3" json:"entourage,omitempty"`
+}
+
+func (x *ArriveGuestRequest) Reset() {
+	*x = ArriveGuestRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_arrival_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArriveGuestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArriveGuestRequest) ProtoMessage() {}
+
+func (x *ArriveGuestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_arrival_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArriveGuestRequest.ProtoReflect.Descriptor instead.
+func (*ArriveGuestRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_arrival_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ArriveGuestRequest) GetGuestName() string {
+	if x != nil {
+		return x.GuestName
+	}
+	return ""
+}
+
+func (x *ArriveGuestRequest) GetEntourage() int32 {
+	if x != nil {
+		return x.Entourage
+	}
+	return 0
+}
+
+type ArriveGuestResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Guest   *Guest   `protobuf:"bytes,1,opt,name=guest,proto3" json:"guest,omitempty"`
+	Table   *Table   `protobuf:"bytes,2,opt,name=table,proto3" json:"table,omitempty"`
+	Arrival *Arrival `protobuf:"bytes,3,opt,name=arrival,proto3" json:"arrival,omitempty"`
+	// Why each of the event's admission policies let the party in
+	Decisions []*AdmissionDecision `protobuf:"bytes,4,rep,name=decisions,proto3" json:"decisions,omitempty"`
+}
+
+func (x *ArriveGuestResponse) Reset() {
+	*x = ArriveGuestResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_arrival_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ArriveGuestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArriveGuestResponse) ProtoMessage() {}
+
+func (x *ArriveGuestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_arrival_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArriveGuestResponse.ProtoReflect.Descriptor instead.
+func (*ArriveGuestResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_arrival_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ArriveGuestResponse) GetGuest() *Guest {
+	if x != nil {
+		return x.Guest
+	}
+	return nil
+}
+
+func (x *ArriveGuestResponse) GetTable() *Table {
+	if x != nil {
+		return x.Table
+	}
+	return nil
+}
+
+func (x *ArriveGuestResponse) GetArrival() *Arrival {
+	if x != nil {
+		return x.Arrival
+	}
+	return nil
+}
+
+func (x *ArriveGuestResponse) GetDecisions() []*AdmissionDecision {
+	if x != nil {
+		return x.Decisions
+	}
+	return nil
+}
+
+type LeaveGuestRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GuestName string `protobuf:"bytes,1,opt,name=guest_name,json=guestName,proto3" json:"guest_name,omitempty"`
+}
+
+func (x *LeaveGuestRequest) Reset() {
+	*x = LeaveGuestRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_arrival_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaveGuestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveGuestRequest) ProtoMessage() {}
+
+func (x *LeaveGuestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_arrival_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveGuestRequest.ProtoReflect.Descriptor instead.
+func (*LeaveGuestRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_arrival_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *LeaveGuestRequest) GetGuestName() string {
+	if x != nil {
+		return x.GuestName
+	}
+	return ""
+}
+
+type LeaveGuestResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Guest *Guest `protobuf:"bytes,1,opt,name=guest,proto3" json:"guest,omitempty"`
+}
+
+func (x *LeaveGuestResponse) Reset() {
+	*x = LeaveGuestResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_arrival_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LeaveGuestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveGuestResponse) ProtoMessage() {}
+
+func (x *LeaveGuestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_arrival_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveGuestResponse.ProtoReflect.Descriptor instead.
+func (*LeaveGuestResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_arrival_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *LeaveGuestResponse) GetGuest() *Guest {
+	if x != nil {
+		return x.Guest
+	}
+	return nil
+}
+
+type AdmissionDecision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Policy  string `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	Allowed bool   `protobuf:"varint,2,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	Reason  string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// Set for the decisions of uploaded admission rules
+	RulesVersion int32 `protobuf:"varint,4,opt,name=rules_version,json=rulesVersion,proto3" json:"rules_version,omitempty"`
+}
+
+func (x *AdmissionDecision) Reset() {
+	*x = AdmissionDecision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_arrival_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AdmissionDecision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdmissionDecision) ProtoMessage() {}
+
+func (x *AdmissionDecision) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_arrival_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdmissionDecision.ProtoReflect.Descriptor instead.
+func (*AdmissionDecision) Descriptor() ([]byte, []int) {
+	return file_rpc_arrival_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AdmissionDecision) GetPolicy() string {
+	if x != nil {
+		return x.Policy
+	}
+	return ""
+}
+
+func (x *AdmissionDecision) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+func (x *AdmissionDecision) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *AdmissionDecision) GetRulesVersion() int32 {
+	if x != nil {
+		return x.RulesVersion
+	}
+	return 0
+}
+
+var File_rpc_arrival_proto protoreflect.FileDescriptor
+
+var file_rpc_arrival_proto_rawDesc = []byte{
+	0x0a, 0x11, 0x72, 0x70, 0x63, 0x5f, 0x61, 0x72, 0x72, 0x69, 0x76, 0x61, 0x6c, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x0d, 0x61, 0x72, 0x72, 0x69, 0x76, 0x61, 0x6c,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0b, 0x67, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x0b, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x51, 0x0a, 0x12, 0x41, 0x72, 0x72, 0x69, 0x76, 0x65, 0x47, 0x75, 0x65, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x75, 0x65, 0x73, 0x74, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x75, 0x65, 0x73,
+	0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x65, 0x6e, 0x74, 0x6f, 0x75, 0x72, 0x61,
+	0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x65, 0x6e, 0x74, 0x6f, 0x75, 0x72,
+	0x61, 0x67, 0x65, 0x22, 0xb3, 0x01, 0x0a, 0x13, 0x41, 0x72, 0x72, 0x69, 0x76, 0x65, 0x47, 0x75,
+	0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x05, 0x67,
+	0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e,
+	0x47, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x67, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x05,
+	0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62,
+	0x2e, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x05, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x12, 0x25, 0x0a,
+	0x07, 0x61, 0x72, 0x72, 0x69, 0x76, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b,
+	0x2e, 0x70, 0x62, 0x2e, 0x41, 0x72, 0x72, 0x69, 0x76, 0x61, 0x6c, 0x52, 0x07, 0x61, 0x72, 0x72,
+	0x69, 0x76, 0x61, 0x6c, 0x12, 0x33, 0x0a, 0x09, 0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x64, 0x6d,
+	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x09,
+	0x64, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x32, 0x0a, 0x11, 0x4c, 0x65, 0x61,
+	0x76, 0x65, 0x47, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x67, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x67, 0x75, 0x65, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x35, 0x0a,
+	0x12, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x47, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x05, 0x67, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x09, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x75, 0x65, 0x73, 0x74, 0x52, 0x05, 0x67,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0x82, 0x01, 0x0a, 0x11, 0x41, 0x64, 0x6d, 0x69, 0x73, 0x73, 0x69,
+	0x6f, 0x6e, 0x44, 0x65, 0x63, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69,
+	0x63, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x72, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65,
+	0x61, 0x73, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x5f, 0x76, 0x65,
+	0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x72, 0x75, 0x6c,
+	0x65, 0x73, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x2d, 0x5a, 0x2b, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x6c, 0x6c, 0x69, 0x73, 0x70, 0x39, 0x37,
+	0x2f, 0x42, 0x45, 0x5f, 0x54, 0x61, 0x73, 0x6b, 0x5f, 0x4f, 0x63, 0x74, 0x32, 0x30, 0x2f, 0x67,
+	0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_rpc_arrival_proto_rawDescOnce sync.Once
+	file_rpc_arrival_proto_rawDescData = file_rpc_arrival_proto_rawDesc
+)
+
+func file_rpc_arrival_proto_rawDescGZIP() []byte {
+	file_rpc_arrival_proto_rawDescOnce.Do(func() {
+		file_rpc_arrival_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_arrival_proto_rawDescData)
+	})
+	return file_rpc_arrival_proto_rawDescData
+}
+
+var file_rpc_arrival_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_rpc_arrival_proto_goTypes = []interface{}{
+	(*ArriveGuestRequest)(nil),  // 0: pb.ArriveGuestRequest
+	(*ArriveGuestResponse)(nil), // 1: pb.ArriveGuestResponse
+	(*LeaveGuestRequest)(nil),   // 2: pb.LeaveGuestRequest
+	(*LeaveGuestResponse)(nil),  // 3: pb.LeaveGuestResponse
+	(*AdmissionDecision)(nil),   // 4: pb.AdmissionDecision
+	(*Guest)(nil),               // 5: pb.Guest
+	(*Table)(nil),               // 6: pb.Table
+	(*Arrival)(nil),             // 7: pb.Arrival
+}
+var file_rpc_arrival_proto_depIdxs = []int32{
+	5, // 0: pb.ArriveGuestResponse.guest:type_name -> pb.Guest
+	6, // 1: pb.ArriveGuestResponse.table:type_name -> pb.Table
+	7, // 2: pb.ArriveGuestResponse.arrival:type_name -> pb.Arrival
+	4, // 3: pb.ArriveGuestResponse.decisions:type_name -> pb.AdmissionDecision
+	5, // 4: pb.LeaveGuestResponse.guest:type_name -> pb.Guest
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_rpc_arrival_proto_init() }
+func file_rpc_arrival_proto_init() {
+	if File_rpc_arrival_proto != nil {
+		return
+	}
+	file_arrival_proto_init()
+	file_guest_proto_init()
+	file_table_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_arrival_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ArriveGuestRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_arrival_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ArriveGuestResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_arrival_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaveGuestRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_arrival_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LeaveGuestResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_arrival_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AdmissionDecision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_arrival_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_arrival_proto_goTypes,
+		DependencyIndexes: file_rpc_arrival_proto_depIdxs,
+		MessageInfos:      file_rpc_arrival_proto_msgTypes,
+	}.Build()
+	File_rpc_arrival_proto = out.File
+	file_rpc_arrival_proto_rawDesc = nil
+	file_rpc_arrival_proto_goTypes = nil
+	file_rpc_arrival_proto_depIdxs = nil
+}
@@ -0,0 +1,468 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.19.1
+// source: rpc_seats.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetEmptySeatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *GetEmptySeatsRequest) Reset() {
+	*x = GetEmptySeatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_seats_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEmptySeatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmptySeatsRequest) ProtoMessage() {}
+
+func (x *GetEmptySeatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_seats_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmptySeatsRequest.ProtoReflect.Descriptor instead.
+func (*GetEmptySeatsRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_seats_proto_rawDescGZIP(), []int{0}
+}
+
+type GetEmptySeatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SeatsEmpty int32 `protobuf:"varint,1,opt,name=seats_empty,json=seatsEmpty,proto3" json:"seats_empty,omitempty"`
+}
+
+func (x *GetEmptySeatsResponse) Reset() {
+	*x = GetEmptySeatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_seats_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetEmptySeatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEmptySeatsResponse) ProtoMessage() {}
+
+func (x *GetEmptySeatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_seats_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEmptySeatsResponse.ProtoReflect.Descriptor instead.
+func (*GetEmptySeatsResponse) Descriptor() ([]byte, []int) {
+	return file_rpc_seats_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetEmptySeatsResponse) GetSeatsEmpty() int32 {
+	if x != nil {
+		return x.SeatsEmpty
+	}
+	return 0
+}
+
+type WatchOccupancyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// table_id restricts the updates to a single table, 0 watches the whole venue
+	TableId int32 `protobuf:"varint,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+}
+
+func (x *WatchOccupancyRequest) Reset() {
+	*x = WatchOccupancyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_seats_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchOccupancyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOccupancyRequest) ProtoMessage() {}
+
+func (x *WatchOccupancyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_seats_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOccupancyRequest.ProtoReflect.Descriptor instead.
+func (*WatchOccupancyRequest) Descriptor() ([]byte, []int) {
+	return file_rpc_seats_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WatchOccupancyRequest) GetTableId() int32 {
+	if x != nil {
+		return x.TableId
+	}
+	return 0
+}
+
+type OccupancyUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TableId    int32                  `protobuf:"varint,1,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	Size       int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Occupied   int32                  `protobuf:"varint,3,opt,name=occupied,proto3" json:"occupied,omitempty"`
+	SeatsEmpty int32                  `protobuf:"varint,4,opt,name=seats_empty,json=seatsEmpty,proto3" json:"seats_empty,omitempty"`
+	ObservedAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=observed_at,json=observedAt,proto3" json:"observed_at,omitempty"`
+	// no_show is set on the update sent when a guest at the table, or in the venue, is marked a no-show
+	NoShow *NoShow `protobuf:"bytes,6,opt,name=no_show,json=noShow,proto3" json:"no_show,omitempty"`
+}
+
+func (x *OccupancyUpdate) Reset() {
+	*x = OccupancyUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_seats_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OccupancyUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OccupancyUpdate) ProtoMessage() {}
+
+func (x *OccupancyUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_seats_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OccupancyUpdate.ProtoReflect.Descriptor instead.
+func (*OccupancyUpdate) Descriptor() ([]byte, []int) {
+	return file_rpc_seats_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *OccupancyUpdate) GetTableId() int32 {
+	if x != nil {
+		return x.TableId
+	}
+	return 0
+}
+
+func (x *OccupancyUpdate) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *OccupancyUpdate) GetOccupied() int32 {
+	if x != nil {
+		return x.Occupied
+	}
+	return 0
+}
+
+func (x *OccupancyUpdate) GetSeatsEmpty() int32 {
+	if x != nil {
+		return x.SeatsEmpty
+	}
+	return 0
+}
+
+func (x *OccupancyUpdate) GetObservedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ObservedAt
+	}
+	return nil
+}
+
+func (x *OccupancyUpdate) GetNoShow() *NoShow {
+	if x != nil {
+		return x.NoShow
+	}
+	return nil
+}
+
+type NoShow struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	GuestName string                 `protobuf:"bytes,1,opt,name=guest_name,json=guestName,proto3" json:"guest_name,omitempty"`
+	TableId   int32                  `protobuf:"varint,2,opt,name=table_id,json=tableId,proto3" json:"table_id,omitempty"`
+	MarkedAt  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=marked_at,json=markedAt,proto3" json:"marked_at,omitempty"`
+}
+
+func (x *NoShow) Reset() {
+	*x = NoShow{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_rpc_seats_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NoShow) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NoShow) ProtoMessage() {}
+
+func (x *NoShow) ProtoReflect() protoreflect.Message {
+	mi := &file_rpc_seats_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NoShow.ProtoReflect.Descriptor instead.
+func (*NoShow) Descriptor() ([]byte, []int) {
+	return file_rpc_seats_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *NoShow) GetGuestName() string {
+	if x != nil {
+		return x.GuestName
+	}
+	return ""
+}
+
+func (x *NoShow) GetTableId() int32 {
+	if x != nil {
+		return x.TableId
+	}
+	return 0
+}
+
+func (x *NoShow) GetMarkedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MarkedAt
+	}
+	return nil
+}
+
+var File_rpc_seats_proto protoreflect.FileDescriptor
+
+var file_rpc_seats_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x61, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x16, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x53, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x38,
+	0x0a, 0x15, 0x47, 0x65, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x53, 0x65, 0x61, 0x74, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x65, 0x61, 0x74, 0x73,
+	0x5f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x65,
+	0x61, 0x74, 0x73, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22, 0x32, 0x0a, 0x15, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x4f, 0x63, 0x63, 0x75, 0x70, 0x61, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x07, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x64, 0x22, 0xdf, 0x01, 0x0a,
+	0x0f, 0x4f, 0x63, 0x63, 0x75, 0x70, 0x61, 0x6e, 0x63, 0x79, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x12, 0x19, 0x0a, 0x08, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x07, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x6f, 0x63, 0x63, 0x75, 0x70, 0x69, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x08, 0x6f, 0x63, 0x63, 0x75, 0x70, 0x69, 0x65, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73,
+	0x65, 0x61, 0x74, 0x73, 0x5f, 0x65, 0x6d, 0x70, 0x74, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0a, 0x73, 0x65, 0x61, 0x74, 0x73, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3b, 0x0a, 0x0b,
+	0x6f, 0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x0a, 0x6f,
+	0x62, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x41, 0x74, 0x12, 0x23, 0x0a, 0x07, 0x6e, 0x6f, 0x5f,
+	0x73, 0x68, 0x6f, 0x77, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x70, 0x62, 0x2e,
+	0x4e, 0x6f, 0x53, 0x68, 0x6f, 0x77, 0x52, 0x06, 0x6e, 0x6f, 0x53, 0x68, 0x6f, 0x77, 0x22, 0x7b,
+	0x0a, 0x06, 0x4e, 0x6f, 0x53, 0x68, 0x6f, 0x77, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x75, 0x65, 0x73,
+	0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x75,
+	0x65, 0x73, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x49, 0x64, 0x12, 0x37, 0x0a, 0x09, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x52, 0x08, 0x6d, 0x61, 0x72, 0x6b, 0x65, 0x64, 0x41, 0x74, 0x42, 0x2d, 0x5a, 0x2b, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x6c, 0x6c, 0x69, 0x73, 0x70,
+	0x39, 0x37, 0x2f, 0x42, 0x45, 0x5f, 0x54, 0x61, 0x73, 0x6b, 0x5f, 0x4f, 0x63, 0x74, 0x32, 0x30,
+	0x2f, 0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_rpc_seats_proto_rawDescOnce sync.Once
+	file_rpc_seats_proto_rawDescData = file_rpc_seats_proto_rawDesc
+)
+
+func file_rpc_seats_proto_rawDescGZIP() []byte {
+	file_rpc_seats_proto_rawDescOnce.Do(func() {
+		file_rpc_seats_proto_rawDescData = protoimpl.X.CompressGZIP(file_rpc_seats_proto_rawDescData)
+	})
+	return file_rpc_seats_proto_rawDescData
+}
+
+var file_rpc_seats_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_rpc_seats_proto_goTypes = []interface{}{
+	(*GetEmptySeatsRequest)(nil),  // 0: pb.GetEmptySeatsRequest
+	(*GetEmptySeatsResponse)(nil), // 1: pb.GetEmptySeatsResponse
+	(*WatchOccupancyRequest)(nil), // 2: pb.WatchOccupancyRequest
+	(*OccupancyUpdate)(nil),       // 3: pb.OccupancyUpdate
+	(*NoShow)(nil),                // 4: pb.NoShow
+	(*timestamppb.Timestamp)(nil), // 5: google.protobuf.Timestamp
+}
+var file_rpc_seats_proto_depIdxs = []int32{
+	5, // 0: pb.OccupancyUpdate.observed_at:type_name -> google.protobuf.Timestamp
+	4, // 1: pb.OccupancyUpdate.no_show:type_name -> pb.NoShow
+	5, // 2: pb.NoShow.marked_at:type_name -> google.protobuf.Timestamp
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_rpc_seats_proto_init() }
+func file_rpc_seats_proto_init() {
+	if File_rpc_seats_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_rpc_seats_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEmptySeatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_seats_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetEmptySeatsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_seats_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WatchOccupancyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_seats_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OccupancyUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_rpc_seats_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NoShow); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_rpc_seats_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_rpc_seats_proto_goTypes,
+		DependencyIndexes: file_rpc_seats_proto_depIdxs,
+		MessageInfos:      file_rpc_seats_proto_msgTypes,
+	}.Build()
+	File_rpc_seats_proto = out.File
+	file_rpc_seats_proto_rawDesc = nil
+	file_rpc_seats_proto_goTypes = nil
+	file_rpc_seats_proto_depIdxs = nil
+}
@@ -0,0 +1,164 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        v3.19.1
+// source: service_guestlist.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+var File_service_guestlist_proto protoreflect.FileDescriptor
+
+var file_service_guestlist_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x67, 0x75, 0x65, 0x73, 0x74, 0x6c,
+	0x69, 0x73, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x11, 0x72,
+	0x70, 0x63, 0x5f, 0x61, 0x72, 0x72, 0x69, 0x76, 0x61, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x1a, 0x14, 0x72, 0x70, 0x63, 0x5f, 0x67, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x6c, 0x69, 0x73, 0x74,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0f, 0x72, 0x70, 0x63, 0x5f, 0x73, 0x65, 0x61, 0x74,
+	0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0f, 0x72, 0x70, 0x63, 0x5f, 0x74, 0x61, 0x62,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x87, 0x02, 0x0a, 0x09, 0x47, 0x75, 0x65,
+	0x73, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x40, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x47, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x47, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x47, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x37, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x47,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x47, 0x75, 0x65,
+	0x73, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x14, 0x2e, 0x70, 0x62, 0x2e, 0x47,
+	0x65, 0x74, 0x47, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x3d, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x75, 0x65, 0x73, 0x74, 0x73, 0x12,
+	0x15, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x75, 0x65, 0x73, 0x74, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x47, 0x75, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x40, 0x0a, 0x0b, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x16, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x47, 0x75, 0x65, 0x73, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x47, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x32, 0xd1, 0x01, 0x0a, 0x08, 0x41, 0x72, 0x72, 0x69, 0x76, 0x61, 0x6c, 0x73, 0x12,
+	0x40, 0x0a, 0x0b, 0x41, 0x72, 0x72, 0x69, 0x76, 0x65, 0x47, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
+	0x2e, 0x70, 0x62, 0x2e, 0x41, 0x72, 0x72, 0x69, 0x76, 0x65, 0x47, 0x75, 0x65, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x41, 0x72, 0x72, 0x69,
+	0x76, 0x65, 0x47, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x12, 0x3d, 0x0a, 0x0a, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x47, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x15, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x65, 0x61, 0x76, 0x65, 0x47, 0x75, 0x65, 0x73, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x65, 0x61, 0x76,
+	0x65, 0x47, 0x75, 0x65, 0x73, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x44, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x72, 0x72, 0x69, 0x76, 0x65, 0x64, 0x47,
+	0x75, 0x65, 0x73, 0x74, 0x73, 0x12, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x47,
+	0x75, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70,
+	0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x47, 0x75, 0x65, 0x73, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x32, 0x89, 0x01, 0x0a, 0x06, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x73, 0x12, 0x40, 0x0a, 0x0b, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x12, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x62, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x00, 0x12, 0x3d, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x73, 0x12, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x70, 0x62, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x54, 0x61, 0x62, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x22, 0x00, 0x32, 0x95, 0x01, 0x0a, 0x05, 0x53, 0x65, 0x61, 0x74, 0x73, 0x12, 0x46, 0x0a, 0x0d,
+	0x47, 0x65, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x53, 0x65, 0x61, 0x74, 0x73, 0x12, 0x18, 0x2e,
+	0x70, 0x62, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x53, 0x65, 0x61, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x70, 0x62, 0x2e, 0x47, 0x65, 0x74,
+	0x45, 0x6d, 0x70, 0x74, 0x79, 0x53, 0x65, 0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x0e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x4f, 0x63, 0x63,
+	0x75, 0x70, 0x61, 0x6e, 0x63, 0x79, 0x12, 0x19, 0x2e, 0x70, 0x62, 0x2e, 0x57, 0x61, 0x74, 0x63,
+	0x68, 0x4f, 0x63, 0x63, 0x75, 0x70, 0x61, 0x6e, 0x63, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x4f, 0x63, 0x63, 0x75, 0x70, 0x61, 0x6e, 0x63, 0x79,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x22, 0x00, 0x30, 0x01, 0x42, 0x2d, 0x5a, 0x2b, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x65, 0x6c, 0x6c, 0x69, 0x73, 0x70, 0x39,
+	0x37, 0x2f, 0x42, 0x45, 0x5f, 0x54, 0x61, 0x73, 0x6b, 0x5f, 0x4f, 0x63, 0x74, 0x32, 0x30, 0x2f,
+	0x67, 0x6f, 0x6c, 0x61, 0x6e, 0x67, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var file_service_guestlist_proto_goTypes = []interface{}{
+	(*CreateGuestRequest)(nil),    // 0: pb.CreateGuestRequest
+	(*GetGuestRequest)(nil),       // 1: pb.GetGuestRequest
+	(*ListGuestsRequest)(nil),     // 2: pb.ListGuestsRequest
+	(*DeleteGuestRequest)(nil),    // 3: pb.DeleteGuestRequest
+	(*ArriveGuestRequest)(nil),    // 4: pb.ArriveGuestRequest
+	(*LeaveGuestRequest)(nil),     // 5: pb.LeaveGuestRequest
+	(*CreateTableRequest)(nil),    // 6: pb.CreateTableRequest
+	(*ListTablesRequest)(nil),     // 7: pb.ListTablesRequest
+	(*GetEmptySeatsRequest)(nil),  // 8: pb.GetEmptySeatsRequest
+	(*WatchOccupancyRequest)(nil), // 9: pb.WatchOccupancyRequest
+	(*CreateGuestResponse)(nil),   // 10: pb.CreateGuestResponse
+	(*GetGuestResponse)(nil),      // 11: pb.GetGuestResponse
+	(*ListGuestsResponse)(nil),    // 12: pb.ListGuestsResponse
+	(*DeleteGuestResponse)(nil),   // 13: pb.DeleteGuestResponse
+	(*ArriveGuestResponse)(nil),   // 14: pb.ArriveGuestResponse
+	(*LeaveGuestResponse)(nil),    // 15: pb.LeaveGuestResponse
+	(*CreateTableResponse)(nil),   // 16: pb.CreateTableResponse
+	(*ListTablesResponse)(nil),    // 17: pb.ListTablesResponse
+	(*GetEmptySeatsResponse)(nil), // 18: pb.GetEmptySeatsResponse
+	(*OccupancyUpdate)(nil),       // 19: pb.OccupancyUpdate
+}
+var file_service_guestlist_proto_depIdxs = []int32{
+	0,  // 0: pb.GuestList.CreateGuest:input_type -> pb.CreateGuestRequest
+	1,  // 1: pb.GuestList.GetGuest:input_type -> pb.GetGuestRequest
+	2,  // 2: pb.GuestList.ListGuests:input_type -> pb.ListGuestsRequest
+	3,  // 3: pb.GuestList.DeleteGuest:input_type -> pb.DeleteGuestRequest
+	4,  // 4: pb.Arrivals.ArriveGuest:input_type -> pb.ArriveGuestRequest
+	5,  // 5: pb.Arrivals.LeaveGuest:input_type -> pb.LeaveGuestRequest
+	2,  // 6: pb.Arrivals.ListArrivedGuests:input_type -> pb.ListGuestsRequest
+	6,  // 7: pb.Tables.CreateTable:input_type -> pb.CreateTableRequest
+	7,  // 8: pb.Tables.ListTables:input_type -> pb.ListTablesRequest
+	8,  // 9: pb.Seats.GetEmptySeats:input_type -> pb.GetEmptySeatsRequest
+	9,  // 10: pb.Seats.WatchOccupancy:input_type -> pb.WatchOccupancyRequest
+	10, // 11: pb.GuestList.CreateGuest:output_type -> pb.CreateGuestResponse
+	11, // 12: pb.GuestList.GetGuest:output_type -> pb.GetGuestResponse
+	12, // 13: pb.GuestList.ListGuests:output_type -> pb.ListGuestsResponse
+	13, // 14: pb.GuestList.DeleteGuest:output_type -> pb.DeleteGuestResponse
+	14, // 15: pb.Arrivals.ArriveGuest:output_type -> pb.ArriveGuestResponse
+	15, // 16: pb.Arrivals.LeaveGuest:output_type -> pb.LeaveGuestResponse
+	12, // 17: pb.Arrivals.ListArrivedGuests:output_type -> pb.ListGuestsResponse
+	16, // 18: pb.Tables.CreateTable:output_type -> pb.CreateTableResponse
+	17, // 19: pb.Tables.ListTables:output_type -> pb.ListTablesResponse
+	18, // 20: pb.Seats.GetEmptySeats:output_type -> pb.GetEmptySeatsResponse
+	19, // 21: pb.Seats.WatchOccupancy:output_type -> pb.OccupancyUpdate
+	11, // [11:22] is the sub-list for method output_type
+	0,  // [0:11] is the sub-list for method input_type
+	0,  // [0:0] is the sub-list for extension type_name
+	0,  // [0:0] is the sub-list for extension extendee
+	0,  // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_service_guestlist_proto_init() }
+func file_service_guestlist_proto_init() {
+	if File_service_guestlist_proto != nil {
+		return
+	}
+	file_rpc_arrival_proto_init()
+	file_rpc_guest_list_proto_init()
+	file_rpc_seats_proto_init()
+	file_rpc_table_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_service_guestlist_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   4,
+		},
+		GoTypes:           file_service_guestlist_proto_goTypes,
+		DependencyIndexes: file_service_guestlist_proto_depIdxs,
+	}.Build()
+	File_service_guestlist_proto = out.File
+	file_service_guestlist_proto_rawDesc = nil
+	file_service_guestlist_proto_goTypes = nil
+	file_service_guestlist_proto_depIdxs = nil
+}
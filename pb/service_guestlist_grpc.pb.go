@@ -0,0 +1,643 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.19.1
+// source: service_guestlist.proto
+
+package pb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// GuestListClient is the client API for GuestList service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type GuestListClient interface {
+	CreateGuest(ctx context.Context, in *CreateGuestRequest, opts ...grpc.CallOption) (*CreateGuestResponse, error)
+	GetGuest(ctx context.Context, in *GetGuestRequest, opts ...grpc.CallOption) (*GetGuestResponse, error)
+	ListGuests(ctx context.Context, in *ListGuestsRequest, opts ...grpc.CallOption) (*ListGuestsResponse, error)
+	DeleteGuest(ctx context.Context, in *DeleteGuestRequest, opts ...grpc.CallOption) (*DeleteGuestResponse, error)
+}
+
+type guestListClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewGuestListClient(cc grpc.ClientConnInterface) GuestListClient {
+	return &guestListClient{cc}
+}
+
+func (c *guestListClient) CreateGuest(ctx context.Context, in *CreateGuestRequest, opts ...grpc.CallOption) (*CreateGuestResponse, error) {
+	out := new(CreateGuestResponse)
+	err := c.cc.Invoke(ctx, "/pb.GuestList/CreateGuest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *guestListClient) GetGuest(ctx context.Context, in *GetGuestRequest, opts ...grpc.CallOption) (*GetGuestResponse, error) {
+	out := new(GetGuestResponse)
+	err := c.cc.Invoke(ctx, "/pb.GuestList/GetGuest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *guestListClient) ListGuests(ctx context.Context, in *ListGuestsRequest, opts ...grpc.CallOption) (*ListGuestsResponse, error) {
+	out := new(ListGuestsResponse)
+	err := c.cc.Invoke(ctx, "/pb.GuestList/ListGuests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *guestListClient) DeleteGuest(ctx context.Context, in *DeleteGuestRequest, opts ...grpc.CallOption) (*DeleteGuestResponse, error) {
+	out := new(DeleteGuestResponse)
+	err := c.cc.Invoke(ctx, "/pb.GuestList/DeleteGuest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// GuestListServer is the server API for GuestList service.
+// All implementations must embed UnimplementedGuestListServer
+// for forward compatibility
+type GuestListServer interface {
+	CreateGuest(context.Context, *CreateGuestRequest) (*CreateGuestResponse, error)
+	GetGuest(context.Context, *GetGuestRequest) (*GetGuestResponse, error)
+	ListGuests(context.Context, *ListGuestsRequest) (*ListGuestsResponse, error)
+	DeleteGuest(context.Context, *DeleteGuestRequest) (*DeleteGuestResponse, error)
+	mustEmbedUnimplementedGuestListServer()
+}
+
+// UnimplementedGuestListServer must be embedded to have forward compatible implementations.
+type UnimplementedGuestListServer struct {
+}
+
+func (UnimplementedGuestListServer) CreateGuest(context.Context, *CreateGuestRequest) (*CreateGuestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateGuest not implemented")
+}
+func (UnimplementedGuestListServer) GetGuest(context.Context, *GetGuestRequest) (*GetGuestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetGuest not implemented")
+}
+func (UnimplementedGuestListServer) ListGuests(context.Context, *ListGuestsRequest) (*ListGuestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGuests not implemented")
+}
+func (UnimplementedGuestListServer) DeleteGuest(context.Context, *DeleteGuestRequest) (*DeleteGuestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteGuest not implemented")
+}
+func (UnimplementedGuestListServer) mustEmbedUnimplementedGuestListServer() {}
+
+// UnsafeGuestListServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to GuestListServer will
+// result in compilation errors.
+type UnsafeGuestListServer interface {
+	mustEmbedUnimplementedGuestListServer()
+}
+
+func RegisterGuestListServer(s grpc.ServiceRegistrar, srv GuestListServer) {
+	s.RegisterService(&GuestList_ServiceDesc, srv)
+}
+
+func _GuestList_CreateGuest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateGuestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GuestListServer).CreateGuest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GuestList/CreateGuest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GuestListServer).CreateGuest(ctx, req.(*CreateGuestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GuestList_GetGuest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGuestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GuestListServer).GetGuest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GuestList/GetGuest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GuestListServer).GetGuest(ctx, req.(*GetGuestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GuestList_ListGuests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGuestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GuestListServer).ListGuests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GuestList/ListGuests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GuestListServer).ListGuests(ctx, req.(*ListGuestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GuestList_DeleteGuest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteGuestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GuestListServer).DeleteGuest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.GuestList/DeleteGuest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GuestListServer).DeleteGuest(ctx, req.(*DeleteGuestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GuestList_ServiceDesc is the grpc.ServiceDesc for GuestList service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GuestList_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.GuestList",
+	HandlerType: (*GuestListServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateGuest",
+			Handler:    _GuestList_CreateGuest_Handler,
+		},
+		{
+			MethodName: "GetGuest",
+			Handler:    _GuestList_GetGuest_Handler,
+		},
+		{
+			MethodName: "ListGuests",
+			Handler:    _GuestList_ListGuests_Handler,
+		},
+		{
+			MethodName: "DeleteGuest",
+			Handler:    _GuestList_DeleteGuest_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "service_guestlist.proto",
+}
+
+// ArrivalsClient is the client API for Arrivals service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ArrivalsClient interface {
+	ArriveGuest(ctx context.Context, in *ArriveGuestRequest, opts ...grpc.CallOption) (*ArriveGuestResponse, error)
+	LeaveGuest(ctx context.Context, in *LeaveGuestRequest, opts ...grpc.CallOption) (*LeaveGuestResponse, error)
+	ListArrivedGuests(ctx context.Context, in *ListGuestsRequest, opts ...grpc.CallOption) (*ListGuestsResponse, error)
+}
+
+type arrivalsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewArrivalsClient(cc grpc.ClientConnInterface) ArrivalsClient {
+	return &arrivalsClient{cc}
+}
+
+func (c *arrivalsClient) ArriveGuest(ctx context.Context, in *ArriveGuestRequest, opts ...grpc.CallOption) (*ArriveGuestResponse, error) {
+	out := new(ArriveGuestResponse)
+	err := c.cc.Invoke(ctx, "/pb.Arrivals/ArriveGuest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arrivalsClient) LeaveGuest(ctx context.Context, in *LeaveGuestRequest, opts ...grpc.CallOption) (*LeaveGuestResponse, error) {
+	out := new(LeaveGuestResponse)
+	err := c.cc.Invoke(ctx, "/pb.Arrivals/LeaveGuest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arrivalsClient) ListArrivedGuests(ctx context.Context, in *ListGuestsRequest, opts ...grpc.CallOption) (*ListGuestsResponse, error) {
+	out := new(ListGuestsResponse)
+	err := c.cc.Invoke(ctx, "/pb.Arrivals/ListArrivedGuests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ArrivalsServer is the server API for Arrivals service.
+// All implementations must embed UnimplementedArrivalsServer
+// for forward compatibility
+type ArrivalsServer interface {
+	ArriveGuest(context.Context, *ArriveGuestRequest) (*ArriveGuestResponse, error)
+	LeaveGuest(context.Context, *LeaveGuestRequest) (*LeaveGuestResponse, error)
+	ListArrivedGuests(context.Context, *ListGuestsRequest) (*ListGuestsResponse, error)
+	mustEmbedUnimplementedArrivalsServer()
+}
+
+// UnimplementedArrivalsServer must be embedded to have forward compatible implementations.
+type UnimplementedArrivalsServer struct {
+}
+
+func (UnimplementedArrivalsServer) ArriveGuest(context.Context, *ArriveGuestRequest) (*ArriveGuestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArriveGuest not implemented")
+}
+func (UnimplementedArrivalsServer) LeaveGuest(context.Context, *LeaveGuestRequest) (*LeaveGuestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveGuest not implemented")
+}
+func (UnimplementedArrivalsServer) ListArrivedGuests(context.Context, *ListGuestsRequest) (*ListGuestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArrivedGuests not implemented")
+}
+func (UnimplementedArrivalsServer) mustEmbedUnimplementedArrivalsServer() {}
+
+// UnsafeArrivalsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ArrivalsServer will
+// result in compilation errors.
+type UnsafeArrivalsServer interface {
+	mustEmbedUnimplementedArrivalsServer()
+}
+
+func RegisterArrivalsServer(s grpc.ServiceRegistrar, srv ArrivalsServer) {
+	s.RegisterService(&Arrivals_ServiceDesc, srv)
+}
+
+func _Arrivals_ArriveGuest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArriveGuestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArrivalsServer).ArriveGuest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Arrivals/ArriveGuest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArrivalsServer).ArriveGuest(ctx, req.(*ArriveGuestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Arrivals_LeaveGuest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveGuestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArrivalsServer).LeaveGuest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Arrivals/LeaveGuest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArrivalsServer).LeaveGuest(ctx, req.(*LeaveGuestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Arrivals_ListArrivedGuests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGuestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArrivalsServer).ListArrivedGuests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Arrivals/ListArrivedGuests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArrivalsServer).ListArrivedGuests(ctx, req.(*ListGuestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Arrivals_ServiceDesc is the grpc.ServiceDesc for Arrivals service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Arrivals_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.Arrivals",
+	HandlerType: (*ArrivalsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ArriveGuest",
+			Handler:    _Arrivals_ArriveGuest_Handler,
+		},
+		{
+			MethodName: "LeaveGuest",
+			Handler:    _Arrivals_LeaveGuest_Handler,
+		},
+		{
+			MethodName: "ListArrivedGuests",
+			Handler:    _Arrivals_ListArrivedGuests_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "service_guestlist.proto",
+}
+
+// TablesClient is the client API for Tables service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type TablesClient interface {
+	CreateTable(ctx context.Context, in *CreateTableRequest, opts ...grpc.CallOption) (*CreateTableResponse, error)
+	ListTables(ctx context.Context, in *ListTablesRequest, opts ...grpc.CallOption) (*ListTablesResponse, error)
+}
+
+type tablesClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTablesClient(cc grpc.ClientConnInterface) TablesClient {
+	return &tablesClient{cc}
+}
+
+func (c *tablesClient) CreateTable(ctx context.Context, in *CreateTableRequest, opts ...grpc.CallOption) (*CreateTableResponse, error) {
+	out := new(CreateTableResponse)
+	err := c.cc.Invoke(ctx, "/pb.Tables/CreateTable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tablesClient) ListTables(ctx context.Context, in *ListTablesRequest, opts ...grpc.CallOption) (*ListTablesResponse, error) {
+	out := new(ListTablesResponse)
+	err := c.cc.Invoke(ctx, "/pb.Tables/ListTables", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TablesServer is the server API for Tables service.
+// All implementations must embed UnimplementedTablesServer
+// for forward compatibility
+type TablesServer interface {
+	CreateTable(context.Context, *CreateTableRequest) (*CreateTableResponse, error)
+	ListTables(context.Context, *ListTablesRequest) (*ListTablesResponse, error)
+	mustEmbedUnimplementedTablesServer()
+}
+
+// UnimplementedTablesServer must be embedded to have forward compatible implementations.
+type UnimplementedTablesServer struct {
+}
+
+func (UnimplementedTablesServer) CreateTable(context.Context, *CreateTableRequest) (*CreateTableResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTable not implemented")
+}
+func (UnimplementedTablesServer) ListTables(context.Context, *ListTablesRequest) (*ListTablesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTables not implemented")
+}
+func (UnimplementedTablesServer) mustEmbedUnimplementedTablesServer() {}
+
+// UnsafeTablesServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TablesServer will
+// result in compilation errors.
+type UnsafeTablesServer interface {
+	mustEmbedUnimplementedTablesServer()
+}
+
+func RegisterTablesServer(s grpc.ServiceRegistrar, srv TablesServer) {
+	s.RegisterService(&Tables_ServiceDesc, srv)
+}
+
+func _Tables_CreateTable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TablesServer).CreateTable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Tables/CreateTable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TablesServer).CreateTable(ctx, req.(*CreateTableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tables_ListTables_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTablesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TablesServer).ListTables(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Tables/ListTables",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TablesServer).ListTables(ctx, req.(*ListTablesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Tables_ServiceDesc is the grpc.ServiceDesc for Tables service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Tables_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.Tables",
+	HandlerType: (*TablesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTable",
+			Handler:    _Tables_CreateTable_Handler,
+		},
+		{
+			MethodName: "ListTables",
+			Handler:    _Tables_ListTables_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "service_guestlist.proto",
+}
+
+// SeatsClient is the client API for Seats service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SeatsClient interface {
+	GetEmptySeats(ctx context.Context, in *GetEmptySeatsRequest, opts ...grpc.CallOption) (*GetEmptySeatsResponse, error)
+	WatchOccupancy(ctx context.Context, in *WatchOccupancyRequest, opts ...grpc.CallOption) (Seats_WatchOccupancyClient, error)
+}
+
+type seatsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSeatsClient(cc grpc.ClientConnInterface) SeatsClient {
+	return &seatsClient{cc}
+}
+
+func (c *seatsClient) GetEmptySeats(ctx context.Context, in *GetEmptySeatsRequest, opts ...grpc.CallOption) (*GetEmptySeatsResponse, error) {
+	out := new(GetEmptySeatsResponse)
+	err := c.cc.Invoke(ctx, "/pb.Seats/GetEmptySeats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *seatsClient) WatchOccupancy(ctx context.Context, in *WatchOccupancyRequest, opts ...grpc.CallOption) (Seats_WatchOccupancyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Seats_ServiceDesc.Streams[0], "/pb.Seats/WatchOccupancy", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &seatsWatchOccupancyClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Seats_WatchOccupancyClient interface {
+	Recv() (*OccupancyUpdate, error)
+	grpc.ClientStream
+}
+
+type seatsWatchOccupancyClient struct {
+	grpc.ClientStream
+}
+
+func (x *seatsWatchOccupancyClient) Recv() (*OccupancyUpdate, error) {
+	m := new(OccupancyUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SeatsServer is the server API for Seats service.
+// All implementations must embed UnimplementedSeatsServer
+// for forward compatibility
+type SeatsServer interface {
+	GetEmptySeats(context.Context, *GetEmptySeatsRequest) (*GetEmptySeatsResponse, error)
+	WatchOccupancy(*WatchOccupancyRequest, Seats_WatchOccupancyServer) error
+	mustEmbedUnimplementedSeatsServer()
+}
+
+// UnimplementedSeatsServer must be embedded to have forward compatible implementations.
+type UnimplementedSeatsServer struct {
+}
+
+func (UnimplementedSeatsServer) GetEmptySeats(context.Context, *GetEmptySeatsRequest) (*GetEmptySeatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmptySeats not implemented")
+}
+func (UnimplementedSeatsServer) WatchOccupancy(*WatchOccupancyRequest, Seats_WatchOccupancyServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchOccupancy not implemented")
+}
+func (UnimplementedSeatsServer) mustEmbedUnimplementedSeatsServer() {}
+
+// UnsafeSeatsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SeatsServer will
+// result in compilation errors.
+type UnsafeSeatsServer interface {
+	mustEmbedUnimplementedSeatsServer()
+}
+
+func RegisterSeatsServer(s grpc.ServiceRegistrar, srv SeatsServer) {
+	s.RegisterService(&Seats_ServiceDesc, srv)
+}
+
+func _Seats_GetEmptySeats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEmptySeatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SeatsServer).GetEmptySeats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.Seats/GetEmptySeats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SeatsServer).GetEmptySeats(ctx, req.(*GetEmptySeatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Seats_WatchOccupancy_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchOccupancyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SeatsServer).WatchOccupancy(m, &seatsWatchOccupancyServer{stream})
+}
+
+type Seats_WatchOccupancyServer interface {
+	Send(*OccupancyUpdate) error
+	grpc.ServerStream
+}
+
+type seatsWatchOccupancyServer struct {
+	grpc.ServerStream
+}
+
+func (x *seatsWatchOccupancyServer) Send(m *OccupancyUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Seats_ServiceDesc is the grpc.ServiceDesc for Seats service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Seats_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pb.Seats",
+	HandlerType: (*SeatsServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetEmptySeats",
+			Handler:    _Seats_GetEmptySeats_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchOccupancy",
+			Handler:       _Seats_WatchOccupancy_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "service_guestlist.proto",
+}
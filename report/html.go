@@ -0,0 +1,41 @@
+package report
+
+import (
+	"database/sql"
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+)
+
+//go:embed report.html
+var reportHTML string
+
+// page renders the report as a single HTML document, styles included, so it can be saved or
+// emailed as it is
+var page = template.Must(template.New("report").Funcs(template.FuncMap{
+	"datetime": func(t time.Time) string {
+		return t.Format("Mon 2 Jan 2006 15:04 MST")
+	},
+	"clock": func(t time.Time) string {
+		return t.Format("15:04")
+	},
+	"nullclock": func(t sql.NullTime) string {
+		if !t.Valid {
+			return ""
+		}
+		return t.Time.Format("15:04")
+	},
+	"percent": func(f float64) string {
+		return fmt.Sprintf("%.0f%%", f*100)
+	},
+	"decimal": func(f float64) string {
+		return fmt.Sprintf("%.1f", f)
+	},
+}).Parse(reportHTML))
+
+// WriteHTML renders the report as a self-contained HTML document
+func (r Report) WriteHTML(w io.Writer) error {
+	return page.Execute(w, r)
+}
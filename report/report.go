@@ -0,0 +1,525 @@
+// Package report builds the end-of-event report from the guest list, arrivals and tables held in
+// db.Store, for organisers to read back as JSON or a self-contained HTML document
+package report
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+)
+
+// Events on the timeline
+const (
+	EventArrival   = "arrival"
+	EventDeparture = "departure"
+)
+
+// What a capacity violation exceeded
+const (
+	ScopeTable = "table"
+	ScopeZone  = "zone"
+	ScopeVenue = "venue"
+)
+
+// correctionActions are the changes made by hand to the guest list, seating and limits during the
+// event which the report lists as corrections
+var correctionActions = map[string]bool{
+	db.AuditUpdateGuestStatus: true,
+	db.AuditUpdateParty:       true,
+	db.AuditMoveGuest:         true,
+	db.AuditDeleteGuest:       true,
+	db.AuditUpdateCapacity:    true,
+}
+
+// Report summarises an event over its window
+type Report struct {
+	Window             db.EventWindow        `json:"window"`
+	GeneratedAt        time.Time             `json:"generated_at"`
+	Totals             Totals                `json:"totals"`
+	TurnedAway         []TurnedAway          `json:"turned_away"`
+	NoShows            []NoShow              `json:"no_shows"`
+	Tables             []db.TableUtilisation `json:"tables"`
+	Timeline           []TimelineEntry       `json:"timeline"`
+	CapacityViolations []CapacityViolation   `json:"capacity_violations"`
+	Corrections        []Correction          `json:"corrections"`
+}
+
+// Totals are the headline numbers of the event. GuestList is the size of the guest list as it stands
+// when the report is generated, whatever the window. The no-shows are those marked within the
+// window, out of them and the guests booked on the list who arrived in it. Walk-ins are left out of
+// both.
+type Totals struct {
+	GuestList          int64             `json:"guest_list"`
+	Arrivals           int64             `json:"arrivals"`
+	WalkIns            int64             `json:"walk_ins"`
+	StandingParties    int64             `json:"standing_parties"`
+	People             int64             `json:"people"`
+	Departures         int64             `json:"departures"`
+	TurnedAway         int64             `json:"turned_away"`
+	NoShows            db.NoShowSummary  `json:"no_shows"`
+	PeakOccupancy      db.OccupancyPoint `json:"peak_occupancy"`
+	AverageStayMinutes float64           `json:"average_stay_minutes"`
+	CapacityViolations int64             `json:"capacity_violations"`
+	Corrections        int64             `json:"corrections"`
+}
+
+// TurnedAway is a party refused at the door, by the admission policies and rules or because the
+// guest is on the ban list, with the reason for every refusal
+type TurnedAway struct {
+	At        time.Time `json:"at"`
+	GuestName string    `json:"guest_name"`
+	TableID   int32     `json:"table_id"`
+	PartySize int32     `json:"party_size"`
+	DecidedBy string    `json:"decided_by"`
+	Reasons   []string  `json:"reasons"`
+}
+
+// NoShow is a guest marked no-show, whether by the scheduler or by hand, at MarkedAt
+type NoShow struct {
+	GuestID       int32        `json:"guest_id"`
+	GuestName     string       `json:"guest_name"`
+	TableID       int32        `json:"table_id"`
+	PartySize     int32        `json:"party_size"`
+	ExpectedUntil sql.NullTime `json:"expected_until"`
+	MarkedAt      time.Time    `json:"marked_at"`
+}
+
+// TimelineEntry is a party arriving or departing, TableID is 0 for standing parties
+type TimelineEntry struct {
+	At        time.Time `json:"at"`
+	Event     string    `json:"event"`
+	GuestName string    `json:"guest_name"`
+	PartySize int32     `json:"party_size"`
+	TableID   int32     `json:"table_id"`
+	Standing  bool      `json:"standing"`
+	WalkIn    bool      `json:"walk_in"`
+}
+
+// CapacityViolation is a period a table held more people than its size, or a zone or the venue more
+// than its maximum occupancy. Name is the table's label or the zone, and Until isn't valid when it
+// was still over at the end of the window.
+type CapacityViolation struct {
+	Scope   string       `json:"scope"`
+	TableID int32        `json:"table_id"`
+	Name    string       `json:"name"`
+	Limit   int32        `json:"limit"`
+	Peak    int32        `json:"peak"`
+	From    time.Time    `json:"from"`
+	Until   sql.NullTime `json:"until"`
+}
+
+// Correction is a change made by hand during the event, from the audit trail
+type Correction struct {
+	At        time.Time     `json:"at"`
+	Action    string        `json:"action"`
+	Actor     string        `json:"actor"`
+	RequestID string        `json:"request_id"`
+	GuestName string        `json:"guest_name"`
+	TableID   sql.NullInt32 `json:"table_id"`
+	Detail    string        `json:"detail"`
+}
+
+// Build reads everything the report needs from the store. window must have an Until, a zero From
+// opens it at the first arrival or change of occupancy.
+func Build(ctx context.Context, store db.Store, window db.EventWindow) (Report, error) {
+	activity, err := db.LoadEventActivity(ctx, store, window)
+	if err != nil {
+		return Report{}, err
+	}
+	window, arrivals, snapshots := activity.Window, activity.Arrivals, activity.Snapshots
+
+	standing, err := store.ListEventStandingAdmissions(ctx, db.ListEventStandingAdmissionsParams{From: window.From, Until: window.Until})
+	if err != nil {
+		return Report{}, err
+	}
+	denied, err := store.ListEventDeniedAdmissions(ctx, db.ListEventDeniedAdmissionsParams{From: window.From, Until: window.Until})
+	if err != nil {
+		return Report{}, err
+	}
+	events, err := store.ListEventAuditEvents(ctx, db.ListEventAuditEventsParams{From: window.From, Until: window.Until})
+	if err != nil {
+		return Report{}, err
+	}
+	counts, err := store.CountGuestsByStatus(ctx)
+	if err != nil {
+		return Report{}, err
+	}
+	noShows, err := store.ListGuestsWithStatus(ctx, db.GuestNoShow)
+	if err != nil {
+		return Report{}, err
+	}
+	tables, err := store.ListTables(ctx)
+	if err != nil {
+		return Report{}, err
+	}
+	limits, err := store.ListCapacityLimits(ctx)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{
+		Window:             window,
+		GeneratedAt:        time.Now(),
+		TurnedAway:         TurnAway(denied, events),
+		NoShows:            NoShows(noShows, events),
+		Tables:             db.UtiliseTables(tables, snapshots, arrivals, window),
+		Timeline:           Timeline(arrivals, standing, window),
+		CapacityViolations: CapacityViolations(snapshots, tables, limits, window),
+		Corrections:        Corrections(events),
+	}
+
+	report.Totals = Totals{
+		Arrivals:           int64(len(arrivals)),
+		StandingParties:    int64(len(standing)),
+		TurnedAway:         int64(len(report.TurnedAway)),
+		NoShows:            SummariseNoShows(arrivals, report.NoShows),
+		PeakOccupancy:      db.PeakOccupancy(db.OccupancySeries(snapshots, window)),
+		AverageStayMinutes: db.SummariseStays(arrivals).Average.Minutes(),
+		CapacityViolations: int64(len(report.CapacityViolations)),
+		Corrections:        int64(len(report.Corrections)),
+	}
+	for _, count := range counts {
+		report.Totals.GuestList += count.Guests
+	}
+	walkIns := make(map[int32]bool)
+	for _, arrival := range arrivals {
+		report.Totals.People += int64(arrival.PartySize)
+		if arrival.WalkIn {
+			walkIns[arrival.GuestID] = true
+		}
+	}
+	report.Totals.WalkIns = int64(len(walkIns))
+	for _, admission := range standing {
+		report.Totals.People += int64(admission.PartySize)
+	}
+	for _, entry := range report.Timeline {
+		if entry.Event == EventDeparture {
+			report.Totals.Departures++
+		}
+	}
+	return report, nil
+}
+
+// banMatch is the part of the ban_match alert in the audit trail the report reads
+type banMatch struct {
+	Stage  string `json:"stage"`
+	Match  string `json:"match"`
+	Banned struct {
+		Name string `json:"name"`
+	} `json:"banned"`
+}
+
+// TurnAway lists the parties refused at the door in the order they were refused, from the denied
+// admission decisions, one party for each request however many policies denied it, and the ban list
+// alerts raised on arrival
+func TurnAway(denied []db.AdmissionLog, events []db.AuditEvent) []TurnedAway {
+	turnedAway := []TurnedAway{}
+	requests := make(map[string]int)
+	for _, decision := range denied {
+		key := fmt.Sprintf("%s/%d", decision.RequestID, decision.GuestID)
+		if i, ok := requests[key]; ok {
+			turnedAway[i].Reasons = append(turnedAway[i].Reasons, decision.Reason)
+			continue
+		}
+		requests[key] = len(turnedAway)
+		turnedAway = append(turnedAway, TurnedAway{
+			At:        decision.DecidedAt,
+			GuestName: decision.GuestName,
+			TableID:   decision.TableID,
+			PartySize: decision.PartySize,
+			DecidedBy: decision.DecidedBy,
+			Reasons:   []string{decision.Reason},
+		})
+	}
+
+	for _, event := range events {
+		if event.Action != db.AuditBanMatch {
+			continue
+		}
+		var match banMatch
+		if err := json.Unmarshal(event.After, &match); err != nil || match.Stage != db.BanStageArrival {
+			continue
+		}
+		turnedAway = append(turnedAway, TurnedAway{
+			At:        event.CreatedAt,
+			GuestName: event.GuestName,
+			TableID:   event.TableID.Int32,
+			DecidedBy: event.Actor,
+			Reasons:   []string{fmt.Sprintf("on the ban list, matching %s by %s", match.Banned.Name, match.Match)},
+		})
+	}
+
+	sort.SliceStable(turnedAway, func(i, j int) bool {
+		return turnedAway[i].At.Before(turnedAway[j].At)
+	})
+	return turnedAway
+}
+
+// Timeline lists every arrival in the window and every departure within it, seated and standing,
+// in the order they happened
+func Timeline(arrivals []db.ListEventArrivalsRow, standing []db.StandingAdmission, window db.EventWindow) []TimelineEntry {
+	timeline := []TimelineEntry{}
+	departed := func(at sql.NullTime) bool {
+		return at.Valid && at.Time.Before(window.Until)
+	}
+
+	for _, arrival := range arrivals {
+		entry := TimelineEntry{
+			At:        arrival.ArrivedAt,
+			Event:     EventArrival,
+			GuestName: arrival.GuestName,
+			PartySize: arrival.PartySize,
+			TableID:   arrival.TableID,
+			WalkIn:    arrival.WalkIn,
+		}
+		timeline = append(timeline, entry)
+		if departed(arrival.DepartedAt) {
+			entry.At, entry.Event = arrival.DepartedAt.Time, EventDeparture
+			timeline = append(timeline, entry)
+		}
+	}
+	for _, admission := range standing {
+		entry := TimelineEntry{
+			At:        admission.AdmittedAt,
+			Event:     EventArrival,
+			GuestName: admission.Name,
+			PartySize: admission.PartySize,
+			Standing:  true,
+		}
+		timeline = append(timeline, entry)
+		if departed(admission.DepartedAt) {
+			entry.At, entry.Event = admission.DepartedAt.Time, EventDeparture
+			timeline = append(timeline, entry)
+		}
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].At.Before(timeline[j].At)
+	})
+	return timeline
+}
+
+// CapacityViolations replays the occupancy snapshots to find every period within the window a table
+// held more people than its size, which the table_capacity policy allows when overbooking, or a zone
+// or the venue was over its maximum occupancy. Limits are compared as they are now, so one lowered
+// during the event counts against the whole of it.
+func CapacityViolations(snapshots []db.OccupancySnapshot, tables []db.Table, limits []db.CapacityLimit, window db.EventWindow) []CapacityViolation {
+	type key struct {
+		scope string
+		name  string
+	}
+	limitOf := make(map[string]int32, len(limits))
+	for _, limit := range limits {
+		limitOf[limit.Zone] = limit.MaxOccupancy
+	}
+	tableOf := make(map[int32]db.Table, len(tables))
+	for _, table := range tables {
+		tableOf[table.ID] = table
+	}
+
+	violations := []CapacityViolation{}
+	open := make(map[key]*CapacityViolation)
+	check := func(k key, violation CapacityViolation, occupancy int32, at time.Time) {
+		current, over := open[k], occupancy > violation.Limit
+		switch {
+		case over && current == nil:
+			violation.Peak, violation.From = occupancy, at
+			if at.Before(window.From) {
+				violation.From = window.From
+			}
+			open[k] = &violation
+		case over:
+			// Before the window only whatever it was over by when the window opened counts
+			if at.Before(window.From) || occupancy > current.Peak {
+				current.Peak = occupancy
+			}
+		case current != nil:
+			// Periods which ended before the window opened aren't part of the event
+			if at.After(window.From) {
+				current.Until = sql.NullTime{Time: at, Valid: true}
+				violations = append(violations, *current)
+			}
+			delete(open, k)
+		}
+	}
+
+	occupied := make(map[int32]int32)
+	zones := make(map[string]int32)
+	for _, snapshot := range snapshots {
+		if !snapshot.RecordedAt.Before(window.Until) {
+			continue
+		}
+		at := snapshot.RecordedAt
+
+		if table, ok := tableOf[snapshot.TableID.Int32]; snapshot.TableID.Valid && ok {
+			zones[table.Zone] += snapshot.TableOccupied - occupied[table.ID]
+			occupied[table.ID] = snapshot.TableOccupied
+
+			check(key{ScopeTable, strconv.Itoa(int(table.ID))}, CapacityViolation{
+				Scope:   ScopeTable,
+				TableID: table.ID,
+				Name:    table.Label,
+				Limit:   table.Size,
+			}, snapshot.TableOccupied, at)
+			if limit := limitOf[table.Zone]; table.Zone != "" && limit > 0 {
+				check(key{ScopeZone, table.Zone}, CapacityViolation{
+					Scope: ScopeZone,
+					Name:  table.Zone,
+					Limit: limit,
+				}, zones[table.Zone], at)
+			}
+		}
+		if limit := limitOf[""]; limit > 0 {
+			check(key{ScopeVenue, ""}, CapacityViolation{Scope: ScopeVenue, Limit: limit}, snapshot.VenueOccupancy, at)
+		}
+	}
+
+	for _, violation := range open {
+		violations = append(violations, *violation)
+	}
+	sort.SliceStable(violations, func(i, j int) bool {
+		if !violations[i].From.Equal(violations[j].From) {
+			return violations[i].From.Before(violations[j].From)
+		}
+		return violations[i].Scope+violations[i].Name < violations[j].Scope+violations[j].Name
+	})
+	return violations
+}
+
+// guestChange is the part of the guest states in the audit trail the report reads
+type guestChange struct {
+	Guest struct {
+		Status  string `json:"status"`
+		TableID int32  `json:"table_id"`
+	} `json:"guest"`
+	Arrival *struct {
+		PartySize int32 `json:"party_size"`
+	} `json:"arrival"`
+}
+
+// NoShows lists the guests who are still no-shows and were marked so within the window, by the
+// scheduler or by hand, going by the audit events of the window. A guest marked more than once is
+// listed at the last time.
+func NoShows(guests []db.Guest, events []db.AuditEvent) []NoShow {
+	marked := make(map[int32]time.Time)
+	for _, event := range events {
+		if !event.GuestID.Valid {
+			continue
+		}
+		switch event.Action {
+		case db.AuditMarkNoShow:
+		case db.AuditUpdateGuestStatus:
+			var after guestChange
+			if json.Unmarshal(event.After, &after) != nil || after.Guest.Status != db.GuestNoShow {
+				continue
+			}
+		default:
+			continue
+		}
+		marked[event.GuestID.Int32] = event.CreatedAt
+	}
+
+	noShows := []NoShow{}
+	for _, guest := range guests {
+		at, ok := marked[guest.ID]
+		if !ok {
+			continue
+		}
+		noShows = append(noShows, NoShow{
+			GuestID:       guest.ID,
+			GuestName:     guest.GuestName,
+			TableID:       guest.TableID,
+			PartySize:     guest.Entourage + 1,
+			ExpectedUntil: guest.ExpectedUntil,
+			MarkedAt:      at,
+		})
+	}
+	return noShows
+}
+
+// SummariseNoShows works out the no-show rate of the window from the guests booked on the list who
+// arrived in it, each counted once however often they came and went, and those marked no-show in it
+func SummariseNoShows(arrivals []db.ListEventArrivalsRow, noShows []NoShow) db.NoShowSummary {
+	arrived := make(map[int32]bool)
+	for _, arrival := range arrivals {
+		if !arrival.WalkIn {
+			arrived[arrival.GuestID] = true
+		}
+	}
+
+	summary := db.NoShowSummary{
+		Expected: int64(len(arrived) + len(noShows)),
+		NoShows:  int64(len(noShows)),
+	}
+	if summary.Expected > 0 {
+		summary.Rate = float64(summary.NoShows) / float64(summary.Expected)
+	}
+	return summary
+}
+
+// Corrections lists the changes made by hand in the audit trail, describing what each changed
+func Corrections(events []db.AuditEvent) []Correction {
+	corrections := []Correction{}
+	for _, event := range events {
+		if !correctionActions[event.Action] {
+			continue
+		}
+		corrections = append(corrections, Correction{
+			At:        event.CreatedAt,
+			Action:    event.Action,
+			Actor:     event.Actor,
+			RequestID: event.RequestID,
+			GuestName: event.GuestName,
+			TableID:   event.TableID,
+			Detail:    describe(event),
+		})
+	}
+	return corrections
+}
+
+// describe says what the audit event changed, or nothing if its states can't be read
+func describe(event db.AuditEvent) string {
+	if event.Action == db.AuditUpdateCapacity {
+		var before, after db.CapacityLimit
+		if json.Unmarshal(event.After, &after) != nil {
+			return ""
+		}
+		scope := "venue"
+		if after.Zone != "" {
+			scope = "zone " + after.Zone
+		}
+		// Without a before the scope had no limit
+		if event.Before == nil || json.Unmarshal(event.Before, &before) != nil {
+			return fmt.Sprintf("%s limit set to %d", scope, after.MaxOccupancy)
+		}
+		return fmt.Sprintf("%s limit %d to %d", scope, before.MaxOccupancy, after.MaxOccupancy)
+	}
+
+	var before, after guestChange
+	if json.Unmarshal(event.Before, &before) != nil {
+		return ""
+	}
+	if event.Action == db.AuditDeleteGuest {
+		return "removed from the guest list"
+	}
+	if json.Unmarshal(event.After, &after) != nil {
+		return ""
+	}
+	switch event.Action {
+	case db.AuditUpdateGuestStatus:
+		return fmt.Sprintf("status %s to %s", before.Guest.Status, after.Guest.Status)
+	case db.AuditUpdateParty:
+		if before.Arrival != nil && after.Arrival != nil {
+			return fmt.Sprintf("party of %d to %d", before.Arrival.PartySize, after.Arrival.PartySize)
+		}
+	case db.AuditMoveGuest:
+		return fmt.Sprintf("table %d to %d", before.Guest.TableID, after.Guest.TableID)
+	}
+	return ""
+}
@@ -0,0 +1,261 @@
+package report
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+var eventStart = time.Date(2026, 10, 19, 19, 0, 0, 0, time.UTC)
+
+func minutesIn(minutes int) time.Time {
+	return eventStart.Add(time.Duration(minutes) * time.Minute)
+}
+
+func at(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: true}
+}
+
+func tableID(id int32) sql.NullInt32 {
+	return sql.NullInt32{Int32: id, Valid: true}
+}
+
+func TestTurnAway(t *testing.T) {
+	denied := []db.AdmissionLog{
+		{GuestID: 1, GuestName: "Late Guest", TableID: 2, PartySize: 3, Policy: "doors_close", Reason: "doors closed", RequestID: "a", DecidedBy: "door_staff", DecidedAt: minutesIn(30)},
+		{GuestID: 1, GuestName: "Late Guest", TableID: 2, PartySize: 3, Policy: "rsvp_limit", Reason: "party too big", RequestID: "a", DecidedBy: "door_staff", DecidedAt: minutesIn(30)},
+		{GuestID: 1, GuestName: "Late Guest", TableID: 2, PartySize: 2, Policy: "doors_close", Reason: "doors closed", RequestID: "b", DecidedBy: "door_staff", DecidedAt: minutesIn(40)},
+	}
+	events := []db.AuditEvent{
+		{Action: db.AuditBanMatch, GuestName: "Banned Guest", TableID: tableID(4), Actor: "door_staff", CreatedAt: minutesIn(10),
+			After: json.RawMessage(`{"stage":"arrival","match":"email","banned":{"name":"Banned Person"}}`)},
+		// Guests caught being booked never got to the door
+		{Action: db.AuditBanMatch, GuestName: "Booked Guest", Actor: "organiser", CreatedAt: minutesIn(20),
+			After: json.RawMessage(`{"stage":"booking","match":"name","banned":{"name":"Booked Guest"}}`)},
+		{Action: db.AuditArriveGuest, GuestName: "Arrived Guest", CreatedAt: minutesIn(25)},
+	}
+
+	turnedAway := TurnAway(denied, events)
+	require.Equal(t, []TurnedAway{
+		{At: minutesIn(10), GuestName: "Banned Guest", TableID: 4, DecidedBy: "door_staff",
+			Reasons: []string{"on the ban list, matching Banned Person by email"}},
+		{At: minutesIn(30), GuestName: "Late Guest", TableID: 2, PartySize: 3, DecidedBy: "door_staff",
+			Reasons: []string{"doors closed", "party too big"}},
+		{At: minutesIn(40), GuestName: "Late Guest", TableID: 2, PartySize: 2, DecidedBy: "door_staff",
+			Reasons: []string{"doors closed"}},
+	}, turnedAway)
+}
+
+func TestTimeline(t *testing.T) {
+	arrivals := []db.ListEventArrivalsRow{
+		{GuestName: "First Guest", TableID: 1, PartySize: 2, ArrivedAt: minutesIn(0), DepartedAt: at(minutesIn(50))},
+		{GuestName: "Walk In", TableID: 2, PartySize: 1, ArrivedAt: minutesIn(20), WalkIn: true},
+		// Departures after the window closed aren't part of it
+		{GuestName: "Late Leaver", TableID: 1, PartySize: 3, ArrivedAt: minutesIn(30), DepartedAt: at(minutesIn(90))},
+	}
+	standing := []db.StandingAdmission{
+		{Name: "Standing Party", PartySize: 4, AdmittedAt: minutesIn(10), DepartedAt: at(minutesIn(40))},
+	}
+
+	timeline := Timeline(arrivals, standing, db.EventWindow{From: minutesIn(0), Until: minutesIn(60)})
+	require.Equal(t, []TimelineEntry{
+		{At: minutesIn(0), Event: EventArrival, GuestName: "First Guest", PartySize: 2, TableID: 1},
+		{At: minutesIn(10), Event: EventArrival, GuestName: "Standing Party", PartySize: 4, Standing: true},
+		{At: minutesIn(20), Event: EventArrival, GuestName: "Walk In", PartySize: 1, TableID: 2, WalkIn: true},
+		{At: minutesIn(30), Event: EventArrival, GuestName: "Late Leaver", PartySize: 3, TableID: 1},
+		{At: minutesIn(40), Event: EventDeparture, GuestName: "Standing Party", PartySize: 4, Standing: true},
+		{At: minutesIn(50), Event: EventDeparture, GuestName: "First Guest", PartySize: 2, TableID: 1},
+	}, timeline)
+}
+
+func TestCapacityViolations(t *testing.T) {
+	tables := []db.Table{
+		{ID: 1, Label: "Booth A", Size: 4, Zone: "terrace"},
+		{ID: 2, Label: "Booth B", Size: 4, Zone: "terrace"},
+	}
+	limits := []db.CapacityLimit{
+		{Zone: "", MaxOccupancy: 10},
+		{Zone: "terrace", MaxOccupancy: 6},
+	}
+	snapshots := []db.OccupancySnapshot{
+		// Booth A was already overbooked when the window opened
+		{TableID: tableID(1), TableOccupied: 5, VenueOccupancy: 5, RecordedAt: minutesIn(-10)},
+		{TableID: tableID(2), TableOccupied: 3, VenueOccupancy: 8, RecordedAt: minutesIn(10)},
+		{TableOccupied: 0, VenueOccupancy: 12, RecordedAt: minutesIn(15)},
+		{TableID: tableID(1), TableOccupied: 4, VenueOccupancy: 11, RecordedAt: minutesIn(20)},
+		{TableID: tableID(2), TableOccupied: 1, VenueOccupancy: 9, RecordedAt: minutesIn(30)},
+	}
+
+	violations := CapacityViolations(snapshots, tables, limits, db.EventWindow{From: minutesIn(0), Until: minutesIn(60)})
+	require.Equal(t, []CapacityViolation{
+		{Scope: ScopeTable, TableID: 1, Name: "Booth A", Limit: 4, Peak: 5, From: minutesIn(0), Until: at(minutesIn(20))},
+		{Scope: ScopeZone, Name: "terrace", Limit: 6, Peak: 8, From: minutesIn(10), Until: at(minutesIn(30))},
+		{Scope: ScopeVenue, Limit: 10, Peak: 12, From: minutesIn(15), Until: at(minutesIn(30))},
+	}, violations)
+
+	// A violation still going when the window closes has no end
+	violations = CapacityViolations(snapshots[:2], tables, limits, db.EventWindow{From: minutesIn(0), Until: minutesIn(60)})
+	require.Len(t, violations, 2)
+	require.False(t, violations[0].Until.Valid)
+}
+
+func TestCorrections(t *testing.T) {
+	events := []db.AuditEvent{
+		{Action: db.AuditUpdateGuestStatus, Actor: "organiser", GuestName: "Guest One", CreatedAt: minutesIn(5),
+			Before: json.RawMessage(`{"guest":{"status":"confirmed"}}`), After: json.RawMessage(`{"guest":{"status":"cancelled"}}`)},
+		{Action: db.AuditArriveGuest, GuestName: "Guest Two", CreatedAt: minutesIn(6)},
+		{Action: db.AuditUpdateParty, Actor: "door_staff", GuestName: "Guest Two", CreatedAt: minutesIn(7),
+			Before: json.RawMessage(`{"guest":{},"arrival":{"party_size":2}}`), After: json.RawMessage(`{"guest":{},"arrival":{"party_size":4}}`)},
+		{Action: db.AuditMoveGuest, Actor: "door_staff", GuestName: "Guest Two", CreatedAt: minutesIn(8),
+			Before: json.RawMessage(`{"guest":{"table_id":1}}`), After: json.RawMessage(`{"guest":{"table_id":3}}`)},
+		{Action: db.AuditDeleteGuest, Actor: "organiser", GuestName: "Guest Three", CreatedAt: minutesIn(9),
+			Before: json.RawMessage(`{"guest":{}}`)},
+		{Action: db.AuditUpdateCapacity, Actor: "organiser", CreatedAt: minutesIn(10),
+			After: json.RawMessage(`{"zone":"terrace","max_occupancy":40}`)},
+		{Action: db.AuditUpdateCapacity, Actor: "organiser", CreatedAt: minutesIn(11),
+			Before: json.RawMessage(`{"zone":"","max_occupancy":100}`), After: json.RawMessage(`{"zone":"","max_occupancy":80}`)},
+	}
+
+	corrections := Corrections(events)
+	details := make([]string, 0, len(corrections))
+	for _, correction := range corrections {
+		details = append(details, correction.Detail)
+	}
+	require.Equal(t, []string{
+		"status confirmed to cancelled",
+		"party of 2 to 4",
+		"table 1 to 3",
+		"removed from the guest list",
+		"zone terrace limit set to 40",
+		"venue limit 100 to 80",
+	}, details)
+}
+
+func TestNoShows(t *testing.T) {
+	guestID := func(id int32) sql.NullInt32 {
+		return sql.NullInt32{Int32: id, Valid: true}
+	}
+	guests := []db.Guest{
+		{ID: 1, GuestName: "Marked By Scheduler", TableID: 1, Entourage: 1, Status: db.GuestNoShow},
+		{ID: 2, GuestName: "Marked By Hand", TableID: 2, Status: db.GuestNoShow},
+		{ID: 3, GuestName: "Marked Before The Window", TableID: 2, Status: db.GuestNoShow},
+	}
+	events := []db.AuditEvent{
+		{Action: db.AuditMarkNoShow, GuestID: guestID(1), CreatedAt: minutesIn(20)},
+		{Action: db.AuditUpdateGuestStatus, GuestID: guestID(2), CreatedAt: minutesIn(25),
+			After: json.RawMessage(`{"guest":{"status":"cancelled"}}`)},
+		{Action: db.AuditUpdateGuestStatus, GuestID: guestID(2), CreatedAt: minutesIn(40),
+			After: json.RawMessage(`{"guest":{"status":"no_show"}}`)},
+		// Marked no-show and then turned up late, they're no longer a no-show
+		{Action: db.AuditMarkNoShow, GuestID: guestID(4), CreatedAt: minutesIn(20)},
+	}
+
+	require.Equal(t, []NoShow{
+		{GuestID: 1, GuestName: "Marked By Scheduler", TableID: 1, PartySize: 2, MarkedAt: minutesIn(20)},
+		{GuestID: 2, GuestName: "Marked By Hand", TableID: 2, PartySize: 1, MarkedAt: minutesIn(40)},
+	}, NoShows(guests, events))
+}
+
+func TestSummariseNoShows(t *testing.T) {
+	arrivals := []db.ListEventArrivalsRow{
+		{ID: 1, GuestID: 1, ArrivedAt: minutesIn(5)},
+		// Coming back in doesn't count them twice
+		{ID: 2, GuestID: 1, ArrivedAt: minutesIn(30)},
+		{ID: 3, GuestID: 2, ArrivedAt: minutesIn(10)},
+		{ID: 4, GuestID: 3, ArrivedAt: minutesIn(15), WalkIn: true},
+	}
+	noShows := []NoShow{{GuestID: 4, MarkedAt: minutesIn(20)}}
+
+	require.Equal(t, db.NoShowSummary{Expected: 3, NoShows: 1, Rate: 1.0 / 3}, SummariseNoShows(arrivals, noShows))
+	require.Equal(t, db.NoShowSummary{}, SummariseNoShows(nil, nil))
+}
+
+func TestBuild(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	until := minutesIn(60)
+	arrivals := []db.ListEventArrivalsRow{
+		{ID: 1, GuestID: 1, GuestName: "First Guest", TableID: 1, PartySize: 2, ArrivedAt: minutesIn(5), DepartedAt: at(minutesIn(45))},
+		{ID: 2, GuestID: 2, GuestName: "Walk In", TableID: 1, PartySize: 1, ArrivedAt: minutesIn(15), WalkIn: true},
+	}
+	snapshots := []db.OccupancySnapshot{
+		{TableID: tableID(1), TableOccupied: 2, VenueOccupancy: 2, RecordedAt: minutesIn(5)},
+		{TableID: tableID(1), TableOccupied: 3, VenueOccupancy: 3, RecordedAt: minutesIn(15)},
+		{TableID: tableID(1), TableOccupied: 1, VenueOccupancy: 1, RecordedAt: minutesIn(45)},
+	}
+	// The window opens at the first arrival
+	window := db.EventWindow{From: minutesIn(5), Until: until}
+
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().ListEventArrivals(gomock.Any(), gomock.Eq(db.ListEventArrivalsParams{Until: until})).Times(1).Return(arrivals, nil)
+	store.EXPECT().ListLastOccupancySnapshots(gomock.Any(), gomock.Eq(time.Time{})).Times(1).Return([]db.OccupancySnapshot{}, nil)
+	store.EXPECT().ListOccupancySnapshots(gomock.Any(), gomock.Eq(db.ListOccupancySnapshotsParams{Until: until})).Times(1).Return(snapshots, nil)
+	store.EXPECT().ListEventStandingAdmissions(gomock.Any(), gomock.Eq(db.ListEventStandingAdmissionsParams(window))).Times(1).
+		Return([]db.StandingAdmission{{Name: "Standing Party", PartySize: 4, AdmittedAt: minutesIn(20)}}, nil)
+	store.EXPECT().ListEventDeniedAdmissions(gomock.Any(), gomock.Eq(db.ListEventDeniedAdmissionsParams(window))).Times(1).
+		Return([]db.AdmissionLog{{GuestID: 3, GuestName: "Late Guest", Reason: "doors closed", RequestID: "a", DecidedAt: minutesIn(50)}}, nil)
+	store.EXPECT().ListEventAuditEvents(gomock.Any(), gomock.Eq(db.ListEventAuditEventsParams(window))).Times(1).Return([]db.AuditEvent{
+		{Action: db.AuditMarkNoShow, GuestID: sql.NullInt32{Int32: 4, Valid: true}, GuestName: "Missing Guest", CreatedAt: minutesIn(30)},
+	}, nil)
+	store.EXPECT().CountGuestsByStatus(gomock.Any()).Times(1).Return([]db.CountGuestsByStatusRow{
+		{Status: db.GuestInvited, Guests: 1},
+		{Status: db.GuestLeft, Guests: 1},
+		{Status: db.GuestNoShow, Guests: 3},
+	}, nil)
+	store.EXPECT().ListGuestsWithStatus(gomock.Any(), gomock.Eq(db.GuestNoShow)).Times(1).
+		Return([]db.Guest{{ID: 4, GuestName: "Missing Guest", TableID: 1, Entourage: 1, Status: db.GuestNoShow}}, nil)
+	store.EXPECT().ListTables(gomock.Any()).Times(1).Return([]db.Table{{ID: 1, Label: "Booth A", Size: 4}}, nil)
+	store.EXPECT().ListCapacityLimits(gomock.Any()).Times(1).Return([]db.CapacityLimit{}, nil)
+
+	report, err := Build(context.Background(), store, db.EventWindow{Until: until})
+	require.NoError(t, err)
+	require.Equal(t, window, report.Window)
+	// The guest list is counted as it stands, the no-shows only within the window
+	require.Equal(t, Totals{
+		GuestList:          5,
+		Arrivals:           2,
+		WalkIns:            1,
+		StandingParties:    1,
+		People:             7,
+		Departures:         1,
+		TurnedAway:         1,
+		NoShows:            db.NoShowSummary{Expected: 2, NoShows: 1, Rate: 0.5},
+		PeakOccupancy:      db.OccupancyPoint{At: minutesIn(15), Occupancy: 3},
+		AverageStayMinutes: 40,
+	}, report.Totals)
+	require.Equal(t, []NoShow{{GuestID: 4, GuestName: "Missing Guest", TableID: 1, PartySize: 2, MarkedAt: minutesIn(30)}}, report.NoShows)
+	require.Len(t, report.Tables, 1)
+	require.Len(t, report.Timeline, 4)
+	require.Empty(t, report.CapacityViolations)
+	require.Empty(t, report.Corrections)
+
+	var page bytes.Buffer
+	require.NoError(t, report.WriteHTML(&page))
+	require.Contains(t, page.String(), "<!DOCTYPE html>")
+	require.Contains(t, page.String(), "Missing Guest")
+	require.Contains(t, page.String(), "doors closed")
+	require.Contains(t, page.String(), "Booth A")
+}
+
+func TestWriteHTMLEscapes(t *testing.T) {
+	report := Report{
+		Window:     db.EventWindow{From: minutesIn(0), Until: minutesIn(60)},
+		NoShows:    []NoShow{{GuestName: "<script>alert(1)</script>"}},
+		TurnedAway: []TurnedAway{},
+	}
+
+	var page bytes.Buffer
+	require.NoError(t, report.WriteHTML(&page))
+	require.NotContains(t, page.String(), "<script>")
+	require.Contains(t, page.String(), "&lt;script&gt;")
+	require.Contains(t, page.String(), "Nobody was turned away.")
+}
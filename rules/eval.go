@@ -0,0 +1,238 @@
+package rules
+
+import (
+	"fmt"
+	"time"
+)
+
+// node is a parsed expression. check returns the type it evaluates to, eval relies on it having
+// been checked so only fails when a value is missing or of the wrong type.
+type node interface {
+	pos() int
+	check(vars Vars) (Type, error)
+	eval(values Values) (interface{}, error)
+}
+
+type literal struct {
+	p     int
+	value interface{}
+}
+
+func (n *literal) pos() int {
+	return n.p
+}
+
+func (n *literal) check(vars Vars) (Type, error) {
+	t, _ := TypeOf(n.value)
+	return t, nil
+}
+
+func (n *literal) eval(values Values) (interface{}, error) {
+	return n.value, nil
+}
+
+type variable struct {
+	p    int
+	name string
+}
+
+func (n *variable) pos() int {
+	return n.p
+}
+
+func (n *variable) check(vars Vars) (Type, error) {
+	t, ok := vars[n.name]
+	if !ok {
+		return 0, errorAt(n.p, "unknown variable %q", n.name)
+	}
+	return t, nil
+}
+
+func (n *variable) eval(values Values) (interface{}, error) {
+	v, ok := values[n.name]
+	if !ok {
+		return nil, fmt.Errorf("no value for %s", n.name)
+	}
+	if _, ok := TypeOf(v); !ok {
+		return nil, fmt.Errorf("%s is a %T, which rules can't hold", n.name, v)
+	}
+	return v, nil
+}
+
+type unary struct {
+	p  int
+	op string
+	x  node
+}
+
+func (n *unary) pos() int {
+	return n.p
+}
+
+func (n *unary) check(vars Vars) (Type, error) {
+	t, err := n.x.check(vars)
+	if err != nil {
+		return 0, err
+	}
+	want := Int
+	if n.op == "not" {
+		want = Bool
+	}
+	if t != want {
+		return 0, errorAt(n.p, "%s needs a %s, not a %s", n.op, want, t)
+	}
+	return t, nil
+}
+
+func (n *unary) eval(values Values) (interface{}, error) {
+	x, err := n.x.eval(values)
+	if err != nil {
+		return nil, err
+	}
+	switch x := x.(type) {
+	case bool:
+		return !x, nil
+	case int64:
+		return -x, nil
+	}
+	return nil, fmt.Errorf("%s of a %T", n.op, x)
+}
+
+type binary struct {
+	p    int
+	op   string
+	x, y node
+}
+
+func (n *binary) pos() int {
+	return n.p
+}
+
+func (n *binary) check(vars Vars) (Type, error) {
+	x, err := n.x.check(vars)
+	if err != nil {
+		return 0, err
+	}
+	y, err := n.y.check(vars)
+	if err != nil {
+		return 0, err
+	}
+	if x != y {
+		return 0, errorAt(n.p, "%s of a %s and a %s", n.op, x, y)
+	}
+
+	switch n.op {
+	case "and", "or":
+		if x != Bool {
+			return 0, errorAt(n.p, "%s needs bools, not %ss", n.op, x)
+		}
+		return Bool, nil
+	case "+", "-":
+		if x != Int {
+			return 0, errorAt(n.p, "%s needs ints, not %ss", n.op, x)
+		}
+		return Int, nil
+	case "<", "<=", ">", ">=":
+		if x != Int && x != Time {
+			return 0, errorAt(n.p, "%s needs ints or times, not %ss", n.op, x)
+		}
+	}
+	return Bool, nil
+}
+
+func (n *binary) eval(values Values) (interface{}, error) {
+	x, err := n.x.eval(values)
+	if err != nil {
+		return nil, err
+	}
+
+	// and and or only evaluate the right hand side when they need to
+	switch n.op {
+	case "and", "or":
+		b, ok := x.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s of a %T", n.op, x)
+		}
+		if b == (n.op == "or") {
+			return b, nil
+		}
+		y, err := n.y.eval(values)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := y.(bool); !ok {
+			return nil, fmt.Errorf("%s of a %T", n.op, y)
+		}
+		return y, nil
+	}
+
+	y, err := n.y.eval(values)
+	if err != nil {
+		return nil, err
+	}
+	cmp, err := compare(x, y)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", n.op, err)
+	}
+
+	switch n.op {
+	case "+":
+		return x.(int64) + y.(int64), nil
+	case "-":
+		return x.(int64) - y.(int64), nil
+	case "==":
+		return cmp == 0, nil
+	case "!=":
+		return cmp != 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	default:
+		return cmp >= 0, nil
+	}
+}
+
+// compare returns -1, 0 or 1 as x is less than, equal to or greater than y, which must be of the
+// same type. Strings and bools are only ever equal or not, 1 standing for not equal.
+func compare(x, y interface{}) (int, error) {
+	switch x := x.(type) {
+	case int64:
+		if y, ok := y.(int64); ok {
+			switch {
+			case x < y:
+				return -1, nil
+			case x > y:
+				return 1, nil
+			}
+			return 0, nil
+		}
+	case time.Time:
+		if y, ok := y.(time.Time); ok {
+			switch {
+			case x.Before(y):
+				return -1, nil
+			case x.After(y):
+				return 1, nil
+			}
+			return 0, nil
+		}
+	case string:
+		if y, ok := y.(string); ok {
+			if x == y {
+				return 0, nil
+			}
+			return 1, nil
+		}
+	case bool:
+		if y, ok := y.(bool); ok {
+			if x == y {
+				return 0, nil
+			}
+			return 1, nil
+		}
+	}
+	return 0, fmt.Errorf("can't compare a %T with a %T", x, y)
+}
@@ -0,0 +1,257 @@
+package rules
+
+import (
+	"strconv"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenInt
+	tokenString
+	tokenOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// keywords can't be used as variable names
+var keywords = map[string]bool{
+	"deny": true, "if": true, "and": true, "or": true, "not": true, "true": true, "false": true,
+}
+
+// lex splits text into tokens, identifiers include the dots between their parts e.g. guest.tier
+func lex(text string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(text); {
+		c := text[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case isLetter(c):
+			start := i
+			for i < len(text) && (isLetter(text[i]) || isDigit(text[i]) || text[i] == '.') {
+				i++
+			}
+			name := text[start:i]
+			if strings.HasSuffix(name, ".") || strings.Contains(name, "..") {
+				return nil, errorAt(start, "malformed name %q", name)
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: name, pos: start})
+		case isDigit(c):
+			start := i
+			for i < len(text) && isDigit(text[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenInt, text: text[start:i], pos: start})
+		case c == '"':
+			start := i
+			for i++; i < len(text) && text[i] != '"'; i++ {
+				if text[i] == '\\' {
+					i++
+				}
+			}
+			if i >= len(text) {
+				return nil, errorAt(start, "unterminated string")
+			}
+			i++
+			tokens = append(tokens, token{kind: tokenString, text: text[start:i], pos: start})
+		default:
+			op := ""
+			for _, candidate := range []string{"==", "!=", "<=", ">=", "<", ">", "+", "-", "(", ")"} {
+				if strings.HasPrefix(text[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, errorAt(i, "unexpected character %q", c)
+			}
+			tokens = append(tokens, token{kind: tokenOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, text: "end of rule", pos: len(text)}), nil
+}
+
+func isLetter(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// parser is a recursive descent parser, lowest precedence first: or, and, not, comparisons, + and -
+type parser struct {
+	tokens []token
+	next   int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.next]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.next]
+	if tok.kind != tokenEOF {
+		p.next++
+	}
+	return tok
+}
+
+// accept consumes the next token if it's the keyword or operator text
+func (p *parser) accept(text string) (token, bool) {
+	tok := p.peek()
+	if (tok.kind == tokenIdent || tok.kind == tokenOp) && tok.text == text {
+		return p.advance(), true
+	}
+	return tok, false
+}
+
+func (p *parser) expectKeyword(keyword string) error {
+	if tok, ok := p.accept(keyword); !ok {
+		return errorAt(tok.pos, "expected %q, not %q", keyword, tok.text)
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	x, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.accept("or")
+		if !ok {
+			return x, nil
+		}
+		y, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		x = &binary{p: tok.pos, op: "or", x: x, y: y}
+	}
+}
+
+func (p *parser) parseAnd() (node, error) {
+	x, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.accept("and")
+		if !ok {
+			return x, nil
+		}
+		y, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		x = &binary{p: tok.pos, op: "and", x: x, y: y}
+	}
+}
+
+func (p *parser) parseNot() (node, error) {
+	if tok, ok := p.accept("not"); ok {
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &unary{p: tok.pos, op: "not", x: x}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	x, err := p.parseSum()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if tok, ok := p.accept(op); ok {
+			y, err := p.parseSum()
+			if err != nil {
+				return nil, err
+			}
+			return &binary{p: tok.pos, op: op, x: x, y: y}, nil
+		}
+	}
+	return x, nil
+}
+
+func (p *parser) parseSum() (node, error) {
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok, ok := p.accept("+")
+		if !ok {
+			tok, ok = p.accept("-")
+		}
+		if !ok {
+			return x, nil
+		}
+		y, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		x = &binary{p: tok.pos, op: tok.text, x: x, y: y}
+	}
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if tok, ok := p.accept("-"); ok {
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unary{p: tok.pos, op: "-", x: x}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	tok := p.advance()
+	switch tok.kind {
+	case tokenInt:
+		n, err := strconv.ParseInt(tok.text, 10, 64)
+		if err != nil {
+			return nil, errorAt(tok.pos, "number %s is too big", tok.text)
+		}
+		return &literal{p: tok.pos, value: n}, nil
+	case tokenString:
+		s, err := strconv.Unquote(tok.text)
+		if err != nil {
+			return nil, errorAt(tok.pos, "malformed string %s", tok.text)
+		}
+		return &literal{p: tok.pos, value: s}, nil
+	case tokenIdent:
+		switch {
+		case tok.text == "true" || tok.text == "false":
+			return &literal{p: tok.pos, value: tok.text == "true"}, nil
+		case keywords[tok.text]:
+			return nil, errorAt(tok.pos, "unexpected %q", tok.text)
+		}
+		return &variable{p: tok.pos, name: tok.text}, nil
+	case tokenOp:
+		if tok.text == "(" {
+			x, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if closing, ok := p.accept(")"); !ok {
+				return nil, errorAt(closing.pos, "expected \")\", not %q", closing.text)
+			}
+			return x, nil
+		}
+	}
+	return nil, errorAt(tok.pos, "unexpected %q", tok.text)
+}
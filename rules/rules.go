@@ -0,0 +1,128 @@
+// Package rules implements the small language door rules are written in, e.g.
+//
+//	deny if party.size > guest.rsvp_size + 2 and guest.tier == ""
+//	deny if now > event.doors_close
+//
+// A rule is "deny if" followed by a condition, made of the variables it's compiled against, integer,
+// string and boolean literals, + and - on integers, comparisons, and "and", "or" and "not". Rules are
+// type checked when compiled so a rule which compiles can only fail to evaluate if it's given the
+// wrong values.
+package rules
+
+import (
+	"fmt"
+	"time"
+)
+
+// Type of a value in a rule
+type Type int
+
+const (
+	Int Type = iota + 1
+	String
+	Bool
+	Time
+)
+
+func (t Type) String() string {
+	switch t {
+	case Int:
+		return "int"
+	case String:
+		return "string"
+	case Bool:
+		return "bool"
+	case Time:
+		return "time"
+	default:
+		return "unknown"
+	}
+}
+
+// TypeOf returns the type of a value rules can hold: an int64, string, bool or time.Time
+func TypeOf(value interface{}) (Type, bool) {
+	switch value.(type) {
+	case int64:
+		return Int, true
+	case string:
+		return String, true
+	case bool:
+		return Bool, true
+	case time.Time:
+		return Time, true
+	default:
+		return 0, false
+	}
+}
+
+// Vars declares the type of every variable rules may refer to
+type Vars map[string]Type
+
+// Values holds the value of every variable a rule is evaluated with, each of the type it was
+// declared with
+type Values map[string]interface{}
+
+// Error is returned when a rule doesn't compile, Column counts from 1
+type Error struct {
+	Column int
+	Msg    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Msg)
+}
+
+func errorAt(pos int, format string, args ...interface{}) error {
+	return &Error{Column: pos + 1, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Rule is a compiled "deny if" rule
+type Rule struct {
+	text string
+	cond node
+}
+
+// Compile parses text and checks it against the variables it may refer to
+func Compile(text string, vars Vars) (*Rule, error) {
+	tokens, err := lex(text)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if err = p.expectKeyword("deny"); err != nil {
+		return nil, err
+	}
+	if err = p.expectKeyword("if"); err != nil {
+		return nil, err
+	}
+	cond, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, errorAt(tok.pos, "unexpected %q", tok.text)
+	}
+
+	t, err := cond.check(vars)
+	if err != nil {
+		return nil, err
+	}
+	if t != Bool {
+		return nil, errorAt(cond.pos(), "the condition is a %s, not a bool", t)
+	}
+	return &Rule{text: text, cond: cond}, nil
+}
+
+// Denies evaluates the rule's condition
+func (r *Rule) Denies(values Values) (bool, error) {
+	v, err := r.cond.eval(values)
+	if err != nil {
+		return false, err
+	}
+	return v.(bool), nil
+}
+
+func (r *Rule) String() string {
+	return r.text
+}
@@ -0,0 +1,99 @@
+package rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testVars = Vars{
+	"party.size":      Int,
+	"guest.rsvp_size": Int,
+	"guest.tier":      String,
+	"guest.vip":       Bool,
+	"now":             Time,
+	"event.close":     Time,
+}
+
+func TestRuleDenies(t *testing.T) {
+	closing := time.Date(2026, 10, 19, 23, 0, 0, 0, time.UTC)
+	values := Values{
+		"party.size":      int64(5),
+		"guest.rsvp_size": int64(2),
+		"guest.tier":      "",
+		"guest.vip":       false,
+		"now":             closing.Add(-time.Hour),
+		"event.close":     closing,
+	}
+
+	testCases := []struct {
+		rule   string
+		denies bool
+	}{
+		{`deny if party.size > guest.rsvp_size + 2 and guest.tier == ""`, true},
+		{`deny if party.size > guest.rsvp_size + 3`, false},
+		{`deny if party.size - guest.rsvp_size >= 3`, true},
+		{`deny if now > event.close`, false},
+		{`deny if not (now < event.close)`, false},
+		{`deny if guest.vip or party.size == 5`, true},
+		{`deny if guest.vip and party.size == 5`, false},
+		{`deny if guest.tier != "gold" and not guest.vip`, true},
+		{`deny if -party.size < -4`, true},
+		{`deny if true`, true},
+		{`deny if party.size > 1 or party.size > 2 and false`, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.rule, func(t *testing.T) {
+			rule, err := Compile(tc.rule, testVars)
+			require.NoError(t, err)
+			require.Equal(t, tc.rule, rule.String())
+
+			denies, err := rule.Denies(values)
+			require.NoError(t, err)
+			require.Equal(t, tc.denies, denies)
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	testCases := []struct {
+		rule   string
+		column int
+	}{
+		{`party.size > 2`, 1},
+		{`deny party.size > 2`, 6},
+		{`deny if`, 8},
+		{`deny if party.size`, 9},
+		{`deny if party.size > "2"`, 20},
+		{`deny if guest.name == "x"`, 9},
+		{`deny if guest.tier < "gold"`, 20},
+		{`deny if party.size > 2 and`, 27},
+		{`deny if (party.size > 2`, 24},
+		{`deny if party.size > 2)`, 23},
+		{`deny if guest.tier == "gold`, 23},
+		{`deny if party.size % 2`, 20},
+		{`deny if not party.size`, 9},
+		{`deny if party. > 2`, 9},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.rule, func(t *testing.T) {
+			_, err := Compile(tc.rule, testVars)
+			require.Error(t, err)
+
+			var ruleErr *Error
+			require.ErrorAs(t, err, &ruleErr)
+			require.Equal(t, tc.column, ruleErr.Column, err.Error())
+		})
+	}
+}
+
+func TestRuleMissingValue(t *testing.T) {
+	rule, err := Compile(`deny if party.size > 2`, testVars)
+	require.NoError(t, err)
+
+	_, err = rule.Denies(Values{})
+	require.Error(t, err)
+}
@@ -0,0 +1,25 @@
+package scheduler
+
+import "time"
+
+// Clock tells the scheduler the time and wakes it up, so tests can step it through time with a
+// fake rather than waiting on the real one
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type realClock struct{}
+
+// RealClock is the wall clock
+func RealClock() Clock {
+	return realClock{}
+}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
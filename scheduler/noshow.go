@@ -0,0 +1,114 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+)
+
+// NoShowActor is recorded in the audit trail as the actor marking guests no-show
+const NoShowActor = "no_show_scheduler"
+
+// noShowBuffer is how many events a subscriber may fall behind before it misses them
+const noShowBuffer = 16
+
+// NoShow is the event published for every guest the scheduler marks no-show, at the time of the
+// check which marked them
+type NoShow struct {
+	Guest    db.Guest
+	MarkedAt time.Time
+}
+
+// NoShowScheduler periodically marks the guests who haven't arrived by the end of their arrival
+// window, plus a grace period, as no-show, releasing the seats reserved for them
+type NoShowScheduler struct {
+	store    db.Store
+	clock    Clock
+	interval time.Duration
+	grace    time.Duration
+
+	mu          sync.Mutex
+	subscribers map[chan NoShow]struct{}
+}
+
+// NewNoShowScheduler creates a scheduler checking for no-shows every interval
+func NewNoShowScheduler(store db.Store, clock Clock, interval, grace time.Duration) *NoShowScheduler {
+	return &NoShowScheduler{
+		store:       store,
+		clock:       clock,
+		interval:    interval,
+		grace:       grace,
+		subscribers: make(map[chan NoShow]struct{}),
+	}
+}
+
+// Subscribe returns a channel receiving every no-show marked from now on, until cancel is called.
+// A subscriber who falls behind misses events rather than holding up the checks.
+func (scheduler *NoShowScheduler) Subscribe() (<-chan NoShow, func()) {
+	events := make(chan NoShow, noShowBuffer)
+
+	scheduler.mu.Lock()
+	scheduler.subscribers[events] = struct{}{}
+	scheduler.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			scheduler.mu.Lock()
+			delete(scheduler.subscribers, events)
+			scheduler.mu.Unlock()
+			close(events)
+		})
+	}
+	return events, cancel
+}
+
+// publish sends the event to every subscriber with room for it
+func (scheduler *NoShowScheduler) publish(event NoShow) {
+	scheduler.mu.Lock()
+	defer scheduler.mu.Unlock()
+
+	for events := range scheduler.subscribers {
+		select {
+		case events <- event:
+		default:
+			log.Printf("Dropped the no-show of %s for a subscriber who fell behind", event.Guest.GuestName)
+		}
+	}
+}
+
+// Run checks for no-shows straight away and then every interval until ctx is cancelled. A check
+// which fails is logged and tried again at the next interval.
+func (scheduler *NoShowScheduler) Run(ctx context.Context) {
+	for {
+		if _, err := scheduler.Check(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Cannot mark no-shows: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-scheduler.clock.After(scheduler.interval):
+		}
+	}
+}
+
+// Check marks every guest overdue at the current time as no-show and publishes a NoShow for each,
+// each check is recorded in the audit trail under a request ID of its own
+func (scheduler *NoShowScheduler) Check(ctx context.Context) ([]db.Guest, error) {
+	now := scheduler.clock.Now()
+	guests, err := scheduler.store.MarkNoShowsTx(ctx, db.MarkNoShowsTxParams{
+		AuditInfo: db.AuditInfo{Actor: NoShowActor, RequestID: util.RequestID("")},
+		Now:       now,
+		Grace:     scheduler.grace,
+	})
+	for _, guest := range guests {
+		log.Printf("Marked %s no-show, their reserved seats at table %d were released", guest.GuestName, guest.TableID)
+		scheduler.publish(NoShow{Guest: guest, MarkedAt: now})
+	}
+	return guests, err
+}
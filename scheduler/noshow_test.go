@@ -0,0 +1,203 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+	"time"
+
+	mockdb "github.com/ellisp97/BE_Task_Oct20/golang/db/mock"
+	db "github.com/ellisp97/BE_Task_Oct20/golang/db/sqlc"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock only moves when advanced, signalling on waiting whenever the scheduler goes to sleep
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	timers  []fakeTimer
+	waiting chan struct{}
+}
+
+type fakeTimer struct {
+	at time.Time
+	c  chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now, waiting: make(chan struct{})}
+}
+
+func (clock *fakeClock) Now() time.Time {
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+	return clock.now
+}
+
+func (clock *fakeClock) After(d time.Duration) <-chan time.Time {
+	clock.mu.Lock()
+	c := make(chan time.Time, 1)
+	clock.timers = append(clock.timers, fakeTimer{at: clock.now.Add(d), c: c})
+	clock.mu.Unlock()
+
+	clock.waiting <- struct{}{}
+	return c
+}
+
+// Advance moves the clock on by d, firing every timer which is then due
+func (clock *fakeClock) Advance(d time.Duration) {
+	clock.mu.Lock()
+	defer clock.mu.Unlock()
+
+	clock.now = clock.now.Add(d)
+	pending := clock.timers[:0]
+	for _, timer := range clock.timers {
+		if clock.now.Before(timer.at) {
+			pending = append(pending, timer)
+			continue
+		}
+		timer.c <- clock.now
+	}
+	clock.timers = pending
+}
+
+func TestNoShowSchedulerRun(t *testing.T) {
+	start := time.Date(2026, 10, 19, 20, 0, 0, 0, time.UTC)
+	interval := time.Minute
+	grace := 15 * time.Minute
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	var checks []db.MarkNoShowsTxParams
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().
+		MarkNoShowsTx(gomock.Any(), gomock.Any()).
+		Times(3).
+		DoAndReturn(func(_ context.Context, arg db.MarkNoShowsTxParams) ([]db.Guest, error) {
+			checks = append(checks, arg)
+			if len(checks) == 2 {
+				// A failed check is retried at the next interval rather than stopping the scheduler
+				return []db.Guest{}, sql.ErrConnDone
+			}
+			return []db.Guest{{ID: int32(len(checks)), GuestName: "Late Guest", TableID: 1, Status: db.GuestNoShow}}, nil
+		})
+
+	clock := newFakeClock(start)
+	scheduler := NewNoShowScheduler(store, clock, interval, grace)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+
+	// Checked straight away, then not again until a whole interval has passed
+	<-clock.waiting
+	clock.Advance(interval / 2)
+	clock.Advance(interval / 2)
+	<-clock.waiting
+	clock.Advance(interval)
+	<-clock.waiting
+
+	cancel()
+	<-done
+
+	require.Len(t, checks, 3)
+	for i, check := range checks {
+		require.Equal(t, start.Add(time.Duration(i)*interval), check.Now)
+		require.Equal(t, grace, check.Grace)
+		require.Equal(t, NoShowActor, check.Actor)
+		require.NotEmpty(t, check.RequestID)
+	}
+	require.NotEqual(t, checks[0].RequestID, checks[1].RequestID)
+}
+
+func TestNoShowSchedulerCheck(t *testing.T) {
+	now := time.Date(2026, 10, 19, 21, 30, 0, 0, time.UTC)
+	marked := []db.Guest{{ID: 7, GuestName: "Late Guest", TableID: 2, Status: db.GuestNoShow}}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mockdb.NewMockStore(controller)
+	store.EXPECT().
+		MarkNoShowsTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		DoAndReturn(func(_ context.Context, arg db.MarkNoShowsTxParams) ([]db.Guest, error) {
+			require.Equal(t, now, arg.Now)
+			require.Equal(t, 10*time.Minute, arg.Grace)
+			return marked, nil
+		})
+
+	scheduler := NewNoShowScheduler(store, newFakeClock(now), time.Minute, 10*time.Minute)
+	guests, err := scheduler.Check(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, marked, guests)
+}
+
+func TestNoShowSchedulerEvents(t *testing.T) {
+	start := time.Date(2026, 10, 19, 21, 30, 0, 0, time.UTC)
+	interval := time.Minute
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	store := mockdb.NewMockStore(controller)
+	first := store.EXPECT().
+		MarkNoShowsTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.Guest{}, nil)
+	second := store.EXPECT().
+		MarkNoShowsTx(gomock.Any(), gomock.Any()).
+		Times(1).
+		Return([]db.Guest{
+			{ID: 7, GuestName: "Late Guest", TableID: 2, Status: db.GuestNoShow},
+			{ID: 8, GuestName: "Later Guest", TableID: 3, Status: db.GuestNoShow},
+		}, nil)
+	store.EXPECT().
+		MarkNoShowsTx(gomock.Any(), gomock.Any()).
+		AnyTimes().
+		After(second).
+		Return([]db.Guest{}, nil)
+	gomock.InOrder(first, second)
+
+	clock := newFakeClock(start)
+	scheduler := NewNoShowScheduler(store, clock, interval, 10*time.Minute)
+	events, cancelEvents := scheduler.Subscribe()
+	unsubscribed, cancelUnsubscribed := scheduler.Subscribe()
+	cancelUnsubscribed()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+
+	// Nobody is overdue at the first check, two are by the next
+	<-clock.waiting
+	require.Empty(t, events)
+	clock.Advance(interval)
+	<-clock.waiting
+
+	for _, name := range []string{"Late Guest", "Later Guest"} {
+		event := <-events
+		require.Equal(t, name, event.Guest.GuestName)
+		require.Equal(t, db.GuestNoShow, event.Guest.Status)
+		require.Equal(t, start.Add(interval), event.MarkedAt)
+	}
+
+	cancel()
+	<-done
+
+	// Cancelled subscriptions are closed and hear nothing more
+	_, open := <-unsubscribed
+	require.False(t, open)
+	cancelEvents()
+	_, open = <-events
+	require.False(t, open)
+}
@@ -0,0 +1,67 @@
+package token
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+)
+
+// ErrInvalidAPIKey is returned when a key doesn't match any configured key
+var ErrInvalidAPIKey = errors.New("api key is invalid")
+
+type apiKey struct {
+	name string
+	role string
+	hash [sha256.Size]byte
+}
+
+// APIKeys holds the long-lived keys services use instead of a JWT. Only a hash of each key is kept.
+type APIKeys struct {
+	keys []apiKey
+}
+
+// ParseAPIKeys reads keys in the form "name:role:key", separated by commas, e.g. the API_KEYS config value
+func ParseAPIKeys(value string) (*APIKeys, error) {
+	apiKeys := &APIKeys{}
+	for i, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		// A malformed entry is named by its position, any part of it could be the key
+		parts := strings.SplitN(entry, ":", 3)
+		if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+			return nil, fmt.Errorf("api key %d must be of the form name:role:key", i+1)
+		}
+		if !util.IsSupportedRole(parts[1]) {
+			return nil, fmt.Errorf("api key %q has unsupported role %q", parts[0], parts[1])
+		}
+		apiKeys.keys = append(apiKeys.keys, apiKey{
+			name: parts[0],
+			role: parts[1],
+			hash: sha256.Sum256([]byte(parts[2])),
+		})
+	}
+	return apiKeys, nil
+}
+
+// Verify returns a payload naming the owner of key, every configured key is compared in constant time
+func (apiKeys *APIKeys) Verify(key string) (*Payload, error) {
+	hash := sha256.Sum256([]byte(key))
+
+	var match *apiKey
+	for i := range apiKeys.keys {
+		if subtle.ConstantTimeCompare(hash[:], apiKeys.keys[i].hash[:]) == 1 {
+			match = &apiKeys.keys[i]
+		}
+	}
+	if match == nil {
+		return nil, ErrInvalidAPIKey
+	}
+	return &Payload{Username: match.name, Role: match.role}, nil
+}
@@ -0,0 +1,44 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeys(t *testing.T) {
+	apiKeys, err := ParseAPIKeys("checkin-app:door_staff:secret-one, dashboard:viewer:secret-two")
+	require.NoError(t, err)
+
+	payload, err := apiKeys.Verify("secret-one")
+	require.NoError(t, err)
+	require.Equal(t, "checkin-app", payload.Username)
+	require.Equal(t, util.DoorStaffRole, payload.Role)
+
+	payload, err = apiKeys.Verify("secret-two")
+	require.NoError(t, err)
+	require.Equal(t, "dashboard", payload.Username)
+	require.Equal(t, util.ViewerRole, payload.Role)
+
+	payload, err = apiKeys.Verify("secret-three")
+	require.ErrorIs(t, err, ErrInvalidAPIKey)
+	require.Nil(t, payload)
+}
+
+func TestParseAPIKeys(t *testing.T) {
+	apiKeys, err := ParseAPIKeys("")
+	require.NoError(t, err)
+	_, err = apiKeys.Verify("")
+	require.ErrorIs(t, err, ErrInvalidAPIKey)
+
+	_, err = ParseAPIKeys("checkin-app:door_staff")
+	require.Error(t, err)
+
+	// The key itself is never echoed back, whichever part of the entry it ended up in
+	_, err = ParseAPIKeys("checkin-app:door_staff:secret, not-a-valid-secret")
+	require.EqualError(t, err, "api key 2 must be of the form name:role:key")
+
+	_, err = ParseAPIKeys("checkin-app:bouncer:secret")
+	require.Error(t, err)
+}
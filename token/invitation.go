@@ -0,0 +1,84 @@
+package token
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidInvitation is returned when an invitation token is malformed or its signature doesn't match
+var ErrInvalidInvitation = errors.New("invitation is invalid")
+
+// InvitationClaims identifies the invitation a token was issued for
+type InvitationClaims struct {
+	InvitationID int32
+	GuestID      int32
+}
+
+// InvitationSigner signs the invitation tokens printed on guests' QR codes. A token is the
+// invitation and guest IDs followed by an HMAC-SHA256 over them and the event, so it can't be
+// forged or guessed, nor reused at another event signed with the same key.
+type InvitationSigner struct {
+	secretKey []byte
+	event     string
+}
+
+// NewInvitationSigner creates an InvitationSigner for the event
+func NewInvitationSigner(secretKey, event string) (*InvitationSigner, error) {
+	if len(secretKey) < minSecretKeySize {
+		return nil, fmt.Errorf("invalid key size: must be at least %d characters", minSecretKeySize)
+	}
+	if event == "" {
+		return nil, errors.New("event must not be empty")
+	}
+	return &InvitationSigner{secretKey: []byte(secretKey), event: event}, nil
+}
+
+// Sign returns the token for the claims, in the form "invitationID.guestID.signature"
+func (signer *InvitationSigner) Sign(claims InvitationClaims) string {
+	ids := fmt.Sprintf("%d.%d", claims.InvitationID, claims.GuestID)
+	return ids + "." + base64.RawURLEncoding.EncodeToString(signer.mac(ids))
+}
+
+// Verify checks the token's signature and returns its claims
+func (signer *InvitationSigner) Verify(token string) (InvitationClaims, error) {
+	var claims InvitationClaims
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims, ErrInvalidInvitation
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return claims, ErrInvalidInvitation
+	}
+	if !hmac.Equal(signature, signer.mac(parts[0]+"."+parts[1])) {
+		return claims, ErrInvalidInvitation
+	}
+
+	invitationID, err := strconv.ParseInt(parts[0], 10, 32)
+	if err != nil {
+		return claims, ErrInvalidInvitation
+	}
+	guestID, err := strconv.ParseInt(parts[1], 10, 32)
+	if err != nil {
+		return claims, ErrInvalidInvitation
+	}
+
+	claims.InvitationID = int32(invitationID)
+	claims.GuestID = int32(guestID)
+	return claims, nil
+}
+
+func (signer *InvitationSigner) mac(ids string) []byte {
+	mac := hmac.New(sha256.New, signer.secretKey)
+	mac.Write([]byte(signer.event))
+	mac.Write([]byte{0})
+	mac.Write([]byte(ids))
+	return mac.Sum(nil)
+}
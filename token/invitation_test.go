@@ -0,0 +1,61 @@
+package token
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ellisp97/BE_Task_Oct20/golang/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInvitationSigner(t *testing.T) {
+	signer, err := NewInvitationSigner(util.RandomString(32), "launch-party")
+	require.NoError(t, err)
+
+	claims := InvitationClaims{InvitationID: util.RandomInt(1, 1000), GuestID: util.RandomInt(1, 1000)}
+	token := signer.Sign(claims)
+	require.NotEmpty(t, token)
+
+	verified, err := signer.Verify(token)
+	require.NoError(t, err)
+	require.Equal(t, claims, verified)
+}
+
+func TestInvalidInvitation(t *testing.T) {
+	key := util.RandomString(32)
+	signer, err := NewInvitationSigner(key, "launch-party")
+	require.NoError(t, err)
+
+	token := signer.Sign(InvitationClaims{InvitationID: 1, GuestID: 2})
+	parts := strings.Split(token, ".")
+
+	otherEvent, err := NewInvitationSigner(key, "after-party")
+	require.NoError(t, err)
+	otherKey, err := NewInvitationSigner(util.RandomString(32), "launch-party")
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		name   string
+		signer *InvitationSigner
+		token  string
+	}{
+		{name: "OtherGuest", signer: signer, token: "1.3." + parts[2]},
+		{name: "OtherEvent", signer: otherEvent, token: token},
+		{name: "OtherKey", signer: otherKey, token: token},
+		{name: "Malformed", signer: signer, token: "1.2"},
+		{name: "BadEncoding", signer: signer, token: "1.2.!!!"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.signer.Verify(tc.token)
+			require.ErrorIs(t, err, ErrInvalidInvitation)
+		})
+	}
+}
+
+func TestNewInvitationSigner(t *testing.T) {
+	_, err := NewInvitationSigner(util.RandomString(31), "launch-party")
+	require.Error(t, err)
+
+	_, err = NewInvitationSigner(util.RandomString(32), "")
+	require.Error(t, err)
+}
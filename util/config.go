@@ -1,6 +1,10 @@
 package util
 
-import "github.com/spf13/viper"
+import (
+	"time"
+
+	"github.com/spf13/viper"
+)
 
 // Config stores all config settings of the app
 // Values are read using viper
@@ -8,6 +12,27 @@ type Config struct {
 	DBDriver     string `mapstructure:"DB_DRIVER"`
 	DBSource     string `mapstructure:"DB_SOURCE"`
 	SeverAddress string `mapstructure:"SERVER_ADDRESS"`
+
+	GRPCServerAddress     string        `mapstructure:"GRPC_SERVER_ADDRESS"`
+	OccupancyPollInterval time.Duration `mapstructure:"OCCUPANCY_POLL_INTERVAL"`
+
+	TokenSymmetricKey   string        `mapstructure:"TOKEN_SYMMETRIC_KEY"`
+	AccessTokenDuration time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
+	// APIKeys are long-lived service credentials in the form "name:role:key", separated by commas
+	APIKeys string `mapstructure:"API_KEYS"`
+
+	// EventName and InvitationSymmetricKey sign the invitation tokens on guests' QR codes
+	EventName              string `mapstructure:"EVENT_NAME"`
+	InvitationSymmetricKey string `mapstructure:"INVITATION_SYMMETRIC_KEY"`
+	// DenyReentry stops guests who have left from arriving again
+	DenyReentry bool `mapstructure:"DENY_REENTRY"`
+	// AdmissionPolicies are the rules every arrival must pass, e.g. "table_capacity:10,rsvp_limit",
+	// see db.ParseAdmissionPolicies
+	AdmissionPolicies string `mapstructure:"ADMISSION_POLICIES"`
+	// NoShowCheckInterval is how often guests who missed their arrival window are marked no-show,
+	// 0 turns the check off. NoShowGrace is how long after their window closes they're given.
+	NoShowCheckInterval time.Duration `mapstructure:"NO_SHOW_CHECK_INTERVAL"`
+	NoShowGrace         time.Duration `mapstructure:"NO_SHOW_GRACE"`
 }
 
 // LoadConfig reads config settings from file/ env variables
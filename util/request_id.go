@@ -0,0 +1,35 @@
+package util
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// RequestIDHeader carries the ID of a request, it is read from callers which set it and
+// echoed back otherwise so the request can be found in the logs and audit trail
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds caller supplied IDs to what fits in audit_events.request_id
+const maxRequestIDLength = 64
+
+type requestIDKey struct{}
+
+// RequestID returns id if the caller supplied a usable one, otherwise a new random ID
+func RequestID(id string) string {
+	if id == "" || len(id) > maxRequestIDLength {
+		return uuid.NewString()
+	}
+	return id
+}
+
+// NewRequestIDContext returns a copy of ctx carrying the request ID
+func NewRequestIDContext(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID stored by NewRequestIDContext, or "" if there is none
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
@@ -0,0 +1,20 @@
+package util
+
+// Roles a user or API key can act as, from most to least privileged
+const (
+	// OrganiserRole has full control of the guest list and tables
+	OrganiserRole = "organiser"
+	// DoorStaffRole can arrive and remove guests and read the guest list
+	DoorStaffRole = "door_staff"
+	// ViewerRole can only read the seat counts and tables
+	ViewerRole = "viewer"
+)
+
+// IsSupportedRole returns true if the role is one of the above
+func IsSupportedRole(role string) bool {
+	switch role {
+	case OrganiserRole, DoorStaffRole, ViewerRole:
+		return true
+	}
+	return false
+}